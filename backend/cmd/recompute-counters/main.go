@@ -0,0 +1,54 @@
+// Command recompute-counters rebuilds every denormalized counter from its source tables
+//
+// Counters are kept in sync as data changes, so this is only needed after
+// manual data fixes, imports, or to repair drift from before they were maintained:
+//
+//	go run cmd/recompute-counters/main.go
+//
+// To backfill only the review-fed rating aggregates of users and user skills:
+//
+//	go run cmd/recompute-counters/main.go -ratings
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/timebankingskill/backend/internal/config"
+	"github.com/timebankingskill/backend/internal/database"
+	"github.com/timebankingskill/backend/internal/repository"
+)
+
+func main() {
+	ratingsOnly := flag.Bool("ratings", false, "only recompute user and user skill rating aggregates")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("❌ Failed to load config: %v", err)
+	}
+
+	if err := database.Connect(&cfg.Database); err != nil {
+		log.Fatalf("❌ Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+
+	counterRepo := repository.NewCounterRepository(database.DB)
+
+	if *ratingsOnly {
+		result, err := counterRepo.RecomputeRatings()
+		if err != nil {
+			log.Fatalf("❌ Failed to recompute ratings: %v", err)
+		}
+		log.Printf("✅ Ratings recomputed, corrected rows: user_skills=%d users=%d", result.UserSkills, result.Users)
+		return
+	}
+
+	result, err := counterRepo.RecomputeAll()
+	if err != nil {
+		log.Fatalf("❌ Failed to recompute counters: %v", err)
+	}
+
+	log.Printf("✅ Counters recomputed, corrected rows: skills=%d user_skills=%d users=%d forum_categories=%d",
+		result.Skills, result.UserSkills, result.Users, result.ForumCategories)
+}
@@ -77,6 +77,8 @@ func main() {
   }
 
   // Apply middleware
+  // Request ID first so every later middleware and handler can reference it
+  router.Use(middleware.RequestID())
   router.Use(middleware.Logger())
   router.Use(middleware.Recovery())
   router.Use(middleware.CORSWithConfig(cfg))
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,19 +12,32 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	CORS     CORSConfig
-	Supabase SupabaseConfig
-	Jitsi    JitsiConfig
+	Server         ServerConfig
+	Database       DatabaseConfig
+	JWT            JWTConfig
+	CORS           CORSConfig
+	Supabase       SupabaseConfig
+	Jitsi          JitsiConfig
+	Finance        FinanceConfig
+	Fraud          FraudConfig
+	Recommendation RecommendationConfig
+	Verification   VerificationConfig
+	SavedSearch    SavedSearchConfig
+	Reputation     ReputationConfig
+	Review         ReviewConfig
+	Badge          BadgeConfig
+	Leaderboard    LeaderboardConfig
+	Streak         StreakConfig
+	Challenge      ChallengeConfig
+	XP             XPConfig
 }
 
 // ServerConfig holds server-related configuration
 type ServerConfig struct {
 	Port           string
 	GinMode        string
-	TrustedProxies string // Comma-separated list of trusted proxy IPs
+	TrustedProxies string        // Comma-separated list of trusted proxy IPs
+	IdempotencyTTL time.Duration // How long responses for Idempotency-Key requests are replayed
 }
 
 // DatabaseConfig holds database connection configuration
@@ -60,6 +74,87 @@ type JitsiConfig struct {
 	BaseURL    string
 }
 
+// FinanceConfig holds credit management configuration
+type FinanceConfig struct {
+	// AdjustmentApprovalThreshold is the absolute credit amount above which
+	// an admin adjustment needs a second admin's approval
+	AdjustmentApprovalThreshold float64
+}
+
+// FraudConfig holds credit farming detection configuration
+type FraudConfig struct {
+	FlagThreshold         float64 // Session score (0-1) at or above which credits are held for review
+	WindowDays            int     // How far back pair history is considered
+	ShortSessionRatio     float64 // Actual/booked duration ratio below which a session is "short"
+	RepeatedPairThreshold int     // Prior sessions between the same pair that count as repeated
+}
+
+// RecommendationConfig holds teacher recommendation configuration
+type RecommendationConfig struct {
+	DigestEnabled bool // Send the weekly "new matches" notification digest
+}
+
+// VerificationConfig holds skill credential verification configuration
+type VerificationConfig struct {
+	ValidityDays      int    // How long an approved verification lasts before it must be renewed
+	RenewalWindowDays int    // Days before expiry when teachers are reminded and may renew
+	UploadDir         string // Where proof files are stored
+	MaxProofSizeMB    int    // Largest accepted proof file
+}
+
+// SavedSearchConfig holds saved marketplace search alert configuration
+type SavedSearchConfig struct {
+	AlertsEnabled bool          // Periodically check saved searches and notify about new matches
+	CheckInterval time.Duration // How often saved searches are checked
+}
+
+// ReputationConfig holds reputation scoring configuration
+type ReputationConfig struct {
+	PriorWeight         float64       // Reviews' worth of platform-average rating every score starts from
+	RecencyHalfLifeDays int           // Age at which a review counts half as much as a new one
+	RecomputeEnabled    bool          // Periodically rebuild every score (recency and reviewer credibility drift)
+	RecomputeInterval   time.Duration // How often every score is rebuilt
+}
+
+// ReviewConfig holds session review configuration
+type ReviewConfig struct {
+	// BlindWindowDays is how long after a session completes its participants can review it
+	// Reviews stay hidden until both are in or the window ends, then publish together
+	BlindWindowDays int
+}
+
+// BadgeConfig holds badge management configuration
+type BadgeConfig struct {
+	IconDir       string // Where uploaded badge icons are stored
+	MaxIconSizeKB int    // Largest accepted badge icon
+}
+
+// LeaderboardConfig holds leaderboard snapshot configuration
+type LeaderboardConfig struct {
+	RefreshEnabled   bool          // Periodically recompute leaderboard snapshots
+	RefreshInterval  time.Duration // How often snapshots are recomputed
+	MinRatingReviews int           // Reviews a user needs in a period to rank on the rating leaderboard
+	HistoryDays      int           // How long weekly and monthly standings are kept; seasons are kept forever
+}
+
+// StreakConfig holds weekly activity streak configuration
+type StreakConfig struct {
+	WarningEnabled bool // Warn users whose streak is about to break
+	WarningHours   int  // How long before the week ends (Sunday 24:00 UTC) the warning goes out
+	MinWeeks       int  // Shortest streak worth a warning
+}
+
+// ChallengeConfig holds community challenge configuration
+type ChallengeConfig struct {
+	ProgressEnabled  bool          // Periodically recompute challenge progress and settle ended challenges
+	ProgressInterval time.Duration // How often progress is recomputed
+}
+
+// XPConfig holds experience point and level configuration
+type XPConfig struct {
+	LevelThresholds []int // Total XP needed to reach level 2, 3, ...; everyone starts at level 1
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	// Load .env file if exists (ignore error in production)
@@ -71,11 +166,42 @@ func Load() (*Config, error) {
 		jwtExpiry = 24 * time.Hour
 	}
 
+	// Parse saved search check interval
+	savedSearchInterval, err := time.ParseDuration(getEnv("SAVED_SEARCH_CHECK_INTERVAL", "1h"))
+	if err != nil || savedSearchInterval <= 0 {
+		savedSearchInterval = time.Hour
+	}
+
+	// Parse reputation recompute interval
+	reputationInterval, err := time.ParseDuration(getEnv("REPUTATION_RECOMPUTE_INTERVAL", "24h"))
+	if err != nil || reputationInterval <= 0 {
+		reputationInterval = 24 * time.Hour
+	}
+
+	// Parse leaderboard refresh interval
+	leaderboardInterval, err := time.ParseDuration(getEnv("LEADERBOARD_REFRESH_INTERVAL", "15m"))
+	if err != nil || leaderboardInterval <= 0 {
+		leaderboardInterval = 15 * time.Minute
+	}
+
+	// Parse challenge progress interval
+	challengeInterval, err := time.ParseDuration(getEnv("CHALLENGE_PROGRESS_INTERVAL", "1h"))
+	if err != nil || challengeInterval <= 0 {
+		challengeInterval = time.Hour
+	}
+
+	// Parse idempotency key TTL
+	idempotencyTTL, err := time.ParseDuration(getEnv("IDEMPOTENCY_KEY_TTL", "24h"))
+	if err != nil || idempotencyTTL <= 0 {
+		idempotencyTTL = 24 * time.Hour
+	}
+
 	config := &Config{
 		Server: ServerConfig{
 			Port:           getEnv("PORT", ""),
 			GinMode:        getEnv("GIN_MODE", ""),
 			TrustedProxies: getEnv("TRUSTED_PROXIES", ""),
+			IdempotencyTTL: idempotencyTTL,
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", ""),
@@ -101,6 +227,59 @@ func Load() (*Config, error) {
 			PrivateKey: getEnv("JITSI_PRIVATE_KEY", ""),
 			BaseURL:    getEnv("JITSI_BASE_URL", "https://meet.jit.si"),
 		},
+		Finance: FinanceConfig{
+			AdjustmentApprovalThreshold: getEnvFloat("CREDIT_ADJUSTMENT_APPROVAL_THRESHOLD", 10),
+		},
+		Fraud: FraudConfig{
+			FlagThreshold:         getEnvFloat("FRAUD_FLAG_THRESHOLD", 0.5),
+			WindowDays:            getEnvInt("FRAUD_WINDOW_DAYS", 30),
+			ShortSessionRatio:     getEnvFloat("FRAUD_SHORT_SESSION_RATIO", 0.25),
+			RepeatedPairThreshold: getEnvInt("FRAUD_REPEATED_PAIR_THRESHOLD", 3),
+		},
+		Recommendation: RecommendationConfig{
+			DigestEnabled: getEnv("RECOMMENDATION_DIGEST_ENABLED", "true") == "true",
+		},
+		Verification: VerificationConfig{
+			ValidityDays:      getEnvInt("VERIFICATION_VALIDITY_DAYS", 365),
+			RenewalWindowDays: getEnvInt("VERIFICATION_RENEWAL_WINDOW_DAYS", 30),
+			UploadDir:         getEnv("VERIFICATION_UPLOAD_DIR", "uploads/verifications"),
+			MaxProofSizeMB:    getEnvInt("VERIFICATION_MAX_PROOF_SIZE_MB", 10),
+		},
+		SavedSearch: SavedSearchConfig{
+			AlertsEnabled: getEnv("SAVED_SEARCH_ALERTS_ENABLED", "true") == "true",
+			CheckInterval: savedSearchInterval,
+		},
+		Reputation: ReputationConfig{
+			PriorWeight:         getEnvFloat("REPUTATION_PRIOR_WEIGHT", 5),
+			RecencyHalfLifeDays: getEnvInt("REPUTATION_RECENCY_HALF_LIFE_DAYS", 180),
+			RecomputeEnabled:    getEnv("REPUTATION_RECOMPUTE_ENABLED", "true") == "true",
+			RecomputeInterval:   reputationInterval,
+		},
+		Review: ReviewConfig{
+			BlindWindowDays: getEnvInt("REVIEW_BLIND_WINDOW_DAYS", 7),
+		},
+		Badge: BadgeConfig{
+			IconDir:       getEnv("BADGE_ICON_DIR", "uploads/badges"),
+			MaxIconSizeKB: getEnvInt("BADGE_MAX_ICON_SIZE_KB", 512),
+		},
+		Leaderboard: LeaderboardConfig{
+			RefreshEnabled:   getEnv("LEADERBOARD_REFRESH_ENABLED", "true") == "true",
+			RefreshInterval:  leaderboardInterval,
+			MinRatingReviews: getEnvInt("LEADERBOARD_MIN_RATING_REVIEWS", 3),
+			HistoryDays:      getEnvInt("LEADERBOARD_HISTORY_DAYS", 365),
+		},
+		Streak: StreakConfig{
+			WarningEnabled: getEnv("STREAK_WARNING_ENABLED", "true") == "true",
+			WarningHours:   getEnvInt("STREAK_WARNING_HOURS", 48),
+			MinWeeks:       getEnvInt("STREAK_WARNING_MIN_WEEKS", 2),
+		},
+		Challenge: ChallengeConfig{
+			ProgressEnabled:  getEnv("CHALLENGE_PROGRESS_ENABLED", "true") == "true",
+			ProgressInterval: challengeInterval,
+		},
+		XP: XPConfig{
+			LevelThresholds: parseLevelThresholds(getEnv("XP_LEVEL_THRESHOLDS", "")),
+		},
 	}
 
 	// Validate required fields
@@ -128,6 +307,24 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// getEnvFloat gets a numeric environment variable with fallback default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvInt gets an integer environment variable with fallback default value
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 // parseAllowedOrigins parses comma-separated origins from environment variable
 func parseAllowedOrigins(originsStr string) []string {
 	if originsStr == "" {
@@ -157,3 +354,25 @@ func (c *DatabaseConfig) GetDSN() string {
 		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode,
 	)
 }
+
+// defaultLevelThresholds is the XP needed for levels 2 to 11
+var defaultLevelThresholds = []int{100, 250, 500, 1000, 1750, 2750, 4000, 5500, 7500, 10000}
+
+// parseLevelThresholds parses comma-separated, strictly increasing XP thresholds
+// Falls back to the defaults when the list is empty or malformed
+func parseLevelThresholds(thresholdsStr string) []int {
+	if thresholdsStr == "" {
+		return defaultLevelThresholds
+	}
+
+	var parsed []int
+	for _, part := range strings.Split(thresholdsStr, ",") {
+		threshold, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || threshold <= 0 || (len(parsed) > 0 && threshold <= parsed[len(parsed)-1]) {
+			return defaultLevelThresholds
+		}
+		parsed = append(parsed, threshold)
+	}
+
+	return parsed
+}
@@ -67,6 +67,11 @@ func AutoMigrate() error {
   // This ensures new models (like Notification) are always created
   log.Println("🔄 Running database migrations...")
 
+  // Publish reviews that predate double-blind reviews before anything adds the column
+  if err := PublishExistingReviews(DB); err != nil {
+    return fmt.Errorf("failed to publish existing reviews: %w", err)
+  }
+
   err := models.AutoMigrate(DB)
   if err != nil {
     return fmt.Errorf("failed to run migrations: %w", err)
@@ -104,6 +109,11 @@ func SeedInitialData() error {
     log.Printf("⚠️  Warning: Failed to seed badges: %v", err)
   }
 
+  // Seed XP rules (has its own duplicate check)
+  if err := seedXPRules(); err != nil {
+    log.Printf("⚠️  Warning: Failed to seed XP rules: %v", err)
+  }
+
   log.Println("✅ Initial data seeding completed")
   return nil
 }
@@ -234,6 +244,29 @@ func seedBadges() error {
   return nil
 }
 
+// seedXPRules creates the default XP award for every action
+// Existing rules are left alone so admin changes survive restarts
+func seedXPRules() error {
+  rules := []models.XPRule{
+    {Action: models.XPSessionCompleted, Description: "Complete a session as teacher or student", Points: 50, DailyCap: 200, IsActive: true},
+    {Action: models.XPReviewWritten, Description: "Review a completed session", Points: 10, DailyCap: 50, IsActive: true},
+    {Action: models.XPForumAnswerAccepted, Description: "Have a forum reply accepted as the answer", Points: 25, DailyCap: 100, IsActive: true},
+    {Action: models.XPEndorsementReceived, Description: "Receive a skill endorsement", Points: 15, DailyCap: 60, IsActive: true},
+  }
+
+  for _, rule := range rules {
+    var existing models.XPRule
+    result := DB.Where("action = ?", rule.Action).First(&existing)
+    if result.Error == gorm.ErrRecordNotFound {
+      if err := DB.Create(&rule).Error; err != nil {
+        return fmt.Errorf("failed to seed XP rule %s: %w", rule.Action, err)
+      }
+    }
+  }
+
+  return nil
+}
+
 // seedAdmin creates initial admin user
 func seedAdmin() error {
   // Check if admin already exists
@@ -2,7 +2,9 @@ package database
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/timebankingskill/backend/internal/models"
 	"gorm.io/gorm"
 )
 
@@ -25,14 +27,113 @@ func RunMigrations(db *gorm.DB) error {
 	// Create all tables (already done in database.go)
 	// This file focuses on adding indexes for optimization
 
+	// Add columns introduced after tables were first created
+	if err := addMissingColumns(db); err != nil {
+		return fmt.Errorf("failed to add columns: %w", err)
+	}
+
 	// Add performance indexes
 	if err := createPerformanceIndexes(db); err != nil {
 		return fmt.Errorf("failed to create indexes: %w", err)
 	}
 
+	// Add full-text and trigram search columns and indexes
+	if err := createSearchIndexes(db); err != nil {
+		return fmt.Errorf("failed to create search indexes: %w", err)
+	}
+
+	return nil
+}
+
+// addMissingColumns adds columns to existing tables
+// models.AutoMigrate only creates missing tables, so columns added to
+// existing models must be added here to reach already-deployed databases
+//
+// Parameters:
+//   - db: GORM database instance
+//
+// Returns:
+//   - error: If a column cannot be added
+func addMissingColumns(db *gorm.DB) error {
+	columns := []string{
+		// Fraud detection: check-in IPs and credit hold pending review
+		"ALTER TABLE sessions ADD COLUMN IF NOT EXISTS teacher_check_in_ip VARCHAR(45)",
+		"ALTER TABLE sessions ADD COLUMN IF NOT EXISTS student_check_in_ip VARCHAR(45)",
+		"ALTER TABLE sessions ADD COLUMN IF NOT EXISTS fraud_hold BOOLEAN DEFAULT false",
+		"CREATE INDEX IF NOT EXISTS idx_sessions_fraud_hold ON sessions(fraud_hold)",
+		// Skill swaps: sessions booked as legs of a reciprocal swap
+		"ALTER TABLE sessions ADD COLUMN IF NOT EXISTS skill_swap_id BIGINT",
+		"CREATE INDEX IF NOT EXISTS idx_sessions_skill_swap_id ON sessions(skill_swap_id)",
+		// Skill taxonomy: parent/child hierarchy and tags
+		"ALTER TABLE skills ADD COLUMN IF NOT EXISTS parent_id BIGINT",
+		"CREATE INDEX IF NOT EXISTS idx_skills_parent_id ON skills(parent_id)",
+		"ALTER TABLE skills ADD COLUMN IF NOT EXISTS tags JSONB DEFAULT '[]'",
+		"CREATE INDEX IF NOT EXISTS idx_skills_tags ON skills USING GIN (tags)",
+		// Skill verification: moderator-approved credentials that expire
+		"ALTER TABLE user_skills ADD COLUMN IF NOT EXISTS is_verified BOOLEAN DEFAULT false",
+		"ALTER TABLE user_skills ADD COLUMN IF NOT EXISTS verified_at TIMESTAMPTZ",
+		"ALTER TABLE user_skills ADD COLUMN IF NOT EXISTS verification_expires_at TIMESTAMPTZ",
+		"CREATE INDEX IF NOT EXISTS idx_user_skills_is_verified ON user_skills(is_verified)",
+		// Skill assessments: levels confirmed by passed placement quizzes
+		"ALTER TABLE user_skills ADD COLUMN IF NOT EXISTS assessed_level VARCHAR(20)",
+		"ALTER TABLE user_skills ADD COLUMN IF NOT EXISTS assessed_at TIMESTAMPTZ",
+		"ALTER TABLE skill_progress ADD COLUMN IF NOT EXISTS placement_level VARCHAR(20)",
+		// Review moderation: report queue and moderator decisions
+		"ALTER TABLE reviews ADD COLUMN IF NOT EXISTS report_count INTEGER DEFAULT 0",
+		"ALTER TABLE reviews ADD COLUMN IF NOT EXISTS moderated_by BIGINT",
+		"ALTER TABLE reviews ADD COLUMN IF NOT EXISTS moderated_at TIMESTAMPTZ",
+		"ALTER TABLE reviews ADD COLUMN IF NOT EXISTS moderation_reason TEXT",
+		"CREATE INDEX IF NOT EXISTS idx_reviews_is_reported ON reviews(is_reported)",
+		// Double-blind reviews: the column is added by publishExistingReviews
+		"CREATE INDEX IF NOT EXISTS idx_reviews_published_at ON reviews(published_at)",
+		// Badge management: uploaded icons, bonus granted per award and revocations
+		"ALTER TABLE badges ADD COLUMN IF NOT EXISTS icon_path TEXT",
+		"ALTER TABLE user_badges ADD COLUMN IF NOT EXISTS bonus_granted DECIMAL",
+		"ALTER TABLE user_badges ADD COLUMN IF NOT EXISTS revoked_by BIGINT",
+		"ALTER TABLE user_badges ADD COLUMN IF NOT EXISTS revoke_reason TEXT",
+		// Forum answers: the reply a thread author accepted (earns XP)
+		"ALTER TABLE forum_threads ADD COLUMN IF NOT EXISTS accepted_reply_id BIGINT",
+		"ALTER TABLE forum_replies ADD COLUMN IF NOT EXISTS is_accepted BOOLEAN DEFAULT false",
+		// Idempotency keys: scoped by actor type since admin and user IDs can collide
+		"ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS actor_type VARCHAR(10) NOT NULL DEFAULT 'user'",
+		"DROP INDEX IF EXISTS idx_idempotency_user_key",
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_actor_key ON idempotency_keys(actor_type, user_id, key)",
+	}
+
+	for _, columnSQL := range columns {
+		if err := db.Exec(columnSQL).Error; err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// PublishExistingReviews adds reviews.published_at to a reviews table created before
+// double-blind reviews, publishing every review already there
+// Reviews written before then were visible right away, and a NULL published_at would
+// seal them. Must run before models.AutoMigrate, so that nothing else adds the column
+// as nullable first; once the column exists this does nothing.
+//
+// Parameters:
+//   - db: GORM database instance
+//
+// Returns:
+//   - error: If the column cannot be added
+func PublishExistingReviews(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.Review{}) || migrator.HasColumn(&models.Review{}, "published_at") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("ALTER TABLE reviews ADD COLUMN published_at TIMESTAMPTZ").Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE reviews SET published_at = created_at WHERE published_at IS NULL").Error
+	})
+}
+
 // createPerformanceIndexes creates database indexes for query optimization
 // Improves query performance by 40-70% on frequently queried columns
 //
@@ -44,6 +145,7 @@ func RunMigrations(db *gorm.DB) error {
 //   - UserBadges: user_id + badge_id (composite index)
 //   - Reviews: reviewee_id + is_hidden (composite index)
 //   - Transactions: user_id + created_at (composite index)
+//   - Transactions: description full-text (GIN index)
 //
 // Performance Impact:
 //   - Reduces query time from 200ms to 50-100ms
@@ -75,6 +177,8 @@ func createPerformanceIndexes(db *gorm.DB) error {
 		"CREATE INDEX IF NOT EXISTS idx_reviews_reviewee_hidden ON reviews(reviewee_id, is_hidden)",
 		// Transaction indexes for user history
 		"CREATE INDEX IF NOT EXISTS idx_transactions_user_date ON transactions(user_id, created_at DESC)",
+		// Transaction full-text index for description search
+		"CREATE INDEX IF NOT EXISTS idx_transactions_description_fts ON transactions USING GIN (to_tsvector('simple', COALESCE(description, '')))",
 	}
 
 	// Execute all index creation queries
@@ -88,6 +192,86 @@ func createPerformanceIndexes(db *gorm.DB) error {
 	return nil
 }
 
+// createSearchIndexes prepares the tables covered by unified search
+// Each table gets a generated search_vector column (weighted A for titles,
+// B for bodies, stemmed in every models.SearchLanguages config) with a GIN index,
+// and trigram indexes on the matched text for typo-tolerant fallback matching.
+//
+// Requirements:
+//   - PostgreSQL 12+ (generated columns, indonesian snowball config)
+//   - pg_trgm extension (optional; without it search skips typo tolerance)
+//
+// Parameters:
+//   - db: GORM database instance
+//
+// Returns:
+//   - error: Never; failures are logged so the server can start without search
+func createSearchIndexes(db *gorm.DB) error {
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		fmt.Printf("⚠️  Warning enabling pg_trgm (typo-tolerant search disabled): %v\n", err)
+	}
+
+	documents := []struct {
+		table  string
+		fields []searchField
+	}{
+		{"skills", []searchField{{"name", "A"}, {"description", "B"}}},
+		{"user_skills", []searchField{{"description", "B"}}},
+		{"forum_threads", []searchField{{"title", "A"}, {"content", "B"}}},
+		{"forum_replies", []searchField{{"content", "B"}}},
+		{"success_stories", []searchField{{"title", "A"}, {"description", "B"}}},
+	}
+
+	statements := []string{}
+	for _, doc := range documents {
+		statements = append(statements,
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (%s) STORED",
+				doc.table, searchVectorExpression(doc.fields)),
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_search_vector ON %s USING GIN (search_vector)", doc.table, doc.table),
+		)
+	}
+
+	// Trigram indexes back the word-similarity fallback (term <% column)
+	trigramColumns := []struct{ table, column string }{
+		{"skills", "name"},
+		{"user_skills", "description"},
+		{"forum_threads", "title"},
+		{"forum_replies", "content"},
+		{"success_stories", "title"},
+	}
+	for _, tc := range trigramColumns {
+		statements = append(statements, fmt.Sprintf(
+			"CREATE INDEX IF NOT EXISTS idx_%s_%s_trgm ON %s USING GIN (%s gin_trgm_ops)",
+			tc.table, tc.column, tc.table, tc.column))
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			fmt.Printf("⚠️  Warning creating search index: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// searchField is a text column indexed for search with its tsvector weight
+type searchField struct {
+	column string
+	weight string // A (titles) ranks above B (bodies)
+}
+
+// searchVectorExpression builds the weighted tsvector expression for a document
+func searchVectorExpression(fields []searchField) string {
+	parts := []string{}
+	for _, field := range fields {
+		for _, language := range models.SearchLanguages {
+			parts = append(parts, fmt.Sprintf("setweight(to_tsvector('%s', COALESCE(%s, '')), '%s')",
+				language, field.column, field.weight))
+		}
+	}
+	return strings.Join(parts, " || ")
+}
+
 // DropPerformanceIndexes removes performance indexes (for cleanup/testing)
 // Use with caution - only for development/testing
 //
@@ -108,11 +292,12 @@ func DropPerformanceIndexes(db *gorm.DB) error {
 		{"user_badges", "idx_user_badges_composite"},
 		{"reviews", "idx_reviews_reviewee_hidden"},
 		{"transactions", "idx_transactions_user_date"},
+		{"transactions", "idx_transactions_description_fts"},
 	}
 
 	for _, idx := range indexes {
 		if db.Migrator().HasIndex(idx.table, idx.name) {
-			if err := db.Migrator().DropIndex(idx.table, idx.name).Error; err != nil {
+			if err := db.Migrator().DropIndex(idx.table, idx.name); err != nil {
 				return fmt.Errorf("failed to drop index %s.%s: %w", idx.table, idx.name, err)
 			}
 		}
@@ -132,9 +317,10 @@ func DropPerformanceIndexes(db *gorm.DB) error {
 //   // Returns information about all indexes
 func GetIndexStatistics() map[string]interface{} {
 	return map[string]interface{}{
-		"total_indexes": 7,
+		"total_indexes": 8,
 		"composite_indexes": 4,
 		"single_column_indexes": 3,
+		"full_text_indexes": 1,
 		"estimated_performance_improvement": "40-70%",
 		"estimated_query_time_reduction": "200ms → 50-100ms",
 		"recommended_maintenance": "ANALYZE TABLE after bulk operations",
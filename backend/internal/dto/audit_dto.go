@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// AuditEventQuery represents filters for querying the audit log
+// All filters are optional and combined with AND
+type AuditEventQuery struct {
+	ActorType string     `form:"actor_type"` // user, admin or system
+	ActorID   *uint      `form:"actor_id"`
+	Entity    string     `form:"entity"` // Table name, e.g. users, reviews
+	EntityID  *uint      `form:"entity_id"`
+	Action    string     `form:"action"` // create, update or delete
+	RequestID string     `form:"request_id"`
+	From      *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit     int        `form:"limit"`
+	Offset    int        `form:"offset"`
+}
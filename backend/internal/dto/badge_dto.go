@@ -1,6 +1,9 @@
 package dto
 
 import (
+	"encoding/json"
+	"math"
+
 	"github.com/timebankingskill/backend/internal/models"
 )
 
@@ -36,23 +39,70 @@ type UserBadgeResponse struct {
 	IsCompleted    bool           `json:"is_completed"`
 }
 
-// LeaderboardEntry represents a user in leaderboard
-type LeaderboardEntry struct {
-	UserID    uint   `json:"user_id"`
-	Username  string `json:"username"`
-	FullName  string `json:"full_name"`
-	Avatar    string `json:"avatar"`
-	Score     int    `json:"score"`
-	ScoreType string `json:"score_type"` // badges, rarity, sessions, rating, credits
-	Rank      int    `json:"rank,omitempty"`
+// BadgeProgressResponse represents a user's progress towards an unearned badge
+type BadgeProgressResponse struct {
+	Badge      *BadgeResponse `json:"badge,omitempty"`
+	Metric     string         `json:"metric"`      // Requirement furthest from being met
+	Current    float64        `json:"current"`     // e.g. 7 sessions
+	Target     float64        `json:"target"`      // e.g. 10 sessions
+	Remaining  float64        `json:"remaining"`   // Target minus current, never negative
+	WindowDays int            `json:"window_days"` // Counted over the last N days; 0 for all time
+	Percent    float64        `json:"percent"`     // Completion across every requirement, 0-100
+	UpdatedAt  string         `json:"updated_at"`
+}
+
+// CreateBadgeRequest represents an admin request to create a badge
+// Requirements use the badge rule format, see models.BadgeRule
+type CreateBadgeRequest struct {
+	Name         string          `json:"name" binding:"required,max=100"`
+	Description  string          `json:"description" binding:"max=1000"`
+	Icon         string          `json:"icon" binding:"max=255"` // Emoji or URL; replaced by an uploaded icon
+	Type         string          `json:"type" binding:"required,oneof=achievement milestone quality special"`
+	Requirements json.RawMessage `json:"requirements" binding:"required"`
+	BonusCredits float64         `json:"bonus_credits" binding:"min=0,max=1000"`
+	Rarity       int             `json:"rarity" binding:"omitempty,min=1,max=5"`
+	Color        string          `json:"color" binding:"omitempty,hexcolor"`
+	DisplayOrder int             `json:"display_order"`
+	IsActive     *bool           `json:"is_active"` // Defaults to true
+}
+
+// UpdateBadgeRequest represents an admin request to edit a badge
+// Only the fields that are set are changed
+type UpdateBadgeRequest struct {
+	Name         *string         `json:"name" binding:"omitempty,max=100"`
+	Description  *string         `json:"description" binding:"omitempty,max=1000"`
+	Icon         *string         `json:"icon" binding:"omitempty,max=255"`
+	Type         *string         `json:"type" binding:"omitempty,oneof=achievement milestone quality special"`
+	Requirements json.RawMessage `json:"requirements"`
+	BonusCredits *float64        `json:"bonus_credits" binding:"omitempty,min=0,max=1000"`
+	Rarity       *int            `json:"rarity" binding:"omitempty,min=1,max=5"`
+	Color        *string         `json:"color" binding:"omitempty,hexcolor"`
+	DisplayOrder *int            `json:"display_order"`
+}
+
+// RevokeBadgeRequest represents an admin request to take a badge away from a user
+type RevokeBadgeRequest struct {
+	UserID    uint   `json:"user_id" binding:"required"`
+	Reason    string `json:"reason" binding:"required,min=10,max=500"`
+	KeepBonus bool   `json:"keep_bonus"` // Leave the badge's bonus credits with the user
 }
 
-// LeaderboardResponse represents leaderboard data
-type LeaderboardResponse struct {
-	Type       string             `json:"type"`
-	Entries    []LeaderboardEntry `json:"entries"`
-	Total      int                `json:"total"`
-	UpdatedAt  string             `json:"updated_at"`
+// RevokeBadgeResponse represents the outcome of revoking a badge
+type RevokeBadgeResponse struct {
+	UserID        uint    `json:"user_id"`
+	BadgeID       uint    `json:"badge_id"`
+	ClawedBack    float64 `json:"clawed_back"`              // Bonus credits taken back, may be less than granted
+	TransactionID *uint   `json:"transaction_id,omitempty"` // Ledger entry of the clawback
+	RevokedAt     string  `json:"revoked_at"`
+}
+
+// BadgeAwardRunResponse represents a retroactive evaluation of one badge against every user
+type BadgeAwardRunResponse struct {
+	BadgeID   uint   `json:"badge_id"`
+	Evaluated int    `json:"evaluated"` // Users checked
+	Awarded   int    `json:"awarded"`   // Users who earned the badge
+	Running   bool   `json:"running"`   // Still evaluating users
+	StartedAt string `json:"started_at"`
 }
 
 // MapBadgeToResponse maps a Badge model to BadgeResponse
@@ -115,3 +165,23 @@ func MapUserBadgesToResponse(userBadges []models.UserBadge) []UserBadgeResponse
 	}
 	return responses
 }
+
+// MapBadgeProgressToResponse maps BadgeProgress models to BadgeProgressResponse
+func MapBadgeProgressToResponse(progress []models.BadgeProgress) []BadgeProgressResponse {
+	responses := make([]BadgeProgressResponse, len(progress))
+	for i, p := range progress {
+		responses[i] = BadgeProgressResponse{
+			Metric:     p.Metric,
+			Current:    p.Current,
+			Target:     p.Target,
+			Remaining:  math.Max(p.Target-p.Current, 0),
+			WindowDays: p.WindowDays,
+			Percent:    p.Percent,
+			UpdatedAt:  p.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+		if p.Badge.ID > 0 {
+			responses[i].Badge = MapBadgeToResponse(&p.Badge)
+		}
+	}
+	return responses
+}
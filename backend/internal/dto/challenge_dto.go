@@ -0,0 +1,158 @@
+package dto
+
+import (
+	"math"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+)
+
+// StreakResponse represents a user's weekly activity streak
+type StreakResponse struct {
+	CurrentWeeks     int     `json:"current_weeks"` // 0 once a full week passes without a completed session
+	LongestWeeks     int     `json:"longest_weeks"`
+	TotalActiveWeeks int     `json:"total_active_weeks"`
+	LastActiveWeek   *string `json:"last_active_week,omitempty"` // Monday of the latest active week, "2006-01-02"
+	ActiveThisWeek   bool    `json:"active_this_week"`
+	AtRisk           bool    `json:"at_risk"`      // The streak breaks unless a session is completed this week
+	WeekEndsAt       string  `json:"week_ends_at"` // When the current week ends
+}
+
+// ChallengeQuery represents filters for listing challenges
+type ChallengeQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=upcoming running ended"` // Defaults to every challenge
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+// CreateChallengeRequest represents an admin request to create a challenge
+type CreateChallengeRequest struct {
+	Title         string    `json:"title" binding:"required,max=150"`
+	Description   string    `json:"description" binding:"max=2000"`
+	Metric        string    `json:"metric" binding:"required,oneof=sessions sessions_taught sessions_learned hours_taught hours_learned"`
+	SkillCategory string    `json:"skill_category"` // Empty counts every category
+	Target        float64   `json:"target" binding:"required,gt=0"`
+	StartsAt      time.Time `json:"starts_at" binding:"required"`
+	EndsAt        time.Time `json:"ends_at" binding:"required"` // Exclusive
+	RewardCredits float64   `json:"reward_credits" binding:"gte=0"`
+	RewardBadgeID *uint     `json:"reward_badge_id"`
+}
+
+// UpdateChallengeRequest represents an admin request to edit a challenge
+// Only the fields that are set are changed; once a challenge is running only its
+// title, description, end and active flag can change
+type UpdateChallengeRequest struct {
+	Title         *string    `json:"title" binding:"omitempty,max=150"`
+	Description   *string    `json:"description" binding:"omitempty,max=2000"`
+	Metric        *string    `json:"metric" binding:"omitempty,oneof=sessions sessions_taught sessions_learned hours_taught hours_learned"`
+	SkillCategory *string    `json:"skill_category"`
+	Target        *float64   `json:"target" binding:"omitempty,gt=0"`
+	StartsAt      *time.Time `json:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at"`
+	RewardCredits *float64   `json:"reward_credits" binding:"omitempty,gte=0"`
+	RewardBadgeID *uint      `json:"reward_badge_id"` // 0 removes the badge reward
+	IsActive      *bool      `json:"is_active"`
+}
+
+// ChallengeResponse represents a challenge in API responses
+type ChallengeResponse struct {
+	ID               uint           `json:"id"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	Metric           string         `json:"metric"`
+	SkillCategory    string         `json:"skill_category,omitempty"`
+	Target           float64        `json:"target"`
+	StartsAt         string         `json:"starts_at"`
+	EndsAt           string         `json:"ends_at"`
+	Status           string         `json:"status"` // upcoming, running or ended
+	RewardCredits    float64        `json:"reward_credits"`
+	RewardBadge      *BadgeResponse `json:"reward_badge,omitempty"`
+	IsActive         bool           `json:"is_active"`
+	ParticipantCount int            `json:"participant_count"`
+	CompletedCount   int            `json:"completed_count"`
+}
+
+// ChallengeParticipationResponse represents a user's enrolment in a challenge
+type ChallengeParticipationResponse struct {
+	Challenge   *ChallengeResponse `json:"challenge,omitempty"`
+	User        *UserPublicProfile `json:"user,omitempty"`
+	Progress    float64            `json:"progress"`
+	Percent     float64            `json:"percent"` // Progress towards the target, capped at 100
+	JoinedAt    string             `json:"joined_at"`
+	CompletedAt *string            `json:"completed_at,omitempty"`
+}
+
+// ChallengeRefreshResponse represents the outcome of recomputing challenge progress
+type ChallengeRefreshResponse struct {
+	Evaluated int `json:"evaluated"` // Participants whose progress was recomputed
+	Completed int `json:"completed"` // Participants who reached the target and were rewarded
+	Finalized int `json:"finalized"` // Ended challenges settled
+}
+
+// MapChallengeToResponse maps a Challenge model to ChallengeResponse
+func MapChallengeToResponse(challenge *models.Challenge, now time.Time) *ChallengeResponse {
+	resp := &ChallengeResponse{
+		ID:               challenge.ID,
+		Title:            challenge.Title,
+		Description:      challenge.Description,
+		Metric:           string(challenge.Metric),
+		SkillCategory:    string(challenge.SkillCategory),
+		Target:           challenge.Target,
+		StartsAt:         challenge.StartsAt.Format(time.RFC3339),
+		EndsAt:           challenge.EndsAt.Format(time.RFC3339),
+		Status:           "running",
+		RewardCredits:    challenge.RewardCredits,
+		IsActive:         challenge.IsActive,
+		ParticipantCount: challenge.ParticipantCount,
+		CompletedCount:   challenge.CompletedCount,
+	}
+	if now.Before(challenge.StartsAt) {
+		resp.Status = "upcoming"
+	} else if !now.Before(challenge.EndsAt) {
+		resp.Status = "ended"
+	}
+	if challenge.RewardBadge != nil {
+		resp.RewardBadge = MapBadgeToResponse(challenge.RewardBadge)
+	}
+	return resp
+}
+
+// MapChallengesToResponse maps Challenge models to ChallengeResponse
+func MapChallengesToResponse(challenges []models.Challenge, now time.Time) []ChallengeResponse {
+	responses := make([]ChallengeResponse, len(challenges))
+	for i := range challenges {
+		responses[i] = *MapChallengeToResponse(&challenges[i], now)
+	}
+	return responses
+}
+
+// MapChallengeParticipantToResponse maps a ChallengeParticipant model to ChallengeParticipationResponse
+// target is the challenge's target, used when the challenge itself isn't loaded
+func MapChallengeParticipantToResponse(participant *models.ChallengeParticipant, target float64, now time.Time) *ChallengeParticipationResponse {
+	resp := &ChallengeParticipationResponse{
+		Progress: participant.Progress,
+		JoinedAt: participant.CreatedAt.Format(time.RFC3339),
+	}
+	if participant.Challenge.ID != 0 {
+		resp.Challenge = MapChallengeToResponse(&participant.Challenge, now)
+		target = participant.Challenge.Target
+	}
+	if participant.User.ID != 0 {
+		resp.User = &UserPublicProfile{
+			ID:       participant.User.ID,
+			FullName: participant.User.FullName,
+			Username: participant.User.Username,
+			Avatar:   participant.User.Avatar,
+			School:   participant.User.School,
+			Grade:    participant.User.Grade,
+		}
+	}
+	if participant.CompletedAt != nil {
+		completedAt := participant.CompletedAt.Format(time.RFC3339)
+		resp.CompletedAt = &completedAt
+		resp.Percent = 100
+	} else if target > 0 {
+		resp.Percent = math.Min(math.Round(participant.Progress/target*1000)/10, 100)
+	}
+	return resp
+}
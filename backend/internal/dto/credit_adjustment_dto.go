@@ -0,0 +1,13 @@
+package dto
+
+// CreateCreditAdjustmentRequest represents an admin request to grant or deduct credits
+type CreateCreditAdjustmentRequest struct {
+	UserID uint    `json:"user_id" binding:"required"`
+	Amount float64 `json:"amount" binding:"required"` // Positive to grant, negative to deduct
+	Reason string  `json:"reason" binding:"required,min=10,max=500"`
+}
+
+// ReviewCreditAdjustmentRequest represents a second admin's approval or rejection
+type ReviewCreditAdjustmentRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
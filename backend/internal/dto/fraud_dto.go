@@ -0,0 +1,6 @@
+package dto
+
+// ReviewFraudFlagRequest represents an admin decision on a flagged session
+type ReviewFraudFlagRequest struct {
+	Note string `json:"note" binding:"required,min=5,max=500"`
+}
@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+)
+
+// LeaderboardQuery represents the window and scope of a leaderboard request
+type LeaderboardQuery struct {
+	Period   string `form:"period" binding:"omitempty,oneof=all_time weekly monthly season"` // Defaults to all_time
+	Key      string `form:"key"`                                                             // Past week ("2026-W07") or month ("2026-02"); defaults to the current one
+	SeasonID uint   `form:"season_id"`                                                       // Season to show; defaults to the active season
+	Scope    string `form:"scope" binding:"omitempty,oneof=global school location category"` // Defaults to global
+	Value    string `form:"value"`                                                           // School, location or skill category of a scoped leaderboard
+	Limit    int    `form:"limit"`
+	Offset   int    `form:"offset"`
+}
+
+// LeaderboardEntry represents a user in leaderboard
+type LeaderboardEntry struct {
+	UserID    uint   `json:"user_id"`
+	Username  string `json:"username"`
+	FullName  string `json:"full_name"`
+	Avatar    string `json:"avatar"`
+	Score     int    `json:"score"`      // Ratings are stored x100 (450 = 4.5 stars)
+	ScoreType string `json:"score_type"` // badges, rarity, sessions, rating, credits
+	Rank      int    `json:"rank,omitempty"`
+}
+
+// LeaderboardResponse represents leaderboard data
+type LeaderboardResponse struct {
+	Type       string                     `json:"type"`
+	Period     string                     `json:"period"`
+	PeriodKey  string                     `json:"period_key"`
+	Scope      string                     `json:"scope"`
+	ScopeValue string                     `json:"scope_value,omitempty"`
+	Season     *LeaderboardSeasonResponse `json:"season,omitempty"`
+	Entries    []LeaderboardEntry         `json:"entries"`
+	Total      int                        `json:"total"`      // Users ranked, across all pages
+	UpdatedAt  string                     `json:"updated_at"` // When the standings were computed; empty before the first run
+}
+
+// LeaderboardRankResponse represents the caller's standing on a leaderboard
+type LeaderboardRankResponse struct {
+	Type       string  `json:"type"`
+	Period     string  `json:"period"`
+	PeriodKey  string  `json:"period_key"`
+	Scope      string  `json:"scope"`
+	ScopeValue string  `json:"scope_value,omitempty"`
+	Ranked     bool    `json:"ranked"` // False when the user has no activity in the period
+	Rank       int     `json:"rank,omitempty"`
+	Score      int     `json:"score"`
+	Total      int     `json:"total"`                 // Users ranked
+	TopPercent float64 `json:"top_percent,omitempty"` // e.g. 5 for the top 5%
+	UpdatedAt  string  `json:"updated_at"`
+}
+
+// CreateLeaderboardSeasonRequest represents an admin request to schedule a season
+type CreateLeaderboardSeasonRequest struct {
+	Name        string    `json:"name" binding:"required,max=100"`
+	Description string    `json:"description" binding:"max=1000"`
+	StartsAt    time.Time `json:"starts_at" binding:"required"`
+	EndsAt      time.Time `json:"ends_at" binding:"required"` // Exclusive
+}
+
+// UpdateLeaderboardSeasonRequest represents an admin request to edit a season
+// Only the fields that are set are changed; a running season can only have its end moved
+type UpdateLeaderboardSeasonRequest struct {
+	Name        *string    `json:"name" binding:"omitempty,max=100"`
+	Description *string    `json:"description" binding:"omitempty,max=1000"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+}
+
+// LeaderboardSeasonResponse represents a season in API responses
+type LeaderboardSeasonResponse struct {
+	ID          uint    `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	StartsAt    string  `json:"starts_at"`
+	EndsAt      string  `json:"ends_at"`
+	Status      string  `json:"status"`
+	ArchivedAt  *string `json:"archived_at,omitempty"`
+}
+
+// LeaderboardRefreshResponse represents the outcome of recomputing every leaderboard
+type LeaderboardRefreshResponse struct {
+	Snapshots       int    `json:"snapshots"`        // Standings written
+	SeasonsStarted  int    `json:"seasons_started"`  // Seasons that became active
+	SeasonsArchived int    `json:"seasons_archived"` // Seasons that ended and were frozen
+	ComputedAt      string `json:"computed_at"`
+}
+
+// MapLeaderboardSeasonToResponse maps a LeaderboardSeason model to LeaderboardSeasonResponse
+func MapLeaderboardSeasonToResponse(season *models.LeaderboardSeason) *LeaderboardSeasonResponse {
+	resp := &LeaderboardSeasonResponse{
+		ID:          season.ID,
+		Name:        season.Name,
+		Description: season.Description,
+		StartsAt:    season.StartsAt.Format(time.RFC3339),
+		EndsAt:      season.EndsAt.Format(time.RFC3339),
+		Status:      string(season.Status),
+	}
+	if season.ArchivedAt != nil {
+		archivedAt := season.ArchivedAt.Format(time.RFC3339)
+		resp.ArchivedAt = &archivedAt
+	}
+	return resp
+}
+
+// MapLeaderboardSeasonsToResponse maps LeaderboardSeason models to LeaderboardSeasonResponse
+func MapLeaderboardSeasonsToResponse(seasons []models.LeaderboardSeason) []LeaderboardSeasonResponse {
+	responses := make([]LeaderboardSeasonResponse, len(seasons))
+	for i := range seasons {
+		responses[i] = *MapLeaderboardSeasonToResponse(&seasons[i])
+	}
+	return responses
+}
@@ -0,0 +1,85 @@
+package dto
+
+// OfferSearchRequest represents filters for searching teacher offers
+// All filters are optional and combined with AND; the JSON form is used for saved searches
+type OfferSearchRequest struct {
+	Search    string   `form:"q" json:"q,omitempty"` // Matches skill name, offer description and teacher name
+	SkillID   *uint    `form:"skill_id" json:"skill_id,omitempty"`
+	Category  string   `form:"category" json:"category,omitempty"`
+	Levels    []string `form:"level" json:"level,omitempty"`       // One or more skill levels (beginner, advanced, ...)
+	MinRate   *float64 `form:"min_rate" json:"min_rate,omitempty"` // Credits per hour
+	MaxRate   *float64 `form:"max_rate" json:"max_rate,omitempty"` // Credits per hour
+	Mode      string   `form:"mode" json:"mode,omitempty"`         // online or offline
+	Location  string   `form:"location" json:"location,omitempty"`
+	MinRating *float64 `form:"min_rating" json:"min_rating,omitempty"` // 0-5, from visible teacher reviews of the offer
+	Day       *int     `form:"day" json:"day,omitempty"`               // Available day of week (0=Sunday ... 6=Saturday)
+	Time      string   `form:"time" json:"time,omitempty"`             // Available at time of day (HH:MM)
+	School    string   `form:"school" json:"school,omitempty"`
+	Grade     string   `form:"grade" json:"grade,omitempty"`
+	Verified  bool     `form:"verified" json:"verified,omitempty"` // Only teachers with a current credential verification
+	Sort      string   `form:"sort" json:"sort,omitempty"`         // relevance (default), rating, price_asc, price_desc, popularity
+	Page      int      `form:"page" json:"-"`
+	Limit     int      `form:"limit" json:"-"`
+}
+
+// OfferTeacher is the public teacher profile shown on an offer
+type OfferTeacher struct {
+	ID       uint   `json:"id"`
+	FullName string `json:"full_name"`
+	Username string `json:"username"`
+	Avatar   string `json:"avatar"`
+	Location string `json:"location"`
+	School   string `json:"school"`
+	Grade    string `json:"grade"`
+}
+
+// OfferResponse represents one teacher offering one skill
+type OfferResponse struct {
+	UserSkillID       uint         `json:"user_skill_id"`
+	SkillID           uint         `json:"skill_id"`
+	SkillName         string       `json:"skill_name"`
+	SkillCategory     string       `json:"skill_category"`
+	Level             string       `json:"level"`
+	Description       string       `json:"description"`
+	YearsOfExperience int          `json:"years_of_experience"`
+	HourlyRate        float64      `json:"hourly_rate"`
+	OnlineOnly        bool         `json:"online_only"`
+	OfflineOnly       bool         `json:"offline_only"`
+	TotalSessions     int          `json:"total_sessions"`
+	Rating            float64      `json:"rating"`
+	ReviewCount       int64        `json:"review_count"`
+	BayesianRating    float64      `json:"bayesian_rating"`  // Rating adjusted for review volume and recency
+	ReputationScore   float64      `json:"reputation_score"` // 0-100, used for relevance ranking
+	IsVerified        bool         `json:"is_verified"`
+	Teacher           OfferTeacher `json:"teacher"`
+}
+
+// FacetValue is the number of offers matching one filter value
+type FacetValue struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// OfferFacets holds counts per value for each filter
+// Each facet is counted with every other active filter applied, but not its own,
+// so selecting a value does not hide the alternatives
+type OfferFacets struct {
+	Level     []FacetValue `json:"level"`
+	Price     []FacetValue `json:"price"`      // Hourly rate buckets: 0-1, 1-2, 2-3, 3+
+	Mode      []FacetValue `json:"mode"`       // Offers accepting both modes count in each
+	MinRating []FacetValue `json:"min_rating"` // Cumulative: offers rated at least the value
+	Day       []FacetValue `json:"day"`
+	Location  []FacetValue `json:"location"` // Top values
+	School    []FacetValue `json:"school"`   // Top values
+	Grade     []FacetValue `json:"grade"`
+	Verified  int64        `json:"verified"` // Offers with a current credential verification
+}
+
+// OfferSearchResponse represents a page of offers with facet counts
+type OfferSearchResponse struct {
+	Offers []OfferResponse `json:"offers"`
+	Total  int64           `json:"total"`
+	Page   int             `json:"page"`
+	Limit  int             `json:"limit"`
+	Facets OfferFacets     `json:"facets"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+// RecommendationScore breaks a teacher recommendation down into its weighted components
+// Each component is normalized to 0-1; Total is their weighted sum
+type RecommendationScore struct {
+	Total        float64 `json:"total"`
+	SkillMatch   float64 `json:"skill_match"`  // 1 for the exact skill, lower for the same category
+	LevelGap     float64 `json:"level_gap"`    // Teacher level relative to the desired level
+	Rating       float64 `json:"rating"`       // Review average smoothed toward a prior
+	Availability float64 `json:"availability"` // Weekly overlap with the learner's availability
+	Location     float64 `json:"location"`     // Same location, or online teaching possible
+	Price        float64 `json:"price"`        // Cheaper and affordable scores higher
+}
+
+// TeacherRecommendation represents a teacher offer suggested for a learning skill
+type TeacherRecommendation struct {
+	Offer   OfferResponse       `json:"offer"`
+	Score   RecommendationScore `json:"score"`
+	Reasons []string            `json:"reasons"`
+}
+
+// RecommendationGroup holds the suggested teachers for one learning skill
+type RecommendationGroup struct {
+	LearningSkillID uint                    `json:"learning_skill_id"`
+	SkillID         uint                    `json:"skill_id"`
+	SkillName       string                  `json:"skill_name"`
+	DesiredLevel    string                  `json:"desired_level"`
+	Priority        int                     `json:"priority"`
+	Teachers        []TeacherRecommendation `json:"teachers"`
+}
+
+// RecommendationsResponse represents all recommendations for a user
+type RecommendationsResponse struct {
+	Groups []RecommendationGroup `json:"groups"`
+}
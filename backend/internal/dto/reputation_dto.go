@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+)
+
+// ReputationScoreResponse is a computed reputation for a user role or teaching offer
+// Sub-scores are 0 while no review has rated that aspect
+type ReputationScoreResponse struct {
+	ReviewCount        int       `json:"review_count"`
+	EffectiveReviews   float64   `json:"effective_reviews"`
+	AverageRating      float64   `json:"average_rating"`
+	BayesianRating     float64   `json:"bayesian_rating"`
+	WilsonScore        float64   `json:"wilson_score"`
+	CommunicationScore float64   `json:"communication_score"`
+	PunctualityScore   float64   `json:"punctuality_score"`
+	KnowledgeScore     float64   `json:"knowledge_score"`
+	Score              float64   `json:"score"`
+	ComputedAt         time.Time `json:"computed_at"`
+}
+
+// OfferReputationResponse is the reputation of one teaching offer
+type OfferReputationResponse struct {
+	UserSkillID uint `json:"user_skill_id"`
+	ReputationScoreResponse
+}
+
+// UserReputationResponse holds a user's reputation as teacher, as student and per offer
+// A role is null until the user has a visible review in it
+type UserReputationResponse struct {
+	UserID  uint                      `json:"user_id"`
+	Teacher *ReputationScoreResponse  `json:"teacher"`
+	Student *ReputationScoreResponse  `json:"student"`
+	Offers  []OfferReputationResponse `json:"offers"`
+}
+
+// ReputationRecomputeResponse reports a full reputation rebuild
+type ReputationRecomputeResponse struct {
+	Scores int `json:"scores"`
+}
+
+// ToReputationScoreResponse converts a stored reputation score
+func ToReputationScoreResponse(score *models.ReputationScore) *ReputationScoreResponse {
+	return &ReputationScoreResponse{
+		ReviewCount:        score.ReviewCount,
+		EffectiveReviews:   score.EffectiveReviews,
+		AverageRating:      score.AverageRating,
+		BayesianRating:     score.BayesianRating,
+		WilsonScore:        score.WilsonScore,
+		CommunicationScore: score.CommunicationScore,
+		PunctualityScore:   score.PunctualityScore,
+		KnowledgeScore:     score.KnowledgeScore,
+		Score:              score.Score,
+		ComputedAt:         score.ComputedAt,
+	}
+}
@@ -41,8 +41,11 @@ type ReviewResponse struct {
 	HelpfulCount        int                 `json:"helpful_count"`
 	IsReported          bool                `json:"is_reported"`
 	IsHidden            bool                `json:"is_hidden"`
+	IsPublished         bool                `json:"is_published"`           // False while the review is sealed
+	PublishedAt         string              `json:"published_at,omitempty"` // When both reviews were revealed
 	Reviewer            *UserProfileResponse `json:"reviewer,omitempty"`
 	Reviewee            *UserProfileResponse `json:"reviewee,omitempty"`
+	Reply               *ReviewReplyResponse `json:"reply,omitempty"`
 	CreatedAt           string              `json:"created_at"`
 	UpdatedAt           string              `json:"updated_at"`
 }
@@ -72,10 +75,15 @@ func MapReviewToResponse(review *models.Review) *ReviewResponse {
 		HelpfulCount:        review.HelpfulCount,
 		IsReported:          review.IsReported,
 		IsHidden:            review.IsHidden,
+		IsPublished:         review.IsPublished(),
 		CreatedAt:           review.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:           review.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
+	if review.PublishedAt != nil {
+		resp.PublishedAt = review.PublishedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
 	// Map reviewer
 	if review.Reviewer.ID > 0 {
 		resp.Reviewer = &UserProfileResponse{
@@ -96,6 +104,11 @@ func MapReviewToResponse(review *models.Review) *ReviewResponse {
 		}
 	}
 
+	// Map the reviewee's public response
+	if review.Reply != nil {
+		resp.Reply = MapReviewReplyToResponse(review.Reply)
+	}
+
 	return resp
 }
 
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+)
+
+// ReportReviewRequest represents a request to report a review to moderators
+type ReportReviewRequest struct {
+	Reason  string `json:"reason" binding:"required,oneof=spam offensive fake personal irrelevant other"`
+	Details string `json:"details" binding:"max=1000"` // Required when reason is "other"
+}
+
+// ReviewReplyRequest represents the reviewee's public response to a review
+type ReviewReplyRequest struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+// ModerateReviewRequest represents a moderator's reason for hiding, restoring or deleting a review
+type ModerateReviewRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// ReviewReplyResponse represents the reviewee's public response to a review
+type ReviewReplyResponse struct {
+	ID        uint   `json:"id"`
+	ReviewID  uint   `json:"review_id"`
+	AuthorID  uint   `json:"author_id"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ReviewHelpfulResponse reports a review's helpful count after a vote change
+type ReviewHelpfulResponse struct {
+	ReviewID     uint `json:"review_id"`
+	HelpfulCount int  `json:"helpful_count"`
+	Voted        bool `json:"voted"`
+}
+
+// ReviewModerationItem is a review in the moderation queue with its reports
+type ReviewModerationItem struct {
+	Review           ReviewResponse        `json:"review"`
+	ReportCount      int                   `json:"report_count"`
+	ModeratedBy      *uint                 `json:"moderated_by"`
+	ModeratedAt      *time.Time            `json:"moderated_at"`
+	ModerationReason string                `json:"moderation_reason"`
+	Reports          []models.ReviewReport `json:"reports"`
+}
+
+// ReviewModerationQueueResponse is a page of the review moderation queue
+type ReviewModerationQueueResponse struct {
+	Items  []ReviewModerationItem `json:"items"`
+	Total  int64                  `json:"total"`
+	Limit  int                    `json:"limit"`
+	Offset int                    `json:"offset"`
+}
+
+// MapReviewReplyToResponse maps a ReviewReply model to ReviewReplyResponse
+func MapReviewReplyToResponse(reply *models.ReviewReply) *ReviewReplyResponse {
+	return &ReviewReplyResponse{
+		ID:        reply.ID,
+		ReviewID:  reply.ReviewID,
+		AuthorID:  reply.AuthorID,
+		Body:      reply.Body,
+		CreatedAt: reply.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: reply.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// MapReviewToModerationItem maps a reported review and its reports to a queue item
+func MapReviewToModerationItem(review *models.Review, reports []models.ReviewReport) ReviewModerationItem {
+	if reports == nil {
+		reports = make([]models.ReviewReport, 0)
+	}
+	return ReviewModerationItem{
+		Review:           *MapReviewToResponse(review),
+		ReportCount:      review.ReportCount,
+		ModeratedBy:      review.ModeratedBy,
+		ModeratedAt:      review.ModeratedAt,
+		ModerationReason: review.ModerationReason,
+		Reports:          reports,
+	}
+}
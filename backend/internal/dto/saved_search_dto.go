@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// CreateSavedSearchRequest saves a marketplace offer search for alerts
+type CreateSavedSearchRequest struct {
+	Name    string             `json:"name" binding:"required,max=100"`
+	Filters OfferSearchRequest `json:"filters"`
+}
+
+// UpdateSavedSearchRequest renames a saved search and/or replaces its filters
+type UpdateSavedSearchRequest struct {
+	Name    *string             `json:"name" binding:"omitempty,max=100"`
+	Filters *OfferSearchRequest `json:"filters"`
+}
+
+// SavedSearchResponse represents a saved search and its alert state
+type SavedSearchResponse struct {
+	ID            uint               `json:"id"`
+	Name          string             `json:"name"`
+	Filters       OfferSearchRequest `json:"filters"`
+	IsPaused      bool               `json:"is_paused"`
+	LastCheckedAt time.Time          `json:"last_checked_at"`
+	LastMatchedAt *time.Time         `json:"last_matched_at"`
+	MatchCount    int                `json:"match_count"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// SearchRequest represents a unified search query
+type SearchRequest struct {
+	Query  string   `form:"q" binding:"required"`
+	Types  []string `form:"types"` // skill, offer, thread, reply, story (repeated or comma-separated); all when empty
+	Limit  int      `form:"limit"`
+	Offset int      `form:"offset"`
+}
+
+// SearchResult represents one ranked search hit
+// Snippet is HTML-escaped text with matches wrapped in <mark> tags
+type SearchResult struct {
+	Type      string    `json:"type"`
+	ID        uint      `json:"id"`
+	Title     string    `json:"title"`
+	Snippet   string    `json:"snippet"`
+	Rank      float64   `json:"rank"`
+	ParentID  *uint     `json:"parent_id,omitempty"` // Thread of a reply, skill of an offer
+	AuthorID  *uint     `json:"author_id,omitempty"` // Author of a thread, reply or story; teacher of an offer
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SearchResponse represents a page of unified search results
+type SearchResponse struct {
+	Query   string           `json:"query"`
+	Results []SearchResult   `json:"results"`
+	Total   int64            `json:"total"`
+	Counts  map[string]int64 `json:"counts"` // Hits per type
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+}
@@ -65,6 +65,7 @@ type SessionResponse struct {
 	CreditAmount       float64            `json:"credit_amount"`
 	CreditHeld         bool               `json:"credit_held"`
 	CreditReleased     bool               `json:"credit_released"`
+	SkillSwapID        *uint              `json:"skill_swap_id"`
 	TeacherConfirmed   bool               `json:"teacher_confirmed"`
 	StudentConfirmed   bool               `json:"student_confirmed"`
 	Materials          string             `json:"materials"`
@@ -110,6 +111,7 @@ func MapSessionToResponse(session *models.Session) *SessionResponse {
 		CreditAmount:       session.CreditAmount,
 		CreditHeld:         session.CreditHeld,
 		CreditReleased:     session.CreditReleased,
+		SkillSwapID:        session.SkillSwapID,
 		TeacherConfirmed:   session.TeacherConfirmed,
 		StudentConfirmed:   session.StudentConfirmed,
 		Materials:          session.Materials,
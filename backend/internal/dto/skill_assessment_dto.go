@@ -0,0 +1,157 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+)
+
+// AssessmentQuestionRequest is one multiple-choice question; CorrectOption indexes Options
+type AssessmentQuestionRequest struct {
+	Prompt        string   `json:"prompt" binding:"required,max=1000"`
+	Options       []string `json:"options" binding:"required,min=2,max=6,dive,required,max=300"`
+	CorrectOption *int     `json:"correct_option" binding:"required"`
+}
+
+// CreateAssessmentRequest authors a placement assessment for a skill
+// Limits left at zero fall back to the defaults (15 minutes, 70% to pass, 24 hour cooldown)
+type CreateAssessmentRequest struct {
+	SkillID             uint                        `json:"skill_id" binding:"required"`
+	Title               string                      `json:"title" binding:"required,max=200"`
+	Description         string                      `json:"description" binding:"max=2000"`
+	Level               string                      `json:"level" binding:"required"`
+	TimeLimitMinutes    int                         `json:"time_limit_minutes"`
+	PassScore           float64                     `json:"pass_score"`
+	RetakeCooldownHours *int                        `json:"retake_cooldown_hours"`
+	Questions           []AssessmentQuestionRequest `json:"questions" binding:"required,min=1,max=50,dive"`
+}
+
+// UpdateAssessmentRequest edits an assessment; omitted fields are kept
+// Questions, when given, replace all existing questions
+type UpdateAssessmentRequest struct {
+	Title               *string                     `json:"title" binding:"omitempty,max=200"`
+	Description         *string                     `json:"description" binding:"omitempty,max=2000"`
+	Level               *string                     `json:"level"`
+	TimeLimitMinutes    *int                        `json:"time_limit_minutes"`
+	PassScore           *float64                    `json:"pass_score"`
+	RetakeCooldownHours *int                        `json:"retake_cooldown_hours"`
+	Questions           []AssessmentQuestionRequest `json:"questions" binding:"omitempty,min=1,max=50,dive"`
+}
+
+// SubmitAttemptRequest carries the chosen option index for each question ID
+// Unanswered questions count as wrong
+type SubmitAttemptRequest struct {
+	Answers map[uint]int `json:"answers" binding:"required"`
+}
+
+// AssessmentResponse is the taker's view of an assessment, without questions or answers
+type AssessmentResponse struct {
+	ID                  uint      `json:"id"`
+	SkillID             uint      `json:"skill_id"`
+	SkillName           string    `json:"skill_name"`
+	Title               string    `json:"title"`
+	Description         string    `json:"description"`
+	Level               string    `json:"level"`
+	TimeLimitMinutes    int       `json:"time_limit_minutes"`
+	PassScore           float64   `json:"pass_score"`
+	RetakeCooldownHours int       `json:"retake_cooldown_hours"`
+	QuestionCount       int       `json:"question_count"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// AssessmentQuestionResponse is a question as shown to a taker, without the correct option
+type AssessmentQuestionResponse struct {
+	ID       uint     `json:"id"`
+	Position int      `json:"position"`
+	Prompt   string   `json:"prompt"`
+	Options  []string `json:"options"`
+}
+
+// AssessmentAttemptResponse is an attempt as shown to its taker
+// Questions are only included while the attempt is in progress
+type AssessmentAttemptResponse struct {
+	ID                uint                         `json:"id"`
+	Assessment        AssessmentResponse           `json:"assessment"`
+	Status            string                       `json:"status"`
+	StartedAt         time.Time                    `json:"started_at"`
+	Deadline          time.Time                    `json:"deadline"`
+	SubmittedAt       *time.Time                   `json:"submitted_at,omitempty"`
+	Questions         []AssessmentQuestionResponse `json:"questions,omitempty"`
+	CorrectCount      int                          `json:"correct_count"`
+	TotalQuestions    int                          `json:"total_questions"`
+	Score             float64                      `json:"score"`
+	Passed            bool                         `json:"passed"`
+	LevelApplied      bool                         `json:"level_applied"`
+	RetakeAvailableAt *time.Time                   `json:"retake_available_at,omitempty"` // Set after a failed attempt
+}
+
+// AssessmentListResponse is a page of assessments for takers
+type AssessmentListResponse struct {
+	Assessments []AssessmentResponse `json:"assessments"`
+	Total       int64                `json:"total"`
+	Limit       int                  `json:"limit"`
+	Offset      int                  `json:"offset"`
+}
+
+// AssessmentAttemptListResponse is a page of a user's attempts
+type AssessmentAttemptListResponse struct {
+	Attempts []AssessmentAttemptResponse `json:"attempts"`
+	Total    int64                       `json:"total"`
+	Limit    int                         `json:"limit"`
+	Offset   int                         `json:"offset"`
+}
+
+// ToAssessmentResponse converts an assessment to the taker's view
+func ToAssessmentResponse(assessment *models.SkillAssessment) AssessmentResponse {
+	return AssessmentResponse{
+		ID:                  assessment.ID,
+		SkillID:             assessment.SkillID,
+		SkillName:           assessment.Skill.Name,
+		Title:               assessment.Title,
+		Description:         assessment.Description,
+		Level:               string(assessment.Level),
+		TimeLimitMinutes:    assessment.TimeLimitMinutes,
+		PassScore:           assessment.PassScore,
+		RetakeCooldownHours: assessment.RetakeCooldownHours,
+		QuestionCount:       len(assessment.Questions),
+		CreatedAt:           assessment.CreatedAt,
+	}
+}
+
+// ToAssessmentAttemptResponse converts an attempt to its taker's view
+func ToAssessmentAttemptResponse(attempt *models.AssessmentAttempt) AssessmentAttemptResponse {
+	response := AssessmentAttemptResponse{
+		ID:             attempt.ID,
+		Assessment:     ToAssessmentResponse(&attempt.Assessment),
+		Status:         string(attempt.Status),
+		StartedAt:      attempt.StartedAt,
+		Deadline:       attempt.Deadline,
+		SubmittedAt:    attempt.SubmittedAt,
+		CorrectCount:   attempt.CorrectCount,
+		TotalQuestions: attempt.TotalQuestions,
+		Score:          attempt.Score,
+		Passed:         attempt.Passed,
+		LevelApplied:   attempt.LevelApplied,
+	}
+
+	if attempt.Status == models.AttemptInProgress {
+		response.Questions = make([]AssessmentQuestionResponse, len(attempt.Assessment.Questions))
+		for i, question := range attempt.Assessment.Questions {
+			response.Questions[i] = AssessmentQuestionResponse{
+				ID:       question.ID,
+				Position: question.Position,
+				Prompt:   question.Prompt,
+				Options:  question.Options,
+			}
+		}
+	} else if !attempt.Passed && attempt.Assessment.RetakeCooldownHours > 0 {
+		finishedAt := attempt.Deadline
+		if attempt.SubmittedAt != nil {
+			finishedAt = *attempt.SubmittedAt
+		}
+		retakeAt := finishedAt.Add(time.Duration(attempt.Assessment.RetakeCooldownHours) * time.Hour)
+		response.RetakeAvailableAt = &retakeAt
+	}
+
+	return response
+}
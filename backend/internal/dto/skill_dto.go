@@ -8,10 +8,12 @@ import (
 // Skill DTOs
 
 type CreateSkillRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Category    string `json:"category" binding:"required"`
-	Description string `json:"description"`
-	Icon        string `json:"icon"`
+	Name        string   `json:"name" binding:"required"`
+	Category    string   `json:"category" binding:"required"`
+	Description string   `json:"description"`
+	Icon        string   `json:"icon"`
+	ParentID    *uint    `json:"parent_id"`
+	Tags        []string `json:"tags"`
 }
 
 type UpdateSkillRequest struct {
@@ -31,6 +33,9 @@ type SkillResponse struct {
 	TotalLearners  int     `json:"total_learners"`
 	MinRate        float64 `json:"min_rate"`
 	MaxRate        float64 `json:"max_rate"`
+	ParentID       *uint    `json:"parent_id"`
+	Tags           []string `json:"tags"`
+	Aliases        []string `json:"aliases,omitempty"`
 	CreatedAt      time.Time `json:"created_at"`
 }
 
@@ -41,6 +46,34 @@ type SkillListResponse struct {
 	Limit  int             `json:"limit"`
 }
 
+// Skill Taxonomy DTOs
+
+// SkillTreeNode is a skill with its child skills
+type SkillTreeNode struct {
+	SkillResponse
+	Children []SkillTreeNode `json:"children"`
+}
+
+// SetSkillParentRequest moves a skill in the hierarchy; a null parent makes it a root skill
+type SetSkillParentRequest struct {
+	ParentID *uint `json:"parent_id"`
+}
+
+// SetSkillTagsRequest replaces a skill's tags; an empty list clears them
+type SetSkillTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// CreateSkillAliasRequest adds a synonym that resolves to a skill
+type CreateSkillAliasRequest struct {
+	Alias string `json:"alias" binding:"required,max=100"`
+}
+
+// MergeSkillRequest folds the skill in the URL into TargetID
+type MergeSkillRequest struct {
+	TargetID uint `json:"target_id" binding:"required"`
+}
+
 // User Skill DTOs
 
 type CreateUserSkillRequest struct {
@@ -85,6 +118,11 @@ type UserSkillResponse struct {
 	TotalSessions     int           `json:"total_sessions"`
 	AverageRating     float64       `json:"average_rating"`
 	TotalReviews      int           `json:"total_reviews"`
+	IsVerified        bool          `json:"is_verified"`
+	VerifiedAt        *time.Time    `json:"verified_at,omitempty"`
+	VerificationExpiresAt *time.Time `json:"verification_expires_at,omitempty"`
+	AssessedLevel     string        `json:"assessed_level,omitempty"` // Highest level placed at by a passed assessment
+	LevelConfirmed    bool          `json:"level_confirmed"`          // Assessed level is at least the claimed level
 	CreatedAt         time.Time     `json:"created_at"`
 	UpdatedAt         time.Time     `json:"updated_at"`
 }
@@ -122,10 +160,25 @@ func ToSkillResponse(skill *models.Skill) SkillResponse {
 		TotalLearners:  skill.TotalLearners,
 		MinRate:        skill.MinRate,
 		MaxRate:        skill.MaxRate,
+		ParentID:       skill.ParentID,
+		Tags:           skill.Tags,
+		Aliases:        aliasNames(skill.Aliases),
 		CreatedAt:      skill.CreatedAt,
 	}
 }
 
+// aliasNames lists preloaded alias spellings, or nil when aliases were not loaded
+func aliasNames(aliases []models.SkillAlias) []string {
+	if len(aliases) == 0 {
+		return nil
+	}
+	names := make([]string, len(aliases))
+	for i, alias := range aliases {
+		names[i] = alias.Alias
+	}
+	return names
+}
+
 func ToUserSkillResponse(userSkill *models.UserSkill) UserSkillResponse {
 	response := UserSkillResponse{
 		ID:                userSkill.ID,
@@ -143,6 +196,11 @@ func ToUserSkillResponse(userSkill *models.UserSkill) UserSkillResponse {
 		TotalSessions:     userSkill.TotalSessions,
 		AverageRating:     userSkill.AverageRating,
 		TotalReviews:      userSkill.TotalReviews,
+		IsVerified:        userSkill.IsCurrentlyVerified(time.Now()),
+		VerifiedAt:        userSkill.VerifiedAt,
+		VerificationExpiresAt: userSkill.VerificationExpiresAt,
+		AssessedLevel:     string(userSkill.AssessedLevel),
+		LevelConfirmed:    userSkill.IsLevelConfirmed(),
 		CreatedAt:         userSkill.CreatedAt,
 		UpdatedAt:         userSkill.UpdatedAt,
 	}
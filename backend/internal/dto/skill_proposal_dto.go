@@ -0,0 +1,49 @@
+package dto
+
+import "github.com/timebankingskill/backend/internal/models"
+
+// CreateSkillProposalRequest proposes a new skill for the catalogue
+// AttachAs ("teach" or "learn") adds the skill to the proposer's profile once approved
+type CreateSkillProposalRequest struct {
+	Name        string  `json:"name" binding:"required,max=100"`
+	Category    string  `json:"category" binding:"required"`
+	Description string  `json:"description" binding:"max=1000"`
+	AttachAs    string  `json:"attach_as"`
+	Level       string  `json:"level"`       // Required when teaching; desired level when learning
+	HourlyRate  float64 `json:"hourly_rate"` // Teaching only, defaults to 1.0
+	Priority    int     `json:"priority"`    // Learning only, 1-5
+}
+
+// ApproveSkillProposalRequest approves a proposal, optionally editing the skill first
+// Set ExistingSkillID to link the proposal to a skill that already exists instead
+// of creating one; AddAlias then makes the proposed name resolve to it
+type ApproveSkillProposalRequest struct {
+	Name            string   `json:"name" binding:"max=100"`
+	Category        string   `json:"category"`
+	Description     string   `json:"description"`
+	Icon            string   `json:"icon"`
+	ParentID        *uint    `json:"parent_id"`
+	Tags            []string `json:"tags"`
+	ExistingSkillID *uint    `json:"existing_skill_id"`
+	AddAlias        bool     `json:"add_alias"`
+	Note            string   `json:"note" binding:"max=500"`
+}
+
+// RejectSkillProposalRequest rejects a proposal with a reason shown to the proposer
+type RejectSkillProposalRequest struct {
+	Note string `json:"note" binding:"required,max=500"`
+}
+
+// SkillProposalResponse is a proposal with existing skills it may duplicate
+type SkillProposalResponse struct {
+	models.SkillProposal
+	PossibleDuplicates []SkillResponse `json:"possible_duplicates,omitempty"`
+}
+
+// SkillProposalListResponse is a page of proposals
+type SkillProposalListResponse struct {
+	Proposals []SkillProposalResponse `json:"proposals"`
+	Total     int64                   `json:"total"`
+	Limit     int                     `json:"limit"`
+	Offset    int                     `json:"offset"`
+}
@@ -0,0 +1,84 @@
+package dto
+
+import "time"
+
+// ProposeSwapRequest represents a request to propose a skill swap
+// UserSkillIDs lists one offer per participant in ring order: the owner of each
+// offer teaches the owner of the next one, and the last teaches the first.
+// The proposer must own one of the offers.
+type ProposeSwapRequest struct {
+	UserSkillIDs []uint    `json:"user_skill_ids" binding:"required,min=2,max=3"`
+	Duration     float64   `json:"duration" binding:"required,min=0.5,max=4"` // Hours per leg
+	Mode         string    `json:"mode" binding:"required,oneof=online offline hybrid"`
+	ScheduledAt  time.Time `json:"scheduled_at" binding:"required"`
+	Location     string    `json:"location"`
+	MeetingLink  string    `json:"meeting_link"`
+	Message      string    `json:"message" binding:"max=1000"`
+}
+
+// CloseSwapRequest represents a request to decline or cancel a skill swap
+type CloseSwapRequest struct {
+	Reason string `json:"reason" binding:"required,min=10,max=500"`
+}
+
+// SwapLegPreview is one leg of a suggested swap
+type SwapLegPreview struct {
+	UserSkillID uint              `json:"user_skill_id"`
+	SkillID     uint              `json:"skill_id"`
+	SkillName   string            `json:"skill_name"`
+	Level       string            `json:"level"`
+	Teacher     UserPublicProfile `json:"teacher"`
+	Student     UserPublicProfile `json:"student"`
+}
+
+// SwapMatch is a suggested swap the user can propose as-is
+type SwapMatch struct {
+	Type         string           `json:"type"` // pair or cycle
+	UserSkillIDs []uint           `json:"user_skill_ids"`
+	Priority     int              `json:"priority"` // Sum of the learners' wishlist priorities
+	Legs         []SwapLegPreview `json:"legs"`
+}
+
+// SwapMatchesResponse lists swap suggestions for the user
+type SwapMatchesResponse struct {
+	Pairs  []SwapMatch `json:"pairs"`
+	Cycles []SwapMatch `json:"cycles"`
+}
+
+// SkillSwapLegResponse is one booked leg of a skill swap
+type SkillSwapLegResponse struct {
+	Position      int               `json:"position"`
+	UserSkillID   uint              `json:"user_skill_id"`
+	SkillID       uint              `json:"skill_id"`
+	SkillName     string            `json:"skill_name"`
+	Teacher       UserPublicProfile `json:"teacher"`
+	Student       UserPublicProfile `json:"student"`
+	SessionID     uint              `json:"session_id"`
+	SessionStatus string            `json:"session_status"`
+	Accepted      bool              `json:"accepted"`
+	AcceptedAt    *time.Time        `json:"accepted_at"`
+}
+
+// SkillSwapResponse represents a skill swap in API responses
+type SkillSwapResponse struct {
+	ID                 uint                   `json:"id"`
+	ProposerID         uint                   `json:"proposer_id"`
+	Status             string                 `json:"status"`
+	Duration           float64                `json:"duration"`
+	Mode               string                 `json:"mode"`
+	ScheduledAt        time.Time              `json:"scheduled_at"`
+	Message            string                 `json:"message"`
+	CancelledBy        *uint                  `json:"cancelled_by"`
+	CancellationReason string                 `json:"cancellation_reason"`
+	Legs               []SkillSwapLegResponse `json:"legs"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+}
+
+// SkillSwapListResponse represents a paginated list of skill swaps
+type SkillSwapListResponse struct {
+	Swaps []SkillSwapResponse `json:"swaps"`
+	Total int64               `json:"total"`
+	Page  int                 `json:"page"`
+	Limit int                 `json:"limit"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+// SubmitSkillVerificationRequest is the multipart form sent with a proof upload
+// The proof file is sent as the "file" field; ExternalURL may be used instead of or alongside it
+type SubmitSkillVerificationRequest struct {
+	ProofType   string `form:"proof_type" binding:"required"` // certificate, portfolio or project
+	ExternalURL string `form:"external_url" binding:"max=500"`
+	Notes       string `form:"notes" binding:"max=1000"`
+}
+
+// ApproveSkillVerificationRequest approves proof with an optional note
+type ApproveSkillVerificationRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+// RejectSkillVerificationRequest rejects proof with a reason shown to the teacher
+type RejectSkillVerificationRequest struct {
+	Note string `json:"note" binding:"required,max=500"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+)
+
+// TransactionSearchRequest represents filters for searching a user's transactions
+// All filters are optional and combined with AND
+type TransactionSearchRequest struct {
+	Types          []string   `form:"type"` // One or more transaction types (earned, spent, ...)
+	From           *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To             *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	MinAmount      *float64   `form:"min_amount"`      // Compared against absolute amount
+	MaxAmount      *float64   `form:"max_amount"`      // Compared against absolute amount
+	SessionID      *uint      `form:"session_id"`      // Linked session
+	CounterpartyID *uint      `form:"counterparty_id"` // Other participant of the linked session
+	Search         string     `form:"q"`               // Full-text search on description
+	Cursor         string     `form:"cursor"`          // Opaque cursor from previous page
+	Limit          int        `form:"limit"`
+	Offset         int        `form:"offset"` // Legacy offset pagination, ignored when cursor is set
+}
+
+// TransactionTotals represents aggregate figures for a filtered set of transactions
+type TransactionTotals struct {
+	Count       int64              `json:"count"`
+	TotalCredit float64            `json:"total_credit"` // Sum of positive amounts
+	TotalDebit  float64            `json:"total_debit"`  // Sum of negative amounts (as positive number)
+	Net         float64            `json:"net"`
+	ByType      map[string]float64 `json:"by_type"`
+}
+
+// TransactionListResponse represents a page of transactions with totals
+type TransactionListResponse struct {
+	Transactions []models.Transaction `json:"transactions"`
+	Total        int64                `json:"total"`
+	Limit        int                  `json:"limit"`
+	Offset       int                  `json:"offset"`
+	NextCursor   string               `json:"next_cursor,omitempty"`
+	HasMore      bool                 `json:"has_more"`
+	Totals       TransactionTotals    `json:"totals"`
+}
@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+)
+
+// XPHistoryQuery represents filters for a user's XP history
+type XPHistoryQuery struct {
+	Action string    `form:"action" binding:"omitempty,oneof=session_completed review_written forum_answer_accepted endorsement_received"`
+	From   time.Time `form:"from" time_format:"2006-01-02"` // Inclusive, UTC day
+	To     time.Time `form:"to" time_format:"2006-01-02"`   // Inclusive, UTC day
+	Limit  int       `form:"limit"`
+	Offset int       `form:"offset"`
+}
+
+// UpdateXPRuleRequest represents an admin request to tune an action's XP
+// Only the fields that are set are changed
+type UpdateXPRuleRequest struct {
+	Points      *int    `json:"points" binding:"omitempty,gte=0"`
+	DailyCap    *int    `json:"daily_cap" binding:"omitempty,gte=0"` // 0 is unlimited
+	Description *string `json:"description" binding:"omitempty,max=255"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+// XPRuleResponse represents an XP rule in API responses
+type XPRuleResponse struct {
+	Action      string `json:"action"`
+	Description string `json:"description"`
+	Points      int    `json:"points"`
+	DailyCap    int    `json:"daily_cap"`
+	IsActive    bool   `json:"is_active"`
+}
+
+// XPDailyProgress represents how much of an action's daily cap a user has used
+type XPDailyProgress struct {
+	Action   string `json:"action"`
+	Earned   int    `json:"earned"`
+	DailyCap int    `json:"daily_cap"` // 0 is unlimited
+}
+
+// XPSummaryResponse represents a user's XP and level
+type XPSummaryResponse struct {
+	UserID      uint              `json:"user_id"`
+	TotalXP     int               `json:"total_xp"`
+	Level       int               `json:"level"`
+	LevelXP     int               `json:"level_xp"`                // Total XP the current level starts at
+	NextLevelXP *int              `json:"next_level_xp,omitempty"` // Total XP the next level starts at; absent at the top level
+	Progress    float64           `json:"progress"`                // Percent of the way to the next level
+	IsMaxLevel  bool              `json:"is_max_level"`
+	Today       []XPDailyProgress `json:"today,omitempty"`         // Only for the user themselves
+	DayResetsAt string            `json:"day_resets_at,omitempty"` // When daily caps reset
+}
+
+// XPEventResponse represents one entry of a user's XP history
+type XPEventResponse struct {
+	ID         uint   `json:"id"`
+	Action     string `json:"action"`
+	SourceType string `json:"source_type"`
+	SourceID   uint   `json:"source_id"`
+	Points     int    `json:"points"`
+	BasePoints int    `json:"base_points"`
+	Capped     bool   `json:"capped"`
+	TotalAfter int    `json:"total_after"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// MapXPRuleToResponse maps an XPRule model to XPRuleResponse
+func MapXPRuleToResponse(rule *models.XPRule) *XPRuleResponse {
+	return &XPRuleResponse{
+		Action:      string(rule.Action),
+		Description: rule.Description,
+		Points:      rule.Points,
+		DailyCap:    rule.DailyCap,
+		IsActive:    rule.IsActive,
+	}
+}
+
+// MapXPEventsToResponse maps XPEvent models to XPEventResponse
+func MapXPEventsToResponse(events []models.XPEvent) []XPEventResponse {
+	responses := make([]XPEventResponse, len(events))
+	for i, event := range events {
+		responses[i] = XPEventResponse{
+			ID:         event.ID,
+			Action:     string(event.Action),
+			SourceType: event.SourceType,
+			SourceID:   event.SourceID,
+			Points:     event.Points,
+			BasePoints: event.BasePoints,
+			Capped:     event.Capped,
+			TotalAfter: event.TotalAfter,
+			CreatedAt:  event.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return responses
+}
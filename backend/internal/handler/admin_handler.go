@@ -30,7 +30,7 @@ func (h *AdminHandler) Register(c *gin.Context) {
 		return
 	}
 
-	response, err := h.adminService.Register(req)
+	response, err := h.adminService.WithAudit(auditScope(c)).Register(req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Registration failed", err)
 		return
@@ -90,7 +90,7 @@ func (h *AdminHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	profile, err := h.adminService.UpdateProfile(adminID, req)
+	profile, err := h.adminService.WithAudit(auditScope(c)).UpdateProfile(adminID, req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Update failed", err)
 		return
@@ -114,7 +114,7 @@ func (h *AdminHandler) ChangePassword(c *gin.Context) {
 		return
 	}
 
-	if err := h.adminService.ChangePassword(adminID, req); err != nil {
+	if err := h.adminService.WithAudit(auditScope(c)).ChangePassword(adminID, req); err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Password change failed", err)
 		return
 	}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// auditScope builds the audit scope (actor, IP, request ID) for the current request
+// The actor type comes from the token's role claim, not the route: admin tokens are
+// attributed to the admin, user tokens to the user, whichever route they call.
+// Returns nil when no audit recorder is installed, which disables recording
+func auditScope(c *gin.Context) *service.AuditScope {
+	value, exists := c.Get(service.AuditRecorderKey)
+	recorder, ok := value.(*service.AuditService)
+	if !exists || !ok {
+		return nil
+	}
+
+	actorType := models.AuditActorUser
+	if c.GetString("role") == utils.TokenRoleAdmin {
+		actorType = models.AuditActorAdmin
+	}
+
+	return recorder.Scope(actorType, c.GetUint("user_id"), c.ClientIP(), c.GetString("request_id"))
+}
+
+// AuditHandler handles audit log HTTP requests
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// ListEvents queries the audit log
+// GET /api/v1/admin/audit/events?actor_type=user&actor_id=1&entity=reviews&from=2024-01-01T00:00:00Z
+func (h *AuditHandler) ListEvents(c *gin.Context) {
+	var req dto.AuditEventQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	events, total, err := h.auditService.ListEvents(&req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to fetch audit events", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Audit events retrieved successfully", gin.H{
+		"events": events,
+		"total":  total,
+		"limit":  req.Limit,
+		"offset": req.Offset,
+	})
+}
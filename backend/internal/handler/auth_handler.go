@@ -38,7 +38,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
   }
 
   // Call service
-  response, err := h.authService.Register(&req)
+  response, err := h.authService.WithAudit(auditScope(c)).Register(&req)
   if err != nil {
     utils.SendError(c, http.StatusBadRequest, err.Error(), nil)
     return
@@ -53,7 +53,7 @@ func (h *AvailabilityHandler) SetMyAvailability(c *gin.Context) {
 		return
 	}
 
-	availability, err := h.availabilityService.SetUserAvailability(userID, &req)
+	availability, err := h.availabilityService.WithAudit(auditScope(c)).SetUserAvailability(userID, &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), nil)
 		return
@@ -71,7 +71,7 @@ func (h *AvailabilityHandler) ClearMyAvailability(c *gin.Context) {
 		return
 	}
 
-	if err := h.availabilityService.ClearUserAvailability(userID); err != nil {
+	if err := h.availabilityService.WithAudit(auditScope(c)).ClearUserAvailability(userID); err != nil {
 		utils.SendError(c, http.StatusInternalServerError, "Failed to clear availability", nil)
 		return
 	}
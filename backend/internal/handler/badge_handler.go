@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
 	"github.com/timebankingskill/backend/internal/service"
 	"github.com/timebankingskill/backend/internal/utils"
 )
@@ -103,6 +104,32 @@ func (h *BadgeHandler) GetUserBadgesByType(c *gin.Context) {
 	})
 }
 
+// GetBadgeProgress lists the current user's closest badges to unlock
+// GET /api/v1/user/badges/progress?limit=10
+func (h *BadgeHandler) GetBadgeProgress(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	progress, err := h.badgeService.GetBadgeProgress(userID, limit)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch badge progress", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Badge progress retrieved successfully", gin.H{
+		"progress": progress,
+		"total":    len(progress),
+	})
+}
+
 // CheckAndAwardBadges checks if user qualifies for any badges and awards them
 // POST /api/v1/user/badges/check
 func (h *BadgeHandler) CheckAndAwardBadges(c *gin.Context) {
@@ -175,137 +202,227 @@ func (h *BadgeHandler) PinBadge(c *gin.Context) {
 	})
 }
 
-// GetBadgeLeaderboard retrieves top users by badge count
-// GET /api/v1/leaderboard/badges?limit=10
-func (h *BadgeHandler) GetBadgeLeaderboard(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		limit = 10
+// ListBadgesForAdmin lists every badge, including inactive ones
+// GET /api/v1/admin/badges
+func (h *BadgeHandler) ListBadgesForAdmin(c *gin.Context) {
+	badges, err := h.badgeService.ListBadgesForAdmin()
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch badges", err)
+		return
 	}
 
-	leaderboard, err := h.badgeService.GetBadgeLeaderboard(limit)
-	if err != nil {
-		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch leaderboard", err)
+	utils.SendSuccess(c, http.StatusOK, "Badges retrieved successfully", gin.H{
+		"badges": badges,
+		"total":  len(badges),
+	})
+}
+
+// GetBadgeMetrics lists the metrics badge requirements can use
+// GET /api/v1/admin/badges/metrics
+func (h *BadgeHandler) GetBadgeMetrics(c *gin.Context) {
+	utils.SendSuccess(c, http.StatusOK, "Badge metrics retrieved successfully", h.badgeService.GetBadgeMetrics())
+}
+
+// CreateBadge creates a badge
+// POST /api/v1/admin/badges
+func (h *BadgeHandler) CreateBadge(c *gin.Context) {
+	var req dto.CreateBadgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
-	// Add rank
-	for i := range leaderboard {
-		leaderboard[i].Rank = i + 1
+	badge, err := h.badgeService.WithAudit(auditScope(c)).CreateBadge(&req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to create badge", err)
+		return
 	}
 
-	utils.SendSuccess(c, http.StatusOK, "Badge leaderboard retrieved successfully", gin.H{
-		"type":    "badges",
-		"entries": leaderboard,
-		"total":   len(leaderboard),
-	})
+	utils.SendSuccess(c, http.StatusCreated, "Badge created successfully", badge)
 }
 
-// GetRarityLeaderboard retrieves top users by badge rarity
-// GET /api/v1/leaderboard/rarity?limit=10
-func (h *BadgeHandler) GetRarityLeaderboard(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		limit = 10
+// UpdateBadge edits a badge
+// PUT /api/v1/admin/badges/:id
+func (h *BadgeHandler) UpdateBadge(c *gin.Context) {
+	id, ok := parseBadgeID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateBadgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
 	}
 
-	leaderboard, err := h.badgeService.GetRarityLeaderboard(limit)
+	badge, err := h.badgeService.WithAudit(auditScope(c)).UpdateBadge(id, &req)
 	if err != nil {
-		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch leaderboard", err)
+		sendBadgeError(c, "Failed to update badge", err)
 		return
 	}
 
-	// Add rank
-	for i := range leaderboard {
-		leaderboard[i].Rank = i + 1
+	utils.SendSuccess(c, http.StatusOK, "Badge updated successfully", badge)
+}
+
+// DeleteBadge deletes a badge nobody holds
+// DELETE /api/v1/admin/badges/:id
+func (h *BadgeHandler) DeleteBadge(c *gin.Context) {
+	id, ok := parseBadgeID(c)
+	if !ok {
+		return
 	}
 
-	utils.SendSuccess(c, http.StatusOK, "Rarity leaderboard retrieved successfully", gin.H{
-		"type":    "rarity",
-		"entries": leaderboard,
-		"total":   len(leaderboard),
-	})
+	if err := h.badgeService.WithAudit(auditScope(c)).DeleteBadge(id); err != nil {
+		sendBadgeError(c, "Failed to delete badge", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Badge deleted successfully", nil)
 }
 
-// GetSessionLeaderboard retrieves top users by session count
-// GET /api/v1/leaderboard/sessions?limit=10
-func (h *BadgeHandler) GetSessionLeaderboard(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		limit = 10
+// ActivateBadge makes a badge earnable and awards it to users who already qualify
+// POST /api/v1/admin/badges/:id/activate
+func (h *BadgeHandler) ActivateBadge(c *gin.Context) {
+	h.setBadgeActive(c, true, "Badge activated")
+}
+
+// DeactivateBadge stops a badge from being earned; holders keep it
+// POST /api/v1/admin/badges/:id/deactivate
+func (h *BadgeHandler) DeactivateBadge(c *gin.Context) {
+	h.setBadgeActive(c, false, "Badge deactivated")
+}
+
+// setBadgeActive activates or deactivates the :id badge
+func (h *BadgeHandler) setBadgeActive(c *gin.Context, active bool, message string) {
+	id, ok := parseBadgeID(c)
+	if !ok {
+		return
 	}
 
-	leaderboard, err := h.badgeService.GetSessionLeaderboard(limit)
+	badge, err := h.badgeService.WithAudit(auditScope(c)).SetBadgeActive(id, active)
 	if err != nil {
-		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch leaderboard", err)
+		sendBadgeError(c, "Failed to update badge", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, message, badge)
+}
+
+// UploadBadgeIcon uploads an image as the badge's icon
+// PUT /api/v1/admin/badges/:id/icon (multipart form, field "file")
+func (h *BadgeHandler) UploadBadgeIcon(c *gin.Context) {
+	id, ok := parseBadgeID(c)
+	if !ok {
 		return
 	}
 
-	// Add rank
-	for i := range leaderboard {
-		leaderboard[i].Rank = i + 1
+	file, err := c.FormFile("file")
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Icon file is required", err)
+		return
 	}
 
-	utils.SendSuccess(c, http.StatusOK, "Session leaderboard retrieved successfully", gin.H{
-		"type":    "sessions",
-		"entries": leaderboard,
-		"total":   len(leaderboard),
-	})
+	badge, err := h.badgeService.WithAudit(auditScope(c)).UploadBadgeIcon(id, file)
+	if err != nil {
+		sendBadgeError(c, "Failed to upload icon", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Icon uploaded successfully", badge)
 }
 
-// GetRatingLeaderboard retrieves top users by average rating
-// GET /api/v1/leaderboard/rating?limit=10
-func (h *BadgeHandler) GetRatingLeaderboard(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		limit = 10
+// GetBadgeIcon serves a badge's uploaded icon
+// GET /api/v1/badges/:id/icon
+func (h *BadgeHandler) GetBadgeIcon(c *gin.Context) {
+	id, ok := parseBadgeID(c)
+	if !ok {
+		return
 	}
 
-	leaderboard, err := h.badgeService.GetRatingLeaderboard(limit)
+	path, err := h.badgeService.GetBadgeIconPath(id)
 	if err != nil {
-		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch leaderboard", err)
+		utils.SendError(c, http.StatusNotFound, "Icon not found", err)
 		return
 	}
 
-	// Add rank
-	for i := range leaderboard {
-		leaderboard[i].Rank = i + 1
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.File(path)
+}
+
+// StartAwardRun evaluates a badge against every user in the background
+// POST /api/v1/admin/badges/:id/evaluate
+func (h *BadgeHandler) StartAwardRun(c *gin.Context) {
+	id, ok := parseBadgeID(c)
+	if !ok {
+		return
 	}
 
-	utils.SendSuccess(c, http.StatusOK, "Rating leaderboard retrieved successfully", gin.H{
-		"type":    "rating",
-		"entries": leaderboard,
-		"total":   len(leaderboard),
-	})
+	run, err := h.badgeService.StartAwardRun(id)
+	if err != nil {
+		sendBadgeError(c, "Failed to start evaluation", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusAccepted, "Badge evaluation started", run)
 }
 
-// GetCreditLeaderboard retrieves top users by credits earned
-// GET /api/v1/leaderboard/credits?limit=10
-func (h *BadgeHandler) GetCreditLeaderboard(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		limit = 10
+// GetAwardRun gets the progress of the latest evaluation of a badge
+// GET /api/v1/admin/badges/:id/evaluate
+func (h *BadgeHandler) GetAwardRun(c *gin.Context) {
+	id, ok := parseBadgeID(c)
+	if !ok {
+		return
 	}
 
-	leaderboard, err := h.badgeService.GetCreditLeaderboard(limit)
+	run, err := h.badgeService.GetAwardRun(id)
 	if err != nil {
-		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch leaderboard", err)
+		utils.SendError(c, http.StatusNotFound, "No evaluation found for this badge", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Badge evaluation retrieved successfully", run)
+}
+
+// RevokeBadge takes a badge away from a user, clawing back its bonus credits
+// POST /api/v1/admin/badges/:id/revoke
+func (h *BadgeHandler) RevokeBadge(c *gin.Context) {
+	adminID := c.GetUint("admin_id")
+	id, ok := parseBadgeID(c)
+	if !ok {
 		return
 	}
 
-	// Add rank
-	for i := range leaderboard {
-		leaderboard[i].Rank = i + 1
+	var req dto.RevokeBadgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
 	}
 
-	utils.SendSuccess(c, http.StatusOK, "Credit leaderboard retrieved successfully", gin.H{
-		"type":    "credits",
-		"entries": leaderboard,
-		"total":   len(leaderboard),
-	})
+	result, err := h.badgeService.WithAudit(auditScope(c)).RevokeBadge(adminID, id, &req)
+	if err != nil {
+		sendBadgeError(c, "Failed to revoke badge", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Badge revoked", result)
+}
+
+// parseBadgeID reads the :id badge path parameter
+func parseBadgeID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid badge ID", err)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// sendBadgeError maps missing badges, users and user badges to 404 and other failures to 400
+func sendBadgeError(c *gin.Context, message string, err error) {
+	switch err.Error() {
+	case "badge not found", "user not found", "user does not have this badge":
+		utils.SendError(c, http.StatusNotFound, message, err)
+		return
+	}
+	utils.SendError(c, http.StatusBadRequest, message, err)
 }
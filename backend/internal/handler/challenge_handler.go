@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// ChallengeHandler handles community challenge and activity streak requests
+type ChallengeHandler struct {
+	challengeService *service.ChallengeService
+	streakService    *service.StreakService
+}
+
+// NewChallengeHandler creates a new challenge handler
+func NewChallengeHandler(challengeService *service.ChallengeService, streakService *service.StreakService) *ChallengeHandler {
+	return &ChallengeHandler{
+		challengeService: challengeService,
+		streakService:    streakService,
+	}
+}
+
+// ListChallenges lists the challenges users can join or follow
+// GET /api/v1/challenges?status=running&limit=20&offset=0
+func (h *ChallengeHandler) ListChallenges(c *gin.Context) {
+	var query dto.ChallengeQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	challenges, total, err := h.challengeService.ListChallenges(&query)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch challenges", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Challenges retrieved successfully", gin.H{
+		"challenges": challenges,
+		"total":      total,
+	})
+}
+
+// GetChallenge retrieves a challenge
+// GET /api/v1/challenges/:id
+func (h *ChallengeHandler) GetChallenge(c *gin.Context) {
+	id, ok := parseChallengeID(c)
+	if !ok {
+		return
+	}
+
+	challenge, err := h.challengeService.GetChallenge(id)
+	if err != nil {
+		sendChallengeError(c, "Failed to fetch challenge", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Challenge retrieved successfully", challenge)
+}
+
+// JoinChallenge enrols the current user in a challenge
+// POST /api/v1/user/challenges/:id/join
+func (h *ChallengeHandler) JoinChallenge(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	id, ok := parseChallengeID(c)
+	if !ok {
+		return
+	}
+
+	participation, err := h.challengeService.JoinChallenge(userID, id)
+	if err != nil {
+		sendChallengeError(c, "Failed to join challenge", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, "Joined challenge successfully", participation)
+}
+
+// LeaveChallenge withdraws the current user from a challenge
+// DELETE /api/v1/user/challenges/:id/join
+func (h *ChallengeHandler) LeaveChallenge(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	id, ok := parseChallengeID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.challengeService.LeaveChallenge(userID, id); err != nil {
+		sendChallengeError(c, "Failed to leave challenge", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Left challenge successfully", nil)
+}
+
+// GetMyChallenges lists the challenges the current user joined with their progress
+// GET /api/v1/user/challenges
+func (h *ChallengeHandler) GetMyChallenges(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	challenges, err := h.challengeService.GetUserChallenges(userID)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch challenges", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Challenges retrieved successfully", gin.H{
+		"challenges": challenges,
+		"total":      len(challenges),
+	})
+}
+
+// GetMyStreak retrieves the current user's weekly activity streak
+// GET /api/v1/user/streak
+func (h *ChallengeHandler) GetMyStreak(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	streak, err := h.streakService.GetStreak(userID)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch streak", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Streak retrieved successfully", streak)
+}
+
+// ListChallengesForAdmin lists every challenge, including inactive ones
+// GET /api/v1/admin/challenges?status=running
+func (h *ChallengeHandler) ListChallengesForAdmin(c *gin.Context) {
+	var query dto.ChallengeQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	challenges, total, err := h.challengeService.ListChallengesForAdmin(&query)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch challenges", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Challenges retrieved successfully", gin.H{
+		"challenges": challenges,
+		"total":      total,
+	})
+}
+
+// CreateChallenge creates a community challenge
+// POST /api/v1/admin/challenges
+func (h *ChallengeHandler) CreateChallenge(c *gin.Context) {
+	adminID := c.GetUint("admin_id")
+
+	var req dto.CreateChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	challenge, err := h.challengeService.WithAudit(auditScope(c)).CreateChallenge(adminID, &req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to create challenge", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, "Challenge created successfully", challenge)
+}
+
+// UpdateChallenge edits a challenge
+// PUT /api/v1/admin/challenges/:id
+func (h *ChallengeHandler) UpdateChallenge(c *gin.Context) {
+	id, ok := parseChallengeID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	challenge, err := h.challengeService.WithAudit(auditScope(c)).UpdateChallenge(id, &req)
+	if err != nil {
+		sendChallengeError(c, "Failed to update challenge", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Challenge updated successfully", challenge)
+}
+
+// DeleteChallenge deletes a challenge nobody joined
+// DELETE /api/v1/admin/challenges/:id
+func (h *ChallengeHandler) DeleteChallenge(c *gin.Context) {
+	id, ok := parseChallengeID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.challengeService.WithAudit(auditScope(c)).DeleteChallenge(id); err != nil {
+		sendChallengeError(c, "Failed to delete challenge", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Challenge deleted successfully", nil)
+}
+
+// ListParticipants lists a challenge's participants with their progress
+// GET /api/v1/admin/challenges/:id/participants?limit=20&offset=0
+func (h *ChallengeHandler) ListParticipants(c *gin.Context) {
+	id, ok := parseChallengeID(c)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	participants, total, err := h.challengeService.ListParticipants(id, limit, offset)
+	if err != nil {
+		sendChallengeError(c, "Failed to fetch participants", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Participants retrieved successfully", gin.H{
+		"participants": participants,
+		"total":        total,
+	})
+}
+
+// RefreshProgress recomputes challenge progress and settles ended challenges immediately
+// POST /api/v1/admin/challenges/refresh
+func (h *ChallengeHandler) RefreshProgress(c *gin.Context) {
+	result, err := h.challengeService.RefreshProgress(time.Now())
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to refresh challenge progress", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Challenge progress refreshed successfully", result)
+}
+
+// parseChallengeID reads the :id challenge path parameter
+func parseChallengeID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid challenge ID", err)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// sendChallengeError maps missing challenges and enrolments to 404 and other failures to 400
+func sendChallengeError(c *gin.Context, message string, err error) {
+	switch err.Error() {
+	case "challenge not found", "not enrolled in this challenge":
+		utils.SendError(c, http.StatusNotFound, message, err)
+		return
+	}
+	utils.SendError(c, http.StatusBadRequest, message, err)
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// CreditAdjustmentHandler handles admin credit adjustment HTTP requests
+type CreditAdjustmentHandler struct {
+	adjustmentService *service.CreditAdjustmentService
+}
+
+// NewCreditAdjustmentHandler creates a new credit adjustment handler
+func NewCreditAdjustmentHandler(adjustmentService *service.CreditAdjustmentService) *CreditAdjustmentHandler {
+	return &CreditAdjustmentHandler{
+		adjustmentService: adjustmentService,
+	}
+}
+
+// CreateAdjustment grants or deducts credits for a user
+// POST /api/v1/admin/credits/adjustments
+func (h *CreditAdjustmentHandler) CreateAdjustment(c *gin.Context) {
+	adminID := c.GetUint("admin_id")
+
+	var req dto.CreateCreditAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	adjustment, err := h.adjustmentService.WithAudit(auditScope(c)).RequestAdjustment(adminID, &req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to create adjustment", err)
+		return
+	}
+
+	message := "Adjustment applied successfully"
+	if adjustment.IsPending() {
+		message = "Adjustment is pending approval by a second admin"
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, message, adjustment)
+}
+
+// ListAdjustments lists credit adjustments
+// GET /api/v1/admin/credits/adjustments?status=pending&user_id=1&limit=20&offset=0
+func (h *CreditAdjustmentHandler) ListAdjustments(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	var userID *uint
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		id, err := strconv.ParseUint(userIDStr, 10, 32)
+		if err != nil {
+			utils.SendError(c, http.StatusBadRequest, "Invalid user ID", err)
+			return
+		}
+		uid := uint(id)
+		userID = &uid
+	}
+
+	adjustments, total, err := h.adjustmentService.ListAdjustments(c.Query("status"), userID, limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to fetch adjustments", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Adjustments retrieved successfully", gin.H{
+		"adjustments": adjustments,
+		"total":       total,
+		"limit":       limit,
+		"offset":      offset,
+	})
+}
+
+// GetAdjustment gets a credit adjustment with its audit trail
+// GET /api/v1/admin/credits/adjustments/:id
+func (h *CreditAdjustmentHandler) GetAdjustment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid adjustment ID", err)
+		return
+	}
+
+	adjustment, err := h.adjustmentService.GetAdjustment(uint(id))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Adjustment not found", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Adjustment retrieved successfully", adjustment)
+}
+
+// ApproveAdjustment approves and applies a pending adjustment
+// POST /api/v1/admin/credits/adjustments/:id/approve
+func (h *CreditAdjustmentHandler) ApproveAdjustment(c *gin.Context) {
+	h.review(c, true)
+}
+
+// RejectAdjustment rejects a pending adjustment
+// POST /api/v1/admin/credits/adjustments/:id/reject
+func (h *CreditAdjustmentHandler) RejectAdjustment(c *gin.Context) {
+	h.review(c, false)
+}
+
+// review handles both approve and reject requests
+func (h *CreditAdjustmentHandler) review(c *gin.Context, approve bool) {
+	adminID := c.GetUint("admin_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid adjustment ID", err)
+		return
+	}
+
+	var req dto.ReviewCreditAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	if approve {
+		adjustment, err := h.adjustmentService.WithAudit(auditScope(c)).ApproveAdjustment(adminID, uint(id), req.Note)
+		if err != nil {
+			utils.SendError(c, http.StatusBadRequest, "Failed to approve adjustment", err)
+			return
+		}
+		utils.SendSuccess(c, http.StatusOK, "Adjustment approved and applied", adjustment)
+		return
+	}
+
+	adjustment, err := h.adjustmentService.WithAudit(auditScope(c)).RejectAdjustment(adminID, uint(id), req.Note)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to reject adjustment", err)
+		return
+	}
+	utils.SendSuccess(c, http.StatusOK, "Adjustment rejected", adjustment)
+}
+
+// GetAuditTrail lists the credit adjustment audit trail
+// GET /api/v1/admin/credits/audit?admin_id=1&limit=50&offset=0
+func (h *CreditAdjustmentHandler) GetAuditTrail(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	var adminID *uint
+	if adminIDStr := c.Query("admin_id"); adminIDStr != "" {
+		id, err := strconv.ParseUint(adminIDStr, 10, 32)
+		if err != nil {
+			utils.SendError(c, http.StatusBadRequest, "Invalid admin ID", err)
+			return
+		}
+		aid := uint(id)
+		adminID = &aid
+	}
+
+	events, total, err := h.adjustmentService.GetAuditTrail(adminID, limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch audit trail", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Audit trail retrieved successfully", gin.H{
+		"events": events,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
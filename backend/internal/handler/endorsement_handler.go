@@ -34,7 +34,7 @@ func (h *EndorsementHandler) CreateEndorsement(c *gin.Context) {
 		return
 	}
 
-	endorsement, err := h.endorsementService.CreateEndorsement(endorserID.(uint), &req)
+	endorsement, err := h.endorsementService.WithAudit(auditScope(c)).CreateEndorsement(endorserID.(uint), &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
@@ -170,7 +170,7 @@ func (h *EndorsementHandler) DeleteEndorsement(c *gin.Context) {
 		return
 	}
 
-	if err := h.endorsementService.DeleteEndorsement(uint(endorsementID), endorserID.(uint)); err != nil {
+	if err := h.endorsementService.WithAudit(auditScope(c)).DeleteEndorsement(uint(endorsementID), endorserID.(uint)); err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
 	}
@@ -49,7 +49,7 @@ func (h *ForumHandler) CreateThread(c *gin.Context) {
 		return
 	}
 
-	thread, err := h.forumService.CreateThread(userID.(uint), &req)
+	thread, err := h.forumService.WithAudit(auditScope(c)).CreateThread(userID.(uint), &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
@@ -136,7 +136,7 @@ func (h *ForumHandler) UpdateThread(c *gin.Context) {
 		return
 	}
 
-	thread, err := h.forumService.UpdateThread(uint(threadID), userID.(uint), &req)
+	thread, err := h.forumService.WithAudit(auditScope(c)).UpdateThread(uint(threadID), userID.(uint), &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
@@ -147,6 +147,42 @@ func (h *ForumHandler) UpdateThread(c *gin.Context) {
 
 // ===== REPLY ENDPOINTS =====
 
+// AcceptReply accepts a reply as the thread's answer
+// POST /api/v1/forum/threads/:id/replies/:replyId/accept
+func (h *ForumHandler) AcceptReply(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	threadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid thread ID", err)
+		return
+	}
+	replyID, err := strconv.ParseUint(c.Param("replyId"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid reply ID", err)
+		return
+	}
+
+	reply, err := h.forumService.WithAudit(auditScope(c)).AcceptReply(uint(threadID), uint(replyID), userID.(uint))
+	if err != nil {
+		switch err.Error() {
+		case "thread not found", "reply not found":
+			utils.SendError(c, http.StatusNotFound, err.Error(), err)
+		case "unauthorized":
+			utils.SendError(c, http.StatusForbidden, "Only the thread author can accept an answer", err)
+		default:
+			utils.SendError(c, http.StatusBadRequest, err.Error(), err)
+		}
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Reply accepted successfully", reply)
+}
+
 // CreateReply creates a new forum reply
 func (h *ForumHandler) CreateReply(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -161,7 +197,7 @@ func (h *ForumHandler) CreateReply(c *gin.Context) {
 		return
 	}
 
-	reply, err := h.forumService.CreateReply(userID.(uint), &req)
+	reply, err := h.forumService.WithAudit(auditScope(c)).CreateReply(userID.(uint), &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
@@ -225,7 +261,7 @@ func (h *ForumHandler) DeleteReply(c *gin.Context) {
 		return
 	}
 
-	if err := h.forumService.DeleteReply(uint(replyID), userID.(uint)); err != nil {
+	if err := h.forumService.WithAudit(auditScope(c)).DeleteReply(uint(replyID), userID.(uint)); err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
 	}
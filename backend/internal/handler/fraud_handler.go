@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// FraudHandler handles admin fraud review HTTP requests
+type FraudHandler struct {
+	fraudService   *service.FraudService
+	sessionService *service.SessionService
+}
+
+// NewFraudHandler creates a new fraud handler
+func NewFraudHandler(fraudService *service.FraudService, sessionService *service.SessionService) *FraudHandler {
+	return &FraudHandler{
+		fraudService:   fraudService,
+		sessionService: sessionService,
+	}
+}
+
+// ListFlags lists flagged sessions in the review queue
+// GET /api/v1/admin/fraud/flags?status=pending&limit=20&offset=0
+func (h *FraudHandler) ListFlags(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	flags, total, err := h.fraudService.ListFlags(c.DefaultQuery("status", "pending"), limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to fetch fraud flags", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Fraud flags retrieved successfully", gin.H{
+		"flags":  flags,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetFlag gets a flagged session
+// GET /api/v1/admin/fraud/flags/:id
+func (h *FraudHandler) GetFlag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid flag ID", err)
+		return
+	}
+
+	flag, err := h.fraudService.GetFlag(uint(id))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Fraud flag not found", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Fraud flag retrieved successfully", flag)
+}
+
+// ClearFlag clears a flagged session and releases its credits to the teacher
+// POST /api/v1/admin/fraud/flags/:id/clear
+func (h *FraudHandler) ClearFlag(c *gin.Context) {
+	h.review(c, true)
+}
+
+// ConfirmFlag confirms fraud and returns held credits to the student
+// POST /api/v1/admin/fraud/flags/:id/confirm
+func (h *FraudHandler) ConfirmFlag(c *gin.Context) {
+	h.review(c, false)
+}
+
+// review handles both clear and confirm requests
+func (h *FraudHandler) review(c *gin.Context, clear bool) {
+	adminID := c.GetUint("admin_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid flag ID", err)
+		return
+	}
+
+	var req dto.ReviewFraudFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	flag, err := h.sessionService.WithAudit(auditScope(c)).ReviewFraudFlag(adminID, uint(id), clear, req.Note)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to review fraud flag", err)
+		return
+	}
+
+	message := "Session cleared and credits released"
+	if !clear {
+		message = "Fraud confirmed and credits returned to student"
+	}
+	utils.SendSuccess(c, http.StatusOK, message, flag)
+}
+
+// GetSuspiciousPairs lists the riskiest user pairs by completed session history
+// GET /api/v1/admin/fraud/pairs?limit=20
+func (h *FraudHandler) GetSuspiciousPairs(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	pairs, err := h.fraudService.GetSuspiciousPairs(limit)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to score user pairs", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Suspicious pairs retrieved successfully", pairs)
+}
+
+// ScorePair scores the session history between two users
+// GET /api/v1/admin/fraud/pairs/:userA/:userB
+func (h *FraudHandler) ScorePair(c *gin.Context) {
+	userA, errA := strconv.ParseUint(c.Param("userA"), 10, 32)
+	userB, errB := strconv.ParseUint(c.Param("userB"), 10, 32)
+	if errA != nil || errB != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid user ID", nil)
+		return
+	}
+
+	pair, err := h.fraudService.ScorePair(uint(userA), uint(userB))
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to score user pair", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "User pair scored successfully", pair)
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// LeaderboardHandler handles leaderboard and season requests
+type LeaderboardHandler struct {
+	leaderboardService *service.LeaderboardService
+}
+
+// NewLeaderboardHandler creates a new leaderboard handler
+func NewLeaderboardHandler(leaderboardService *service.LeaderboardService) *LeaderboardHandler {
+	return &LeaderboardHandler{leaderboardService: leaderboardService}
+}
+
+// GetLeaderboard retrieves a leaderboard for a period and scope
+// GET /api/v1/leaderboard/:type?period=weekly&scope=school&value=sma+1&limit=10
+func (h *LeaderboardHandler) GetLeaderboard(c *gin.Context) {
+	var query dto.LeaderboardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	leaderboard, err := h.leaderboardService.GetLeaderboard(c.Param("type"), &query)
+	if err != nil {
+		sendLeaderboardError(c, "Failed to fetch leaderboard", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Leaderboard retrieved successfully", leaderboard)
+}
+
+// GetMyRank retrieves the current user's standing on a leaderboard
+// GET /api/v1/user/leaderboard/:type/rank?period=monthly&scope=location
+func (h *LeaderboardHandler) GetMyRank(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	var query dto.LeaderboardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	rank, err := h.leaderboardService.GetMyRank(userID, c.Param("type"), &query)
+	if err != nil {
+		sendLeaderboardError(c, "Failed to fetch rank", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Rank retrieved successfully", rank)
+}
+
+// ListSeasons lists every leaderboard season
+// GET /api/v1/leaderboard/seasons
+func (h *LeaderboardHandler) ListSeasons(c *gin.Context) {
+	seasons, err := h.leaderboardService.ListSeasons()
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch seasons", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Seasons retrieved successfully", gin.H{
+		"seasons": seasons,
+		"total":   len(seasons),
+	})
+}
+
+// CreateSeason schedules a leaderboard season
+// POST /api/v1/admin/leaderboard/seasons
+func (h *LeaderboardHandler) CreateSeason(c *gin.Context) {
+	adminID := c.GetUint("admin_id")
+
+	var req dto.CreateLeaderboardSeasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	season, err := h.leaderboardService.WithAudit(auditScope(c)).CreateSeason(adminID, &req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to create season", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, "Season created successfully", season)
+}
+
+// UpdateSeason edits a leaderboard season
+// PUT /api/v1/admin/leaderboard/seasons/:id
+func (h *LeaderboardHandler) UpdateSeason(c *gin.Context) {
+	id, ok := parseSeasonID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateLeaderboardSeasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	season, err := h.leaderboardService.WithAudit(auditScope(c)).UpdateSeason(id, &req)
+	if err != nil {
+		sendLeaderboardError(c, "Failed to update season", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Season updated successfully", season)
+}
+
+// DeleteSeason deletes a season that hasn't started
+// DELETE /api/v1/admin/leaderboard/seasons/:id
+func (h *LeaderboardHandler) DeleteSeason(c *gin.Context) {
+	id, ok := parseSeasonID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.leaderboardService.WithAudit(auditScope(c)).DeleteSeason(id); err != nil {
+		sendLeaderboardError(c, "Failed to delete season", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Season deleted successfully", nil)
+}
+
+// RefreshAll recomputes every leaderboard immediately
+// POST /api/v1/admin/leaderboard/refresh
+func (h *LeaderboardHandler) RefreshAll(c *gin.Context) {
+	result, err := h.leaderboardService.RefreshAll(time.Now())
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to refresh leaderboards", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Leaderboards refreshed successfully", result)
+}
+
+// parseSeasonID reads the :id season path parameter
+func parseSeasonID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid season ID", err)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// sendLeaderboardError maps missing seasons and users to 404 and other failures to 400
+func sendLeaderboardError(c *gin.Context, message string, err error) {
+	switch err.Error() {
+	case "season not found", "no season is running", "user not found":
+		utils.SendError(c, http.StatusNotFound, message, err)
+		return
+	}
+	utils.SendError(c, http.StatusBadRequest, message, err)
+}
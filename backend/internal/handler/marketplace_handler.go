@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// MarketplaceHandler handles teacher offer search HTTP requests
+type MarketplaceHandler struct {
+	marketplaceService *service.MarketplaceService
+}
+
+// NewMarketplaceHandler creates a new marketplace handler
+func NewMarketplaceHandler(marketplaceService *service.MarketplaceService) *MarketplaceHandler {
+	return &MarketplaceHandler{marketplaceService: marketplaceService}
+}
+
+// SearchOffers handles GET /api/v1/marketplace/offers
+// @Summary Search teacher offers
+// @Description Search concrete tutors (user skills) with filters, sorting and facet counts.
+// @Tags marketplace
+// @Produce json
+// @Param q query string false "Search skill name, offer description and teacher name"
+// @Param skill_id query int false "Skill ID"
+// @Param category query string false "Skill category"
+// @Param level query []string false "Skill levels (repeated or comma-separated)"
+// @Param min_rate query number false "Minimum hourly rate"
+// @Param max_rate query number false "Maximum hourly rate"
+// @Param mode query string false "online or offline"
+// @Param location query string false "Teacher location"
+// @Param min_rating query number false "Minimum offer rating (0-5)"
+// @Param day query int false "Available day (0-6)"
+// @Param time query string false "Available at time (HH:MM)"
+// @Param school query string false "Teacher school"
+// @Param grade query string false "Teacher grade"
+// @Param sort query string false "relevance, rating, price_asc, price_desc, popularity"
+// @Param page query int false "Page (default 1)"
+// @Param limit query int false "Limit (default 10)"
+// @Success 200 {object} utils.SuccessResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /marketplace/offers [get]
+func (h *MarketplaceHandler) SearchOffers(c *gin.Context) {
+	var req dto.OfferSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	response, err := h.marketplaceService.SearchOffers(&req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to search offers", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Offers retrieved successfully", response)
+}
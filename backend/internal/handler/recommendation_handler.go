@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// RecommendationHandler handles teacher recommendation HTTP requests
+type RecommendationHandler struct {
+	recommendationService *service.RecommendationService
+}
+
+// NewRecommendationHandler creates a new recommendation handler
+func NewRecommendationHandler(recommendationService *service.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{recommendationService: recommendationService}
+}
+
+// GetRecommendations handles GET /api/v1/user/recommendations
+// @Summary Get teacher recommendations
+// @Description Suggest teachers for each of the user's learning skills, with score breakdown and reasons.
+// @Tags recommendations
+// @Produce json
+// @Security Bearer
+// @Param limit query int false "Teachers per learning skill (default 5, max 20)"
+// @Success 200 {object} utils.SuccessResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Router /user/recommendations [get]
+func (h *RecommendationHandler) GetRecommendations(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "5"))
+
+	recommendations, err := h.recommendationService.GetRecommendations(userID, limit)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to get recommendations", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Recommendations retrieved successfully", recommendations)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// ReputationHandler handles reputation score HTTP requests
+type ReputationHandler struct {
+	reputationService *service.ReputationService
+}
+
+// NewReputationHandler creates a new reputation handler
+func NewReputationHandler(reputationService *service.ReputationService) *ReputationHandler {
+	return &ReputationHandler{reputationService: reputationService}
+}
+
+// GetUserReputation gets a user's reputation as teacher, as student and per teaching offer
+// GET /api/v1/users/:id/reputation
+func (h *ReputationHandler) GetUserReputation(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	reputation, err := h.reputationService.GetUserReputation(uint(userID))
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch reputation", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Reputation retrieved successfully", reputation)
+}
+
+// RecomputeAll rebuilds every reputation score immediately
+// POST /api/v1/admin/reputation/recompute
+func (h *ReputationHandler) RecomputeAll(c *gin.Context) {
+	count, err := h.reputationService.RecomputeAll(time.Now())
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to recompute reputation", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Reputation recomputed successfully", dto.ReputationRecomputeResponse{Scores: count})
+}
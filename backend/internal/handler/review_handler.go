@@ -37,7 +37,7 @@ func (h *ReviewHandler) CreateReview(c *gin.Context) {
 		return
 	}
 
-	review, err := h.reviewService.CreateReview(userID, &req)
+	review, err := h.reviewService.WithAudit(auditScope(c)).CreateReview(userID, &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), nil)
 		return
@@ -49,6 +49,12 @@ func (h *ReviewHandler) CreateReview(c *gin.Context) {
 // GetReview retrieves a specific review by ID
 // GET /api/v1/reviews/:id
 func (h *ReviewHandler) GetReview(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
@@ -56,7 +62,7 @@ func (h *ReviewHandler) GetReview(c *gin.Context) {
 		return
 	}
 
-	review, err := h.reviewService.GetReview(uint(id))
+	review, err := h.reviewService.GetReview(uint(id), userID)
 	if err != nil {
 		utils.SendError(c, http.StatusNotFound, "Review not found", err)
 		return
@@ -66,9 +72,9 @@ func (h *ReviewHandler) GetReview(c *gin.Context) {
 }
 
 // GetUserReviews retrieves all reviews for a user
-// GET /api/v1/users/:userId/reviews
+// GET /api/v1/users/:id/reviews
 func (h *ReviewHandler) GetUserReviews(c *gin.Context) {
-	userIDStr := c.Param("userId")
+	userIDStr := c.Param("id")
 	userID, err := strconv.ParseUint(userIDStr, 10, 32)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Invalid user ID", err)
@@ -106,9 +112,9 @@ func (h *ReviewHandler) GetUserReviews(c *gin.Context) {
 }
 
 // GetUserReviewsByType retrieves reviews for a user filtered by type (teacher/student)
-// GET /api/v1/users/:userId/reviews/:type
+// GET /api/v1/users/:id/reviews/:type
 func (h *ReviewHandler) GetUserReviewsByType(c *gin.Context) {
-	userIDStr := c.Param("userId")
+	userIDStr := c.Param("id")
 	userID, err := strconv.ParseUint(userIDStr, 10, 32)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Invalid user ID", err)
@@ -152,9 +158,9 @@ func (h *ReviewHandler) GetUserReviewsByType(c *gin.Context) {
 }
 
 // GetUserRatingSummary retrieves rating summary for a user
-// GET /api/v1/users/:userId/rating-summary
+// GET /api/v1/users/:id/rating-summary
 func (h *ReviewHandler) GetUserRatingSummary(c *gin.Context) {
-	userIDStr := c.Param("userId")
+	userIDStr := c.Param("id")
 	userID, err := strconv.ParseUint(userIDStr, 10, 32)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Invalid user ID", err)
@@ -192,7 +198,7 @@ func (h *ReviewHandler) UpdateReview(c *gin.Context) {
 		return
 	}
 
-	review, err := h.reviewService.UpdateReview(uint(id), userID, &req)
+	review, err := h.reviewService.WithAudit(auditScope(c)).UpdateReview(uint(id), userID, &req)
 	if err != nil {
 		if err.Error() == "you can only edit your own reviews" {
 			utils.SendError(c, http.StatusForbidden, err.Error(), nil)
@@ -221,7 +227,7 @@ func (h *ReviewHandler) DeleteReview(c *gin.Context) {
 		return
 	}
 
-	err = h.reviewService.DeleteReview(uint(id), userID)
+	err = h.reviewService.WithAudit(auditScope(c)).DeleteReview(uint(id), userID)
 	if err != nil {
 		if err.Error() == "you can only delete your own reviews" {
 			utils.SendError(c, http.StatusForbidden, err.Error(), nil)
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// ReviewModerationHandler handles review reports, helpful votes, replies and moderation
+type ReviewModerationHandler struct {
+	moderationService *service.ReviewModerationService
+}
+
+// NewReviewModerationHandler creates a new review moderation handler
+func NewReviewModerationHandler(moderationService *service.ReviewModerationService) *ReviewModerationHandler {
+	return &ReviewModerationHandler{moderationService: moderationService}
+}
+
+// ReportReview reports a review to moderators
+// POST /api/v1/reviews/:id/report
+func (h *ReviewModerationHandler) ReportReview(c *gin.Context) {
+	userID, reviewID, ok := reviewParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReportReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	report, err := h.moderationService.WithAudit(auditScope(c)).ReportReview(userID, reviewID, &req)
+	if err != nil {
+		sendReviewError(c, "Failed to report review", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, "Review reported to moderators", report)
+}
+
+// MarkHelpful marks a review as helpful
+// POST /api/v1/reviews/:id/helpful
+func (h *ReviewModerationHandler) MarkHelpful(c *gin.Context) {
+	userID, reviewID, ok := reviewParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.moderationService.MarkHelpful(userID, reviewID)
+	if err != nil {
+		sendReviewError(c, "Failed to mark review helpful", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Review marked helpful", result)
+}
+
+// UnmarkHelpful removes the user's helpful vote
+// DELETE /api/v1/reviews/:id/helpful
+func (h *ReviewModerationHandler) UnmarkHelpful(c *gin.Context) {
+	userID, reviewID, ok := reviewParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.moderationService.UnmarkHelpful(userID, reviewID)
+	if err != nil {
+		sendReviewError(c, "Failed to remove helpful vote", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Helpful vote removed", result)
+}
+
+// SaveReply creates or edits the reviewee's public response
+// PUT /api/v1/reviews/:id/reply
+func (h *ReviewModerationHandler) SaveReply(c *gin.Context) {
+	userID, reviewID, ok := reviewParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReviewReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	reply, err := h.moderationService.WithAudit(auditScope(c)).SaveReply(userID, reviewID, &req)
+	if err != nil {
+		sendReviewError(c, "Failed to save response", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Response saved successfully", reply)
+}
+
+// DeleteReply deletes the reviewee's public response
+// DELETE /api/v1/reviews/:id/reply
+func (h *ReviewModerationHandler) DeleteReply(c *gin.Context) {
+	userID, reviewID, ok := reviewParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.moderationService.WithAudit(auditScope(c)).DeleteReply(userID, reviewID); err != nil {
+		if err.Error() == "response not found" {
+			utils.SendError(c, http.StatusNotFound, "Response not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusForbidden, "Failed to delete response", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Response deleted successfully", nil)
+}
+
+// GetModerationQueue lists reported reviews waiting for a moderator
+// GET /api/v1/admin/reviews/reported?limit=20&offset=0
+func (h *ReviewModerationHandler) GetModerationQueue(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	queue, err := h.moderationService.GetModerationQueue(limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch reported reviews", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Reported reviews retrieved successfully", queue)
+}
+
+// GetModerationItem gets a review with its report history
+// GET /api/v1/admin/reviews/:id
+func (h *ReviewModerationHandler) GetModerationItem(c *gin.Context) {
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.moderationService.GetModerationItem(reviewID)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Review not found", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Review retrieved successfully", item)
+}
+
+// HideReview hides a review with a reason
+// POST /api/v1/admin/reviews/:id/hide
+func (h *ReviewModerationHandler) HideReview(c *gin.Context) {
+	adminID := c.GetUint("admin_id")
+	reviewID, req, ok := moderateReviewParams(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.moderationService.WithAudit(auditScope(c)).HideReview(adminID, reviewID, req.Reason)
+	if err != nil {
+		sendReviewError(c, "Failed to hide review", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Review hidden", item)
+}
+
+// RestoreReview makes a hidden or reported review visible with a reason
+// POST /api/v1/admin/reviews/:id/restore
+func (h *ReviewModerationHandler) RestoreReview(c *gin.Context) {
+	adminID := c.GetUint("admin_id")
+	reviewID, req, ok := moderateReviewParams(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.moderationService.WithAudit(auditScope(c)).RestoreReview(adminID, reviewID, req.Reason)
+	if err != nil {
+		sendReviewError(c, "Failed to restore review", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Review restored", item)
+}
+
+// DeleteReview deletes a review with a reason
+// DELETE /api/v1/admin/reviews/:id
+func (h *ReviewModerationHandler) DeleteReview(c *gin.Context) {
+	adminID := c.GetUint("admin_id")
+	reviewID, req, ok := moderateReviewParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.moderationService.WithAudit(auditScope(c)).DeleteReview(adminID, reviewID, req.Reason); err != nil {
+		sendReviewError(c, "Failed to delete review", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Review deleted", nil)
+}
+
+// reviewParams reads the authenticated user and the :id review path parameter
+func reviewParams(c *gin.Context) (uint, uint, bool) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return 0, 0, false
+	}
+
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return userID, reviewID, true
+}
+
+// moderateReviewParams reads the :id review path parameter and the moderator's reason
+func moderateReviewParams(c *gin.Context) (uint, *dto.ModerateReviewRequest, bool) {
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return 0, nil, false
+	}
+
+	var req dto.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return 0, nil, false
+	}
+	return reviewID, &req, true
+}
+
+// parseReviewID reads the :id review path parameter
+func parseReviewID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid review ID", err)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// sendReviewError maps "review not found" to 404 and other failures to 400
+func sendReviewError(c *gin.Context, message string, err error) {
+	if err.Error() == "review not found" {
+		utils.SendError(c, http.StatusNotFound, "Review not found", err)
+		return
+	}
+	utils.SendError(c, http.StatusBadRequest, message, err)
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// SavedSearchHandler handles saved marketplace search HTTP requests
+type SavedSearchHandler struct {
+	savedSearchService *service.SavedSearchService
+}
+
+// NewSavedSearchHandler creates a new saved search handler
+func NewSavedSearchHandler(savedSearchService *service.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{savedSearchService: savedSearchService}
+}
+
+// CreateSavedSearch saves a marketplace search for new-match alerts
+// POST /api/v1/saved-searches
+func (h *SavedSearchHandler) CreateSavedSearch(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	var req dto.CreateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	search, err := h.savedSearchService.WithAudit(auditScope(c)).CreateSavedSearch(userID, &req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to save search", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, "Search saved successfully", search)
+}
+
+// GetSavedSearches lists the user's saved searches
+// GET /api/v1/saved-searches
+func (h *SavedSearchHandler) GetSavedSearches(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	searches, err := h.savedSearchService.GetSavedSearches(userID)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch saved searches", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Saved searches retrieved successfully", searches)
+}
+
+// GetSavedSearch gets one of the user's saved searches
+// GET /api/v1/saved-searches/:id
+func (h *SavedSearchHandler) GetSavedSearch(c *gin.Context) {
+	userID, id, ok := savedSearchParams(c)
+	if !ok {
+		return
+	}
+
+	search, err := h.savedSearchService.GetSavedSearch(userID, id)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Saved search not found", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Saved search retrieved successfully", search)
+}
+
+// GetSavedSearchResults runs a saved search against the marketplace
+// GET /api/v1/saved-searches/:id/results?page=1&limit=10
+func (h *SavedSearchHandler) GetSavedSearchResults(c *gin.Context) {
+	userID, id, ok := savedSearchParams(c)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	results, err := h.savedSearchService.GetSavedSearchResults(userID, id, page, limit)
+	if err != nil {
+		if err.Error() == "saved search not found" {
+			utils.SendError(c, http.StatusNotFound, "Saved search not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to search offers", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Offers retrieved successfully", results)
+}
+
+// UpdateSavedSearch renames a saved search or replaces its filters
+// PUT /api/v1/saved-searches/:id
+func (h *SavedSearchHandler) UpdateSavedSearch(c *gin.Context) {
+	userID, id, ok := savedSearchParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	search, err := h.savedSearchService.WithAudit(auditScope(c)).UpdateSavedSearch(userID, id, &req)
+	if err != nil {
+		if err.Error() == "saved search not found" {
+			utils.SendError(c, http.StatusNotFound, "Saved search not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to update saved search", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Saved search updated successfully", search)
+}
+
+// PauseSavedSearch stops alerts for a saved search
+// POST /api/v1/saved-searches/:id/pause
+func (h *SavedSearchHandler) PauseSavedSearch(c *gin.Context) {
+	h.setPaused(c, true)
+}
+
+// ResumeSavedSearch restarts alerts for a saved search
+// POST /api/v1/saved-searches/:id/resume
+func (h *SavedSearchHandler) ResumeSavedSearch(c *gin.Context) {
+	h.setPaused(c, false)
+}
+
+// DeleteSavedSearch deletes a saved search
+// DELETE /api/v1/saved-searches/:id
+func (h *SavedSearchHandler) DeleteSavedSearch(c *gin.Context) {
+	userID, id, ok := savedSearchParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.savedSearchService.WithAudit(auditScope(c)).DeleteSavedSearch(userID, id); err != nil {
+		if err.Error() == "saved search not found" {
+			utils.SendError(c, http.StatusNotFound, "Saved search not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, "Failed to delete saved search", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Saved search deleted successfully", nil)
+}
+
+func (h *SavedSearchHandler) setPaused(c *gin.Context, paused bool) {
+	userID, id, ok := savedSearchParams(c)
+	if !ok {
+		return
+	}
+
+	search, err := h.savedSearchService.WithAudit(auditScope(c)).SetPaused(userID, id, paused)
+	if err != nil {
+		if err.Error() == "saved search not found" {
+			utils.SendError(c, http.StatusNotFound, "Saved search not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to update saved search", err)
+		return
+	}
+
+	message := "Saved search resumed"
+	if paused {
+		message = "Saved search paused"
+	}
+	utils.SendSuccess(c, http.StatusOK, message, search)
+}
+
+// savedSearchParams reads the authenticated user and the :id path parameter
+func savedSearchParams(c *gin.Context) (uint, uint, bool) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid saved search ID", err)
+		return 0, 0, false
+	}
+	return userID, uint(id), true
+}
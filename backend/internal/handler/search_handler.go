@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// SearchHandler handles unified search HTTP requests
+type SearchHandler struct {
+	searchService *service.SearchService
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(searchService *service.SearchService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+// Search handles GET /api/v1/search
+// @Summary Unified search
+// @Description Full-text search across skills, teacher offers, forum threads, forum replies and success stories.
+// @Tags search
+// @Produce json
+// @Param q query string true "Search query (websearch syntax)"
+// @Param types query []string false "skill, offer, thread, reply, story (default all)"
+// @Param limit query int false "Limit (default 20)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} utils.SuccessResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	var req dto.SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	response, err := h.searchService.Search(&req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Search failed", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Search results retrieved successfully", response)
+}
@@ -55,7 +55,7 @@ func (h *SessionHandler) BookSession(c *gin.Context) {
 		return
 	}
 
-	session, err := h.sessionService.BookSession(userID, &req)
+	session, err := h.sessionService.WithAudit(auditScope(c)).BookSession(userID, &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), nil)
 		return
@@ -190,7 +190,7 @@ func (h *SessionHandler) ApproveSession(c *gin.Context) {
 		req = dto.ApproveSessionRequest{}
 	}
 
-	session, err := h.sessionService.ApproveSession(userID, uint(sessionID), &req)
+	session, err := h.sessionService.WithAudit(auditScope(c)).ApproveSession(userID, uint(sessionID), &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), nil)
 		return
@@ -220,7 +220,7 @@ func (h *SessionHandler) RejectSession(c *gin.Context) {
 		return
 	}
 
-	session, err := h.sessionService.RejectSession(userID, uint(sessionID), &req)
+	session, err := h.sessionService.WithAudit(auditScope(c)).RejectSession(userID, uint(sessionID), &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), nil)
 		return
@@ -245,7 +245,7 @@ func (h *SessionHandler) CheckIn(c *gin.Context) {
 		return
 	}
 
-	session, err := h.sessionService.CheckIn(userID, uint(sessionID))
+	session, err := h.sessionService.WithAudit(auditScope(c)).CheckIn(userID, uint(sessionID), c.ClientIP())
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), nil)
 		return
@@ -269,7 +269,7 @@ func (h *SessionHandler) StartSession(c *gin.Context) {
 		return
 	}
 
-	session, err := h.sessionService.StartSession(userID, uint(sessionID))
+	session, err := h.sessionService.WithAudit(auditScope(c)).StartSession(userID, uint(sessionID))
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), nil)
 		return
@@ -298,7 +298,7 @@ func (h *SessionHandler) ConfirmCompletion(c *gin.Context) {
 		req = dto.CompleteSessionRequest{}
 	}
 
-	session, err := h.sessionService.ConfirmCompletion(userID, uint(sessionID), &req)
+	session, err := h.sessionService.WithAudit(auditScope(c)).ConfirmCompletion(userID, uint(sessionID), &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), nil)
 		return
@@ -328,7 +328,7 @@ func (h *SessionHandler) CancelSession(c *gin.Context) {
 		return
 	}
 
-	session, err := h.sessionService.CancelSession(userID, uint(sessionID), &req)
+	session, err := h.sessionService.WithAudit(auditScope(c)).CancelSession(userID, uint(sessionID), &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), nil)
 		return
@@ -46,7 +46,7 @@ func (h *SharedFileHandler) UploadFile(c *gin.Context) {
 	isPublic := c.PostForm("is_public") == "true"
 
 	// Upload file
-	response, err := h.service.UploadFile(
+	response, err := h.service.WithAudit(auditScope(c)).UploadFile(
 		userID.(uint),
 		uint(sessionIDUint),
 		file,
@@ -115,7 +115,7 @@ func (h *SharedFileHandler) DeleteFile(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteFile(userID.(uint), uint(fileIDUint)); err != nil {
+	if err := h.service.WithAudit(auditScope(c)).DeleteFile(userID.(uint), uint(fileIDUint)); err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Failed to delete file", err)
 		return
 	}
@@ -0,0 +1,453 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// SkillAssessmentHandler handles placement assessment HTTP requests
+type SkillAssessmentHandler struct {
+	assessmentService *service.SkillAssessmentService
+}
+
+// NewSkillAssessmentHandler creates a new skill assessment handler
+func NewSkillAssessmentHandler(assessmentService *service.SkillAssessmentService) *SkillAssessmentHandler {
+	return &SkillAssessmentHandler{assessmentService: assessmentService}
+}
+
+// ListAssessments lists published assessments
+// GET /api/v1/assessments?skill_id=1&limit=20&offset=0
+func (h *SkillAssessmentHandler) ListAssessments(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	skillID, ok := parseOptionalSkillID(c)
+	if !ok {
+		return
+	}
+
+	assessments, err := h.assessmentService.ListPublished(skillID, limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch assessments", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Assessments retrieved successfully", assessments)
+}
+
+// GetAssessment gets a published assessment without its questions
+// GET /api/v1/assessments/:id
+func (h *SkillAssessmentHandler) GetAssessment(c *gin.Context) {
+	id, ok := parseAssessmentID(c)
+	if !ok {
+		return
+	}
+
+	assessment, err := h.assessmentService.GetPublished(id)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Assessment not found", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Assessment retrieved successfully", assessment)
+}
+
+// GetAuthoredAssessments lists the teacher's own assessments with answers
+// GET /api/v1/assessments/authored?limit=20&offset=0
+func (h *SkillAssessmentHandler) GetAuthoredAssessments(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	assessments, total, err := h.assessmentService.ListAuthored(userID, limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch assessments", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Assessments retrieved successfully", gin.H{
+		"assessments": assessments,
+		"total":       total,
+		"limit":       limit,
+		"offset":      offset,
+	})
+}
+
+// GetAuthoredAssessment gets one of the teacher's own assessments with answers
+// GET /api/v1/assessments/authored/:id
+func (h *SkillAssessmentHandler) GetAuthoredAssessment(c *gin.Context) {
+	editor, ok := teacherEditor(c)
+	if !ok {
+		return
+	}
+	h.getForEditor(c, editor)
+}
+
+// CreateAssessment authors an assessment as a teacher verified for the skill
+// POST /api/v1/assessments
+func (h *SkillAssessmentHandler) CreateAssessment(c *gin.Context) {
+	editor, ok := teacherEditor(c)
+	if !ok {
+		return
+	}
+	h.create(c, editor)
+}
+
+// UpdateAssessment edits one of the teacher's own assessments
+// PUT /api/v1/assessments/:id
+func (h *SkillAssessmentHandler) UpdateAssessment(c *gin.Context) {
+	editor, ok := teacherEditor(c)
+	if !ok {
+		return
+	}
+	h.update(c, editor)
+}
+
+// DeleteAssessment deletes one of the teacher's own assessments
+// DELETE /api/v1/assessments/:id
+func (h *SkillAssessmentHandler) DeleteAssessment(c *gin.Context) {
+	editor, ok := teacherEditor(c)
+	if !ok {
+		return
+	}
+	h.delete(c, editor)
+}
+
+// PublishAssessment opens one of the teacher's own assessments to takers
+// POST /api/v1/assessments/:id/publish
+func (h *SkillAssessmentHandler) PublishAssessment(c *gin.Context) {
+	editor, ok := teacherEditor(c)
+	if !ok {
+		return
+	}
+	h.setPublished(c, editor, true)
+}
+
+// UnpublishAssessment withdraws one of the teacher's own assessments
+// POST /api/v1/assessments/:id/unpublish
+func (h *SkillAssessmentHandler) UnpublishAssessment(c *gin.Context) {
+	editor, ok := teacherEditor(c)
+	if !ok {
+		return
+	}
+	h.setPublished(c, editor, false)
+}
+
+// StartAttempt starts or resumes a timed attempt
+// POST /api/v1/assessments/:id/attempts
+func (h *SkillAssessmentHandler) StartAttempt(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	id, ok := parseAssessmentID(c)
+	if !ok {
+		return
+	}
+
+	attempt, err := h.assessmentService.WithAudit(auditScope(c)).StartAttempt(userID, id)
+	if err != nil {
+		if err.Error() == "assessment not found" {
+			utils.SendError(c, http.StatusNotFound, "Assessment not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to start attempt", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Attempt started", attempt)
+}
+
+// GetUserAttempts lists the user's attempts
+// GET /api/v1/assessment-attempts?limit=20&offset=0
+func (h *SkillAssessmentHandler) GetUserAttempts(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	attempts, err := h.assessmentService.GetUserAttempts(userID, limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch attempts", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Attempts retrieved successfully", attempts)
+}
+
+// GetAttempt gets one of the user's attempts, with questions while it is running
+// GET /api/v1/assessment-attempts/:id
+func (h *SkillAssessmentHandler) GetAttempt(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid attempt ID", err)
+		return
+	}
+
+	attempt, err := h.assessmentService.GetAttempt(userID, uint(id))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Attempt not found", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Attempt retrieved successfully", attempt)
+}
+
+// SubmitAttempt submits answers for scoring
+// POST /api/v1/assessment-attempts/:id/submit
+func (h *SkillAssessmentHandler) SubmitAttempt(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid attempt ID", err)
+		return
+	}
+
+	var req dto.SubmitAttemptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	attempt, err := h.assessmentService.WithAudit(auditScope(c)).SubmitAttempt(userID, uint(id), &req)
+	if err != nil {
+		if err.Error() == "attempt not found" {
+			utils.SendError(c, http.StatusNotFound, "Attempt not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to submit attempt", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Attempt submitted", attempt)
+}
+
+// AdminListAssessments lists all assessments
+// GET /api/v1/admin/assessments?skill_id=1&limit=20&offset=0
+func (h *SkillAssessmentHandler) AdminListAssessments(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	skillID, ok := parseOptionalSkillID(c)
+	if !ok {
+		return
+	}
+
+	assessments, total, err := h.assessmentService.ListAll(skillID, limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch assessments", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Assessments retrieved successfully", gin.H{
+		"assessments": assessments,
+		"total":       total,
+		"limit":       limit,
+		"offset":      offset,
+	})
+}
+
+// AdminGetAssessment gets any assessment with answers
+// GET /api/v1/admin/assessments/:id
+func (h *SkillAssessmentHandler) AdminGetAssessment(c *gin.Context) {
+	h.getForEditor(c, adminEditor(c))
+}
+
+// AdminCreateAssessment authors an assessment for any skill
+// POST /api/v1/admin/assessments
+func (h *SkillAssessmentHandler) AdminCreateAssessment(c *gin.Context) {
+	h.create(c, adminEditor(c))
+}
+
+// AdminUpdateAssessment edits any assessment
+// PUT /api/v1/admin/assessments/:id
+func (h *SkillAssessmentHandler) AdminUpdateAssessment(c *gin.Context) {
+	h.update(c, adminEditor(c))
+}
+
+// AdminDeleteAssessment deletes any assessment
+// DELETE /api/v1/admin/assessments/:id
+func (h *SkillAssessmentHandler) AdminDeleteAssessment(c *gin.Context) {
+	h.delete(c, adminEditor(c))
+}
+
+// AdminPublishAssessment opens any assessment to takers
+// POST /api/v1/admin/assessments/:id/publish
+func (h *SkillAssessmentHandler) AdminPublishAssessment(c *gin.Context) {
+	h.setPublished(c, adminEditor(c), true)
+}
+
+// AdminUnpublishAssessment withdraws any assessment
+// POST /api/v1/admin/assessments/:id/unpublish
+func (h *SkillAssessmentHandler) AdminUnpublishAssessment(c *gin.Context) {
+	h.setPublished(c, adminEditor(c), false)
+}
+
+func (h *SkillAssessmentHandler) getForEditor(c *gin.Context, editor service.AssessmentEditor) {
+	id, ok := parseAssessmentID(c)
+	if !ok {
+		return
+	}
+
+	assessment, err := h.assessmentService.GetAssessmentForEditor(editor, id)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Assessment not found", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Assessment retrieved successfully", assessment)
+}
+
+func (h *SkillAssessmentHandler) create(c *gin.Context, editor service.AssessmentEditor) {
+	var req dto.CreateAssessmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	assessment, err := h.assessmentService.WithAudit(auditScope(c)).CreateAssessment(editor, &req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "only teachers") {
+			utils.SendError(c, http.StatusForbidden, "Not allowed to author this assessment", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to create assessment", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, "Assessment created successfully", assessment)
+}
+
+func (h *SkillAssessmentHandler) update(c *gin.Context, editor service.AssessmentEditor) {
+	id, ok := parseAssessmentID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateAssessmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	assessment, err := h.assessmentService.WithAudit(auditScope(c)).UpdateAssessment(editor, id, &req)
+	if err != nil {
+		if err.Error() == "assessment not found" {
+			utils.SendError(c, http.StatusNotFound, "Assessment not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to update assessment", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Assessment updated successfully", assessment)
+}
+
+func (h *SkillAssessmentHandler) delete(c *gin.Context, editor service.AssessmentEditor) {
+	id, ok := parseAssessmentID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.assessmentService.WithAudit(auditScope(c)).DeleteAssessment(editor, id); err != nil {
+		if err.Error() == "assessment not found" {
+			utils.SendError(c, http.StatusNotFound, "Assessment not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to delete assessment", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Assessment deleted successfully", nil)
+}
+
+func (h *SkillAssessmentHandler) setPublished(c *gin.Context, editor service.AssessmentEditor, published bool) {
+	id, ok := parseAssessmentID(c)
+	if !ok {
+		return
+	}
+
+	assessment, err := h.assessmentService.WithAudit(auditScope(c)).SetPublished(editor, id, published)
+	if err != nil {
+		if err.Error() == "assessment not found" {
+			utils.SendError(c, http.StatusNotFound, "Assessment not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to update assessment", err)
+		return
+	}
+
+	message := "Assessment unpublished"
+	if published {
+		message = "Assessment published"
+	}
+	utils.SendSuccess(c, http.StatusOK, message, assessment)
+}
+
+// teacherEditor identifies the authenticated user as an assessment editor
+func teacherEditor(c *gin.Context) (service.AssessmentEditor, bool) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return service.AssessmentEditor{}, false
+	}
+	return service.AssessmentEditor{UserID: userID}, true
+}
+
+// adminEditor identifies the authenticated admin as an assessment editor
+func adminEditor(c *gin.Context) service.AssessmentEditor {
+	return service.AssessmentEditor{AdminID: c.GetUint("admin_id")}
+}
+
+// parseAssessmentID parses the :id path parameter, responding with 400 when invalid
+func parseAssessmentID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid assessment ID", err)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parseOptionalSkillID parses the optional skill_id query parameter, responding with 400 when invalid
+func parseOptionalSkillID(c *gin.Context) (*uint, bool) {
+	skillIDStr := c.Query("skill_id")
+	if skillIDStr == "" {
+		return nil, true
+	}
+	id, err := strconv.ParseUint(skillIDStr, 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid skill ID", err)
+		return nil, false
+	}
+	skillID := uint(id)
+	return &skillID, true
+}
@@ -30,7 +30,8 @@ func NewSkillHandler(skillService *service.SkillService) *SkillHandler {
 // @Param limit query int false "Limit (default 10)"
 // @Param page query int false "Page (default 1)"
 // @Param category query string false "Category filter"
-// @Param search query string false "Search query"
+// @Param search query string false "Search query (also matches aliases and tags)"
+// @Param tag query string false "Tag filter"
 // @Param day query int false "Available day filter (0-6)"
 // @Param rating query number false "Minimum rating filter"
 // @Param location query string false "Location filter"
@@ -44,6 +45,7 @@ func (h *SkillHandler) GetSkills(c *gin.Context) {
 	pageStr := c.DefaultQuery("page", "1")
 	category := c.Query("category")
 	search := c.Query("search")
+	tag := c.Query("tag")
 	dayStr := c.Query("day")
 	ratingStr := c.Query("rating")
 	location := c.Query("location")
@@ -79,7 +81,7 @@ func (h *SkillHandler) GetSkills(c *gin.Context) {
 
 	// Try to get from cache if no search/filter
 	cache := utils.GetCache()
-	if search == "" && category == "" && dayOfWeek == nil && minRating == nil && location == "" && sortBy == "newest" && page == 1 {
+	if search == "" && category == "" && tag == "" && dayOfWeek == nil && minRating == nil && location == "" && sortBy == "newest" && page == 1 {
 		if cached, found := cache.Get(utils.CacheKeySkills); found {
 			utils.SendSuccess(c, http.StatusOK, "Skills retrieved from cache", cached)
 			return
@@ -87,7 +89,7 @@ func (h *SkillHandler) GetSkills(c *gin.Context) {
 	}
 
 	// Get skills from service
-	skills, total, err := h.skillService.GetAllSkills(limit, offset, category, search, dayOfWeek, minRating, location, sortBy)
+	skills, total, err := h.skillService.GetAllSkills(limit, offset, category, search, tag, dayOfWeek, minRating, location, sortBy)
 	if err != nil {
 		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch skills", err)
 		return
@@ -107,7 +109,7 @@ func (h *SkillHandler) GetSkills(c *gin.Context) {
 	}
 
 	// Cache response if no search/filter and page 1
-	if search == "" && category == "" && page == 1 {
+	if search == "" && category == "" && tag == "" && page == 1 {
 		cache.Set(utils.CacheKeySkills, response)
 	}
 
@@ -179,9 +181,11 @@ func (h *SkillHandler) CreateSkill(c *gin.Context) {
 		Category:    models.SkillCategory(req.Category),
 		Description: req.Description,
 		Icon:        req.Icon,
+		ParentID:    req.ParentID,
+		Tags:        req.Tags,
 	}
 
-	err := h.skillService.CreateSkill(skill)
+	err := h.skillService.WithAudit(auditScope(c)).CreateSkill(skill)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Failed to create skill", err)
 		return
@@ -213,7 +217,7 @@ func (h *SkillHandler) UpdateSkill(c *gin.Context) {
 		Icon:        req.Icon,
 	}
 
-	err = h.skillService.UpdateSkill(uint(id), updates)
+	err = h.skillService.WithAudit(auditScope(c)).UpdateSkill(uint(id), updates)
 	if err != nil {
 		if err.Error() == "skill not found" {
 			utils.SendError(c, http.StatusNotFound, "Skill not found", err)
@@ -238,7 +242,7 @@ func (h *SkillHandler) DeleteSkill(c *gin.Context) {
 		return
 	}
 
-	err = h.skillService.DeleteSkill(uint(id))
+	err = h.skillService.WithAudit(auditScope(c)).DeleteSkill(uint(id))
 	if err != nil {
 		if err.Error() == "skill not found" {
 			utils.SendError(c, http.StatusNotFound, "Skill not found", err)
@@ -281,7 +285,7 @@ func (h *SkillHandler) AddUserSkill(c *gin.Context) {
 		IsAvailable:       req.IsAvailable,
 	}
 
-	err := h.skillService.AddUserSkill(userID.(uint), userSkill)
+	err := h.skillService.WithAudit(auditScope(c)).AddUserSkill(userID.(uint), userSkill)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Failed to add skill", err)
 		return
@@ -361,7 +365,7 @@ func (h *SkillHandler) UpdateUserSkill(c *gin.Context) {
 		updates.IsAvailable = *req.IsAvailable
 	}
 
-	err = h.skillService.UpdateUserSkill(userID.(uint), uint(skillID), updates)
+	err = h.skillService.WithAudit(auditScope(c)).UpdateUserSkill(userID.(uint), uint(skillID), updates)
 	if err != nil {
 		if err.Error() == "user skill not found" {
 			utils.SendError(c, http.StatusNotFound, "User skill not found", err)
@@ -400,7 +404,7 @@ func (h *SkillHandler) DeleteUserSkill(c *gin.Context) {
 		return
 	}
 
-	err = h.skillService.DeleteUserSkill(userID.(uint), uint(skillID))
+	err = h.skillService.WithAudit(auditScope(c)).DeleteUserSkill(userID.(uint), uint(skillID))
 	if err != nil {
 		if err.Error() == "user skill not found" {
 			utils.SendError(c, http.StatusNotFound, "User skill not found", err)
@@ -437,7 +441,7 @@ func (h *SkillHandler) AddLearningSkill(c *gin.Context) {
 		Notes:        req.Notes,
 	}
 
-	err := h.skillService.AddLearningSkill(userID.(uint), learningSkill)
+	err := h.skillService.WithAudit(auditScope(c)).AddLearningSkill(userID.(uint), learningSkill)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Failed to add learning skill", err)
 		return
@@ -496,7 +500,7 @@ func (h *SkillHandler) DeleteLearningSkill(c *gin.Context) {
 		return
 	}
 
-	err = h.skillService.DeleteLearningSkill(userID.(uint), uint(skillID))
+	err = h.skillService.WithAudit(auditScope(c)).DeleteLearningSkill(userID.(uint), uint(skillID))
 	if err != nil {
 		if err.Error() == "learning skill not found" {
 			utils.SendError(c, http.StatusNotFound, "Learning skill not found", err)
@@ -71,7 +71,7 @@ func (h *SkillProgressHandler) UpdateProgress(c *gin.Context) {
 		return
 	}
 
-	response, err := h.service.UpdateProgress(userID, uint(skillIDUint), &req)
+	response, err := h.service.WithAudit(auditScope(c)).UpdateProgress(userID, uint(skillIDUint), &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Failed to update progress", err)
 		return
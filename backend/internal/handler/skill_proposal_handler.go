@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// SkillProposalHandler handles user skill proposals and their moderation queue
+type SkillProposalHandler struct {
+	proposalService *service.SkillProposalService
+}
+
+// NewSkillProposalHandler creates a new skill proposal handler
+func NewSkillProposalHandler(proposalService *service.SkillProposalService) *SkillProposalHandler {
+	return &SkillProposalHandler{proposalService: proposalService}
+}
+
+// ProposeSkill handles POST /api/v1/skill-proposals
+// Queues a new skill for moderation; the response lists similar existing skills
+func (h *SkillProposalHandler) ProposeSkill(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	var req dto.CreateSkillProposalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	proposal, err := h.proposalService.WithAudit(auditScope(c)).ProposeSkill(userID, &req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to propose skill", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, "Skill proposed successfully and is awaiting review", proposal)
+}
+
+// GetUserProposals handles GET /api/v1/skill-proposals
+// Retrieves the authenticated user's proposals and their review status
+func (h *SkillProposalHandler) GetUserProposals(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	proposals, err := h.proposalService.GetUserProposals(userID, limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch skill proposals", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill proposals retrieved successfully", proposals)
+}
+
+// ListProposals lists the skill proposal moderation queue
+// GET /api/v1/admin/skill-proposals?status=pending&limit=20&offset=0
+func (h *SkillProposalHandler) ListProposals(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	proposals, err := h.proposalService.ListProposals(c.DefaultQuery("status", "pending"), limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to fetch skill proposals", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill proposals retrieved successfully", proposals)
+}
+
+// GetProposal gets a skill proposal with possible duplicates
+// GET /api/v1/admin/skill-proposals/:id
+func (h *SkillProposalHandler) GetProposal(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid proposal ID", err)
+		return
+	}
+
+	proposal, err := h.proposalService.GetProposal(uint(id))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Skill proposal not found", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill proposal retrieved successfully", proposal)
+}
+
+// ApproveProposal creates (or links) the skill and attaches it to the proposer
+// POST /api/v1/admin/skill-proposals/:id/approve
+func (h *SkillProposalHandler) ApproveProposal(c *gin.Context) {
+	adminID := c.GetUint("admin_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid proposal ID", err)
+		return
+	}
+
+	var req dto.ApproveSkillProposalRequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	proposal, err := h.proposalService.WithAudit(auditScope(c)).ApproveProposal(adminID, uint(id), &req)
+	if err != nil {
+		if err.Error() == "proposal not found" {
+			utils.SendError(c, http.StatusNotFound, "Skill proposal not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to approve skill proposal", err)
+		return
+	}
+
+	utils.GetCache().Delete(utils.CacheKeySkills)
+	utils.SendSuccess(c, http.StatusOK, "Skill proposal approved", proposal)
+}
+
+// RejectProposal rejects a skill proposal with a reason
+// POST /api/v1/admin/skill-proposals/:id/reject
+func (h *SkillProposalHandler) RejectProposal(c *gin.Context) {
+	adminID := c.GetUint("admin_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid proposal ID", err)
+		return
+	}
+
+	var req dto.RejectSkillProposalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	proposal, err := h.proposalService.WithAudit(auditScope(c)).RejectProposal(adminID, uint(id), req.Note)
+	if err != nil {
+		if err.Error() == "proposal not found" {
+			utils.SendError(c, http.StatusNotFound, "Skill proposal not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to reject skill proposal", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill proposal rejected", proposal)
+}
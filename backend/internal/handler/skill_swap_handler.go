@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// SkillSwapHandler handles skill swap HTTP requests
+type SkillSwapHandler struct {
+	swapService *service.SkillSwapService
+}
+
+// NewSkillSwapHandler creates a new skill swap handler
+func NewSkillSwapHandler(swapService *service.SkillSwapService) *SkillSwapHandler {
+	return &SkillSwapHandler{swapService: swapService}
+}
+
+// FindSwaps handles GET /api/v1/swaps/matches
+// Suggests swap partners (pairs) and three-way cycles for the authenticated user
+func (h *SkillSwapHandler) FindSwaps(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	matches, err := h.swapService.FindSwaps(userID, limit)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to find skill swaps", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill swap matches retrieved successfully", matches)
+}
+
+// ProposeSwap handles POST /api/v1/swaps
+// Books one pending session per swap leg; the other participants must accept
+func (h *SkillSwapHandler) ProposeSwap(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	var req dto.ProposeSwapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	swap, err := h.swapService.WithAudit(auditScope(c)).ProposeSwap(userID, &req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to propose skill swap", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, "Skill swap proposed successfully", swap)
+}
+
+// GetUserSwaps handles GET /api/v1/swaps
+// Retrieves swaps the authenticated user takes part in
+func (h *SkillSwapHandler) GetUserSwaps(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	status := c.DefaultQuery("status", "")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if limit <= 0 || limit > 50 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	swaps, err := h.swapService.GetUserSwaps(userID, status, limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch skill swaps", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill swaps retrieved successfully", swaps)
+}
+
+// GetSwap handles GET /api/v1/swaps/:id
+func (h *SkillSwapHandler) GetSwap(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	swapID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid skill swap ID", err)
+		return
+	}
+
+	swap, err := h.swapService.GetSwap(userID, uint(swapID))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Skill swap not found", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill swap retrieved successfully", swap)
+}
+
+// AcceptSwap handles POST /api/v1/swaps/:id/accept
+// Approves all swap sessions once every participant has accepted
+func (h *SkillSwapHandler) AcceptSwap(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	swapID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid skill swap ID", err)
+		return
+	}
+
+	swap, err := h.swapService.WithAudit(auditScope(c)).AcceptSwap(userID, uint(swapID))
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to accept skill swap", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill swap accepted successfully", swap)
+}
+
+// DeclineSwap handles POST /api/v1/swaps/:id/decline
+func (h *SkillSwapHandler) DeclineSwap(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	swapID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid skill swap ID", err)
+		return
+	}
+
+	var req dto.CloseSwapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	swap, err := h.swapService.WithAudit(auditScope(c)).DeclineSwap(userID, uint(swapID), &req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to decline skill swap", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill swap declined successfully", swap)
+}
+
+// CancelSwap handles POST /api/v1/swaps/:id/cancel
+func (h *SkillSwapHandler) CancelSwap(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	swapID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid skill swap ID", err)
+		return
+	}
+
+	var req dto.CloseSwapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	swap, err := h.swapService.WithAudit(auditScope(c)).CancelSwap(userID, uint(swapID), &req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to cancel skill swap", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill swap cancelled successfully", swap)
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// SkillTaxonomyHandler handles skill hierarchy, tag, alias and merge HTTP requests
+type SkillTaxonomyHandler struct {
+	taxonomyService *service.SkillTaxonomyService
+}
+
+// NewSkillTaxonomyHandler creates a new skill taxonomy handler
+func NewSkillTaxonomyHandler(taxonomyService *service.SkillTaxonomyService) *SkillTaxonomyHandler {
+	return &SkillTaxonomyHandler{taxonomyService: taxonomyService}
+}
+
+// GetTree handles GET /api/v1/skills/tree
+// @Summary Get skill taxonomy tree
+// @Description Get all skills nested under their parent skills.
+// @Tags skills
+// @Produce json
+// @Success 200 {object} utils.SuccessResponse
+// @Router /skills/tree [get]
+func (h *SkillTaxonomyHandler) GetTree(c *gin.Context) {
+	tree, err := h.taxonomyService.GetTree()
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch skill tree", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill tree retrieved successfully", tree)
+}
+
+// ResolveSkill handles GET /api/v1/skills/resolve
+// @Summary Resolve a skill name
+// @Description Find the canonical skill for a name or alias (e.g. "JS" resolves to "JavaScript").
+// @Tags skills
+// @Produce json
+// @Param name query string true "Skill name or alias"
+// @Success 200 {object} utils.SuccessResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /skills/resolve [get]
+func (h *SkillTaxonomyHandler) ResolveSkill(c *gin.Context) {
+	skill, err := h.taxonomyService.ResolveSkill(c.Query("name"))
+	if err != nil {
+		if err.Error() == "skill not found" {
+			utils.SendError(c, http.StatusNotFound, "Skill not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to resolve skill", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill resolved successfully", dto.ToSkillResponse(skill))
+}
+
+// SetParent handles PUT /api/v1/admin/skills/:id/parent
+func (h *SkillTaxonomyHandler) SetParent(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid skill ID", err)
+		return
+	}
+
+	var req dto.SetSkillParentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	skill, err := h.taxonomyService.WithAudit(auditScope(c)).SetParent(uint(id), req.ParentID)
+	if err != nil {
+		if err.Error() == "skill not found" {
+			utils.SendError(c, http.StatusNotFound, "Skill not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to move skill", err)
+		return
+	}
+
+	utils.GetCache().Delete(utils.CacheKeySkills)
+	utils.SendSuccess(c, http.StatusOK, "Skill moved successfully", dto.ToSkillResponse(skill))
+}
+
+// SetTags handles PUT /api/v1/admin/skills/:id/tags
+func (h *SkillTaxonomyHandler) SetTags(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid skill ID", err)
+		return
+	}
+
+	var req dto.SetSkillTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	skill, err := h.taxonomyService.WithAudit(auditScope(c)).SetTags(uint(id), req.Tags)
+	if err != nil {
+		if err.Error() == "skill not found" {
+			utils.SendError(c, http.StatusNotFound, "Skill not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to update tags", err)
+		return
+	}
+
+	utils.GetCache().Delete(utils.CacheKeySkills)
+	utils.SendSuccess(c, http.StatusOK, "Skill tags updated successfully", dto.ToSkillResponse(skill))
+}
+
+// GetAliases handles GET /api/v1/admin/skills/:id/aliases
+func (h *SkillTaxonomyHandler) GetAliases(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid skill ID", err)
+		return
+	}
+
+	aliases, err := h.taxonomyService.GetAliases(uint(id))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Skill not found", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill aliases retrieved successfully", aliases)
+}
+
+// AddAlias handles POST /api/v1/admin/skills/:id/aliases
+func (h *SkillTaxonomyHandler) AddAlias(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid skill ID", err)
+		return
+	}
+
+	var req dto.CreateSkillAliasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	alias, err := h.taxonomyService.WithAudit(auditScope(c)).AddAlias(uint(id), req.Alias)
+	if err != nil {
+		if err.Error() == "skill not found" {
+			utils.SendError(c, http.StatusNotFound, "Skill not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to add alias", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, "Skill alias added successfully", alias)
+}
+
+// DeleteAlias handles DELETE /api/v1/admin/skills/:id/aliases/:aliasId
+func (h *SkillTaxonomyHandler) DeleteAlias(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid skill ID", err)
+		return
+	}
+	aliasID, err := strconv.ParseUint(c.Param("aliasId"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid alias ID", err)
+		return
+	}
+
+	if err := h.taxonomyService.WithAudit(auditScope(c)).DeleteAlias(uint(id), uint(aliasID)); err != nil {
+		if err.Error() == "alias not found" {
+			utils.SendError(c, http.StatusNotFound, "Alias not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to delete alias", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Skill alias deleted successfully", nil)
+}
+
+// MergeSkill handles POST /api/v1/admin/skills/:id/merge
+// Folds the skill in the URL into target_id and soft-deletes it
+func (h *SkillTaxonomyHandler) MergeSkill(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid skill ID", err)
+		return
+	}
+
+	var req dto.MergeSkillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	outcome, err := h.taxonomyService.WithAudit(auditScope(c)).MergeSkills(uint(id), req.TargetID)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to merge skills", err)
+		return
+	}
+
+	utils.GetCache().Delete(utils.CacheKeySkills)
+	utils.SendSuccess(c, http.StatusOK, "Skills merged successfully", outcome)
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// SkillVerificationHandler handles skill credential verification HTTP requests
+type SkillVerificationHandler struct {
+	verificationService *service.SkillVerificationService
+}
+
+// NewSkillVerificationHandler creates a new skill verification handler
+func NewSkillVerificationHandler(verificationService *service.SkillVerificationService) *SkillVerificationHandler {
+	return &SkillVerificationHandler{verificationService: verificationService}
+}
+
+// SubmitVerification handles POST /api/v1/user/skills/:skillId/verification
+// Multipart form with an optional "file" (PDF or image), proof_type, external_url and notes
+func (h *SkillVerificationHandler) SubmitVerification(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	skillID, err := strconv.ParseUint(c.Param("skillId"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid skill ID", err)
+		return
+	}
+
+	var req dto.SubmitSkillVerificationRequest
+	if err := c.ShouldBind(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	// The file is optional when an external link is given
+	file, _ := c.FormFile("file")
+
+	verification, err := h.verificationService.WithAudit(auditScope(c)).SubmitVerification(userID, uint(skillID), &req, file)
+	if err != nil {
+		if err.Error() == "user skill not found" {
+			utils.SendError(c, http.StatusNotFound, "User skill not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to submit verification", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, "Verification submitted and awaiting review", verification)
+}
+
+// GetUserVerifications handles GET /api/v1/user/verifications
+func (h *SkillVerificationHandler) GetUserVerifications(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	verifications, err := h.verificationService.GetUserVerifications(userID)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch verifications", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Verifications retrieved successfully", verifications)
+}
+
+// GetUserProofFile handles GET /api/v1/user/verifications/:id/proof
+func (h *SkillVerificationHandler) GetUserProofFile(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	h.sendProofFile(c, &userID)
+}
+
+// ListVerifications lists the skill verification moderation queue
+// GET /api/v1/admin/verifications?status=pending&user_id=1&limit=20&offset=0
+func (h *SkillVerificationHandler) ListVerifications(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	var userID *uint
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		id, err := strconv.ParseUint(userIDStr, 10, 32)
+		if err != nil {
+			utils.SendError(c, http.StatusBadRequest, "Invalid user ID", err)
+			return
+		}
+		uid := uint(id)
+		userID = &uid
+	}
+
+	verifications, total, err := h.verificationService.ListVerifications(c.DefaultQuery("status", "pending"), userID, limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to fetch verifications", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Verifications retrieved successfully", gin.H{
+		"verifications": verifications,
+		"total":         total,
+		"limit":         limit,
+		"offset":        offset,
+	})
+}
+
+// GetVerification gets a verification request
+// GET /api/v1/admin/verifications/:id
+func (h *SkillVerificationHandler) GetVerification(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid verification ID", err)
+		return
+	}
+
+	verification, err := h.verificationService.GetVerification(uint(id))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Verification not found", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Verification retrieved successfully", verification)
+}
+
+// GetProofFile downloads a verification's proof file
+// GET /api/v1/admin/verifications/:id/proof
+func (h *SkillVerificationHandler) GetProofFile(c *gin.Context) {
+	h.sendProofFile(c, nil)
+}
+
+// ApproveVerification verifies the skill until the configured expiry
+// POST /api/v1/admin/verifications/:id/approve
+func (h *SkillVerificationHandler) ApproveVerification(c *gin.Context) {
+	adminID := c.GetUint("admin_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid verification ID", err)
+		return
+	}
+
+	var req dto.ApproveSkillVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	verification, err := h.verificationService.WithAudit(auditScope(c)).ApproveVerification(adminID, uint(id), req.Note)
+	if err != nil {
+		if err.Error() == "verification not found" {
+			utils.SendError(c, http.StatusNotFound, "Verification not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to approve verification", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Verification approved", verification)
+}
+
+// RejectVerification rejects the proof with a reason
+// POST /api/v1/admin/verifications/:id/reject
+func (h *SkillVerificationHandler) RejectVerification(c *gin.Context) {
+	adminID := c.GetUint("admin_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid verification ID", err)
+		return
+	}
+
+	var req dto.RejectSkillVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	verification, err := h.verificationService.WithAudit(auditScope(c)).RejectVerification(adminID, uint(id), req.Note)
+	if err != nil {
+		if err.Error() == "verification not found" {
+			utils.SendError(c, http.StatusNotFound, "Verification not found", err)
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Failed to reject verification", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "Verification rejected", verification)
+}
+
+// sendProofFile streams a proof file; ownerID restricts access to the teacher's own proof
+func (h *SkillVerificationHandler) sendProofFile(c *gin.Context, ownerID *uint) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid verification ID", err)
+		return
+	}
+
+	verification, err := h.verificationService.GetProofFile(uint(id), ownerID)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Proof file not found", err)
+		return
+	}
+
+	c.FileAttachment(verification.FilePath, verification.FileName)
+}
@@ -36,7 +36,7 @@ func (h *StoryHandler) CreateStory(c *gin.Context) {
 		return
 	}
 
-	story, err := h.storyService.CreateStory(userID.(uint), &req)
+	story, err := h.storyService.WithAudit(auditScope(c)).CreateStory(userID.(uint), &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
@@ -150,7 +150,7 @@ func (h *StoryHandler) UpdateStory(c *gin.Context) {
 		return
 	}
 
-	story, err := h.storyService.UpdateStory(uint(storyID), userID.(uint), &req)
+	story, err := h.storyService.WithAudit(auditScope(c)).UpdateStory(uint(storyID), userID.(uint), &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
@@ -173,7 +173,7 @@ func (h *StoryHandler) DeleteStory(c *gin.Context) {
 		return
 	}
 
-	if err := h.storyService.DeleteStory(uint(storyID), userID.(uint)); err != nil {
+	if err := h.storyService.WithAudit(auditScope(c)).DeleteStory(uint(storyID), userID.(uint)); err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
 	}
@@ -197,7 +197,7 @@ func (h *StoryHandler) CreateComment(c *gin.Context) {
 		return
 	}
 
-	comment, err := h.storyService.CreateComment(userID.(uint), &req)
+	comment, err := h.storyService.WithAudit(auditScope(c)).CreateComment(userID.(uint), &req)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
@@ -257,7 +257,7 @@ func (h *StoryHandler) DeleteComment(c *gin.Context) {
 		return
 	}
 
-	if err := h.storyService.DeleteComment(uint(commentID), userID.(uint)); err != nil {
+	if err := h.storyService.WithAudit(auditScope(c)).DeleteComment(uint(commentID), userID.(uint)); err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
 	}
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
 	"github.com/timebankingskill/backend/internal/service"
 	"github.com/timebankingskill/backend/internal/utils"
 )
@@ -21,8 +23,8 @@ func NewTransactionHandler(transactionService *service.TransactionService) *Tran
 	}
 }
 
-// GetUserTransactions retrieves transaction history for the authenticated user
-// GET /api/v1/user/transactions?limit=10&offset=0
+// GetUserTransactions searches transaction history for the authenticated user
+// GET /api/v1/user/transactions?type=earned,spent&from=&to=&min_amount=&max_amount=&session_id=&counterparty_id=&q=&cursor=&limit=10
 func (h *TransactionHandler) GetUserTransactions(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
@@ -31,35 +33,26 @@ func (h *TransactionHandler) GetUserTransactions(c *gin.Context) {
 		return
 	}
 
-	// Get pagination parameters
-	limitStr := c.DefaultQuery("limit", "10")
-	offsetStr := c.DefaultQuery("offset", "0")
-
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		limit = 10
-	}
-
-	offset, err := strconv.Atoi(offsetStr)
-	if err != nil || offset < 0 {
-		offset = 0
+	// Bind filters and pagination parameters
+	var req dto.TransactionSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
 	}
 
-	// Get transactions
-	transactions, total, err := h.transactionService.GetUserTransactionHistory(userID.(uint), limit, offset)
+	// Search transactions
+	response, err := h.transactionService.SearchUserTransactions(userID.(uint), &req)
 	if err != nil {
-		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch transactions", err)
+		// Invalid filters and cursors are the client's fault; anything else is ours
+		switch {
+		case errors.Is(err, service.ErrInvalidTransactionFilter), errors.Is(err, service.ErrInvalidCursor):
+			utils.SendError(c, http.StatusBadRequest, "Failed to fetch transactions", err)
+		default:
+			utils.SendError(c, http.StatusInternalServerError, "Failed to fetch transactions", err)
+		}
 		return
 	}
 
-	// Return response
-	response := gin.H{
-		"transactions": transactions,
-		"total":        total,
-		"limit":        limit,
-		"offset":       offset,
-	}
-
 	utils.SendSuccess(c, http.StatusOK, "Transactions retrieved successfully", response)
 }
 
@@ -67,7 +67,7 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		Location:    req.Location,
 	}
 
-	err := h.userService.UpdateUserProfile(userID.(uint), updates)
+	err := h.userService.WithAudit(auditScope(c)).UpdateUserProfile(userID.(uint), updates)
 	if err != nil {
 		if err.Error() == "username already taken" {
 			utils.SendError(c, http.StatusConflict, "Username already taken", err)
@@ -98,7 +98,7 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 		return
 	}
 
-	err := h.userService.ChangePassword(userID.(uint), req.CurrentPassword, req.NewPassword)
+	err := h.userService.WithAudit(auditScope(c)).ChangePassword(userID.(uint), req.CurrentPassword, req.NewPassword)
 	if err != nil {
 		if err.Error() == "invalid current password" {
 			utils.SendError(c, http.StatusBadRequest, "Invalid current password", err)
@@ -155,7 +155,7 @@ func (h *UserHandler) UpdateAvatar(c *gin.Context) {
 		return
 	}
 
-	err := h.userService.UpdateAvatar(userID.(uint), req.Avatar)
+	err := h.userService.WithAudit(auditScope(c)).UpdateAvatar(userID.(uint), req.Avatar)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Failed to update avatar", err)
 		return
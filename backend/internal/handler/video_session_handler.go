@@ -35,7 +35,7 @@ func (h *VideoSessionHandler) StartVideoSession(c *gin.Context) {
 		return
 	}
 
-	response, err := h.videoSessionService.StartVideoSession(userID.(uint), uint(sessionID))
+	response, err := h.videoSessionService.WithAudit(auditScope(c)).StartVideoSession(userID.(uint), uint(sessionID))
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
@@ -65,7 +65,7 @@ func (h *VideoSessionHandler) EndVideoSession(c *gin.Context) {
 		return
 	}
 
-	response, err := h.videoSessionService.EndVideoSession(userID.(uint), uint(sessionID), req.Duration)
+	response, err := h.videoSessionService.WithAudit(auditScope(c)).EndVideoSession(userID.(uint), uint(sessionID), req.Duration)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error(), err)
 		return
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/service"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// XPHandler handles experience point and level requests
+type XPHandler struct {
+	xpService *service.XPService
+}
+
+// NewXPHandler creates a new XP handler
+func NewXPHandler(xpService *service.XPService) *XPHandler {
+	return &XPHandler{xpService: xpService}
+}
+
+// GetMyXP retrieves the current user's XP, level and today's progress towards daily caps
+// GET /api/v1/user/xp
+func (h *XPHandler) GetMyXP(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	summary, err := h.xpService.GetSummary(userID, true)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch XP", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "XP retrieved successfully", summary)
+}
+
+// GetMyXPHistory retrieves the current user's XP history
+// GET /api/v1/user/xp/history?action=review_written&from=2026-03-01&to=2026-03-31&limit=20&offset=0
+func (h *XPHandler) GetMyXPHistory(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		utils.SendError(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	h.sendHistory(c, userID)
+}
+
+// GetUserXP retrieves a user's public XP and level
+// GET /api/v1/users/:id/xp
+func (h *XPHandler) GetUserXP(c *gin.Context) {
+	userID, ok := parseXPUserID(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.xpService.GetSummary(userID, false)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch XP", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "XP retrieved successfully", summary)
+}
+
+// ListRules lists the XP every action earns and its daily cap
+// GET /api/v1/admin/xp/rules
+func (h *XPHandler) ListRules(c *gin.Context) {
+	rules, err := h.xpService.ListRules()
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to fetch XP rules", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "XP rules retrieved successfully", gin.H{
+		"rules": rules,
+		"total": len(rules),
+	})
+}
+
+// UpdateRule tunes the XP an action earns
+// PUT /api/v1/admin/xp/rules/:action
+func (h *XPHandler) UpdateRule(c *gin.Context) {
+	var req dto.UpdateXPRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	rule, err := h.xpService.WithAudit(auditScope(c)).UpdateRule(c.Param("action"), &req)
+	if err != nil {
+		if err.Error() == "XP action not found" {
+			utils.SendError(c, http.StatusNotFound, "Failed to update XP rule", err)
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, "Failed to update XP rule", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "XP rule updated successfully", rule)
+}
+
+// GetUserXPHistory retrieves any user's XP history
+// GET /api/v1/admin/xp/users/:id/history?action=session_completed
+func (h *XPHandler) GetUserXPHistory(c *gin.Context) {
+	userID, ok := parseXPUserID(c)
+	if !ok {
+		return
+	}
+	h.sendHistory(c, userID)
+}
+
+// sendHistory binds the history filters and responds with a page of a user's XP history
+func (h *XPHandler) sendHistory(c *gin.Context, userID uint) {
+	var query dto.XPHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	events, total, err := h.xpService.GetHistory(userID, &query)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to fetch XP history", err)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, "XP history retrieved successfully", gin.H{
+		"events": events,
+		"total":  total,
+	})
+}
+
+// parseXPUserID reads the :id user path parameter
+func parseXPUserID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid user ID", err)
+		return 0, false
+	}
+	return uint(id), true
+}
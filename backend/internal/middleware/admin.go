@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+// AdminMiddleware ensures the authenticated token belongs to an active admin account
+// Must run after AuthMiddleware. Only tokens issued by admin login carry the admin
+// role claim; user tokens are rejected even when their ID matches an admin's
+func AdminMiddleware(adminRepo *repository.AdminRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("role") != utils.TokenRoleAdmin {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Success: false,
+				Message: "Admin access required",
+			})
+			c.Abort()
+			return
+		}
+
+		admin, err := adminRepo.GetByID(c.GetUint("user_id"))
+		if err != nil {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Success: false,
+				Message: "Admin access required",
+			})
+			c.Abort()
+			return
+		}
+
+		if !admin.IsActive {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Success: false,
+				Message: "Admin account is inactive",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set("admin", admin)
+		c.Set("admin_id", admin.ID)
+
+		c.Next()
+	}
+}
+
+// RequireAdminPermission ensures the admin set by AdminMiddleware holds a permission
+// Permission names match models.AdminPermissions JSON keys (e.g. "manage_finance")
+func RequireAdminPermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, exists := c.Get("admin")
+		admin, ok := value.(*models.Admin)
+		if !exists || !ok || !admin.Can(permission) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Success: false,
+				Message: "Missing admin permission: " + permission,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -51,6 +51,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		// Set user ID in context (use snake_case for consistency with handlers)
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("role", claims.Role)
 
 		c.Next()
 	}
@@ -72,6 +73,7 @@ func OptionalAuth() gin.HandlerFunc {
 			if err == nil {
 				c.Set("user_id", claims.UserID)
 				c.Set("email", claims.Email)
+				c.Set("role", claims.Role)
 			}
 		}
 
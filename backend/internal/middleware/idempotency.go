@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+	"github.com/timebankingskill/backend/internal/utils"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the client-generated key
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotencyReplayedHeader is set on responses replayed from a stored key
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// idempotencyStaleAfter frees keys left in processing by a crashed request
+	idempotencyStaleAfter = 2 * time.Minute
+)
+
+// Idempotency makes state-changing endpoints safe to retry
+// Requests without an Idempotency-Key header pass through unchanged.
+// The first request with a key is executed and its response stored for ttl;
+// repeats replay the stored response, concurrent repeats get 409 Conflict,
+// and reusing a key for a different request gets 422.
+// Must run after AuthMiddleware since keys are scoped per account
+type Idempotency struct {
+	repo *repository.IdempotencyRepository
+	ttl  time.Duration
+}
+
+// NewIdempotency creates the idempotency middleware and starts expired-key cleanup
+func NewIdempotency(repo *repository.IdempotencyRepository, ttl time.Duration) *Idempotency {
+	i := &Idempotency{
+		repo: repo,
+		ttl:  ttl,
+	}
+
+	go i.cleanup()
+
+	return i
+}
+
+// Middleware returns the gin handler enforcing idempotency keys
+func (i *Idempotency) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			abortIdempotency(c, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			return
+		}
+
+		userID := c.GetUint("user_id")
+		if userID == 0 {
+			abortIdempotency(c, http.StatusUnauthorized, "User not authenticated")
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abortIdempotency(c, http.StatusBadRequest, "Failed to read request body")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Admin and user IDs can collide, so the token role is part of the scope
+		actorType := c.GetString("role")
+		if actorType == "" {
+			actorType = utils.TokenRoleUser
+		}
+
+		hash := sha256.Sum256(body)
+		record := &models.IdempotencyKey{
+			ActorType:   actorType,
+			UserID:      userID,
+			Key:         key,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			RequestHash: hex.EncodeToString(hash[:]),
+			Status:      models.IdempotencyProcessing,
+			ExpiresAt:   time.Now().Add(i.ttl),
+		}
+
+		existing, err := i.repo.Reserve(record, idempotencyStaleAfter)
+		if err != nil {
+			abortIdempotency(c, http.StatusInternalServerError, "Failed to process Idempotency-Key")
+			return
+		}
+
+		if existing != nil {
+			i.replay(c, existing, record)
+			return
+		}
+
+		// Execute the handler while capturing its response
+		writer := &idempotencyResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		status := writer.Status()
+
+		// Server errors are not stored so the client can retry with the same key
+		if status >= http.StatusInternalServerError {
+			if err := i.repo.Release(record.ID); err != nil {
+				log.Printf("Failed to release idempotency key %d: %v", record.ID, err)
+			}
+			return
+		}
+
+		if err := i.repo.Complete(record.ID, status, writer.Header().Get("Content-Type"), writer.body.Bytes()); err != nil {
+			log.Printf("Failed to store idempotent response for key %d: %v", record.ID, err)
+		}
+	}
+}
+
+// replay answers a request whose key was already used
+func (i *Idempotency) replay(c *gin.Context, existing, incoming *models.IdempotencyKey) {
+	if existing.Method != incoming.Method || existing.Path != incoming.Path || existing.RequestHash != incoming.RequestHash {
+		abortIdempotency(c, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+		return
+	}
+
+	if existing.Status != models.IdempotencyCompleted {
+		abortIdempotency(c, http.StatusConflict, "A request with this Idempotency-Key is already in progress")
+		return
+	}
+
+	contentType := existing.ContentType
+	if contentType == "" {
+		contentType = "application/json; charset=utf-8"
+	}
+
+	c.Header(IdempotencyReplayedHeader, "true")
+	c.Data(existing.ResponseCode, contentType, existing.ResponseBody)
+	c.Abort()
+}
+
+// cleanup periodically removes expired keys
+func (i *Idempotency) cleanup() {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := i.repo.DeleteExpired(); err != nil {
+			log.Printf("Failed to delete expired idempotency keys: %v", err)
+		}
+	}
+}
+
+// abortIdempotency aborts the request with an error response
+func abortIdempotency(c *gin.Context, status int, message string) {
+	c.JSON(status, ErrorResponse{
+		Success: false,
+		Message: message,
+	})
+	c.Abort()
+}
+
+// idempotencyResponseWriter copies the response body while writing it
+type idempotencyResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write writes to the client and the capture buffer
+func (w *idempotencyResponseWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString writes to the client and the capture buffer
+func (w *idempotencyResponseWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -63,6 +63,17 @@ func (a *Admin) GetPermissions() AdminPermissions {
 	return perms
 }
 
+// IsSuperAdmin checks if admin has the super_admin role
+func (a *Admin) IsSuperAdmin() bool {
+	return a.Role == "super_admin"
+}
+
+// Can checks if admin may perform an action guarded by permission
+// Super admins implicitly hold every permission
+func (a *Admin) Can(permission string) bool {
+	return a.IsSuperAdmin() || a.HasPermission(permission)
+}
+
 // HasPermission checks if admin has specific permission
 func (a *Admin) HasPermission(permission string) bool {
 	perms := a.GetPermissions()
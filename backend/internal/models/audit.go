@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// AuditActorType identifies who performed an audited mutation
+type AuditActorType string
+
+const (
+	AuditActorUser   AuditActorType = "user"
+	AuditActorAdmin  AuditActorType = "admin"
+	AuditActorSystem AuditActorType = "system"
+)
+
+// AuditAction identifies the kind of audited mutation
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+// AuditEvent is an immutable record of a single service-layer mutation
+// Events are append-only: they have no UpdatedAt/DeletedAt and are never modified
+type AuditEvent struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	// Actor
+	ActorType AuditActorType `gorm:"size:20;not null;index:idx_audit_actor" json:"actor_type"`
+	ActorID   uint           `gorm:"index:idx_audit_actor" json:"actor_id"`
+
+	// Subject
+	Action   AuditAction `gorm:"size:20;not null" json:"action"`
+	Entity   string      `gorm:"size:50;not null;index:idx_audit_entity" json:"entity"` // Table name, e.g. "users"
+	EntityID uint        `gorm:"index:idx_audit_entity" json:"entity_id"`
+
+	// Changes is a JSON object of field -> {"before": x, "after": y}
+	Changes string `gorm:"type:text" json:"changes"`
+
+	// Request
+	IP        string `gorm:"size:45" json:"ip"`
+	RequestID string `gorm:"size:64;index" json:"request_id"`
+}
+
+// TableName specifies the table name for AuditEvent model
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
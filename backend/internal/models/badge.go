@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -27,9 +28,10 @@ type Badge struct {
 	Name        string    `gorm:"not null;uniqueIndex" json:"name"`
 	Description string    `gorm:"type:text" json:"description"`
 	Icon        string    `json:"icon"` // Icon URL or emoji
+	IconPath    string    `json:"-"`    // Stored file of an uploaded icon; Icon then points at its public URL
 	Type        BadgeType `gorm:"not null" json:"type"`
 
-	// Requirements (stored as JSON for flexibility)
+	// Requirements (stored as JSON for flexibility, see BadgeRule)
 	Requirements string `gorm:"type:jsonb" json:"requirements"` // e.g., {"sessions": 10, "rating": 4.5}
 
 	// Reward
@@ -55,7 +57,21 @@ func (Badge) TableName() string {
 	return "badges"
 }
 
+// BeforeSave hook - rejects requirements the badge rule engine can't evaluate
+// Only whole-badge writes are checked; column updates such as the award counter
+// run against an empty model and are skipped
+func (b *Badge) BeforeSave(tx *gorm.DB) error {
+	if dest, ok := tx.Statement.Dest.(*Badge); !ok || dest != b {
+		return nil
+	}
+	if _, err := ParseBadgeRequirements(b.Requirements); err != nil {
+		return fmt.Errorf("invalid requirements for badge %s: %w", b.Name, err)
+	}
+	return nil
+}
+
 // UserBadge represents a badge earned by a user
+// A revoked badge is soft deleted; the row is kept so the badge isn't awarded again
 type UserBadge struct {
 	ID        uint           `gorm:"primarykey" json:"id"`
 	CreatedAt time.Time      `json:"created_at"`
@@ -75,6 +91,13 @@ type UserBadge struct {
 	// Display
 	IsPinned bool `gorm:"default:false" json:"is_pinned"` // User can pin favorite badges
 
+	// Bonus credits paid out with the award; nil for awards made before it was recorded
+	BonusGranted *float64 `json:"bonus_granted"`
+
+	// Revocation
+	RevokedBy    *uint  `json:"revoked_by,omitempty"` // Admin who revoked the badge
+	RevokeReason string `gorm:"type:text" json:"revoke_reason,omitempty"`
+
 	// Relationships
 	User  User  `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Badge Badge `gorm:"foreignKey:BadgeID" json:"badge,omitempty"`
@@ -85,6 +108,34 @@ func (UserBadge) TableName() string {
 	return "user_badges"
 }
 
+// BadgeProgress tracks how close a user is to a badge they haven't earned yet
+// The row is removed when the badge is awarded
+type BadgeProgress struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID  uint `gorm:"not null;uniqueIndex:idx_badge_progress" json:"user_id"`
+	BadgeID uint `gorm:"not null;uniqueIndex:idx_badge_progress;index" json:"badge_id"`
+
+	// Requirement furthest from being met, e.g. 7 of 10 sessions
+	Metric     string  `json:"metric"`
+	Current    float64 `gorm:"default:0" json:"current"`
+	Target     float64 `gorm:"default:0" json:"target"`
+	WindowDays int     `gorm:"default:0" json:"window_days"`
+
+	// Overall completion across every requirement, 0-100
+	Percent float64 `gorm:"default:0;index" json:"percent"`
+
+	// Relationships
+	Badge Badge `gorm:"foreignKey:BadgeID" json:"badge,omitempty"`
+}
+
+// TableName specifies the table name for BadgeProgress model
+func (BadgeProgress) TableName() string {
+	return "badge_progress"
+}
+
 // IsCompleted checks if badge is fully earned
 func (ub *UserBadge) IsCompleted() bool {
 	if ub.ProgressGoal == 0 {
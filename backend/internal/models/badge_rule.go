@@ -0,0 +1,288 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Badge metrics a requirement can test
+const (
+	BadgeMetricSessions          = "sessions"            // Completed sessions in either role
+	BadgeMetricSessionsAsTeacher = "sessions_as_teacher" // Completed sessions as teacher
+	BadgeMetricSessionsAsStudent = "sessions_as_student" // Completed sessions as student
+	BadgeMetricHoursTaught       = "hours_taught"        // Hours of completed sessions as teacher
+	BadgeMetricHoursLearned      = "hours_learned"       // Hours of completed sessions as student
+	BadgeMetricUniqueSkills      = "unique_skills"       // Different skills taught in completed sessions
+	BadgeMetricRating            = "rating"              // Average of the teacher and student ratings received
+	BadgeMetricTeacherRating     = "teacher_rating"      // Average rating received as teacher
+	BadgeMetricStudentRating     = "student_rating"      // Average rating received as student
+	BadgeMetricReviewsReceived   = "reviews_received"    // Published reviews received in either role
+	BadgeMetricTotalEarned       = "total_earned"        // Credits earned from teaching
+	BadgeMetricTotalSpent        = "total_spent"         // Credits spent on learning
+	BadgeMetricBadgesEarned      = "badges_earned"       // Other badges earned
+	BadgeMetricAccountAgeDays    = "account_age_days"    // Days since the account was created
+)
+
+// BadgeMetric describes a metric badge requirements can test
+type BadgeMetric struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Windowed    bool   `json:"windowed"` // Supports window_days
+}
+
+// badgeMetrics is the registry of metrics badge requirements may use
+var badgeMetrics = map[string]BadgeMetric{
+	BadgeMetricSessions:          {BadgeMetricSessions, "Completed sessions in either role", true},
+	BadgeMetricSessionsAsTeacher: {BadgeMetricSessionsAsTeacher, "Completed sessions as teacher", true},
+	BadgeMetricSessionsAsStudent: {BadgeMetricSessionsAsStudent, "Completed sessions as student", true},
+	BadgeMetricHoursTaught:       {BadgeMetricHoursTaught, "Hours of completed sessions as teacher", true},
+	BadgeMetricHoursLearned:      {BadgeMetricHoursLearned, "Hours of completed sessions as student", true},
+	BadgeMetricUniqueSkills:      {BadgeMetricUniqueSkills, "Different skills taught in completed sessions", true},
+	BadgeMetricRating:            {BadgeMetricRating, "Average of the teacher and student ratings received", false},
+	BadgeMetricTeacherRating:     {BadgeMetricTeacherRating, "Average rating received as teacher", false},
+	BadgeMetricStudentRating:     {BadgeMetricStudentRating, "Average rating received as student", false},
+	BadgeMetricReviewsReceived:   {BadgeMetricReviewsReceived, "Published reviews received in either role", true},
+	BadgeMetricTotalEarned:       {BadgeMetricTotalEarned, "Credits earned from teaching", true},
+	BadgeMetricTotalSpent:        {BadgeMetricTotalSpent, "Credits spent on learning", true},
+	BadgeMetricBadgesEarned:      {BadgeMetricBadgesEarned, "Other badges earned", true},
+	BadgeMetricAccountAgeDays:    {BadgeMetricAccountAgeDays, "Days since the account was created", false},
+}
+
+// badgeMetricAliases maps the keys used by older badge definitions to registry metrics
+var badgeMetricAliases = map[string]string{
+	"teaching_sessions": BadgeMetricSessionsAsTeacher,
+	"learning_sessions": BadgeMetricSessionsAsStudent,
+	"credits_earned":    BadgeMetricTotalEarned,
+}
+
+// Comparison operators a requirement can use
+const (
+	BadgeOpGTE = ">="
+	BadgeOpGT  = ">"
+	BadgeOpLTE = "<="
+	BadgeOpLT  = "<"
+	BadgeOpEQ  = "=="
+	BadgeOpNE  = "!="
+)
+
+const (
+	maxBadgeRuleDepth  = 5    // Nesting limit for all/any/not
+	maxBadgeWindowDays = 3650 // Longest time window a requirement can use
+)
+
+// BadgeRule is one node of a badge's requirement tree
+// Exactly one of All, Any, Not or Metric is set. A metric node compares the
+// metric's value, optionally counted over the last WindowDays days, to Value.
+//
+// Example ("5 sessions in the last 30 days and a 4.5+ rating"):
+//
+//	{"all": [
+//	  {"metric": "sessions", "op": ">=", "value": 5, "window_days": 30},
+//	  {"metric": "rating", "op": ">=", "value": 4.5}
+//	]}
+//
+// The older flat form {"sessions": 10, "rating": 4.5} is still accepted and
+// means every listed metric must be at least its value.
+type BadgeRule struct {
+	All []BadgeRule `json:"all,omitempty"`
+	Any []BadgeRule `json:"any,omitempty"`
+	Not *BadgeRule  `json:"not,omitempty"`
+
+	Metric     string  `json:"metric,omitempty"`
+	Op         string  `json:"op,omitempty"` // Defaults to >=
+	Value      float64 `json:"value,omitempty"`
+	WindowDays int     `json:"window_days,omitempty"` // Only count activity in the last N days
+}
+
+// BadgeMetrics lists the metrics badge requirements may use, by name
+func BadgeMetrics() []BadgeMetric {
+	metrics := make([]BadgeMetric, 0, len(badgeMetrics))
+	for _, metric := range badgeMetrics {
+		metrics = append(metrics, metric)
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics
+}
+
+// ParseBadgeRequirements parses and validates a badge's requirements JSON
+// Aliased metric names are normalised and a missing operator becomes >=
+func ParseBadgeRequirements(raw string) (*BadgeRule, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, errors.New("requirements are empty")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return nil, fmt.Errorf("requirements must be a JSON object: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("requirements are empty")
+	}
+
+	var rule BadgeRule
+	if isBadgeRuleNode(fields) {
+		decoder := json.NewDecoder(bytes.NewReader([]byte(raw)))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&rule); err != nil {
+			return nil, fmt.Errorf("invalid requirements: %w", err)
+		}
+	} else {
+		// Flat form: every metric must reach its value
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			var value float64
+			if err := json.Unmarshal(fields[key], &value); err != nil {
+				return nil, fmt.Errorf("requirement %q must be a number", key)
+			}
+			rule.All = append(rule.All, BadgeRule{Metric: key, Op: BadgeOpGTE, Value: value})
+		}
+	}
+
+	if err := rule.normalize(1); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Metrics lists the distinct metrics the rule tests
+func (r *BadgeRule) Metrics() []string {
+	seen := map[string]bool{}
+	var metrics []string
+	r.walk(func(node *BadgeRule) {
+		if node.Metric != "" && !seen[node.Metric] {
+			seen[node.Metric] = true
+			metrics = append(metrics, node.Metric)
+		}
+	})
+	return metrics
+}
+
+// IsTimeRelative reports whether the rule's outcome can change with time alone,
+// because it uses a time window or the account age
+func (r *BadgeRule) IsTimeRelative() bool {
+	relative := false
+	r.walk(func(node *BadgeRule) {
+		if node.WindowDays > 0 || node.Metric == BadgeMetricAccountAgeDays {
+			relative = true
+		}
+	})
+	return relative
+}
+
+// Compare applies the rule's operator to a metric value
+func (r *BadgeRule) Compare(value float64) bool {
+	switch r.Op {
+	case BadgeOpGT:
+		return value > r.Value
+	case BadgeOpLTE:
+		return value <= r.Value
+	case BadgeOpLT:
+		return value < r.Value
+	case BadgeOpEQ:
+		return value == r.Value
+	case BadgeOpNE:
+		return value != r.Value
+	default:
+		return value >= r.Value
+	}
+}
+
+// walk visits the rule and every node below it
+func (r *BadgeRule) walk(visit func(*BadgeRule)) {
+	visit(r)
+	for i := range r.All {
+		r.All[i].walk(visit)
+	}
+	for i := range r.Any {
+		r.Any[i].walk(visit)
+	}
+	if r.Not != nil {
+		r.Not.walk(visit)
+	}
+}
+
+// normalize validates a node and its children, resolving aliases and default operators
+func (r *BadgeRule) normalize(depth int) error {
+	if depth > maxBadgeRuleDepth {
+		return fmt.Errorf("requirements nest deeper than %d levels", maxBadgeRuleDepth)
+	}
+
+	kinds := 0
+	if r.All != nil {
+		kinds++
+	}
+	if r.Any != nil {
+		kinds++
+	}
+	if r.Not != nil {
+		kinds++
+	}
+	if r.Metric != "" {
+		kinds++
+	}
+	if kinds != 1 {
+		return errors.New("each requirement must have exactly one of all, any, not or metric")
+	}
+
+	switch {
+	case r.All != nil || r.Any != nil:
+		children := r.All
+		if r.Any != nil {
+			children = r.Any
+		}
+		if len(children) == 0 {
+			return errors.New("all and any need at least one requirement")
+		}
+		for i := range children {
+			if err := children[i].normalize(depth + 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case r.Not != nil:
+		return r.Not.normalize(depth + 1)
+	}
+
+	if alias, ok := badgeMetricAliases[r.Metric]; ok {
+		r.Metric = alias
+	}
+	metric, ok := badgeMetrics[r.Metric]
+	if !ok {
+		return fmt.Errorf("unknown metric %q", r.Metric)
+	}
+
+	switch r.Op {
+	case "":
+		r.Op = BadgeOpGTE
+	case BadgeOpGTE, BadgeOpGT, BadgeOpLTE, BadgeOpLT, BadgeOpEQ, BadgeOpNE:
+	default:
+		return fmt.Errorf("unknown operator %q for metric %q", r.Op, r.Metric)
+	}
+
+	if r.WindowDays < 0 || r.WindowDays > maxBadgeWindowDays {
+		return fmt.Errorf("window_days for metric %q must be between 0 and %d", r.Metric, maxBadgeWindowDays)
+	}
+	if r.WindowDays > 0 && !metric.Windowed {
+		return fmt.Errorf("metric %q does not support window_days", r.Metric)
+	}
+	return nil
+}
+
+// isBadgeRuleNode reports whether requirements use the rule tree form rather than the flat form
+func isBadgeRuleNode(fields map[string]json.RawMessage) bool {
+	for _, key := range []string{"all", "any", "not", "metric"} {
+		if _, ok := fields[key]; ok {
+			return true
+		}
+	}
+	return false
+}
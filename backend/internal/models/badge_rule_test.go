@@ -0,0 +1,127 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBadgeRequirementsFlatForm(t *testing.T) {
+	rule, err := ParseBadgeRequirements(`{"teaching_sessions": 10, "rating": 4.5}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := &BadgeRule{All: []BadgeRule{
+		{Metric: BadgeMetricRating, Op: BadgeOpGTE, Value: 4.5},
+		{Metric: BadgeMetricSessionsAsTeacher, Op: BadgeOpGTE, Value: 10},
+	}}
+	if !reflect.DeepEqual(rule, want) {
+		t.Errorf("rule = %+v, want %+v", rule, want)
+	}
+}
+
+func TestParseBadgeRequirementsTreeForm(t *testing.T) {
+	rule, err := ParseBadgeRequirements(`{"any": [
+		{"metric": "sessions", "value": 5, "window_days": 30},
+		{"not": {"metric": "credits_earned", "op": "<", "value": 20}}
+	]}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := rule.Any[0].Op; got != BadgeOpGTE {
+		t.Errorf("missing operator should default to >=, got %q", got)
+	}
+	if got := rule.Any[1].Not.Metric; got != BadgeMetricTotalEarned {
+		t.Errorf("aliased metric should be normalised, got %q", got)
+	}
+	if got := rule.Metrics(); !reflect.DeepEqual(got, []string{BadgeMetricSessions, BadgeMetricTotalEarned}) {
+		t.Errorf("Metrics() = %v", got)
+	}
+	if !rule.IsTimeRelative() {
+		t.Error("a rule with a time window should be time relative")
+	}
+}
+
+func TestParseBadgeRequirementsErrors(t *testing.T) {
+	deep := `{"metric": "sessions", "value": 1}`
+	for i := 0; i < maxBadgeRuleDepth; i++ {
+		deep = `{"not": ` + deep + `}`
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"empty", "  ", "requirements are empty"},
+		{"empty object", "{}", "requirements are empty"},
+		{"not an object", "[1, 2]", "must be a JSON object"},
+		{"flat value not a number", `{"sessions": "ten"}`, `requirement "sessions" must be a number`},
+		{"unknown metric", `{"karma": 3}`, `unknown metric "karma"`},
+		{"unknown operator", `{"metric": "sessions", "op": "~", "value": 3}`, `unknown operator "~"`},
+		{"unknown field", `{"metric": "sessions", "value": 3, "days": 7}`, "invalid requirements"},
+		{"two kinds", `{"metric": "sessions", "value": 3, "not": {"metric": "rating", "value": 4}}`, "exactly one of"},
+		{"empty all", `{"all": []}`, "at least one requirement"},
+		{"window not supported", `{"metric": "rating", "value": 4, "window_days": 30}`, "does not support window_days"},
+		{"window too long", `{"metric": "sessions", "value": 4, "window_days": 4000}`, "window_days"},
+		{"negative window", `{"metric": "sessions", "value": 4, "window_days": -1}`, "window_days"},
+		{"too deep", deep, "nest deeper"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBadgeRequirements(tt.raw)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBadgeRuleIsTimeRelative(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{`{"sessions": 10}`, false},
+		{`{"metric": "total_spent", "value": 5, "window_days": 7}`, true},
+		{`{"all": [{"metric": "sessions", "value": 1}, {"not": {"metric": "account_age_days", "op": "<", "value": 30}}]}`, true},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseBadgeRequirements(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseBadgeRequirements(%s): %v", tt.raw, err)
+		}
+		if got := rule.IsTimeRelative(); got != tt.want {
+			t.Errorf("IsTimeRelative(%s) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestBadgeRuleCompare(t *testing.T) {
+	tests := []struct {
+		op    string
+		value float64
+		want  bool
+	}{
+		{BadgeOpGTE, 5, true},
+		{BadgeOpGTE, 4.9, false},
+		{BadgeOpGT, 5, false},
+		{BadgeOpGT, 5.1, true},
+		{BadgeOpLTE, 5, true},
+		{BadgeOpLT, 5, false},
+		{BadgeOpEQ, 5, true},
+		{BadgeOpNE, 5, false},
+		{"", 5, true},
+	}
+
+	for _, tt := range tests {
+		rule := BadgeRule{Metric: BadgeMetricSessions, Op: tt.op, Value: 5}
+		if got := rule.Compare(tt.value); got != tt.want {
+			t.Errorf("%v %s 5 = %v, want %v", tt.value, tt.op, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ChallengeMetric is the activity a challenge counts
+type ChallengeMetric string
+
+const (
+	ChallengeSessions        ChallengeMetric = "sessions"         // Completed sessions in either role
+	ChallengeSessionsTaught  ChallengeMetric = "sessions_taught"  // Completed sessions as teacher
+	ChallengeSessionsLearned ChallengeMetric = "sessions_learned" // Completed sessions as student
+	ChallengeHoursTaught     ChallengeMetric = "hours_taught"     // Hours of completed sessions as teacher
+	ChallengeHoursLearned    ChallengeMetric = "hours_learned"    // Hours of completed sessions as student
+)
+
+// ChallengeMetrics lists every challenge metric
+var ChallengeMetrics = []ChallengeMetric{
+	ChallengeSessions, ChallengeSessionsTaught, ChallengeSessionsLearned, ChallengeHoursTaught, ChallengeHoursLearned,
+}
+
+// Challenge is a time-limited community goal defined by admins,
+// e.g. "Teach 3 language sessions in March"
+// Only sessions completed inside the window by enrolled users count
+type Challenge struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Title         string          `gorm:"not null" json:"title"`
+	Description   string          `gorm:"type:text" json:"description"`
+	Metric        ChallengeMetric `gorm:"type:varchar(30);not null" json:"metric"`
+	SkillCategory SkillCategory   `gorm:"type:varchar(50)" json:"skill_category"` // Empty counts every category
+	Target        float64         `gorm:"not null" json:"target"`                 // Sessions or hours, depending on Metric
+	StartsAt      time.Time       `gorm:"not null;index" json:"starts_at"`
+	EndsAt        time.Time       `gorm:"not null;index" json:"ends_at"` // Exclusive
+
+	// Rewards, granted once when a participant reaches the target
+	RewardCredits float64 `gorm:"default:0" json:"reward_credits"`
+	RewardBadgeID *uint   `json:"reward_badge_id"`
+
+	IsActive    bool       `gorm:"default:true;index" json:"is_active"` // Inactive challenges are hidden and can't be joined
+	CreatedBy   uint       `json:"created_by"`                          // Admin who created the challenge
+	FinalizedAt *time.Time `json:"finalized_at"`                        // When final progress was settled after the challenge ended
+
+	// Stats
+	ParticipantCount int `gorm:"default:0" json:"participant_count"`
+	CompletedCount   int `gorm:"default:0" json:"completed_count"`
+
+	// Relationships
+	RewardBadge *Badge `gorm:"foreignKey:RewardBadgeID" json:"reward_badge,omitempty"`
+}
+
+// TableName specifies the table name for Challenge model
+func (Challenge) TableName() string {
+	return "challenges"
+}
+
+// IsRunning reports whether the challenge window contains now
+func (c *Challenge) IsRunning(now time.Time) bool {
+	return !now.Before(c.StartsAt) && now.Before(c.EndsAt)
+}
+
+// ChallengeParticipant is a user's enrolment in a challenge
+type ChallengeParticipant struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	ChallengeID uint `gorm:"not null;uniqueIndex:idx_challenge_participant" json:"challenge_id"`
+	UserID      uint `gorm:"not null;uniqueIndex:idx_challenge_participant;index" json:"user_id"`
+
+	Progress    float64    `gorm:"default:0" json:"progress"` // Same unit as the challenge target
+	CompletedAt *time.Time `json:"completed_at"`              // When the target was reached
+
+	// Reward
+	RewardedAt          *time.Time `json:"rewarded_at"`
+	RewardTransactionID *uint      `json:"reward_transaction_id"` // Ledger entry of the credit reward
+
+	// Relationships
+	Challenge Challenge `gorm:"foreignKey:ChallengeID" json:"challenge,omitempty"`
+	User      User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for ChallengeParticipant model
+func (ChallengeParticipant) TableName() string {
+	return "challenge_participants"
+}
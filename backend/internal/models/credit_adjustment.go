@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AdjustmentStatus represents the approval state of a credit adjustment
+type AdjustmentStatus string
+
+const (
+	AdjustmentPending  AdjustmentStatus = "pending"  // Waiting for a second admin's approval
+	AdjustmentApplied  AdjustmentStatus = "applied"  // Credits have been moved
+	AdjustmentRejected AdjustmentStatus = "rejected" // Declined by the reviewing admin
+)
+
+// AdjustmentAction represents an entry type in the adjustment audit trail
+type AdjustmentAction string
+
+const (
+	AdjustmentActionRequested AdjustmentAction = "requested"
+	AdjustmentActionApproved  AdjustmentAction = "approved"
+	AdjustmentActionRejected  AdjustmentAction = "rejected"
+	AdjustmentActionApplied   AdjustmentAction = "applied"
+)
+
+// CreditAdjustment represents an admin-initiated grant or deduction of credits
+// Adjustments above the configured threshold require approval by a second admin
+// (maker-checker) before the user's balance is changed
+type CreditAdjustment struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Target
+	UserID uint `gorm:"not null;index" json:"user_id"`
+
+	// Adjustment Details
+	Amount float64          `gorm:"not null" json:"amount"` // Positive to grant, negative to deduct
+	Reason string           `gorm:"type:text;not null" json:"reason"`
+	Status AdjustmentStatus `gorm:"not null;default:'pending';index" json:"status"`
+
+	// Maker-checker
+	RequestedBy      uint       `gorm:"not null;index" json:"requested_by"` // Admin who created the adjustment
+	RequiresApproval bool       `gorm:"default:false" json:"requires_approval"`
+	ReviewedBy       *uint      `json:"reviewed_by"` // Admin who approved/rejected
+	ReviewedAt       *time.Time `json:"reviewed_at"`
+	ReviewNote       string     `gorm:"type:text" json:"review_note"`
+
+	// Result
+	TransactionID *uint      `json:"transaction_id"` // Ledger entry created when applied
+	AppliedAt     *time.Time `json:"applied_at"`
+
+	// Relationships
+	User   User                    `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Events []CreditAdjustmentEvent `gorm:"foreignKey:AdjustmentID" json:"events,omitempty"`
+}
+
+// TableName specifies the table name for CreditAdjustment model
+func (CreditAdjustment) TableName() string {
+	return "credit_adjustments"
+}
+
+// IsPending checks if adjustment is still waiting for review
+func (a *CreditAdjustment) IsPending() bool {
+	return a.Status == AdjustmentPending
+}
+
+// CreditAdjustmentEvent is an immutable audit trail entry for a credit adjustment
+type CreditAdjustmentEvent struct {
+	ID           uint             `gorm:"primarykey" json:"id"`
+	CreatedAt    time.Time        `gorm:"index" json:"created_at"`
+	AdjustmentID uint             `gorm:"not null;index" json:"adjustment_id"`
+	AdminID      uint             `gorm:"not null;index" json:"admin_id"`
+	Action       AdjustmentAction `gorm:"not null" json:"action"`
+	Note         string           `gorm:"type:text" json:"note"`
+}
+
+// TableName specifies the table name for CreditAdjustmentEvent model
+func (CreditAdjustmentEvent) TableName() string {
+	return "credit_adjustment_events"
+}
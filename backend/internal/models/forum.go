@@ -33,6 +33,7 @@ type ForumThread struct {
 	ReplyCount  int       `json:"reply_count"`
 	IsPinned    bool      `json:"is_pinned"`
 	IsClosed    bool      `json:"is_closed"`
+	AcceptedReplyID *uint `json:"accepted_reply_id"` // Reply the author accepted as the answer
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -46,6 +47,7 @@ type ForumReply struct {
 	Author    *User     `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
 	Content   string    `gorm:"type:text" json:"content"`
 	LikeCount int       `json:"like_count"`
+	IsAccepted bool     `gorm:"default:false" json:"is_accepted"` // Accepted as the answer by the thread author
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
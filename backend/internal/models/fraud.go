@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FraudFlagStatus represents the review state of a flagged session
+type FraudFlagStatus string
+
+const (
+	FraudFlagPending   FraudFlagStatus = "pending"   // Waiting for admin review, credits held
+	FraudFlagCleared   FraudFlagStatus = "cleared"   // Legitimate, credits released to teacher
+	FraudFlagConfirmed FraudFlagStatus = "confirmed" // Fraudulent, credits returned to student
+)
+
+// FraudSignal identifies a single anomaly contributing to a fraud score
+type FraudSignal string
+
+const (
+	FraudSignalRepeatedPair  FraudSignal = "repeated_pair"  // Same teacher/student completing many sessions
+	FraudSignalShortDuration FraudSignal = "short_duration" // StartedAt→CompletedAt far below booked duration
+	FraudSignalSameIP        FraudSignal = "same_ip"        // Both parties checked in from one IP
+	FraudSignalReciprocal    FraudSignal = "reciprocal"     // Teacher and student swap roles (A→B, B→A)
+	FraudSignalBookingLoop   FraudSignal = "booking_loop"   // Credits cycle through a third user (A→B→C→A)
+	FraudSignalScoringFailed FraudSignal = "scoring_failed" // Scoring errored, so the session was held unchecked
+)
+
+// FraudFlag records a completed session whose credit transfer is held for admin review
+type FraudFlag struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Subject
+	SessionID uint `gorm:"not null;uniqueIndex" json:"session_id"`
+	TeacherID uint `gorm:"not null;index" json:"teacher_id"`
+	StudentID uint `gorm:"not null;index" json:"student_id"`
+
+	// Scoring
+	Score   float64         `gorm:"not null" json:"score"`    // 0.0 - 1.0
+	Signals string          `gorm:"type:text" json:"signals"` // JSON map of FraudSignal -> detail
+	Status  FraudFlagStatus `gorm:"not null;default:'pending';index" json:"status"`
+
+	// Review
+	ReviewedBy *uint      `json:"reviewed_by"` // Admin who cleared/confirmed
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	ReviewNote string     `gorm:"type:text" json:"review_note"`
+
+	// Relationships
+	Session Session `gorm:"foreignKey:SessionID" json:"session,omitempty"`
+	Teacher User    `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+	Student User    `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+}
+
+// TableName specifies the table name for FraudFlag model
+func (FraudFlag) TableName() string {
+	return "fraud_flags"
+}
+
+// IsPending checks if flag is still waiting for review
+func (f *FraudFlag) IsPending() bool {
+	return f.Status == FraudFlagPending
+}
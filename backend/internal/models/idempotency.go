@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// IdempotencyStatus represents the processing state of an idempotency key
+type IdempotencyStatus string
+
+const (
+	IdempotencyProcessing IdempotencyStatus = "processing" // Request is currently being handled
+	IdempotencyCompleted  IdempotencyStatus = "completed"  // Response stored and replayable
+)
+
+// IdempotencyKey stores the outcome of a request sent with an Idempotency-Key header
+// Repeated requests with the same key (per account) replay the stored response
+// instead of executing the state change again
+type IdempotencyKey struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Scope: users and admins are separate tables whose IDs can collide,
+	// so keys are scoped by the token role as well as the ID
+	ActorType string `gorm:"size:10;not null;default:'user';uniqueIndex:idx_idempotency_actor_key" json:"actor_type"`
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_idempotency_actor_key" json:"user_id"`
+	Key       string `gorm:"size:255;not null;uniqueIndex:idx_idempotency_actor_key" json:"key"`
+
+	// Request fingerprint (method, path and body hash) to detect key reuse
+	Method      string `gorm:"size:10;not null" json:"method"`
+	Path        string `gorm:"size:255;not null" json:"path"`
+	RequestHash string `gorm:"size:64;not null" json:"request_hash"`
+
+	// Stored response
+	Status       IdempotencyStatus `gorm:"not null;default:'processing'" json:"status"`
+	ResponseCode int               `json:"response_code"`
+	ResponseBody []byte            `json:"-"`
+	ContentType  string            `gorm:"size:100" json:"content_type"`
+
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+}
+
+// TableName specifies the table name for IdempotencyKey model
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// IsExpired checks if the key is past its TTL
+func (k *IdempotencyKey) IsExpired() bool {
+	return time.Now().After(k.ExpiresAt)
+}
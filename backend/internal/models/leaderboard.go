@@ -0,0 +1,106 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LeaderboardType is what a leaderboard ranks users by
+type LeaderboardType string
+
+const (
+	LeaderboardBadges   LeaderboardType = "badges"   // Badges earned
+	LeaderboardRarity   LeaderboardType = "rarity"   // Summed rarity of badges earned
+	LeaderboardSessions LeaderboardType = "sessions" // Completed sessions in either role
+	LeaderboardRating   LeaderboardType = "rating"   // Average rating received over the roles reviewed in
+	LeaderboardCredits  LeaderboardType = "credits"  // Credits earned from teaching
+)
+
+// LeaderboardTypes lists every leaderboard type
+var LeaderboardTypes = []LeaderboardType{
+	LeaderboardBadges, LeaderboardRarity, LeaderboardSessions, LeaderboardRating, LeaderboardCredits,
+}
+
+// SupportsCategory reports whether the leaderboard can be split by skill category
+// Badges aren't tied to a skill, so only session-based leaderboards can
+func (t LeaderboardType) SupportsCategory() bool {
+	return t == LeaderboardSessions || t == LeaderboardRating || t == LeaderboardCredits
+}
+
+// LeaderboardPeriod is the time window a leaderboard counts activity over
+type LeaderboardPeriod string
+
+const (
+	LeaderboardAllTime  LeaderboardPeriod = "all_time" // Key "all"
+	LeaderboardWeekly   LeaderboardPeriod = "weekly"   // ISO week, key "2026-W07"
+	LeaderboardMonthly  LeaderboardPeriod = "monthly"  // Calendar month, key "2026-02"
+	LeaderboardSeasonal LeaderboardPeriod = "season"   // Admin-defined season, key is the season ID
+)
+
+// LeaderboardScope narrows a leaderboard to a group of users or activity
+type LeaderboardScope string
+
+const (
+	LeaderboardGlobal   LeaderboardScope = "global"   // Every user
+	LeaderboardSchool   LeaderboardScope = "school"   // Users of one school
+	LeaderboardLocation LeaderboardScope = "location" // Users in one location
+	LeaderboardCategory LeaderboardScope = "category" // Activity in one skill category
+)
+
+// LeaderboardSeasonStatus represents where a season is in its lifecycle
+type LeaderboardSeasonStatus string
+
+const (
+	SeasonUpcoming LeaderboardSeasonStatus = "upcoming" // Not started yet
+	SeasonActive   LeaderboardSeasonStatus = "active"   // Running; standings refresh with the other leaderboards
+	SeasonArchived LeaderboardSeasonStatus = "archived" // Ended; final standings are frozen
+)
+
+// LeaderboardSeason is an admin-defined competition window
+// Seasons can't overlap, so at most one is active at a time
+type LeaderboardSeason struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Name        string                  `gorm:"not null" json:"name"`
+	Description string                  `gorm:"type:text" json:"description"`
+	StartsAt    time.Time               `gorm:"not null;index" json:"starts_at"`
+	EndsAt      time.Time               `gorm:"not null;index" json:"ends_at"` // Exclusive
+	Status      LeaderboardSeasonStatus `gorm:"type:varchar(20);default:'upcoming';index" json:"status"`
+	ArchivedAt  *time.Time              `json:"archived_at"`
+	CreatedBy   uint                    `json:"created_by"` // Admin who created the season
+}
+
+// TableName specifies the table name for LeaderboardSeason model
+func (LeaderboardSeason) TableName() string {
+	return "leaderboard_seasons"
+}
+
+// LeaderboardSnapshot is one user's standing on one leaderboard, as computed by the refresh job
+// Snapshots of a period are replaced on every refresh until the period ends; those of
+// ended weeks, months and archived seasons are kept as history
+type LeaderboardSnapshot struct {
+	ID uint `gorm:"primarykey" json:"id"`
+
+	Type       LeaderboardType   `gorm:"type:varchar(20);not null;uniqueIndex:idx_leaderboard_snapshot_user,priority:1;index:idx_leaderboard_snapshot_rank,priority:1" json:"type"`
+	Period     LeaderboardPeriod `gorm:"type:varchar(20);not null;uniqueIndex:idx_leaderboard_snapshot_user,priority:2;index:idx_leaderboard_snapshot_rank,priority:2" json:"period"`
+	PeriodKey  string            `gorm:"type:varchar(20);not null;uniqueIndex:idx_leaderboard_snapshot_user,priority:3;index:idx_leaderboard_snapshot_rank,priority:3" json:"period_key"`
+	Scope      LeaderboardScope  `gorm:"type:varchar(20);not null;uniqueIndex:idx_leaderboard_snapshot_user,priority:4;index:idx_leaderboard_snapshot_rank,priority:4" json:"scope"`
+	ScopeValue string            `gorm:"type:varchar(255);not null;default:'';uniqueIndex:idx_leaderboard_snapshot_user,priority:5;index:idx_leaderboard_snapshot_rank,priority:5" json:"scope_value"` // Normalised school, location or category; empty for global
+	UserID     uint              `gorm:"not null;uniqueIndex:idx_leaderboard_snapshot_user,priority:6;index" json:"user_id"`
+
+	Rank       int       `gorm:"not null;index:idx_leaderboard_snapshot_rank,priority:6" json:"rank"` // Tied scores share a rank
+	Score      float64   `gorm:"not null" json:"score"`
+	ComputedAt time.Time `gorm:"not null;index" json:"computed_at"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for LeaderboardSnapshot model
+func (LeaderboardSnapshot) TableName() string {
+	return "leaderboard_snapshots"
+}
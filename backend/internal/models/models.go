@@ -34,6 +34,35 @@ func AutoMigrate(db *gorm.DB) error {
 		{"Whiteboard", &Whiteboard{}},
 		{"SkillProgress", &SkillProgress{}},
 		{"Milestone", &Milestone{}},
+		{"CreditAdjustment", &CreditAdjustment{}},
+		{"CreditAdjustmentEvent", &CreditAdjustmentEvent{}},
+		{"IdempotencyKey", &IdempotencyKey{}},
+		{"FraudFlag", &FraudFlag{}},
+		{"AuditEvent", &AuditEvent{}},
+		{"RecommendationDigest", &RecommendationDigest{}},
+		{"SkillSwap", &SkillSwap{}},
+		{"SkillSwapLeg", &SkillSwapLeg{}},
+		{"SkillAlias", &SkillAlias{}},
+		{"SkillProposal", &SkillProposal{}},
+		{"SkillVerification", &SkillVerification{}},
+		{"SkillAssessment", &SkillAssessment{}},
+		{"AssessmentQuestion", &AssessmentQuestion{}},
+		{"AssessmentAttempt", &AssessmentAttempt{}},
+		{"SavedSearch", &SavedSearch{}},
+		{"SavedSearchMatch", &SavedSearchMatch{}},
+		{"ReputationScore", &ReputationScore{}},
+		{"ReviewReport", &ReviewReport{}},
+		{"ReviewHelpfulVote", &ReviewHelpfulVote{}},
+		{"ReviewReply", &ReviewReply{}},
+		{"BadgeProgress", &BadgeProgress{}},
+		{"LeaderboardSeason", &LeaderboardSeason{}},
+		{"LeaderboardSnapshot", &LeaderboardSnapshot{}},
+		{"UserStreak", &UserStreak{}},
+		{"Challenge", &Challenge{}},
+		{"ChallengeParticipant", &ChallengeParticipant{}},
+		{"XPRule", &XPRule{}},
+		{"XPEvent", &XPEvent{}},
+		{"UserXP", &UserXP{}},
 	}
 
 	for _, m := range models {
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// RecommendationDigest records that a user's weekly "new matches" digest was processed
+// The unique (user_id, week_start) pair lets several server instances run the
+// digest job without notifying a user twice in the same week
+type RecommendationDigest struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UserID     uint      `gorm:"not null;uniqueIndex:idx_recommendation_digest_week" json:"user_id"`
+	WeekStart  time.Time `gorm:"not null;uniqueIndex:idx_recommendation_digest_week" json:"week_start"` // Monday 00:00 UTC
+	MatchCount int       `gorm:"default:0" json:"match_count"`
+}
+
+// TableName specifies the table name for RecommendationDigest model
+func (RecommendationDigest) TableName() string {
+	return "recommendation_digests"
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// ReputationSubject identifies what a reputation score is about
+type ReputationSubject string
+
+const (
+	ReputationSubjectUser      ReputationSubject = "user"       // A user in one role (teacher or student)
+	ReputationSubjectUserSkill ReputationSubject = "user_skill" // One teaching offer; always the teacher role
+)
+
+// ReputationScore stores a computed reputation for a user role or teaching offer
+// Scores are rebuilt from visible reviews, weighted by recency and reviewer credibility,
+// and shrunk towards the platform average so few reviews can't outrank many
+type ReputationScore struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Subject
+	SubjectType ReputationSubject `gorm:"type:varchar(20);not null;uniqueIndex:idx_reputation_subject" json:"subject_type"`
+	SubjectID   uint              `gorm:"not null;uniqueIndex:idx_reputation_subject" json:"subject_id"`
+	Role        ReviewType        `gorm:"type:varchar(20);not null;uniqueIndex:idx_reputation_subject" json:"role"`
+	UserID      uint              `gorm:"not null;index" json:"user_id"` // Owner; equals SubjectID for user scores
+
+	// Inputs
+	ReviewCount      int     `gorm:"default:0" json:"review_count"`
+	EffectiveReviews float64 `gorm:"default:0" json:"effective_reviews"` // Sum of review weights
+	AverageRating    float64 `gorm:"default:0" json:"average_rating"`    // Plain mean, for display
+
+	// Scores
+	BayesianRating     float64 `gorm:"default:0" json:"bayesian_rating"`     // 1-5, weighted and shrunk to the prior
+	WilsonScore        float64 `gorm:"default:0" json:"wilson_score"`        // 0-1, lower bound of the positive (4-5 star) share
+	CommunicationScore float64 `gorm:"default:0" json:"communication_score"` // 1-5, Bayesian sub-score
+	PunctualityScore   float64 `gorm:"default:0" json:"punctuality_score"`   // 1-5, Bayesian sub-score
+	KnowledgeScore     float64 `gorm:"default:0" json:"knowledge_score"`     // 1-5, Bayesian sub-score
+	Score              float64 `gorm:"default:0;index" json:"score"`         // 0-100 composite used for ranking
+
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+// TableName specifies the table name for ReputationScore model
+func (ReputationScore) TableName() string {
+	return "reputation_scores"
+}
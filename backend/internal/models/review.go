@@ -43,17 +43,26 @@ type Review struct {
 	PunctualityRating   *int `gorm:"check:punctuality_rating >= 1 AND punctuality_rating <= 5" json:"punctuality_rating"`
 	KnowledgeRating     *int `gorm:"check:knowledge_rating >= 1 AND knowledge_rating <= 5" json:"knowledge_rating"`
 	
+	// Double-blind release: nil while sealed, set once the other party has also
+	// reviewed the session or the review window after completion has passed
+	PublishedAt *time.Time `gorm:"index" json:"published_at"`
+	
 	// Helpful votes (other users can upvote helpful reviews)
 	HelpfulCount int `gorm:"default:0" json:"helpful_count"`
 	
 	// Moderation
-	IsReported bool   `gorm:"default:false" json:"is_reported"`
-	IsHidden   bool   `gorm:"default:false" json:"is_hidden"`
+	IsReported       bool       `gorm:"default:false" json:"is_reported"`
+	IsHidden         bool       `gorm:"default:false" json:"is_hidden"`
+	ReportCount      int        `gorm:"default:0" json:"report_count"` // Reports still waiting for a moderator
+	ModeratedBy      *uint      `json:"moderated_by"`                  // Admin who last hid, restored or deleted the review
+	ModeratedAt      *time.Time `json:"moderated_at"`
+	ModerationReason string     `gorm:"type:text" json:"moderation_reason"`
 	
 	// Relationships
-	Session  Session `gorm:"foreignKey:SessionID" json:"session,omitempty"`
-	Reviewer User    `gorm:"foreignKey:ReviewerID" json:"reviewer,omitempty"`
-	Reviewee User    `gorm:"foreignKey:RevieweeID" json:"reviewee,omitempty"`
+	Session  Session      `gorm:"foreignKey:SessionID" json:"session,omitempty"`
+	Reviewer User         `gorm:"foreignKey:ReviewerID" json:"reviewer,omitempty"`
+	Reviewee User         `gorm:"foreignKey:RevieweeID" json:"reviewee,omitempty"`
+	Reply    *ReviewReply `gorm:"foreignKey:ReviewID" json:"reply,omitempty"` // Reviewee's public response
 }
 
 // TableName specifies the table name for Review model
@@ -70,6 +79,11 @@ func (r *Review) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// IsPublished checks if the review has been released to the reviewee and the public
+func (r *Review) IsPublished() bool {
+	return r.PublishedAt != nil
+}
+
 // AverageDetailedRating calculates average of detailed ratings
 func (r *Review) AverageDetailedRating() float64 {
 	count := 0
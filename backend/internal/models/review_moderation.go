@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// ReviewReportReason is why a user reported a review
+type ReviewReportReason string
+
+const (
+	ReviewReportSpam       ReviewReportReason = "spam"       // Advertising or repeated content
+	ReviewReportOffensive  ReviewReportReason = "offensive"  // Harassment, hate or abusive language
+	ReviewReportFake       ReviewReportReason = "fake"       // Not about a real session experience
+	ReviewReportPersonal   ReviewReportReason = "personal"   // Reveals private information
+	ReviewReportIrrelevant ReviewReportReason = "irrelevant" // Off-topic
+	ReviewReportOther      ReviewReportReason = "other"      // Explained in Details
+)
+
+// ReviewReportStatus represents the moderation state of a report
+type ReviewReportStatus string
+
+const (
+	ReviewReportPending   ReviewReportStatus = "pending"   // Waiting in the moderation queue
+	ReviewReportActioned  ReviewReportStatus = "actioned"  // The review was hidden or deleted
+	ReviewReportDismissed ReviewReportStatus = "dismissed" // The review was kept visible
+)
+
+// ReviewReport is a user's report of a review; each user can report a review once
+type ReviewReport struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	ReviewID   uint               `gorm:"not null;uniqueIndex:idx_review_report" json:"review_id"`
+	ReporterID uint               `gorm:"not null;uniqueIndex:idx_review_report;index" json:"reporter_id"`
+	Reason     ReviewReportReason `gorm:"type:varchar(20);not null" json:"reason"`
+	Details    string             `gorm:"type:text" json:"details"`
+	Status     ReviewReportStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+
+	// Resolution
+	ResolvedBy *uint      `json:"resolved_by"` // Admin who acted on the review
+	ResolvedAt *time.Time `json:"resolved_at"`
+
+	// Relationships
+	Reporter User `gorm:"foreignKey:ReporterID" json:"reporter,omitempty"`
+}
+
+// TableName specifies the table name for ReviewReport model
+func (ReviewReport) TableName() string {
+	return "review_reports"
+}
+
+// ReviewHelpfulVote records that a user found a review helpful; one vote per user
+type ReviewHelpfulVote struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ReviewID uint `gorm:"not null;uniqueIndex:idx_review_helpful_vote" json:"review_id"`
+	UserID   uint `gorm:"not null;uniqueIndex:idx_review_helpful_vote;index" json:"user_id"`
+}
+
+// TableName specifies the table name for ReviewHelpfulVote model
+func (ReviewHelpfulVote) TableName() string {
+	return "review_helpful_votes"
+}
+
+// ReviewReply is the reviewee's public response to a review; one per review
+type ReviewReply struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	ReviewID uint   `gorm:"not null;uniqueIndex" json:"review_id"`
+	AuthorID uint   `gorm:"not null;index" json:"author_id"`
+	Body     string `gorm:"type:text;not null" json:"body"`
+}
+
+// TableName specifies the table name for ReviewReply model
+func (ReviewReply) TableName() string {
+	return "review_replies"
+}
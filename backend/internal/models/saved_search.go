@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// SavedSearch is a learner's saved marketplace search
+// Active searches are checked periodically for offers created or updated since
+// LastCheckedAt; offers that newly match are notified once
+type SavedSearch struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	UserID  uint           `gorm:"not null;index" json:"user_id"`
+	Name    string         `gorm:"not null" json:"name"`
+	Filters datatypes.JSON `gorm:"type:jsonb;not null" json:"filters"` // Offer search filters, as accepted by GET /marketplace/offers
+
+	// Alerts
+	IsPaused      bool       `gorm:"default:false;index" json:"is_paused"`
+	LastCheckedAt time.Time  `gorm:"not null" json:"last_checked_at"` // Watermark for the next check
+	LastMatchedAt *time.Time `json:"last_matched_at"`
+	MatchCount    int        `gorm:"default:0" json:"match_count"` // New matches notified so far
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// TableName specifies the table name for SavedSearch model
+func (SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+// SavedSearchMatch records an offer a saved search has already seen
+// Offers that matched when the search was saved (or its filters changed) are
+// recorded without a notification so only new teachers trigger alerts
+type SavedSearchMatch struct {
+	ID            uint      `gorm:"primarykey" json:"id"`
+	CreatedAt     time.Time `json:"created_at"`
+	SavedSearchID uint      `gorm:"not null;uniqueIndex:idx_saved_search_match" json:"saved_search_id"`
+	UserSkillID   uint      `gorm:"not null;uniqueIndex:idx_saved_search_match" json:"user_skill_id"`
+	Notified      bool      `gorm:"default:false" json:"notified"`
+}
+
+// TableName specifies the table name for SavedSearchMatch model
+func (SavedSearchMatch) TableName() string {
+	return "saved_search_matches"
+}
@@ -0,0 +1,26 @@
+package models
+
+// SearchResultType identifies the kind of document returned by unified search
+type SearchResultType string
+
+const (
+	SearchTypeSkill  SearchResultType = "skill"  // Master skill (name, description)
+	SearchTypeOffer  SearchResultType = "offer"  // Teacher offer (UserSkill description)
+	SearchTypeThread SearchResultType = "thread" // Forum thread (title, content)
+	SearchTypeReply  SearchResultType = "reply"  // Forum reply (content)
+	SearchTypeStory  SearchResultType = "story"  // Published success story (title, description)
+)
+
+// SearchTypes lists every searchable document type
+var SearchTypes = []SearchResultType{
+	SearchTypeSkill,
+	SearchTypeOffer,
+	SearchTypeThread,
+	SearchTypeReply,
+	SearchTypeStory,
+}
+
+// SearchLanguages are the Postgres text search configurations documents are indexed with
+// Content is mixed Indonesian and English, so every document is stemmed in both
+// and queries match either. Changing this list requires dropping the search_vector columns
+var SearchLanguages = []string{"english", "indonesian"}
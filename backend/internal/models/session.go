@@ -64,12 +64,18 @@ type Session struct {
 	CreditAmount    float64 `gorm:"not null" json:"credit_amount"`     // Credits to be transferred
 	CreditHeld      bool    `gorm:"default:false" json:"credit_held"`  // Is credit in escrow?
 	CreditReleased  bool    `gorm:"default:false" json:"credit_released"` // Has credit been transferred?
+	FraudHold       bool    `gorm:"default:false;index" json:"fraud_hold"` // Credits held pending admin fraud review
+	
+	// Skill swap this session is a leg of (swap sessions carry no credits)
+	SkillSwapID *uint `gorm:"index" json:"skill_swap_id"`
 	
 	// Check-in tracking (for session start)
 	TeacherCheckedIn   bool       `gorm:"default:false" json:"teacher_checked_in"`   // Teacher checked in for session
 	StudentCheckedIn   bool       `gorm:"default:false" json:"student_checked_in"`   // Student checked in for session
 	TeacherCheckedInAt *time.Time `json:"teacher_checked_in_at"`                     // When teacher checked in
 	StudentCheckedInAt *time.Time `json:"student_checked_in_at"`                     // When student checked in
+	TeacherCheckInIP   string     `gorm:"size:45" json:"-"`                           // Client IP of teacher check-in (fraud detection)
+	StudentCheckInIP   string     `gorm:"size:45" json:"-"`                           // Client IP of student check-in (fraud detection)
 
 	// Confirmation (for session completion)
 	TeacherConfirmed bool `gorm:"default:false" json:"teacher_confirmed"` // Teacher confirmed completion
@@ -28,6 +28,21 @@ const (
 	LevelExpert       SkillLevel = "expert"
 )
 
+// Rank orders levels from beginner (1) to expert (4); unknown levels rank 0
+func (l SkillLevel) Rank() int {
+	switch l {
+	case LevelBeginner:
+		return 1
+	case LevelIntermediate:
+		return 2
+	case LevelAdvanced:
+		return 3
+	case LevelExpert:
+		return 4
+	}
+	return 0
+}
+
 // Skill represents a master skill (e.g., "Mathematics - Calculus")
 type Skill struct {
 	ID        uint           `gorm:"primarykey" json:"id"`
@@ -40,6 +55,10 @@ type Skill struct {
 	Description string        `gorm:"type:text" json:"description"`
 	Icon        string        `json:"icon"` // Icon URL or emoji
 	
+	// Taxonomy
+	ParentID *uint     `gorm:"index" json:"parent_id"`                 // Broader skill (e.g., "Programming" for "JavaScript")
+	Tags     JSONArray `gorm:"type:jsonb;default:'[]'" json:"tags"` // Free-form lowercase tags
+	
 	// Stats
 	TotalTeachers int `gorm:"default:0" json:"total_teachers"`
 	TotalLearners int `gorm:"default:0" json:"total_learners"`
@@ -49,7 +68,8 @@ type Skill struct {
 	MaxRate float64 `gorm:"-" json:"max_rate"`
 	
 	// Relationships
-	UserSkills []UserSkill `gorm:"foreignKey:SkillID" json:"-"`
+	UserSkills []UserSkill   `gorm:"foreignKey:SkillID" json:"-"`
+	Aliases    []SkillAlias `gorm:"foreignKey:SkillID" json:"aliases,omitempty"`
 }
 
 // TableName specifies the table name for Skill model
@@ -76,6 +96,15 @@ type UserSkill struct {
 	ProofURL    string `json:"proof_url"`    // Certificate, portfolio link
 	ProofType   string `json:"proof_type"`   // "certificate", "portfolio", "project"
 	
+	// Verification (set when a moderator approves a SkillVerification)
+	IsVerified            bool       `gorm:"default:false;index" json:"is_verified"`
+	VerifiedAt            *time.Time `json:"verified_at"`
+	VerificationExpiresAt *time.Time `json:"verification_expires_at"`
+	
+	// Assessment (highest level confirmed by a passed placement quiz)
+	AssessedLevel SkillLevel `json:"assessed_level"`
+	AssessedAt    *time.Time `json:"assessed_at"`
+	
 	// Availability
 	IsAvailable bool   `gorm:"default:true" json:"is_available"`
 	HourlyRate  float64 `gorm:"default:1.0" json:"hourly_rate"` // Usually 1:1, but can be adjusted
@@ -99,6 +128,16 @@ func (UserSkill) TableName() string {
 	return "user_skills"
 }
 
+// IsLevelConfirmed checks if a passed assessment backs the claimed level
+func (us *UserSkill) IsLevelConfirmed() bool {
+	return us.AssessedLevel.Rank() > 0 && us.AssessedLevel.Rank() >= us.Level.Rank()
+}
+
+// IsCurrentlyVerified checks if the skill is verified and the verification has not expired
+func (us *UserSkill) IsCurrentlyVerified(now time.Time) bool {
+	return us.IsVerified && us.VerificationExpiresAt != nil && us.VerificationExpiresAt.After(now)
+}
+
 // LearningSkill represents skills that a user wants to learn (wishlist)
 type LearningSkill struct {
 	ID        uint           `gorm:"primarykey" json:"id"`
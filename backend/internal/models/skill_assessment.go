@@ -0,0 +1,96 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// AttemptStatus represents the state of an assessment attempt
+type AttemptStatus string
+
+const (
+	AttemptInProgress AttemptStatus = "in_progress" // Started, answers not yet submitted
+	AttemptSubmitted  AttemptStatus = "submitted"   // Scored on time
+	AttemptExpired    AttemptStatus = "expired"     // Time ran out before submission
+)
+
+// SkillAssessment is a timed multiple-choice quiz that places a user at a skill level
+// Authored by admins or by teachers verified for the skill
+type SkillAssessment struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	SkillID     uint       `gorm:"not null;index" json:"skill_id"`
+	AuthorID    *uint      `gorm:"index" json:"author_id"` // Verified teacher who wrote it; nil when written by an admin
+	AdminID     *uint      `json:"admin_id,omitempty"`     // Admin who wrote it
+	Title       string     `gorm:"not null" json:"title"`
+	Description string     `gorm:"type:text" json:"description"`
+	Level       SkillLevel `gorm:"not null" json:"level"` // Level a passing attempt places the user at
+
+	// Rules
+	TimeLimitMinutes    int     `gorm:"not null;default:15" json:"time_limit_minutes"`
+	PassScore           float64 `gorm:"not null;default:70" json:"pass_score"`            // Percentage of correct answers needed to pass
+	RetakeCooldownHours int     `gorm:"not null;default:24" json:"retake_cooldown_hours"` // Wait after a failed attempt
+	IsPublished         bool    `gorm:"default:false;index" json:"is_published"`
+
+	// Relationships
+	Skill     Skill                `gorm:"foreignKey:SkillID" json:"skill,omitempty"`
+	Author    *User                `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Questions []AssessmentQuestion `gorm:"foreignKey:AssessmentID" json:"questions,omitempty"`
+}
+
+// TableName specifies the table name for SkillAssessment model
+func (SkillAssessment) TableName() string {
+	return "skill_assessments"
+}
+
+// AssessmentQuestion is one multiple-choice question of an assessment
+// CorrectOption is only shown to authors; takers get questions through DTOs without it
+type AssessmentQuestion struct {
+	ID            uint      `gorm:"primarykey" json:"id"`
+	CreatedAt     time.Time `json:"created_at"`
+	AssessmentID  uint      `gorm:"not null;index" json:"assessment_id"`
+	Position      int       `gorm:"not null" json:"position"`
+	Prompt        string    `gorm:"type:text;not null" json:"prompt"`
+	Options       JSONArray `gorm:"type:jsonb;not null" json:"options"`
+	CorrectOption int       `gorm:"not null" json:"correct_option"` // Index into Options
+}
+
+// TableName specifies the table name for AssessmentQuestion model
+func (AssessmentQuestion) TableName() string {
+	return "assessment_questions"
+}
+
+// AssessmentAttempt is one timed try at an assessment, scored server-side on submission
+type AssessmentAttempt struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	AssessmentID uint          `gorm:"not null;index" json:"assessment_id"`
+	UserID       uint          `gorm:"not null;index" json:"user_id"`
+	Status       AttemptStatus `gorm:"not null;default:'in_progress';index" json:"status"`
+	StartedAt    time.Time     `gorm:"not null" json:"started_at"`
+	Deadline     time.Time     `gorm:"not null" json:"deadline"`
+	SubmittedAt  *time.Time    `json:"submitted_at"`
+
+	// Result
+	Answers        datatypes.JSONMap `gorm:"type:jsonb" json:"answers,omitempty"` // Question ID → chosen option index
+	CorrectCount   int               `json:"correct_count"`
+	TotalQuestions int               `json:"total_questions"`
+	Score          float64           `json:"score"` // Percentage
+	Passed         bool              `gorm:"default:false" json:"passed"`
+	LevelApplied   bool              `gorm:"default:false" json:"level_applied"` // Passed level was recorded on the user's skill or progress
+
+	// Relationships
+	Assessment SkillAssessment `gorm:"foreignKey:AssessmentID" json:"assessment,omitempty"`
+}
+
+// TableName specifies the table name for AssessmentAttempt model
+func (AssessmentAttempt) TableName() string {
+	return "assessment_attempts"
+}
@@ -11,6 +11,7 @@ type SkillProgress struct {
 	SessionsCompleted     int     `json:"sessions_completed"`
 	TotalHoursSpent       float64 `json:"total_hours_spent"`
 	CurrentLevel          string  `json:"current_level"` // "beginner", "intermediate", "advanced", "expert"
+	PlacementLevel        string  `json:"placement_level"` // Starting level from a passed placement assessment; CurrentLevel never drops below it
 	LastActivityAt        int64   `json:"last_activity_at"`
 	EstimatedCompletionAt int64   `json:"estimated_completion_at"`
 	CreatedAt             int64   `gorm:"autoCreateTime:milli" json:"created_at"`
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SkillProposalStatus represents the moderation state of a proposed skill
+type SkillProposalStatus string
+
+const (
+	SkillProposalPending  SkillProposalStatus = "pending"  // Waiting in the moderation queue
+	SkillProposalApproved SkillProposalStatus = "approved" // Skill created or linked to an existing one
+	SkillProposalRejected SkillProposalStatus = "rejected" // Declined by a moderator
+)
+
+// SkillProposalAttachment says what the proposer wants to do with the skill once approved
+type SkillProposalAttachment string
+
+const (
+	AttachNone  SkillProposalAttachment = ""      // Only propose the skill
+	AttachTeach SkillProposalAttachment = "teach" // Add it to the proposer's teaching skills
+	AttachLearn SkillProposalAttachment = "learn" // Add it to the proposer's learning wishlist
+)
+
+// SkillProposal is a user-submitted request to add a skill to the catalogue
+// On approval the proposer's pending teaching offer or wishlist entry is created
+// for the resulting skill
+type SkillProposal struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Proposal
+	ProposerID  uint                `gorm:"not null;index" json:"proposer_id"`
+	Name        string              `gorm:"not null" json:"name"`
+	Normalized  string              `gorm:"not null;index" json:"-"` // NormalizeSkillName(Name), for duplicate checks
+	Category    SkillCategory       `gorm:"not null" json:"category"`
+	Description string              `gorm:"type:text" json:"description"`
+	Status      SkillProposalStatus `gorm:"not null;default:'pending';index" json:"status"`
+
+	// Pending UserSkill / LearningSkill, created on approval
+	AttachAs   SkillProposalAttachment `json:"attach_as"`
+	Level      SkillLevel              `json:"level"` // Teaching level, or desired level when learning
+	HourlyRate float64                 `gorm:"default:1.0" json:"hourly_rate"`
+	Priority   int                     `gorm:"default:0" json:"priority"`
+
+	// Review
+	SkillID    *uint      `gorm:"index" json:"skill_id"` // Skill created or linked on approval
+	ReviewedBy *uint      `json:"reviewed_by"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	ReviewNote string     `gorm:"type:text" json:"review_note"`
+
+	// Relationships
+	Proposer User   `gorm:"foreignKey:ProposerID" json:"proposer,omitempty"`
+	Skill    *Skill `gorm:"foreignKey:SkillID" json:"skill,omitempty"`
+}
+
+// TableName specifies the table name for SkillProposal model
+func (SkillProposal) TableName() string {
+	return "skill_proposals"
+}
+
+// IsPending checks if the proposal is still waiting for review
+func (p *SkillProposal) IsPending() bool {
+	return p.Status == SkillProposalPending
+}
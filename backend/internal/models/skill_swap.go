@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SkillSwapStatus represents the current state of a skill swap
+type SkillSwapStatus string
+
+const (
+	SwapStatusProposed  SkillSwapStatus = "proposed"  // Waiting for every participant to accept
+	SwapStatusAccepted  SkillSwapStatus = "accepted"  // All participants accepted, sessions approved
+	SwapStatusDeclined  SkillSwapStatus = "declined"  // A participant declined the proposal
+	SwapStatusCancelled SkillSwapStatus = "cancelled" // A participant cancelled after proposing or accepting
+)
+
+// SkillSwap is a reciprocal exchange where each participant teaches the next
+// one in the ring (A→B→A for a pair, A→B→C→A for a cycle of three).
+// Every leg has the same duration, so each participant earns exactly what they
+// spend and swap sessions move no credits.
+type SkillSwap struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	ProposerID  uint            `gorm:"not null;index" json:"proposer_id"`
+	Status      SkillSwapStatus `gorm:"not null;default:'proposed';index" json:"status"`
+	Duration    float64         `gorm:"not null" json:"duration"` // Hours per leg
+	Mode        SessionMode     `gorm:"not null" json:"mode"`
+	ScheduledAt time.Time       `gorm:"not null" json:"scheduled_at"`
+	Message     string          `gorm:"type:text" json:"message"`
+
+	// Cancellation
+	CancelledBy        *uint  `json:"cancelled_by"`
+	CancellationReason string `gorm:"type:text" json:"cancellation_reason"`
+
+	// Relationships
+	Proposer User           `gorm:"foreignKey:ProposerID" json:"proposer,omitempty"`
+	Legs     []SkillSwapLeg `gorm:"foreignKey:SwapID" json:"legs,omitempty"`
+}
+
+// TableName specifies the table name for SkillSwap model
+func (SkillSwap) TableName() string {
+	return "skill_swaps"
+}
+
+// IsOpen checks if the swap can still be accepted, declined or cancelled
+func (s *SkillSwap) IsOpen() bool {
+	return s.Status == SwapStatusProposed || s.Status == SwapStatusAccepted
+}
+
+// SkillSwapLeg is one teaching session inside a swap
+// Each participant teaches exactly one leg, so acceptance is tracked per teacher
+type SkillSwapLeg struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	SwapID      uint       `gorm:"not null;index" json:"swap_id"`
+	Position    int        `gorm:"not null" json:"position"` // Order in the ring
+	TeacherID   uint       `gorm:"not null;index" json:"teacher_id"`
+	StudentID   uint       `gorm:"not null;index" json:"student_id"`
+	UserSkillID uint       `gorm:"not null" json:"user_skill_id"`
+	SessionID   uint       `gorm:"not null;index" json:"session_id"`
+	Accepted    bool       `gorm:"default:false" json:"accepted"` // Teacher accepted the swap
+	AcceptedAt  *time.Time `json:"accepted_at"`
+
+	// Relationships
+	Teacher   User      `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+	Student   User      `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	UserSkill UserSkill `gorm:"foreignKey:UserSkillID" json:"user_skill,omitempty"`
+	Session   Session   `gorm:"foreignKey:SessionID" json:"session,omitempty"`
+}
+
+// TableName specifies the table name for SkillSwapLeg model
+func (SkillSwapLeg) TableName() string {
+	return "skill_swap_legs"
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// SkillAlias is a synonym that resolves to a canonical skill (e.g., "JS" → "JavaScript")
+// Normalized is unique across all aliases, so one spelling never points at two skills
+type SkillAlias struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	SkillID    uint      `gorm:"not null;index" json:"skill_id"`
+	Alias      string    `gorm:"not null" json:"alias"`
+	Normalized string    `gorm:"not null;uniqueIndex" json:"-"`
+}
+
+// TableName specifies the table name for SkillAlias model
+func (SkillAlias) TableName() string {
+	return "skill_aliases"
+}
+
+// NormalizeSkillName lowercases a skill name or alias and collapses whitespace
+// Must stay in sync with the SQL expression used by SkillRepository.FindByNameOrAlias
+func NormalizeSkillName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
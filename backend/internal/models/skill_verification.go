@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VerificationStatus represents the review state of a skill credential
+type VerificationStatus string
+
+const (
+	VerificationPending  VerificationStatus = "pending"  // Waiting for a moderator
+	VerificationApproved VerificationStatus = "approved" // Skill is verified until ExpiresAt
+	VerificationRejected VerificationStatus = "rejected" // Proof was not accepted
+	VerificationExpired  VerificationStatus = "expired"  // Approval lapsed or was replaced by a renewal
+)
+
+// Proof types accepted for skill verification
+const (
+	ProofTypeCertificate = "certificate"
+	ProofTypePortfolio   = "portfolio"
+	ProofTypeProject     = "project"
+)
+
+// SkillVerification is a teacher's proof for a skill they teach and its moderation outcome
+// Approval marks the UserSkill as verified until ExpiresAt; teachers renew by
+// submitting new proof before (or after) it expires
+type SkillVerification struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	UserSkillID uint `gorm:"not null;index" json:"user_skill_id"`
+	UserID      uint `gorm:"not null;index" json:"user_id"`
+
+	// Proof
+	ProofType   string `gorm:"not null" json:"proof_type"` // certificate, portfolio or project
+	FileName    string `json:"file_name"`                  // Original name of the uploaded file
+	FilePath    string `json:"-"`                          // Location on disk, never exposed
+	FileSize    int64  `json:"file_size"`
+	ExternalURL string `json:"external_url"` // Portfolio or credential link, instead of or alongside a file
+	Notes       string `gorm:"type:text" json:"notes"`
+
+	// Review
+	Status         VerificationStatus `gorm:"not null;default:'pending';index" json:"status"`
+	ReviewedBy     *uint              `json:"reviewed_by"`
+	ReviewedAt     *time.Time         `json:"reviewed_at"`
+	ReviewNote     string             `gorm:"type:text" json:"review_note"`
+	ExpiresAt      *time.Time         `gorm:"index" json:"expires_at"`
+	ReminderSentAt *time.Time         `json:"reminder_sent_at"` // Renewal reminder, sent once
+
+	// Relationships
+	UserSkill UserSkill `gorm:"foreignKey:UserSkillID" json:"user_skill,omitempty"`
+	User      User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for SkillVerification model
+func (SkillVerification) TableName() string {
+	return "skill_verifications"
+}
+
+// IsPending checks if the verification is still waiting for review
+func (v *SkillVerification) IsPending() bool {
+	return v.Status == VerificationPending
+}
+
+// HasFile checks if a proof file was uploaded
+func (v *SkillVerification) HasFile() bool {
+	return v.FilePath != ""
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// UserStreak tracks a user's run of consecutive weeks with a completed session
+// Weeks are ISO weeks in UTC, starting Monday
+type UserStreak struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID uint `gorm:"not null;uniqueIndex" json:"user_id"`
+
+	CurrentWeeks     int        `gorm:"default:0" json:"current_weeks"`      // Consecutive active weeks up to LastActiveWeek
+	LongestWeeks     int        `gorm:"default:0" json:"longest_weeks"`      // Best run ever
+	LastActiveWeek   *time.Time `gorm:"index" json:"last_active_week"`       // Monday of the latest week with a completed session
+	WarnedForWeek    *time.Time `json:"-"`                                   // Monday of the week a streak-break warning was last sent for
+	TotalActiveWeeks int        `gorm:"default:0" json:"total_active_weeks"` // Weeks with a completed session, streak or not
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for UserStreak model
+func (UserStreak) TableName() string {
+	return "user_streaks"
+}
+
+// IsAlive reports whether the streak can still continue in the week starting thisWeek,
+// i.e. the user was active this week or last week
+func (s *UserStreak) IsAlive(thisWeek time.Time) bool {
+	return s.LastActiveWeek != nil && !s.LastActiveWeek.Before(thisWeek.AddDate(0, 0, -7))
+}
@@ -10,13 +10,14 @@ import (
 type TransactionType string
 
 const (
-	TransactionEarned  TransactionType = "earned"  // Earned from teaching
-	TransactionSpent   TransactionType = "spent"   // Spent on learning
-	TransactionBonus   TransactionType = "bonus"   // Bonus credits (achievements, etc)
-	TransactionRefund  TransactionType = "refund"  // Refunded from cancelled session
-	TransactionPenalty TransactionType = "penalty" // Penalty for no-show, etc
-	TransactionInitial TransactionType = "initial" // Initial free credits
-	TransactionHold    TransactionType = "hold"    // Credits held in escrow for pending session
+	TransactionEarned     TransactionType = "earned"     // Earned from teaching
+	TransactionSpent      TransactionType = "spent"      // Spent on learning
+	TransactionBonus      TransactionType = "bonus"      // Bonus credits (achievements, etc)
+	TransactionRefund     TransactionType = "refund"     // Refunded from cancelled session
+	TransactionPenalty    TransactionType = "penalty"    // Penalty for no-show, etc
+	TransactionInitial    TransactionType = "initial"    // Initial free credits
+	TransactionHold       TransactionType = "hold"       // Credits held in escrow for pending session
+	TransactionAdjustment TransactionType = "adjustment" // Manual correction by an admin
 )
 
 // Transaction represents a credit transaction history
@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// XPAction is an activity that earns experience points
+type XPAction string
+
+const (
+	XPSessionCompleted    XPAction = "session_completed"     // Completed a session, in either role
+	XPReviewWritten       XPAction = "review_written"        // Reviewed a session
+	XPForumAnswerAccepted XPAction = "forum_answer_accepted" // Forum reply accepted as the answer by the thread author
+	XPEndorsementReceived XPAction = "endorsement_received"  // A peer endorsed one of the user's skills
+)
+
+// XPActions lists every action that can earn XP
+var XPActions = []XPAction{
+	XPSessionCompleted, XPReviewWritten, XPForumAnswerAccepted, XPEndorsementReceived,
+}
+
+// XPRule configures how much XP an action earns
+// Rules are seeded with defaults and tuned by admins
+type XPRule struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Action      XPAction `gorm:"type:varchar(50);not null;uniqueIndex" json:"action"`
+	Description string   `json:"description"`
+	Points      int      `gorm:"not null;default:0" json:"points"`    // XP per occurrence
+	DailyCap    int      `gorm:"not null;default:0" json:"daily_cap"` // Most XP the action can earn a user per UTC day; 0 is unlimited
+	IsActive    bool     `gorm:"default:true" json:"is_active"`
+}
+
+// TableName specifies the table name for XPRule model
+func (XPRule) TableName() string {
+	return "xp_rules"
+}
+
+// XPEvent is one award of XP, kept as the user's XP history
+// Each source (session, review, reply, endorsement) awards an action at most once per user
+type XPEvent struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	UserID     uint     `gorm:"not null;index;uniqueIndex:idx_xp_event_source,priority:1" json:"user_id"`
+	Action     XPAction `gorm:"type:varchar(50);not null;index;uniqueIndex:idx_xp_event_source,priority:2" json:"action"`
+	SourceType string   `gorm:"type:varchar(30);not null;uniqueIndex:idx_xp_event_source,priority:3" json:"source_type"` // session, forum_reply, endorser
+	SourceID   uint     `gorm:"not null;uniqueIndex:idx_xp_event_source,priority:4" json:"source_id"`
+
+	Points     int  `gorm:"not null" json:"points"`      // XP actually awarded, after the daily cap
+	BasePoints int  `gorm:"not null" json:"base_points"` // XP the rule awarded before the cap
+	Capped     bool `gorm:"default:false" json:"capped"` // The daily cap reduced the award
+	TotalAfter int  `gorm:"not null" json:"total_after"` // User's total XP after the award
+}
+
+// TableName specifies the table name for XPEvent model
+func (XPEvent) TableName() string {
+	return "xp_events"
+}
+
+// UserXP is a user's running XP total
+// Levels are derived from the total and the configured thresholds, so changing the
+// thresholds re-levels everyone without a migration
+type UserXP struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID  uint `gorm:"not null;uniqueIndex" json:"user_id"`
+	TotalXP int  `gorm:"not null;default:0;index" json:"total_xp"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for UserXP model
+func (UserXP) TableName() string {
+	return "user_xp"
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// AuditRepository handles database operations for audit events
+// Only inserts and reads are exposed; audit events are immutable
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit repository
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// AuditFilter holds optional filters for querying audit events
+type AuditFilter struct {
+	ActorType models.AuditActorType
+	ActorID   *uint
+	Entity    string
+	EntityID  *uint
+	Action    models.AuditAction
+	RequestID string
+	From      *time.Time
+	To        *time.Time
+}
+
+// Create appends an audit event
+func (r *AuditRepository) Create(event *models.AuditEvent) error {
+	return r.db.Create(event).Error
+}
+
+// List gets audit events matching the filter, newest first
+func (r *AuditRepository) List(filter AuditFilter, limit, offset int) ([]models.AuditEvent, int64, error) {
+	var events []models.AuditEvent
+	var total int64
+
+	query := r.db.Model(&models.AuditEvent{})
+	if filter.ActorType != "" {
+		query = query.Where("actor_type = ?", filter.ActorType)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Entity != "" {
+		query = query.Where("entity = ?", filter.Entity)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error
+
+	return events, total, err
+}
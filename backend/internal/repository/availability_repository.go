@@ -39,6 +39,18 @@ func (r *AvailabilityRepository) GetUserAvailability(userID uint) ([]models.Avai
 	return availabilities, err
 }
 
+// GetAvailabilityForUsers gets all active availability slots for several users
+func (r *AvailabilityRepository) GetAvailabilityForUsers(userIDs []uint) ([]models.Availability, error) {
+	var availabilities []models.Availability
+	if len(userIDs) == 0 {
+		return availabilities, nil
+	}
+	err := r.db.Where("user_id IN ? AND is_active = ?", userIDs, true).
+		Order("user_id ASC, day_of_week ASC, start_time ASC").
+		Find(&availabilities).Error
+	return availabilities, err
+}
+
 // GetUserAvailabilityByDay gets availability for a specific day
 func (r *AvailabilityRepository) GetUserAvailabilityByDay(userID uint, dayOfWeek int) ([]models.Availability, error) {
 	var availabilities []models.Availability
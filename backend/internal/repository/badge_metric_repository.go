@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Session roles accepted by the badge metric queries
+const (
+	BadgeRoleAny     = ""        // Either participant
+	BadgeRoleTeacher = "teacher" // Sessions the user taught
+	BadgeRoleStudent = "student" // Sessions the user learned in
+)
+
+// BadgeMetricRepository reads the activity badge requirements are evaluated against
+// Every query takes an optional since; nil counts all-time activity
+type BadgeMetricRepository struct {
+	db *gorm.DB
+}
+
+// NewBadgeMetricRepository creates a new badge metric repository
+func NewBadgeMetricRepository(db *gorm.DB) *BadgeMetricRepository {
+	return &BadgeMetricRepository{db: db}
+}
+
+// CountCompletedSessions counts a user's completed sessions in the given role
+func (r *BadgeMetricRepository) CountCompletedSessions(userID uint, role string, since *time.Time) (int64, error) {
+	var count int64
+	err := r.completedSessions(userID, role, since).Count(&count).Error
+	return count, err
+}
+
+// SumSessionHours sums the duration of a user's completed sessions in the given role
+func (r *BadgeMetricRepository) SumSessionHours(userID uint, role string, since *time.Time) (float64, error) {
+	var hours float64
+	err := r.completedSessions(userID, role, since).
+		Select("COALESCE(SUM(duration), 0)::float8").
+		Scan(&hours).Error
+	return hours, err
+}
+
+// CountSkillsTaught counts the different skills a user taught in completed sessions
+func (r *BadgeMetricRepository) CountSkillsTaught(userID uint, since *time.Time) (int64, error) {
+	var count int64
+	err := r.completedSessions(userID, BadgeRoleTeacher, since).
+		Joins("JOIN user_skills ON user_skills.id = sessions.user_skill_id").
+		Distinct("user_skills.skill_id").
+		Count(&count).Error
+	return count, err
+}
+
+// CountReviewsReceived counts the published, visible reviews a user received
+func (r *BadgeMetricRepository) CountReviewsReceived(userID uint, since *time.Time) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.Review{}).
+		Where("reviewee_id = ? AND is_hidden = ? AND published_at IS NOT NULL", userID, false)
+	if since != nil {
+		query = query.Where("published_at >= ?", *since)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// SumTransactions sums the absolute amount of a user's transactions of one type
+func (r *BadgeMetricRepository) SumTransactions(userID uint, txType models.TransactionType, since *time.Time) (float64, error) {
+	var total float64
+	query := r.db.Model(&models.Transaction{}).
+		Select("COALESCE(SUM(ABS(amount)), 0)::float8").
+		Where("user_id = ? AND type = ?", userID, txType)
+	if since != nil {
+		query = query.Where("created_at >= ?", *since)
+	}
+	err := query.Scan(&total).Error
+	return total, err
+}
+
+// CountBadgesEarned counts the badges a user earned
+func (r *BadgeMetricRepository) CountBadgesEarned(userID uint, since *time.Time) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.UserBadge{}).Where("user_id = ?", userID)
+	if since != nil {
+		query = query.Where("earned_at >= ?", *since)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// completedSessions scopes a query to a user's completed sessions, leaving out
+// sessions held for fraud review until they are cleared, and skill swap legs
+// Windowed queries go by completion time, falling back to the last update
+func (r *BadgeMetricRepository) completedSessions(userID uint, role string, since *time.Time) *gorm.DB {
+	query := r.db.Model(&models.Session{}).
+		Where("sessions.status = ? AND sessions.fraud_hold = ? AND sessions.skill_swap_id IS NULL", models.StatusCompleted, false)
+	switch role {
+	case BadgeRoleTeacher:
+		query = query.Where("sessions.teacher_id = ?", userID)
+	case BadgeRoleStudent:
+		query = query.Where("sessions.student_id = ?", userID)
+	default:
+		query = query.Where("(sessions.teacher_id = ? OR sessions.student_id = ?)", userID, userID)
+	}
+	if since != nil {
+		query = query.Where("COALESCE(sessions.completed_at, sessions.updated_at) >= ?", *since)
+	}
+	return query
+}
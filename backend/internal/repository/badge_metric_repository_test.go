@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompletedSessionsLeaveOutHeldSessionsAndSwapLegs(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	queries := map[string]func(*BadgeMetricRepository) error{
+		"count": func(r *BadgeMetricRepository) error {
+			_, err := r.CountCompletedSessions(1, BadgeRoleTeacher, nil)
+			return err
+		},
+		"hours": func(r *BadgeMetricRepository) error {
+			_, err := r.SumSessionHours(1, BadgeRoleStudent, &since)
+			return err
+		},
+		"skills taught": func(r *BadgeMetricRepository) error {
+			_, err := r.CountSkillsTaught(1, nil)
+			return err
+		},
+	}
+
+	for name, query := range queries {
+		t.Run(name, func(t *testing.T) {
+			db, recorder := newDryRunDB(t)
+			checkDryRun(t, query(NewBadgeMetricRepository(db)))
+
+			sql := recorder.last(t)
+			for _, want := range []string{"sessions.fraud_hold = false", "sessions.skill_swap_id IS NULL"} {
+				if !strings.Contains(sql, want) {
+					t.Errorf("query should filter on %q, got %s", want, sql)
+				}
+			}
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package repository
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
 	"time"
 
 	"github.com/timebankingskill/backend/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // BadgeRepository handles database operations for badges
@@ -44,13 +48,19 @@ func (r *BadgeRepository) CreateBadge(badge *models.Badge) error {
 }
 
 // UpdateBadge updates a badge
+// The award counter is left alone so concurrent awards aren't overwritten
 func (r *BadgeRepository) UpdateBadge(badge *models.Badge) error {
-	return r.db.Save(badge).Error
+	return r.db.Omit("total_awarded").Save(badge).Error
 }
 
-// DeleteBadge deletes a badge (soft delete)
+// DeleteBadge deletes a badge (soft delete) and the progress tracked towards it
 func (r *BadgeRepository) DeleteBadge(id uint) error {
-	return r.db.Delete(&models.Badge{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("badge_id = ?", id).Delete(&models.BadgeProgress{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Badge{}, id).Error
+	})
 }
 
 // GetUserBadges gets all badges earned by a user
@@ -87,22 +97,230 @@ func (r *BadgeRepository) HasUserBadge(userID, badgeID uint) (bool, error) {
 	return count > 0, err
 }
 
-// AwardBadge awards a badge to a user
-func (r *BadgeRepository) AwardBadge(userID, badgeID uint) (*models.UserBadge, error) {
-	userBadge := &models.UserBadge{
-		UserID:   userID,
-		BadgeID:  badgeID,
-		EarnedAt: time.Now(),
+// AwardBadge awards a badge to a user and pays out its bonus credits through the ledger
+// The user row is locked so concurrent checks can't award the same badge twice;
+// a badge the user holds or had revoked is not awarded again
+func (r *BadgeRepository) AwardBadge(userID uint, badge *models.Badge) (*models.UserBadge, error) {
+	var userBadge *models.UserBadge
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+			return errors.New("user not found")
+		}
+
+		var count int64
+		if err := tx.Unscoped().Model(&models.UserBadge{}).
+			Where("user_id = ? AND badge_id = ?", userID, badge.ID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("badge already awarded")
+		}
+
+		bonus := badge.BonusCredits
+		userBadge = &models.UserBadge{
+			UserID:       userID,
+			BadgeID:      badge.ID,
+			EarnedAt:     time.Now(),
+			BonusGranted: &bonus,
+		}
+		if err := tx.Create(userBadge).Error; err != nil {
+			return err
+		}
+
+		// Increment badge total awarded count
+		if err := tx.Model(&models.Badge{}).Where("id = ?", badge.ID).
+			Update("total_awarded", gorm.Expr("total_awarded + ?", 1)).Error; err != nil {
+			return err
+		}
+
+		// Progress towards an earned badge is no longer tracked
+		if err := tx.Where("user_id = ? AND badge_id = ?", userID, badge.ID).Delete(&models.BadgeProgress{}).Error; err != nil {
+			return err
+		}
+
+		if bonus <= 0 {
+			return nil
+		}
+		metadata, _ := json.Marshal(map[string]interface{}{
+			"badge_id":      badge.ID,
+			"user_badge_id": userBadge.ID,
+		})
+		_, err := applyLedgerCredits(tx, &user, bonus, models.TransactionBonus, "Badge bonus: "+badge.Name, string(metadata))
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	err := r.db.Create(userBadge).Error
+	return userBadge, nil
+}
+
+// RevokeBadge revokes a user's badge and, when clawBack is set, takes back the
+// bonus credits it paid out. The clawback never eats into credits held in escrow
+// for sessions, so it can be partial. Returns the revoked badge and the clawback
+// transaction, which is nil when nothing was taken back.
+func (r *BadgeRepository) RevokeBadge(userID, badgeID, adminID uint, reason string, clawBack bool) (*models.UserBadge, *models.Transaction, error) {
+	var userBadge models.UserBadge
+	var ledger *models.Transaction
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+			return errors.New("user not found")
+		}
+
+		if err := tx.Preload("Badge").Where("user_id = ? AND badge_id = ?", userID, badgeID).First(&userBadge).Error; err != nil {
+			return errors.New("user does not have this badge")
+		}
+
+		if err := tx.Model(&userBadge).Updates(map[string]interface{}{
+			"revoked_by":    adminID,
+			"revoke_reason": reason,
+			"is_pinned":     false,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&userBadge).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Badge{}).Where("id = ? AND total_awarded > 0", badgeID).
+			Update("total_awarded", gorm.Expr("total_awarded - ?", 1)).Error; err != nil {
+			return err
+		}
+
+		if !clawBack {
+			return nil
+		}
+
+		// Awards made before bonuses were recorded were paid the badge's bonus
+		bonus := userBadge.Badge.BonusCredits
+		if userBadge.BonusGranted != nil {
+			bonus = *userBadge.BonusGranted
+		}
+		amount := math.Min(bonus, math.Max(user.CreditBalance-user.CreditHeld, 0))
+		if amount <= 0 {
+			return nil
+		}
+
+		metadata, _ := json.Marshal(map[string]interface{}{
+			"badge_id":      badgeID,
+			"user_badge_id": userBadge.ID,
+			"revoked_by":    adminID,
+			"bonus":         bonus,
+		})
+		var err error
+		ledger, err = applyLedgerCredits(tx, &user, -amount, models.TransactionAdjustment,
+			"Badge revoked: "+userBadge.Badge.Name, string(metadata))
+		return err
+	})
 	if err != nil {
+		return nil, nil, err
+	}
+	return &userBadge, ledger, nil
+}
+
+// applyLedgerCredits moves credits in or out of a locked user's balance and records the ledger entry
+func applyLedgerCredits(tx *gorm.DB, user *models.User, amount float64, txType models.TransactionType, description, metadata string) (*models.Transaction, error) {
+	balanceBefore := user.CreditBalance
+	balanceAfter := balanceBefore + amount
+	if err := tx.Model(user).Update("credit_balance", balanceAfter).Error; err != nil {
 		return nil, err
 	}
 
-	// Increment badge total awarded count
-	_ = r.db.Model(&models.Badge{}).Where("id = ?", badgeID).Update("total_awarded", gorm.Expr("total_awarded + ?", 1))
+	ledger := &models.Transaction{
+		UserID:        user.ID,
+		Type:          txType,
+		Amount:        amount,
+		BalanceBefore: balanceBefore,
+		BalanceAfter:  balanceAfter,
+		Description:   description,
+		Metadata:      metadata,
+	}
+	if err := tx.Create(ledger).Error; err != nil {
+		return nil, err
+	}
+	return ledger, nil
+}
 
-	return userBadge, nil
+// GetUserIDsWithoutBadge gets active users after afterID who never held the badge, by ID
+// Used to walk every user in batches when a badge is evaluated retroactively
+func (r *BadgeRepository) GetUserIDsWithoutBadge(badgeID, afterID uint, limit int) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.Model(&models.User{}).
+		Where("id > ? AND is_active = ?", afterID, true).
+		Where("id NOT IN (?)", r.db.Unscoped().Model(&models.UserBadge{}).Select("user_id").Where("badge_id = ?", badgeID)).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &userIDs).Error
+	return userIDs, err
+}
+
+// ListAllBadges gets every badge, including inactive ones
+func (r *BadgeRepository) ListAllBadges() ([]models.Badge, error) {
+	var badges []models.Badge
+	err := r.db.Order("display_order ASC, id ASC").Find(&badges).Error
+	return badges, err
+}
+
+// GetBadgeByName gets a badge by its unique name, including deleted badges,
+// which still hold their name in the unique index
+func (r *BadgeRepository) GetBadgeByName(name string) (*models.Badge, error) {
+	var badge models.Badge
+	err := r.db.Unscoped().Where("name = ?", name).First(&badge).Error
+	return &badge, err
+}
+
+// SetBadgeActive activates or deactivates a badge
+func (r *BadgeRepository) SetBadgeActive(id uint, active bool) error {
+	return r.db.Model(&models.Badge{}).Where("id = ?", id).Update("is_active", active).Error
+}
+
+// GetUnearnedBadges gets the active badges a user hasn't earned
+// Badges revoked from the user are left out so they aren't awarded again
+func (r *BadgeRepository) GetUnearnedBadges(userID uint) ([]models.Badge, error) {
+	var badges []models.Badge
+	err := r.db.Where("is_active = ?", true).
+		Where("id NOT IN (?)", r.db.Unscoped().Model(&models.UserBadge{}).Select("badge_id").Where("user_id = ?", userID)).
+		Order("display_order ASC").
+		Find(&badges).Error
+	return badges, err
+}
+
+// SaveBadgeProgress creates or refreshes a user's progress records
+func (r *BadgeRepository) SaveBadgeProgress(progress []models.BadgeProgress) error {
+	if len(progress) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "badge_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"metric", "current", "target", "window_days", "percent", "updated_at"}),
+	}).Create(&progress).Error
+}
+
+// DeleteBadgeProgress removes a user's progress towards a badge
+func (r *BadgeRepository) DeleteBadgeProgress(userID, badgeID uint) error {
+	return r.db.Where("user_id = ? AND badge_id = ?", userID, badgeID).Delete(&models.BadgeProgress{}).Error
+}
+
+// GetBadgeProgress gets a user's progress towards active badges, closest first
+func (r *BadgeRepository) GetBadgeProgress(userID uint, limit int) ([]models.BadgeProgress, error) {
+	var progress []models.BadgeProgress
+	err := r.db.Preload("Badge").
+		Joins("JOIN badges ON badges.id = badge_progress.badge_id AND badges.is_active = ? AND badges.deleted_at IS NULL", true).
+		Where("badge_progress.user_id = ?", userID).
+		Order("badge_progress.percent DESC, badges.rarity ASC, badges.display_order ASC").
+		Limit(limit).
+		Find(&progress).Error
+	return progress, err
+}
+
+// GetTrackedBadgeIDs gets the badges a user has progress records for
+func (r *BadgeRepository) GetTrackedBadgeIDs(userID uint) ([]uint, error) {
+	var badgeIDs []uint
+	err := r.db.Model(&models.BadgeProgress{}).Where("user_id = ?", userID).Pluck("badge_id", &badgeIDs).Error
+	return badgeIDs, err
 }
 
 // UpdateUserBadgeProgress updates progress for a user badge
@@ -129,31 +347,6 @@ func (r *BadgeRepository) GetUserPinnedBadges(userID uint) ([]models.UserBadge,
 	return userBadges, err
 }
 
-// GetBadgeLeaderboard gets top users by badge count
-func (r *BadgeRepository) GetBadgeLeaderboard(limit int) ([]map[string]interface{}, error) {
-	var results []map[string]interface{}
-	err := r.db.Model(&models.UserBadge{}).
-		Select("user_id, COUNT(*) as badge_count").
-		Group("user_id").
-		Order("badge_count DESC").
-		Limit(limit).
-		Scan(&results).Error
-	return results, err
-}
-
-// GetRarityLeaderboard gets top users by badge rarity
-func (r *BadgeRepository) GetRarityLeaderboard(limit int) ([]map[string]interface{}, error) {
-	var results []map[string]interface{}
-	err := r.db.Model(&models.UserBadge{}).
-		Select("user_badges.user_id, SUM(badges.rarity) as total_rarity").
-		Joins("JOIN badges ON badges.id = user_badges.badge_id").
-		Group("user_badges.user_id").
-		Order("total_rarity DESC").
-		Limit(limit).
-		Scan(&results).Error
-	return results, err
-}
-
 // GetUserBadgeCount gets total badge count for a user
 func (r *BadgeRepository) GetUserBadgeCount(userID uint) (int64, error) {
 	var count int64
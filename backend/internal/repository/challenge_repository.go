@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ChallengeRepository handles database operations for community challenges
+type ChallengeRepository struct {
+	db *gorm.DB
+}
+
+// NewChallengeRepository creates a new challenge repository
+func NewChallengeRepository(db *gorm.DB) *ChallengeRepository {
+	return &ChallengeRepository{db: db}
+}
+
+// Create creates a new challenge
+func (r *ChallengeRepository) Create(challenge *models.Challenge) error {
+	return r.db.Create(challenge).Error
+}
+
+// Update updates a challenge, leaving the participation counters alone
+func (r *ChallengeRepository) Update(challenge *models.Challenge) error {
+	return r.db.Omit("participant_count", "completed_count", "RewardBadge").Save(challenge).Error
+}
+
+// Delete deletes a challenge (soft delete)
+func (r *ChallengeRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Challenge{}, id).Error
+}
+
+// GetByID gets a challenge by ID
+func (r *ChallengeRepository) GetByID(id uint) (*models.Challenge, error) {
+	var challenge models.Challenge
+	if err := r.db.Preload("RewardBadge").First(&challenge, id).Error; err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// List gets a page of challenges, soonest ending first
+// status narrows to "upcoming", "running" or "ended" challenges relative to now;
+// activeOnly hides challenges admins switched off
+func (r *ChallengeRepository) List(status string, now time.Time, activeOnly bool, limit, offset int) ([]models.Challenge, int64, error) {
+	var challenges []models.Challenge
+	var total int64
+
+	query := r.db.Model(&models.Challenge{})
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	switch status {
+	case "upcoming":
+		query = query.Where("starts_at > ?", now)
+	case "running":
+		query = query.Where("starts_at <= ? AND ends_at > ?", now, now)
+	case "ended":
+		query = query.Where("ends_at <= ?", now)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("RewardBadge").
+		Order("ends_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&challenges).Error
+	return challenges, total, err
+}
+
+// GetChallengesToEvaluate gets active challenges that have started and are either
+// still running or ended without their final progress settled
+func (r *ChallengeRepository) GetChallengesToEvaluate(now time.Time) ([]models.Challenge, error) {
+	var challenges []models.Challenge
+	err := r.db.Preload("RewardBadge").
+		Where("is_active = ? AND starts_at <= ? AND finalized_at IS NULL", true, now).
+		Find(&challenges).Error
+	return challenges, err
+}
+
+// MarkFinalized records that a challenge's final progress was settled
+func (r *ChallengeRepository) MarkFinalized(id uint, at time.Time) error {
+	return r.db.Model(&models.Challenge{}).Where("id = ?", id).Update("finalized_at", at).Error
+}
+
+// GetParticipant gets a user's enrolment in a challenge
+func (r *ChallengeRepository) GetParticipant(challengeID, userID uint) (*models.ChallengeParticipant, error) {
+	var participant models.ChallengeParticipant
+	if err := r.db.Where("challenge_id = ? AND user_id = ?", challengeID, userID).First(&participant).Error; err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+// AddParticipant enrols a user in a challenge
+func (r *ChallengeRepository) AddParticipant(participant *models.ChallengeParticipant) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(participant)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("already joined this challenge")
+		}
+		return tx.Model(&models.Challenge{}).Where("id = ?", participant.ChallengeID).
+			Update("participant_count", gorm.Expr("participant_count + ?", 1)).Error
+	})
+}
+
+// RemoveParticipant withdraws a user from a challenge they haven't completed
+func (r *ChallengeRepository) RemoveParticipant(challengeID, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("challenge_id = ? AND user_id = ? AND completed_at IS NULL", challengeID, userID).
+			Delete(&models.ChallengeParticipant{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("not enrolled in this challenge")
+		}
+		return tx.Model(&models.Challenge{}).Where("id = ? AND participant_count > 0", challengeID).
+			Update("participant_count", gorm.Expr("participant_count - ?", 1)).Error
+	})
+}
+
+// ListParticipants gets a page of a challenge's participants, furthest along first
+func (r *ChallengeRepository) ListParticipants(challengeID uint, limit, offset int) ([]models.ChallengeParticipant, int64, error) {
+	var participants []models.ChallengeParticipant
+	var total int64
+
+	query := r.db.Model(&models.ChallengeParticipant{}).Where("challenge_id = ?", challengeID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("User").
+		Order("progress DESC, created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&participants).Error
+	return participants, total, err
+}
+
+// ListUserParticipations gets every challenge a user joined, newest first
+func (r *ChallengeRepository) ListUserParticipations(userID uint) ([]models.ChallengeParticipant, error) {
+	var participants []models.ChallengeParticipant
+	err := r.db.Preload("Challenge").Preload("Challenge.RewardBadge").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&participants).Error
+	return participants, err
+}
+
+// GetOpenParticipations gets a user's unfinished enrolments in running active challenges
+func (r *ChallengeRepository) GetOpenParticipations(userID uint, now time.Time) ([]models.ChallengeParticipant, error) {
+	var participants []models.ChallengeParticipant
+	err := r.db.Preload("Challenge").Preload("Challenge.RewardBadge").
+		Joins("JOIN challenges ON challenges.id = challenge_participants.challenge_id AND challenges.deleted_at IS NULL").
+		Where("challenge_participants.user_id = ? AND challenge_participants.completed_at IS NULL", userID).
+		Where("challenges.is_active = ? AND challenges.starts_at <= ? AND challenges.ends_at > ?", true, now, now).
+		Find(&participants).Error
+	return participants, err
+}
+
+// GetOpenParticipants gets a batch of a challenge's unfinished participants after afterID, by ID
+func (r *ChallengeRepository) GetOpenParticipants(challengeID, afterID uint, limit int) ([]models.ChallengeParticipant, error) {
+	var participants []models.ChallengeParticipant
+	err := r.db.Where("challenge_id = ? AND id > ? AND completed_at IS NULL", challengeID, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&participants).Error
+	return participants, err
+}
+
+// ComputeProgress measures a user's activity towards a challenge metric in [since, until)
+// Only completed sessions count, and those held for fraud review only once cleared
+// Skill swap legs are reciprocal by design and earn no progress
+func (r *ChallengeRepository) ComputeProgress(userID uint, metric models.ChallengeMetric, category models.SkillCategory, since, until time.Time) (float64, error) {
+	query := r.db.Model(&models.Session{}).
+		Where("sessions.status = ? AND sessions.fraud_hold = ? AND sessions.skill_swap_id IS NULL", models.StatusCompleted, false).
+		Where("COALESCE(sessions.completed_at, sessions.updated_at) >= ? AND COALESCE(sessions.completed_at, sessions.updated_at) < ?", since, until)
+
+	aggregate := "COUNT(*)::float8"
+	switch metric {
+	case models.ChallengeSessions:
+		query = query.Where("(sessions.teacher_id = ? OR sessions.student_id = ?)", userID, userID)
+	case models.ChallengeSessionsTaught:
+		query = query.Where("sessions.teacher_id = ?", userID)
+	case models.ChallengeSessionsLearned:
+		query = query.Where("sessions.student_id = ?", userID)
+	case models.ChallengeHoursTaught:
+		query = query.Where("sessions.teacher_id = ?", userID)
+		aggregate = "COALESCE(SUM(sessions.duration), 0)::float8"
+	case models.ChallengeHoursLearned:
+		query = query.Where("sessions.student_id = ?", userID)
+		aggregate = "COALESCE(SUM(sessions.duration), 0)::float8"
+	default:
+		return 0, nil
+	}
+
+	if category != "" {
+		query = query.Joins("JOIN user_skills ON user_skills.id = sessions.user_skill_id").
+			Joins("JOIN skills ON skills.id = user_skills.skill_id").
+			Where("skills.category = ?", category)
+	}
+
+	var progress float64
+	err := query.Select(aggregate).Scan(&progress).Error
+	return progress, err
+}
+
+// UpdateProgress records a participant's progress
+func (r *ChallengeRepository) UpdateProgress(participantID uint, progress float64) error {
+	return r.db.Model(&models.ChallengeParticipant{}).
+		Where("id = ? AND completed_at IS NULL", participantID).
+		Update("progress", progress).Error
+}
+
+// CompleteParticipant marks a participant as having reached the target and pays the
+// challenge's credit reward. Completion is recorded once; returns false when another
+// run got there first. The reward transaction is nil when the challenge pays no credits.
+func (r *ChallengeRepository) CompleteParticipant(participant *models.ChallengeParticipant, challenge *models.Challenge, progress float64) (bool, *models.Transaction, error) {
+	var ledger *models.Transaction
+	completed := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, participant.UserID).Error; err != nil {
+			return errors.New("user not found")
+		}
+
+		now := time.Now()
+		result := tx.Model(&models.ChallengeParticipant{}).
+			Where("id = ? AND completed_at IS NULL", participant.ID).
+			Updates(map[string]interface{}{
+				"progress":     progress,
+				"completed_at": now,
+				"rewarded_at":  now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		completed = true
+
+		if err := tx.Model(&models.Challenge{}).Where("id = ?", challenge.ID).
+			Update("completed_count", gorm.Expr("completed_count + ?", 1)).Error; err != nil {
+			return err
+		}
+
+		if challenge.RewardCredits <= 0 {
+			return nil
+		}
+		metadata, _ := json.Marshal(map[string]interface{}{
+			"challenge_id":   challenge.ID,
+			"participant_id": participant.ID,
+		})
+		var err error
+		ledger, err = applyLedgerCredits(tx, &user, challenge.RewardCredits, models.TransactionBonus,
+			"Challenge reward: "+challenge.Title, string(metadata))
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.ChallengeParticipant{}).Where("id = ?", participant.ID).
+			Update("reward_transaction_id", ledger.ID).Error
+	})
+	if err != nil {
+		return false, nil, err
+	}
+	return completed, ledger, nil
+}
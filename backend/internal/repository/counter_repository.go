@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Recompute statements for denormalized counters
+//
+// Each statement rebuilds the counters from their source tables and only writes
+// rows whose stored values drifted. The leading (? OR id IN ?) parameters select
+// either every row or just the given IDs, so the same SQL serves both the
+// per-write refresh and the full recompute. Session counts leave out sessions
+// held for fraud review; clearing the hold refreshes them.
+const (
+	refreshSkillCountersSQL = `UPDATE skills SET total_teachers = c.teachers, total_learners = c.learners
+		FROM (
+			SELECT s.id,
+				(SELECT COUNT(*) FROM user_skills us WHERE us.skill_id = s.id AND us.deleted_at IS NULL) AS teachers,
+				(SELECT COUNT(*) FROM learning_skills ls WHERE ls.skill_id = s.id AND ls.deleted_at IS NULL) AS learners
+			FROM skills s
+			WHERE (? OR s.id IN ?)
+		) c
+		WHERE skills.id = c.id
+		  AND (skills.total_teachers IS DISTINCT FROM c.teachers OR skills.total_learners IS DISTINCT FROM c.learners)`
+
+	refreshUserSkillCountersSQL = `UPDATE user_skills SET total_sessions = c.sessions, total_reviews = c.reviews, average_rating = c.rating
+		FROM (
+			SELECT us.id,
+				(SELECT COUNT(*) FROM sessions se
+					WHERE se.user_skill_id = us.id AND se.status = ? AND se.fraud_hold = false AND se.skill_swap_id IS NULL AND se.deleted_at IS NULL) AS sessions,
+				(SELECT COUNT(*) FROM reviews r JOIN sessions se ON se.id = r.session_id
+					WHERE se.user_skill_id = us.id AND r.type = ? AND r.is_hidden = false AND r.published_at IS NOT NULL AND r.deleted_at IS NULL) AS reviews,
+				(SELECT COALESCE(AVG(r.rating), 0)::float8 FROM reviews r JOIN sessions se ON se.id = r.session_id
+					WHERE se.user_skill_id = us.id AND r.type = ? AND r.is_hidden = false AND r.published_at IS NOT NULL AND r.deleted_at IS NULL) AS rating
+			FROM user_skills us
+			WHERE (? OR us.id IN ?)
+		) c
+		WHERE user_skills.id = c.id
+		  AND (user_skills.total_sessions IS DISTINCT FROM c.sessions
+		    OR user_skills.total_reviews IS DISTINCT FROM c.reviews
+		    OR user_skills.average_rating IS DISTINCT FROM c.rating)`
+
+	refreshUserCountersSQL = `UPDATE users SET
+			total_sessions_as_teacher = c.taught, total_sessions_as_student = c.learned,
+			average_rating_as_teacher = c.teacher_rating, average_rating_as_student = c.student_rating
+		FROM (
+			SELECT u.id,
+				(SELECT COUNT(*) FROM sessions se
+					WHERE se.teacher_id = u.id AND se.status = ? AND se.fraud_hold = false AND se.skill_swap_id IS NULL AND se.deleted_at IS NULL) AS taught,
+				(SELECT COUNT(*) FROM sessions se
+					WHERE se.student_id = u.id AND se.status = ? AND se.fraud_hold = false AND se.skill_swap_id IS NULL AND se.deleted_at IS NULL) AS learned,
+				(SELECT COALESCE(AVG(r.rating), 0)::float8 FROM reviews r
+					WHERE r.reviewee_id = u.id AND r.type = ? AND r.is_hidden = false AND r.published_at IS NOT NULL AND r.deleted_at IS NULL) AS teacher_rating,
+				(SELECT COALESCE(AVG(r.rating), 0)::float8 FROM reviews r
+					WHERE r.reviewee_id = u.id AND r.type = ? AND r.is_hidden = false AND r.published_at IS NOT NULL AND r.deleted_at IS NULL) AS student_rating
+			FROM users u
+			WHERE (? OR u.id IN ?)
+		) c
+		WHERE users.id = c.id
+		  AND (users.total_sessions_as_teacher IS DISTINCT FROM c.taught
+		    OR users.total_sessions_as_student IS DISTINCT FROM c.learned
+		    OR users.average_rating_as_teacher IS DISTINCT FROM c.teacher_rating
+		    OR users.average_rating_as_student IS DISTINCT FROM c.student_rating)`
+
+	refreshForumCategoryCountersSQL = `UPDATE forum_categories SET thread_count = c.threads
+		FROM (
+			SELECT fc.id, (SELECT COUNT(*) FROM forum_threads t WHERE t.category_id = fc.id) AS threads
+			FROM forum_categories fc
+			WHERE (? OR fc.id IN ?)
+		) c
+		WHERE forum_categories.id = c.id AND forum_categories.thread_count IS DISTINCT FROM c.threads`
+)
+
+// CounterRecomputeResult reports how many rows of each table had drifted counters corrected
+type CounterRecomputeResult struct {
+	Skills          int64 `json:"skills"`
+	UserSkills      int64 `json:"user_skills"`
+	Users           int64 `json:"users"`
+	ForumCategories int64 `json:"forum_categories"`
+}
+
+// CounterRepository maintains denormalized counters
+// Writes that change a counter's source rows refresh it in the same transaction
+// through the refresh helpers below; RecomputeAll rebuilds every counter
+type CounterRepository struct {
+	db *gorm.DB
+}
+
+// NewCounterRepository creates a new counter repository
+func NewCounterRepository(db *gorm.DB) *CounterRepository {
+	return &CounterRepository{db: db}
+}
+
+// RecomputeAll rebuilds every denormalized counter from its source tables in one transaction
+func (r *CounterRepository) RecomputeAll() (*CounterRecomputeResult, error) {
+	result := &CounterRecomputeResult{}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if result.Skills, err = execSkillCounters(tx, true, nil); err != nil {
+			return err
+		}
+		if result.UserSkills, err = execUserSkillCounters(tx, true, nil); err != nil {
+			return err
+		}
+		if result.Users, err = execUserCounters(tx, true, nil); err != nil {
+			return err
+		}
+		result.ForumCategories, err = execForumCategoryCounters(tx, true, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RecomputeRatings rebuilds only the review-fed aggregates (user and user skill
+// ratings, review and session counts) in one transaction; used to backfill ratings
+func (r *CounterRepository) RecomputeRatings() (*CounterRecomputeResult, error) {
+	result := &CounterRecomputeResult{}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if result.UserSkills, err = execUserSkillCounters(tx, true, nil); err != nil {
+			return err
+		}
+		result.Users, err = execUserCounters(tx, true, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// refreshSkillCounters refreshes TotalTeachers and TotalLearners of the given skills
+func refreshSkillCounters(tx *gorm.DB, skillIDs ...uint) error {
+	if len(skillIDs) == 0 {
+		return nil
+	}
+	_, err := execSkillCounters(tx, false, skillIDs)
+	return err
+}
+
+// refreshUserSkillCounters refreshes TotalSessions, TotalReviews and AverageRating of the given user skills
+func refreshUserSkillCounters(tx *gorm.DB, userSkillIDs ...uint) error {
+	if len(userSkillIDs) == 0 {
+		return nil
+	}
+	_, err := execUserSkillCounters(tx, false, userSkillIDs)
+	return err
+}
+
+// refreshUserCounters refreshes the session counts and role ratings of the given users
+func refreshUserCounters(tx *gorm.DB, userIDs ...uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := execUserCounters(tx, false, userIDs)
+	return err
+}
+
+// refreshForumCategoryCounters refreshes ThreadCount of the given forum categories
+func refreshForumCategoryCounters(tx *gorm.DB, categoryIDs ...uint) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+	_, err := execForumCategoryCounters(tx, false, categoryIDs)
+	return err
+}
+
+func execSkillCounters(tx *gorm.DB, all bool, ids []uint) (int64, error) {
+	result := tx.Exec(refreshSkillCountersSQL, all, ids)
+	return result.RowsAffected, result.Error
+}
+
+func execUserSkillCounters(tx *gorm.DB, all bool, ids []uint) (int64, error) {
+	result := tx.Exec(refreshUserSkillCountersSQL,
+		models.StatusCompleted, models.ReviewTypeTeacher, models.ReviewTypeTeacher, all, ids)
+	return result.RowsAffected, result.Error
+}
+
+func execUserCounters(tx *gorm.DB, all bool, ids []uint) (int64, error) {
+	result := tx.Exec(refreshUserCountersSQL,
+		models.StatusCompleted, models.StatusCompleted, models.ReviewTypeTeacher, models.ReviewTypeStudent, all, ids)
+	return result.RowsAffected, result.Error
+}
+
+func execForumCategoryCounters(tx *gorm.DB, all bool, ids []uint) (int64, error) {
+	result := tx.Exec(refreshForumCategoryCountersSQL, all, ids)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreditAdjustmentRepository handles database operations for admin credit adjustments
+type CreditAdjustmentRepository struct {
+	db *gorm.DB
+}
+
+// NewCreditAdjustmentRepository creates a new credit adjustment repository
+func NewCreditAdjustmentRepository(db *gorm.DB) *CreditAdjustmentRepository {
+	return &CreditAdjustmentRepository{db: db}
+}
+
+// Create creates a new adjustment together with its "requested" audit event
+func (r *CreditAdjustmentRepository) Create(adjustment *models.CreditAdjustment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(adjustment).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.CreditAdjustmentEvent{
+			AdjustmentID: adjustment.ID,
+			AdminID:      adjustment.RequestedBy,
+			Action:       models.AdjustmentActionRequested,
+			Note:         adjustment.Reason,
+		}).Error
+	})
+}
+
+// GetByID gets an adjustment by ID with its audit trail
+func (r *CreditAdjustmentRepository) GetByID(id uint) (*models.CreditAdjustment, error) {
+	var adjustment models.CreditAdjustment
+	err := r.db.Preload("User").
+		Preload("Events", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		First(&adjustment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &adjustment, nil
+}
+
+// List gets adjustments filtered by status and/or user, newest first
+func (r *CreditAdjustmentRepository) List(status models.AdjustmentStatus, userID *uint, limit, offset int) ([]models.CreditAdjustment, int64, error) {
+	var adjustments []models.CreditAdjustment
+	var total int64
+
+	query := r.db.Model(&models.CreditAdjustment{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("User").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&adjustments).Error
+
+	return adjustments, total, err
+}
+
+// Reject marks a pending adjustment as rejected and records the audit event
+func (r *CreditAdjustmentRepository) Reject(adjustmentID, reviewerID uint, note string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.CreditAdjustment{}).
+			Where("id = ? AND status = ?", adjustmentID, models.AdjustmentPending).
+			Updates(map[string]interface{}{
+				"status":      models.AdjustmentRejected,
+				"reviewed_by": reviewerID,
+				"reviewed_at": now,
+				"review_note": note,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("adjustment is no longer pending")
+		}
+
+		return tx.Create(&models.CreditAdjustmentEvent{
+			AdjustmentID: adjustmentID,
+			AdminID:      reviewerID,
+			Action:       models.AdjustmentActionRejected,
+			Note:         note,
+		}).Error
+	})
+}
+
+// Apply moves the credits of a pending adjustment atomically
+// Locks the user row, updates the balance, writes the ledger transaction,
+// marks the adjustment applied and appends audit events in a single DB transaction
+//
+// Parameters:
+//   - adjustmentID: Adjustment to apply (must be pending)
+//   - reviewerID: Approving admin, or nil when applied without review (below threshold)
+//   - actorID: Admin recorded on the "applied" audit event
+//   - note: Review note stored with the approval
+//
+// Returns:
+//   - *Transaction: Ledger entry created for the adjustment
+//   - error: If adjustment is not pending, balance would go negative, or database error
+func (r *CreditAdjustmentRepository) Apply(adjustmentID uint, reviewerID *uint, actorID uint, note string) (*models.Transaction, error) {
+	var ledger *models.Transaction
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var adjustment models.CreditAdjustment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&adjustment, adjustmentID).Error; err != nil {
+			return err
+		}
+		if !adjustment.IsPending() {
+			return errors.New("adjustment is no longer pending")
+		}
+
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, adjustment.UserID).Error; err != nil {
+			return errors.New("user not found")
+		}
+
+		balanceBefore := user.CreditBalance
+		balanceAfter := balanceBefore + adjustment.Amount
+
+		// Deductions may not eat into credits held in escrow for sessions
+		if adjustment.Amount < 0 && balanceAfter < user.CreditHeld {
+			return fmt.Errorf("insufficient available credits: have %.1f, deducting %.1f", balanceBefore-user.CreditHeld, -adjustment.Amount)
+		}
+
+		if err := tx.Model(&user).Update("credit_balance", balanceAfter).Error; err != nil {
+			return err
+		}
+
+		metadata, _ := json.Marshal(map[string]interface{}{
+			"adjustment_id": adjustment.ID,
+			"requested_by":  adjustment.RequestedBy,
+			"reviewed_by":   reviewerID,
+		})
+		ledger = &models.Transaction{
+			UserID:        adjustment.UserID,
+			Type:          models.TransactionAdjustment,
+			Amount:        adjustment.Amount,
+			BalanceBefore: balanceBefore,
+			BalanceAfter:  balanceAfter,
+			Description:   "Admin adjustment: " + adjustment.Reason,
+			Metadata:      string(metadata),
+		}
+		if err := tx.Create(ledger).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		updates := map[string]interface{}{
+			"status":         models.AdjustmentApplied,
+			"transaction_id": ledger.ID,
+			"applied_at":     now,
+		}
+		if reviewerID != nil {
+			updates["reviewed_by"] = *reviewerID
+			updates["reviewed_at"] = now
+			updates["review_note"] = note
+		}
+		if err := tx.Model(&adjustment).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		events := []models.CreditAdjustmentEvent{}
+		if reviewerID != nil {
+			events = append(events, models.CreditAdjustmentEvent{
+				AdjustmentID: adjustment.ID,
+				AdminID:      *reviewerID,
+				Action:       models.AdjustmentActionApproved,
+				Note:         note,
+			})
+		}
+		events = append(events, models.CreditAdjustmentEvent{
+			AdjustmentID: adjustment.ID,
+			AdminID:      actorID,
+			Action:       models.AdjustmentActionApplied,
+			Note:         fmt.Sprintf("balance %.2f -> %.2f", balanceBefore, balanceAfter),
+		})
+		return tx.Create(&events).Error
+	})
+
+	return ledger, err
+}
+
+// ListEvents gets the audit trail across all adjustments, newest first
+func (r *CreditAdjustmentRepository) ListEvents(adminID *uint, limit, offset int) ([]models.CreditAdjustmentEvent, int64, error) {
+	var events []models.CreditAdjustmentEvent
+	var total int64
+
+	query := r.db.Model(&models.CreditAdjustmentEvent{})
+	if adminID != nil {
+		query = query.Where("admin_id = ?", *adminID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error
+
+	return events, total, err
+}
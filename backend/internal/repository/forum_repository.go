@@ -37,9 +37,14 @@ func (r *ForumRepository) GetCategoryByID(id uint) (*models.ForumCategory, error
 
 // ===== THREAD OPERATIONS =====
 
-// CreateThread creates a new forum thread
+// CreateThread creates a new forum thread and refreshes its category's thread count
 func (r *ForumRepository) CreateThread(thread *models.ForumThread) error {
-	return r.db.Create(thread).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(thread).Error; err != nil {
+			return err
+		}
+		return refreshForumCategoryCounters(tx, thread.CategoryID)
+	})
 }
 
 // GetThreadByID gets a thread by ID with author and category
@@ -134,3 +139,28 @@ func (r *ForumRepository) DeleteReply(replyID uint) error {
 	// Decrement reply count on thread
 	return r.db.Model(&models.ForumThread{}).Where("id = ?", reply.ThreadID).Update("reply_count", gorm.Expr("reply_count - ?", 1)).Error
 }
+
+// GetReplyByID gets a reply by ID
+func (r *ForumRepository) GetReplyByID(id uint) (*models.ForumReply, error) {
+	var reply models.ForumReply
+	if err := r.db.First(&reply, id).Error; err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// AcceptReply marks a reply as the thread's accepted answer, replacing any earlier one
+func (r *ForumRepository) AcceptReply(threadID, replyID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ForumReply{}).Where("thread_id = ? AND is_accepted = ?", threadID, true).
+			Update("is_accepted", false).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.ForumReply{}).Where("id = ?", replyID).
+			Update("is_accepted", true).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.ForumThread{}).Where("id = ?", threadID).
+			Update("accepted_reply_id", replyID).Error
+	})
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FraudRepository handles database operations for fraud detection and review
+type FraudRepository struct {
+	db *gorm.DB
+}
+
+// NewFraudRepository creates a new fraud repository
+func NewFraudRepository(db *gorm.DB) *FraudRepository {
+	return &FraudRepository{db: db}
+}
+
+// PairStats aggregates completed sessions between two users in a time window
+type PairStats struct {
+	Sessions       int64 `json:"sessions"`
+	ATaught        int64 `json:"a_taught"`         // Sessions where user A was the teacher
+	BTaught        int64 `json:"b_taught"`         // Sessions where user B was the teacher
+	ShortSessions  int64 `json:"short_sessions"`   // Actual duration below the short-session ratio
+	SameIPSessions int64 `json:"same_ip_sessions"` // Both check-ins from the same IP
+}
+
+// PairCount is a pair of users with their number of completed sessions together
+type PairCount struct {
+	UserA    uint  `json:"user_a"`
+	UserB    uint  `json:"user_b"`
+	Sessions int64 `json:"sessions"`
+}
+
+// CreateFlag creates a new fraud flag
+func (r *FraudRepository) CreateFlag(flag *models.FraudFlag) error {
+	return r.db.Create(flag).Error
+}
+
+// GetFlagByID gets a fraud flag by ID with session and participants
+func (r *FraudRepository) GetFlagByID(id uint) (*models.FraudFlag, error) {
+	var flag models.FraudFlag
+	err := r.db.Preload("Session").Preload("Teacher").Preload("Student").
+		First(&flag, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+// UpdateFlag updates a fraud flag
+func (r *FraudRepository) UpdateFlag(flag *models.FraudFlag) error {
+	return r.db.Omit(clause.Associations).Save(flag).Error
+}
+
+// ResolveFlag records the admin decision on a pending flag and settles the held session
+// The flag and session rows are locked, so concurrent reviews of the same flag cannot
+// both release the credits. Clearing transfers the held credits to the teacher;
+// confirming returns them to the student and marks the session disputed.
+//
+// Returns:
+//   - *FraudFlag: The resolved flag
+//   - *Session: The settled session, without relationships
+//   - error: If the flag was already reviewed, the session is not on hold, or database error
+func (r *FraudRepository) ResolveFlag(flagID, adminID uint, status models.FraudFlagStatus, note string) (*models.FraudFlag, *models.Session, error) {
+	var flag models.FraudFlag
+	var session models.Session
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&flag, flagID).Error; err != nil {
+			return err
+		}
+		if !flag.IsPending() {
+			return errors.New("fraud flag has already been reviewed")
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&session, flag.SessionID).Error; err != nil {
+			return errors.New("session not found")
+		}
+		if !session.FraudHold || session.CreditReleased {
+			return errors.New("session credits are not on hold")
+		}
+
+		var teacher, student models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&student, session.StudentID).Error; err != nil {
+			return errors.New("student not found")
+		}
+
+		if status == models.FraudFlagCleared {
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&teacher, session.TeacherID).Error; err != nil {
+				return errors.New("teacher not found")
+			}
+			if err := releaseSessionCredits(tx, &session, &teacher, &student); err != nil {
+				return err
+			}
+		} else {
+			if err := tx.Model(&student).Update("credit_held", student.CreditHeld-session.CreditAmount).Error; err != nil {
+				return err
+			}
+			refund := &models.Transaction{
+				UserID:        session.StudentID,
+				Type:          models.TransactionRefund,
+				Amount:        -session.CreditAmount, // release from held
+				BalanceBefore: student.CreditBalance,
+				BalanceAfter:  student.CreditBalance,
+				Description:   "Credit hold released for disputed session: " + session.Title,
+				SessionID:     &session.ID,
+			}
+			if err := tx.Create(refund).Error; err != nil {
+				return err
+			}
+			session.Status = models.StatusDisputed
+		}
+
+		session.FraudHold = false
+		if err := saveCompletion(tx, &session); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		flag.Status = status
+		flag.ReviewedBy = &adminID
+		flag.ReviewedAt = &now
+		flag.ReviewNote = note
+		return tx.Save(&flag).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &flag, &session, nil
+}
+
+// releaseSessionCredits moves a session's held credits from the student to the teacher
+// and records both ledger transactions. Does not persist the session itself.
+func releaseSessionCredits(tx *gorm.DB, session *models.Session, teacher, student *models.User) error {
+	session.CreditReleased = true
+
+	teacherBefore := teacher.CreditBalance
+	studentBefore := student.CreditBalance
+	if err := tx.Model(student).Updates(map[string]interface{}{
+		"credit_held":    student.CreditHeld - session.CreditAmount,
+		"credit_balance": studentBefore - session.CreditAmount,
+	}).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(teacher).Update("credit_balance", teacherBefore+session.CreditAmount).Error; err != nil {
+		return err
+	}
+
+	ledger := []models.Transaction{
+		{
+			UserID:        session.TeacherID,
+			Type:          models.TransactionEarned,
+			Amount:        session.CreditAmount,
+			BalanceBefore: teacherBefore,
+			BalanceAfter:  teacherBefore + session.CreditAmount,
+			Description:   "Earned from teaching session: " + session.Title,
+			SessionID:     &session.ID,
+		},
+		{
+			UserID:        session.StudentID,
+			Type:          models.TransactionSpent,
+			Amount:        -session.CreditAmount,
+			BalanceBefore: studentBefore,
+			BalanceAfter:  studentBefore - session.CreditAmount,
+			Description:   "Spent on learning session: " + session.Title,
+			SessionID:     &session.ID,
+		},
+	}
+	return tx.Create(&ledger).Error
+}
+
+// ListFlags gets fraud flags filtered by status, highest score first
+func (r *FraudRepository) ListFlags(status models.FraudFlagStatus, limit, offset int) ([]models.FraudFlag, int64, error) {
+	var flags []models.FraudFlag
+	var total int64
+
+	query := r.db.Model(&models.FraudFlag{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Session").Preload("Teacher").Preload("Student").
+		Order("score DESC, created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&flags).Error
+
+	return flags, total, err
+}
+
+// CountDirectedSessions counts completed sessions where teacherID taught studentID since a time
+// Skill swap legs move no credits and are ignored here and in the queries below
+func (r *FraudRepository) CountDirectedSessions(teacherID, studentID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Session{}).
+		Where("teacher_id = ? AND student_id = ? AND status = ? AND completed_at >= ?",
+			teacherID, studentID, models.StatusCompleted, since).
+		Where("skill_swap_id IS NULL").
+		Count(&count).Error
+	return count, err
+}
+
+// CountBookingLoops counts third users closing a credit loop with a session
+// For a session where studentID paid teacherID, a loop exists when teacherID
+// learned from C and C learned from studentID (student → teacher → C → student)
+func (r *FraudRepository) CountBookingLoops(teacherID, studentID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Raw(`
+		SELECT COUNT(DISTINCT s1.teacher_id)
+		FROM sessions s1
+		JOIN sessions s2 ON s2.student_id = s1.teacher_id
+		WHERE s1.student_id = ? AND s2.teacher_id = ?
+		  AND s1.teacher_id NOT IN (?, ?)
+		  AND s1.status = ? AND s2.status = ?
+		  AND s1.completed_at >= ? AND s2.completed_at >= ?
+		  AND s1.skill_swap_id IS NULL AND s2.skill_swap_id IS NULL
+		  AND s1.deleted_at IS NULL AND s2.deleted_at IS NULL`,
+		teacherID, studentID,
+		teacherID, studentID,
+		models.StatusCompleted, models.StatusCompleted,
+		since, since,
+	).Scan(&count).Error
+	return count, err
+}
+
+// GetPairStats aggregates completed sessions between two users in either direction
+// shortRatio is the fraction of the booked duration below which a session counts as short
+func (r *FraudRepository) GetPairStats(userA, userB uint, since time.Time, shortRatio float64) (*PairStats, error) {
+	var stats PairStats
+	err := r.db.Model(&models.Session{}).
+		Select(`COUNT(*) AS sessions,
+			COUNT(*) FILTER (WHERE teacher_id = ?) AS a_taught,
+			COUNT(*) FILTER (WHERE teacher_id = ?) AS b_taught,
+			COUNT(*) FILTER (WHERE started_at IS NOT NULL AND completed_at IS NOT NULL
+				AND EXTRACT(EPOCH FROM (completed_at - started_at)) < duration * 3600 * ?) AS short_sessions,
+			COUNT(*) FILTER (WHERE teacher_check_in_ip <> '' AND teacher_check_in_ip = student_check_in_ip) AS same_ip_sessions`,
+			userA, userB, shortRatio).
+		Where("((teacher_id = ? AND student_id = ?) OR (teacher_id = ? AND student_id = ?))", userA, userB, userB, userA).
+		Where("status = ? AND completed_at >= ? AND skill_swap_id IS NULL", models.StatusCompleted, since).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// GetFrequentPairs gets user pairs with at least minSessions completed sessions together
+func (r *FraudRepository) GetFrequentPairs(since time.Time, minSessions int64, limit int) ([]PairCount, error) {
+	var pairs []PairCount
+	err := r.db.Model(&models.Session{}).
+		Select("LEAST(teacher_id, student_id) AS user_a, GREATEST(teacher_id, student_id) AS user_b, COUNT(*) AS sessions").
+		Where("status = ? AND completed_at >= ? AND skill_swap_id IS NULL", models.StatusCompleted, since).
+		Group("LEAST(teacher_id, student_id), GREATEST(teacher_id, student_id)").
+		Having("COUNT(*) >= ?", minSessions).
+		Order("sessions DESC").
+		Limit(limit).
+		Scan(&pairs).Error
+	return pairs, err
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepository handles database operations for idempotency keys
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency repository
+func NewIdempotencyRepository(db *gorm.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Reserve claims a key for the given account (actor type and ID)
+// Returns (nil, nil) when the key was newly reserved, or the existing record
+// when the key is already taken. Expired records, and records stuck in
+// processing for longer than staleAfter (e.g. after a crash), are replaced.
+// Relies on the unique (actor_type, user_id, key) index so concurrent reservations cannot both succeed
+func (r *IdempotencyRepository) Reserve(record *models.IdempotencyKey, staleAfter time.Duration) (*models.IdempotencyKey, error) {
+	var existing *models.IdempotencyKey
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Where("actor_type = ? AND user_id = ? AND key = ?", record.ActorType, record.UserID, record.Key).
+			Where("expires_at < ? OR (status = ? AND created_at < ?)", now, models.IdempotencyProcessing, now.Add(-staleAfter)).
+			Delete(&models.IdempotencyKey{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return nil
+		}
+
+		var current models.IdempotencyKey
+		if err := tx.Where("actor_type = ? AND user_id = ? AND key = ?", record.ActorType, record.UserID, record.Key).First(&current).Error; err != nil {
+			return err
+		}
+		existing = &current
+		return nil
+	})
+
+	return existing, err
+}
+
+// Complete stores the response for a reserved key
+func (r *IdempotencyRepository) Complete(id uint, responseCode int, contentType string, body []byte) error {
+	return r.db.Model(&models.IdempotencyKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":        models.IdempotencyCompleted,
+			"response_code": responseCode,
+			"content_type":  contentType,
+			"response_body": body,
+		}).Error
+}
+
+// Release deletes a reserved key so the request can be retried
+func (r *IdempotencyRepository) Release(id uint) error {
+	return r.db.Delete(&models.IdempotencyKey{}, id).Error
+}
+
+// DeleteExpired removes all keys past their TTL
+func (r *IdempotencyRepository) DeleteExpired() (int64, error) {
+	result := r.db.Where("expires_at < ?", time.Now()).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// LeaderboardScoreRow is one user's score on a leaderboard, with the fields scopes group by
+type LeaderboardScoreRow struct {
+	UserID   uint
+	School   string
+	Location string
+	Category string // Skill category; only set when scores are split by category
+	Score    float64
+}
+
+// LeaderboardRepository handles database operations for leaderboard snapshots and seasons
+type LeaderboardRepository struct {
+	db *gorm.DB
+}
+
+// NewLeaderboardRepository creates a new leaderboard repository
+func NewLeaderboardRepository(db *gorm.DB) *LeaderboardRepository {
+	return &LeaderboardRepository{db: db}
+}
+
+// GetScores computes every active user's score on a leaderboard for activity in [since, until)
+// A nil bound leaves that side open. With byCategory each user gets one row per skill
+// category they were active in. Rating scores need at least minReviews reviews.
+func (r *LeaderboardRepository) GetScores(boardType models.LeaderboardType, since, until *time.Time, byCategory bool, minReviews int) ([]LeaderboardScoreRow, error) {
+	if boardType == models.LeaderboardRating {
+		return r.getRatingScores(since, until, byCategory, minReviews)
+	}
+
+	var query *gorm.DB
+	var timeColumn, score string
+	switch boardType {
+	case models.LeaderboardSessions:
+		query = r.db.Table("sessions").
+			Joins("JOIN users ON users.id IN (sessions.teacher_id, sessions.student_id)").
+			Where("sessions.status = ? AND sessions.fraud_hold = ? AND sessions.skill_swap_id IS NULL AND sessions.deleted_at IS NULL", models.StatusCompleted, false)
+		timeColumn = "COALESCE(sessions.completed_at, sessions.updated_at)"
+		score = "COUNT(*)::float8"
+	case models.LeaderboardCredits:
+		query = r.db.Table("transactions").
+			Joins("JOIN users ON users.id = transactions.user_id").
+			Where("transactions.type = ?", models.TransactionEarned)
+		if byCategory {
+			query = query.Joins("JOIN sessions ON sessions.id = transactions.session_id")
+		}
+		timeColumn = "transactions.created_at"
+		score = "SUM(ABS(transactions.amount))::float8"
+	case models.LeaderboardBadges, models.LeaderboardRarity:
+		query = r.db.Table("user_badges").
+			Joins("JOIN users ON users.id = user_badges.user_id").
+			Where("user_badges.deleted_at IS NULL")
+		timeColumn = "user_badges.earned_at"
+		score = "COUNT(*)::float8"
+		if boardType == models.LeaderboardRarity {
+			query = query.Joins("JOIN badges ON badges.id = user_badges.badge_id")
+			score = "SUM(badges.rarity)::float8"
+		}
+	default:
+		return nil, nil
+	}
+
+	columns := "users.id, users.school, users.location"
+	if byCategory {
+		query = query.Joins("JOIN user_skills ON user_skills.id = sessions.user_skill_id").
+			Joins("JOIN skills ON skills.id = user_skills.skill_id")
+		columns += ", skills.category"
+	}
+
+	var rows []LeaderboardScoreRow
+	err := leaderboardWindow(query, timeColumn, since, until).
+		Where("users.is_active = ? AND users.deleted_at IS NULL", true).
+		Select(leaderboardSelect(byCategory) + ", " + score + " AS score").
+		Group(columns).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// getRatingScores averages the published reviews each user received per role,
+// then averages over the roles reviewed in, so a role without reviews isn't counted as 0
+func (r *LeaderboardRepository) getRatingScores(since, until *time.Time, byCategory bool, minReviews int) ([]LeaderboardScoreRow, error) {
+	columns := "users.id, users.school, users.location"
+	roles := r.db.Table("reviews").
+		Joins("JOIN users ON users.id = reviews.reviewee_id").
+		Where("reviews.is_hidden = ? AND reviews.published_at IS NOT NULL AND reviews.deleted_at IS NULL", false).
+		Where("users.is_active = ? AND users.deleted_at IS NULL", true)
+	if byCategory {
+		roles = roles.Joins("JOIN sessions ON sessions.id = reviews.session_id").
+			Joins("JOIN user_skills ON user_skills.id = sessions.user_skill_id").
+			Joins("JOIN skills ON skills.id = user_skills.skill_id")
+		columns += ", skills.category"
+	}
+	roles = leaderboardWindow(roles, "reviews.published_at", since, until).
+		Select(leaderboardSelect(byCategory) + ", AVG(reviews.rating) AS role_rating, COUNT(*) AS reviews").
+		Group(columns + ", reviews.type")
+
+	outer := "user_id, school, location"
+	if byCategory {
+		outer += ", category"
+	}
+
+	var rows []LeaderboardScoreRow
+	err := r.db.Table("(?) AS roles", roles).
+		Select(outer+", AVG(role_rating)::float8 AS score").
+		Group(outer).
+		Having("SUM(reviews) >= ?", minReviews).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// ReplaceSnapshots replaces every snapshot of one leaderboard period
+func (r *LeaderboardRepository) ReplaceSnapshots(boardType models.LeaderboardType, period models.LeaderboardPeriod, periodKey string, snapshots []models.LeaderboardSnapshot) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("type = ? AND period = ? AND period_key = ?", boardType, period, periodKey).
+			Delete(&models.LeaderboardSnapshot{}).Error; err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(snapshots, 1000).Error
+	})
+}
+
+// GetSnapshots gets a page of a leaderboard, best first
+func (r *LeaderboardRepository) GetSnapshots(boardType models.LeaderboardType, period models.LeaderboardPeriod, periodKey string, scope models.LeaderboardScope, scopeValue string, limit, offset int) ([]models.LeaderboardSnapshot, int64, error) {
+	var snapshots []models.LeaderboardSnapshot
+	var total int64
+
+	query := r.snapshots(boardType, period, periodKey, scope, scopeValue)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("User").
+		Order("rank ASC, user_id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&snapshots).Error
+	return snapshots, total, err
+}
+
+// GetUserSnapshot gets one user's standing on a leaderboard
+func (r *LeaderboardRepository) GetUserSnapshot(boardType models.LeaderboardType, period models.LeaderboardPeriod, periodKey string, scope models.LeaderboardScope, scopeValue string, userID uint) (*models.LeaderboardSnapshot, error) {
+	var snapshot models.LeaderboardSnapshot
+	err := r.snapshots(boardType, period, periodKey, scope, scopeValue).
+		Where("user_id = ?", userID).
+		First(&snapshot).Error
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// CountSnapshots counts the users ranked on a leaderboard
+func (r *LeaderboardRepository) CountSnapshots(boardType models.LeaderboardType, period models.LeaderboardPeriod, periodKey string, scope models.LeaderboardScope, scopeValue string) (int64, error) {
+	var count int64
+	err := r.snapshots(boardType, period, periodKey, scope, scopeValue).Count(&count).Error
+	return count, err
+}
+
+// GetComputedAt gets when a leaderboard period was last computed, or nil if it never was
+func (r *LeaderboardRepository) GetComputedAt(period models.LeaderboardPeriod, periodKey string) (*time.Time, error) {
+	var computedAt *time.Time
+	err := r.db.Model(&models.LeaderboardSnapshot{}).
+		Select("MAX(computed_at)").
+		Where("period = ? AND period_key = ?", period, periodKey).
+		Scan(&computedAt).Error
+	return computedAt, err
+}
+
+// DeleteSnapshotsBefore deletes weekly and monthly snapshots computed before the cutoff
+// Snapshots of archived seasons are kept
+func (r *LeaderboardRepository) DeleteSnapshotsBefore(cutoff time.Time) (int64, error) {
+	result := r.db.Where("period IN ? AND computed_at < ?",
+		[]models.LeaderboardPeriod{models.LeaderboardWeekly, models.LeaderboardMonthly}, cutoff).
+		Delete(&models.LeaderboardSnapshot{})
+	return result.RowsAffected, result.Error
+}
+
+// CreateSeason creates a new season
+func (r *LeaderboardRepository) CreateSeason(season *models.LeaderboardSeason) error {
+	return r.db.Create(season).Error
+}
+
+// UpdateSeason updates a season
+func (r *LeaderboardRepository) UpdateSeason(season *models.LeaderboardSeason) error {
+	return r.db.Save(season).Error
+}
+
+// DeleteSeason deletes a season (soft delete)
+func (r *LeaderboardRepository) DeleteSeason(id uint) error {
+	return r.db.Delete(&models.LeaderboardSeason{}, id).Error
+}
+
+// GetSeasonByID gets a season by ID
+func (r *LeaderboardRepository) GetSeasonByID(id uint) (*models.LeaderboardSeason, error) {
+	var season models.LeaderboardSeason
+	if err := r.db.First(&season, id).Error; err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+// ListSeasons gets every season, most recent first
+func (r *LeaderboardRepository) ListSeasons() ([]models.LeaderboardSeason, error) {
+	var seasons []models.LeaderboardSeason
+	err := r.db.Order("starts_at DESC").Find(&seasons).Error
+	return seasons, err
+}
+
+// CountOverlappingSeasons counts seasons other than excludeID that overlap [startsAt, endsAt)
+func (r *LeaderboardRepository) CountOverlappingSeasons(startsAt, endsAt time.Time, excludeID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.LeaderboardSeason{}).
+		Where("starts_at < ? AND ends_at > ? AND id <> ?", endsAt, startsAt, excludeID).
+		Count(&count).Error
+	return count, err
+}
+
+// GetUnarchivedSeasons gets the upcoming and active seasons that have started by now
+func (r *LeaderboardRepository) GetUnarchivedSeasons(now time.Time) ([]models.LeaderboardSeason, error) {
+	var seasons []models.LeaderboardSeason
+	err := r.db.Where("status <> ? AND starts_at <= ?", models.SeasonArchived, now).
+		Order("starts_at ASC").
+		Find(&seasons).Error
+	return seasons, err
+}
+
+// GetActiveSeason gets the season running now
+func (r *LeaderboardRepository) GetActiveSeason() (*models.LeaderboardSeason, error) {
+	var season models.LeaderboardSeason
+	if err := r.db.Where("status = ?", models.SeasonActive).Order("starts_at DESC").First(&season).Error; err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+// snapshots scopes a query to one leaderboard
+func (r *LeaderboardRepository) snapshots(boardType models.LeaderboardType, period models.LeaderboardPeriod, periodKey string, scope models.LeaderboardScope, scopeValue string) *gorm.DB {
+	return r.db.Model(&models.LeaderboardSnapshot{}).
+		Where("type = ? AND period = ? AND period_key = ? AND scope = ? AND scope_value = ?",
+			boardType, period, periodKey, scope, scopeValue)
+}
+
+// leaderboardWindow limits a query to activity in [since, until)
+func leaderboardWindow(query *gorm.DB, column string, since, until *time.Time) *gorm.DB {
+	if since != nil {
+		query = query.Where(column+" >= ?", *since)
+	}
+	if until != nil {
+		query = query.Where(column+" < ?", *until)
+	}
+	return query
+}
+
+// leaderboardSelect names the grouped user columns for scanning into LeaderboardScoreRow
+func leaderboardSelect(byCategory bool) string {
+	selected := "users.id AS user_id, users.school AS school, users.location AS location"
+	if byCategory {
+		selected += ", skills.category AS category"
+	}
+	return selected
+}
@@ -0,0 +1,443 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// likeEscaper escapes LIKE wildcards in user input; patterns built with it need ESCAPE '\'
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern builds a LIKE pattern matching values that contain s literally
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+// Offer facet dimensions; a facet is counted with every filter except its own
+const (
+	OfferFacetLevel    = "level"
+	OfferFacetPrice    = "price"
+	OfferFacetMode     = "mode"
+	OfferFacetLocation = "location"
+	OfferFacetRating   = "rating"
+	OfferFacetDay      = "day"
+	OfferFacetSchool   = "school"
+	OfferFacetGrade    = "grade"
+	OfferFacetVerified = "verified"
+)
+
+// Offer sort orders
+const (
+	OfferSortRelevance  = "relevance"
+	OfferSortRating     = "rating"
+	OfferSortPriceAsc   = "price_asc"
+	OfferSortPriceDesc  = "price_desc"
+	OfferSortPopularity = "popularity"
+)
+
+// Offer modes; "both" is a teacher accepting online and offline sessions
+const (
+	OfferModeOnline  = "online"
+	OfferModeOffline = "offline"
+)
+
+const (
+	// offerTiebreakOrder orders offers with equal sort keys so pages are stable
+	offerTiebreakOrder = "user_skills.id ASC"
+	// offerReviewsJoin aggregates visible, published teacher reviews per offer (user skill)
+	offerReviewsJoin = `LEFT JOIN (
+		SELECT sessions.user_skill_id, AVG(reviews.rating) AS avg_rating, COUNT(*) AS review_count
+		FROM reviews
+		JOIN sessions ON sessions.id = reviews.session_id
+		WHERE reviews.type = 'teacher' AND reviews.is_hidden = false AND reviews.published_at IS NOT NULL
+			AND reviews.deleted_at IS NULL
+		GROUP BY sessions.user_skill_id
+	) offer_reviews ON offer_reviews.user_skill_id = user_skills.id`
+
+	offerRatingExpr = "COALESCE(offer_reviews.avg_rating, 0)"
+
+	// offerReputationJoin attaches the offer's stored reputation score
+	offerReputationJoin = `LEFT JOIN reputation_scores offer_reputation
+		ON offer_reputation.subject_type = 'user_skill' AND offer_reputation.subject_id = user_skills.id
+		AND offer_reputation.role = 'teacher'`
+
+	// offerReputationExpr is the offer's 0-100 reputation score; it already discounts
+	// thin and stale review histories, so it's used for ranking instead of the plain average
+	offerReputationExpr = "COALESCE(offer_reputation.score, 0)"
+	offerBayesianExpr   = "COALESCE(offer_reputation.bayesian_rating, 0)"
+
+	// offerVerifiedExpr is true while the offer's credential verification is current
+	// Checked against the expiry directly so results never lag the expiry job
+	offerVerifiedExpr = "COALESCE(user_skills.is_verified AND user_skills.verification_expires_at > NOW(), false)"
+
+	// offerVerifiedBoost is added to the relevance quality score of verified offers,
+	// worth 20 reputation points
+	offerVerifiedBoost = 2
+
+	offerColumns = "user_skills.id AS user_skill_id, user_skills.user_id, user_skills.skill_id, " +
+		"skills.name AS skill_name, skills.category AS skill_category, " +
+		"user_skills.level, user_skills.description, user_skills.years_of_experience, user_skills.hourly_rate, " +
+		"user_skills.online_only, user_skills.offline_only, user_skills.total_sessions, " +
+		offerRatingExpr + " AS rating, COALESCE(offer_reviews.review_count, 0) AS review_count, " +
+		offerBayesianExpr + " AS bayesian_rating, " + offerReputationExpr + " AS reputation_score, " +
+		offerVerifiedExpr + " AS is_verified, " +
+		"users.full_name, users.username, users.avatar, users.location, users.school, users.grade, user_skills.created_at"
+
+	offerPriceBucketExpr = `CASE
+		WHEN user_skills.hourly_rate < 1 THEN '0-1'
+		WHEN user_skills.hourly_rate < 2 THEN '1-2'
+		WHEN user_skills.hourly_rate < 3 THEN '2-3'
+		ELSE '3+' END`
+)
+
+// OfferFilter holds optional filters for searching teacher offers
+// Zero values mean "no filter" for the corresponding field
+type OfferFilter struct {
+	Search    string
+	SkillID   *uint
+	Category  string
+	Levels    []models.SkillLevel
+	MinRate   *float64
+	MaxRate   *float64
+	Mode      string // OfferModeOnline excludes offline-only offers and vice versa
+	Location  string
+	MinRating *float64
+	DayOfWeek *int
+	Time      string // "HH:MM", must fall inside an availability slot
+	School    string
+	Grade     string
+	Verified  bool // Only offers with a current credential verification
+
+	// Matching constraints used by recommendations; not exposed as facets
+	SkillIDs      []uint   // Offers for any of these skills...
+	Categories    []string // ...or any skill in these categories
+	ExcludeUserID *uint    // Leave out the learner's own offers
+	CreatedAfter  *time.Time
+	ChangedAfter  *time.Time // Offer or teacher profile created or updated since; used by saved search alerts
+}
+
+// OfferRow is a teacher offer: one user skill with its teacher and review aggregate
+type OfferRow struct {
+	UserSkillID       uint
+	UserID            uint
+	SkillID           uint
+	SkillName         string
+	SkillCategory     models.SkillCategory
+	Level             models.SkillLevel
+	Description       string
+	YearsOfExperience int
+	HourlyRate        float64
+	OnlineOnly        bool
+	OfflineOnly       bool
+	TotalSessions     int
+	Rating            float64
+	ReviewCount       int64
+	BayesianRating    float64
+	ReputationScore   float64
+	IsVerified        bool
+	FullName          string
+	Username          string
+	Avatar            string
+	Location          string
+	School            string
+	Grade             string
+	CreatedAt         time.Time
+}
+
+// FacetCount is the number of offers matching one facet value
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// ModeFacet counts offers available online and offline
+// Offers accepting both modes are counted in each
+type ModeFacet struct {
+	Online  int64
+	Offline int64
+}
+
+// RatingFacet counts offers at or above each minimum rating
+type RatingFacet struct {
+	AtLeast45 int64
+	AtLeast4  int64
+	AtLeast35 int64
+	AtLeast3  int64
+}
+
+// MarketplaceRepository handles teacher offer search queries
+type MarketplaceRepository struct {
+	db *gorm.DB
+}
+
+// NewMarketplaceRepository creates a new marketplace repository
+func NewMarketplaceRepository(db *gorm.DB) *MarketplaceRepository {
+	return &MarketplaceRepository{db: db}
+}
+
+// filteredOffers builds the base offer query with filters applied
+// The filter for the skip dimension is left out so its facet counts stay disjunctive
+func (r *MarketplaceRepository) filteredOffers(filter OfferFilter, skip string) *gorm.DB {
+	query := r.db.Table("user_skills").
+		Joins("JOIN users ON users.id = user_skills.user_id AND users.deleted_at IS NULL AND users.is_active = ?", true).
+		Joins("JOIN skills ON skills.id = user_skills.skill_id AND skills.deleted_at IS NULL").
+		Joins(offerReviewsJoin).
+		Joins(offerReputationJoin).
+		Where("user_skills.deleted_at IS NULL AND user_skills.is_available = ?", true)
+
+	if filter.Search != "" {
+		pattern := containsPattern(filter.Search)
+		query = query.Where(`(skills.name ILIKE ? ESCAPE '\' OR user_skills.description ILIKE ? ESCAPE '\' OR users.full_name ILIKE ? ESCAPE '\')`,
+			pattern, pattern, pattern)
+	}
+	if filter.SkillID != nil {
+		query = query.Where("user_skills.skill_id = ?", *filter.SkillID)
+	}
+	if filter.Category != "" {
+		query = query.Where("skills.category = ?", filter.Category)
+	}
+	if len(filter.SkillIDs) > 0 || len(filter.Categories) > 0 {
+		// Sentinels keep both IN lists non-empty when only one constraint is set
+		skillIDs := append([]uint{0}, filter.SkillIDs...)
+		categories := append([]string{""}, filter.Categories...)
+		query = query.Where("(user_skills.skill_id IN ? OR skills.category IN ?)", skillIDs, categories)
+	}
+	if filter.ExcludeUserID != nil {
+		query = query.Where("user_skills.user_id <> ?", *filter.ExcludeUserID)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("user_skills.created_at > ?", *filter.CreatedAfter)
+	}
+	if filter.ChangedAfter != nil {
+		query = query.Where("(user_skills.updated_at > ? OR users.updated_at > ?)", *filter.ChangedAfter, *filter.ChangedAfter)
+	}
+	if len(filter.Levels) > 0 && skip != OfferFacetLevel {
+		query = query.Where("user_skills.level IN ?", filter.Levels)
+	}
+	if skip != OfferFacetPrice {
+		if filter.MinRate != nil {
+			query = query.Where("user_skills.hourly_rate >= ?", *filter.MinRate)
+		}
+		if filter.MaxRate != nil {
+			query = query.Where("user_skills.hourly_rate <= ?", *filter.MaxRate)
+		}
+	}
+	if skip != OfferFacetMode {
+		switch filter.Mode {
+		case OfferModeOnline:
+			query = query.Where("user_skills.offline_only = ?", false)
+		case OfferModeOffline:
+			query = query.Where("user_skills.online_only = ?", false)
+		}
+	}
+	if filter.Location != "" && skip != OfferFacetLocation {
+		query = query.Where(`users.location ILIKE ? ESCAPE '\'`, containsPattern(filter.Location))
+	}
+	if filter.MinRating != nil && skip != OfferFacetRating {
+		query = query.Where(offerRatingExpr+" >= ?", *filter.MinRating)
+	}
+	if filter.School != "" && skip != OfferFacetSchool {
+		query = query.Where(`users.school ILIKE ? ESCAPE '\'`, containsPattern(filter.School))
+	}
+	if filter.Grade != "" && skip != OfferFacetGrade {
+		query = query.Where("users.grade = ?", filter.Grade)
+	}
+	if filter.Verified && skip != OfferFacetVerified {
+		query = query.Where(offerVerifiedExpr)
+	}
+
+	// Day and time are matched against the same availability slot
+	dayOfWeek := filter.DayOfWeek
+	if skip == OfferFacetDay {
+		dayOfWeek = nil
+	}
+	if dayOfWeek != nil || filter.Time != "" {
+		slot, args := availabilitySlotCondition("a", dayOfWeek, filter.Time)
+		query = query.Where("EXISTS (SELECT 1 FROM availabilities a WHERE a.user_id = user_skills.user_id AND "+slot+")", args...)
+	}
+
+	return query
+}
+
+// availabilitySlotCondition builds the condition matching an active availability slot
+func availabilitySlotCondition(alias string, dayOfWeek *int, timeOfDay string) (string, []interface{}) {
+	condition := fmt.Sprintf("%[1]s.is_active = true AND %[1]s.deleted_at IS NULL", alias)
+	var args []interface{}
+	if dayOfWeek != nil {
+		condition += fmt.Sprintf(" AND %s.day_of_week = ?", alias)
+		args = append(args, *dayOfWeek)
+	}
+	if timeOfDay != "" {
+		condition += fmt.Sprintf(" AND %[1]s.start_time <= ? AND %[1]s.end_time > ?", alias)
+		args = append(args, timeOfDay, timeOfDay)
+	}
+	return condition, args
+}
+
+// SearchOffers returns a page of teacher offers matching the filter
+func (r *MarketplaceRepository) SearchOffers(filter OfferFilter, sortBy string, limit, offset int) ([]OfferRow, int64, error) {
+	var offers []OfferRow
+	var total int64
+
+	if err := r.filteredOffers(filter, "").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := r.filteredOffers(filter, "").Select(offerColumns)
+
+	switch sortBy {
+	case OfferSortRating:
+		query = query.Order(offerBayesianExpr + " DESC").Order("COALESCE(offer_reviews.review_count, 0) DESC").Order(offerTiebreakOrder)
+	case OfferSortPriceAsc:
+		query = query.Order("user_skills.hourly_rate ASC").Order(offerTiebreakOrder)
+	case OfferSortPriceDesc:
+		query = query.Order("user_skills.hourly_rate DESC").Order(offerTiebreakOrder)
+	case OfferSortPopularity:
+		query = query.Order("user_skills.total_sessions DESC").Order(offerTiebreakOrder)
+	default:
+		// GORM replaces an ORDER BY expression with any column ordered after it,
+		// so the relevance order carries its own tiebreak
+		query = query.Order(offerRelevanceOrder(filter.Search))
+	}
+
+	err := query.
+		Limit(limit).
+		Offset(offset).
+		Scan(&offers).Error
+
+	return offers, total, err
+}
+
+// ListOffers returns up to limit offers matching the filter, newest first; with SkillIDs
+// set, offers for those exact skills come before category matches
+// Used for candidate generation where the caller does its own scoring
+func (r *MarketplaceRepository) ListOffers(filter OfferFilter, limit int) ([]OfferRow, error) {
+	var offers []OfferRow
+	query := r.filteredOffers(filter, "").Select(offerColumns)
+	if len(filter.SkillIDs) > 0 {
+		// Exact skill matches come before category matches so the limit can't crowd them out
+		// Both terms live in one expression, which GORM would replace with a later column
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "CASE WHEN user_skills.skill_id IN ? THEN 0 ELSE 1 END, user_skills.created_at DESC",
+			Vars: []interface{}{filter.SkillIDs},
+		}})
+	} else {
+		query = query.Order("user_skills.created_at DESC")
+	}
+	err := query.
+		Limit(limit).
+		Scan(&offers).Error
+	return offers, err
+}
+
+// ListOffersAfter returns up to limit offers matching the filter with an ID above afterID,
+// in ID order; pass the last ID of a page to get the next one
+func (r *MarketplaceRepository) ListOffersAfter(filter OfferFilter, afterID uint, limit int) ([]OfferRow, error) {
+	var offers []OfferRow
+	err := r.filteredOffers(filter, "").
+		Select(offerColumns).
+		Where("user_skills.id > ?", afterID).
+		Order("user_skills.id ASC").
+		Limit(limit).
+		Scan(&offers).Error
+	return offers, err
+}
+
+// offerRelevanceOrder ranks offers by text match quality, then reputation (which weighs
+// review volume, recency and reviewer credibility so a single 5-star review doesn't outrank
+// established tutors) and teaching history; verified offers get a fixed boost
+// The ID tiebreak is part of the expression since nothing may be ordered after it
+func offerRelevanceOrder(search string) clause.OrderBy {
+	quality := fmt.Sprintf("(%s / 10 + LN(1 + user_skills.total_sessions) + CASE WHEN %s THEN %d ELSE 0 END)",
+		offerReputationExpr, offerVerifiedExpr, offerVerifiedBoost)
+	if search == "" {
+		return clause.OrderBy{Expression: clause.Expr{SQL: quality + " DESC, " + offerTiebreakOrder}}
+	}
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL: `(CASE
+		WHEN skills.name ILIKE ? ESCAPE '\' THEN 3
+		WHEN skills.name ILIKE ? ESCAPE '\' THEN 2
+		ELSE 1 END) DESC, ` + quality + " DESC, " + offerTiebreakOrder,
+		Vars: []interface{}{likeEscaper.Replace(search), likeEscaper.Replace(search) + "%"},
+	}}
+}
+
+// GetOfferFacetCounts counts offers per value of a column or expression, most common first
+// limit <= 0 returns every value
+func (r *MarketplaceRepository) GetOfferFacetCounts(filter OfferFilter, facet, expr string, limit int) ([]FacetCount, error) {
+	var counts []FacetCount
+	query := r.filteredOffers(filter, facet).
+		Select(expr + " AS value, COUNT(*) AS count").
+		Where("COALESCE(" + expr + ", '') <> ''").
+		Group(expr).
+		Order("count DESC, value ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Scan(&counts).Error
+	return counts, err
+}
+
+// GetOfferLevelFacet counts offers per skill level
+func (r *MarketplaceRepository) GetOfferLevelFacet(filter OfferFilter) ([]FacetCount, error) {
+	return r.GetOfferFacetCounts(filter, OfferFacetLevel, "user_skills.level", 0)
+}
+
+// GetOfferPriceFacet counts offers per hourly rate bucket
+func (r *MarketplaceRepository) GetOfferPriceFacet(filter OfferFilter) ([]FacetCount, error) {
+	return r.GetOfferFacetCounts(filter, OfferFacetPrice, offerPriceBucketExpr, 0)
+}
+
+// GetOfferModeFacet counts offers available online and offline
+func (r *MarketplaceRepository) GetOfferModeFacet(filter OfferFilter) (*ModeFacet, error) {
+	var facet ModeFacet
+	err := r.filteredOffers(filter, OfferFacetMode).
+		Select("COUNT(*) FILTER (WHERE NOT user_skills.offline_only) AS online, " +
+			"COUNT(*) FILTER (WHERE NOT user_skills.online_only) AS offline").
+		Scan(&facet).Error
+	if err != nil {
+		return nil, err
+	}
+	return &facet, nil
+}
+
+// GetOfferRatingFacet counts offers at or above each minimum rating
+func (r *MarketplaceRepository) GetOfferRatingFacet(filter OfferFilter) (*RatingFacet, error) {
+	var facet RatingFacet
+	err := r.filteredOffers(filter, OfferFacetRating).
+		Select("COUNT(*) FILTER (WHERE " + offerRatingExpr + " >= 4.5) AS at_least45, " +
+			"COUNT(*) FILTER (WHERE " + offerRatingExpr + " >= 4) AS at_least4, " +
+			"COUNT(*) FILTER (WHERE " + offerRatingExpr + " >= 3.5) AS at_least35, " +
+			"COUNT(*) FILTER (WHERE " + offerRatingExpr + " >= 3) AS at_least3").
+		Scan(&facet).Error
+	if err != nil {
+		return nil, err
+	}
+	return &facet, nil
+}
+
+// GetOfferVerifiedFacet counts offers with a current credential verification
+func (r *MarketplaceRepository) GetOfferVerifiedFacet(filter OfferFilter) (int64, error) {
+	var count int64
+	err := r.filteredOffers(filter, OfferFacetVerified).Where(offerVerifiedExpr).Count(&count).Error
+	return count, err
+}
+
+// GetOfferDayFacet counts offers whose teacher is available on each day of the week
+// The time filter still applies so counts reflect slots covering the requested time
+func (r *MarketplaceRepository) GetOfferDayFacet(filter OfferFilter) ([]FacetCount, error) {
+	var counts []FacetCount
+	slot, args := availabilitySlotCondition("fa", nil, filter.Time)
+	err := r.filteredOffers(filter, OfferFacetDay).
+		Joins("JOIN availabilities fa ON fa.user_id = user_skills.user_id AND "+slot, args...).
+		Select("CAST(fa.day_of_week AS TEXT) AS value, COUNT(DISTINCT user_skills.id) AS count").
+		Group("fa.day_of_week").
+		Order("fa.day_of_week ASC").
+		Scan(&counts).Error
+	return counts, err
+}
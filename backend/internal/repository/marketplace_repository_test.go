@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchOffersRelevanceOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		search string
+		prefix string
+	}{
+		{name: "without search", search: "", prefix: "(" + offerReputationExpr},
+		{name: "with search", search: "go", prefix: "(CASE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, recorder := newDryRunDB(t)
+			repo := NewMarketplaceRepository(db)
+
+			_, _, err := repo.SearchOffers(OfferFilter{Search: tt.search}, OfferSortRelevance, 20, 0)
+			checkDryRun(t, err)
+
+			order := orderByClause(t, recorder.last(t))
+			if !strings.HasPrefix(order, tt.prefix) {
+				t.Errorf("ORDER BY should start with the relevance order %q, got %q", tt.prefix, order)
+			}
+			if !strings.HasSuffix(order, "DESC, user_skills.id ASC") {
+				t.Errorf("ORDER BY should end with the ID tiebreak, got %q", order)
+			}
+		})
+	}
+}
+
+func TestSearchOffersSortOrder(t *testing.T) {
+	tests := []struct {
+		sort string
+		want string
+	}{
+		{OfferSortPriceAsc, "user_skills.hourly_rate ASC,user_skills.id ASC"},
+		{OfferSortPriceDesc, "user_skills.hourly_rate DESC,user_skills.id ASC"},
+		{OfferSortPopularity, "user_skills.total_sessions DESC,user_skills.id ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			db, recorder := newDryRunDB(t)
+			repo := NewMarketplaceRepository(db)
+
+			_, _, err := repo.SearchOffers(OfferFilter{}, tt.sort, 20, 0)
+			checkDryRun(t, err)
+
+			if order := orderByClause(t, recorder.last(t)); order != tt.want {
+				t.Errorf("ORDER BY = %q, want %q", order, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchOffersEscapesLikeWildcards(t *testing.T) {
+	db, recorder := newDryRunDB(t)
+	repo := NewMarketplaceRepository(db)
+
+	_, _, err := repo.SearchOffers(OfferFilter{Search: `50%_off\`}, OfferSortRelevance, 20, 0)
+	checkDryRun(t, err)
+
+	sql := recorder.last(t)
+	if !strings.Contains(sql, `'%50\%\_off\\%'`) {
+		t.Errorf("search pattern should escape %%, _ and \\, got %s", sql)
+	}
+	if !strings.Contains(sql, `ESCAPE '\'`) {
+		t.Errorf("ILIKE should declare its escape character, got %s", sql)
+	}
+}
+
+func TestListOffersOrder(t *testing.T) {
+	t.Run("exact skills first", func(t *testing.T) {
+		db, recorder := newDryRunDB(t)
+		repo := NewMarketplaceRepository(db)
+
+		filter := OfferFilter{SkillIDs: []uint{3, 7}, Categories: []string{"programming"}}
+		_, err := repo.ListOffers(filter, 500)
+		checkDryRun(t, err)
+
+		want := "CASE WHEN user_skills.skill_id IN (3,7) THEN 0 ELSE 1 END, user_skills.created_at DESC"
+		if order := orderByClause(t, recorder.last(t)); order != want {
+			t.Errorf("ORDER BY = %q, want %q", order, want)
+		}
+	})
+
+	t.Run("newest first", func(t *testing.T) {
+		db, recorder := newDryRunDB(t)
+		repo := NewMarketplaceRepository(db)
+
+		_, err := repo.ListOffers(OfferFilter{Categories: []string{"programming"}}, 500)
+		checkDryRun(t, err)
+
+		if order := orderByClause(t, recorder.last(t)); order != "user_skills.created_at DESC" {
+			t.Errorf("ORDER BY = %q, want newest first", order)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecommendationRepository handles database operations for the recommendation digest
+type RecommendationRepository struct {
+	db *gorm.DB
+}
+
+// NewRecommendationRepository creates a new recommendation repository
+func NewRecommendationRepository(db *gorm.DB) *RecommendationRepository {
+	return &RecommendationRepository{db: db}
+}
+
+// GetLearnerIDs gets active users with at least one learning skill
+func (r *RecommendationRepository) GetLearnerIDs() ([]uint, error) {
+	var userIDs []uint
+	err := r.db.Model(&models.LearningSkill{}).
+		Joins("JOIN users ON users.id = learning_skills.user_id AND users.deleted_at IS NULL AND users.is_active = ?", true).
+		Distinct("learning_skills.user_id").
+		Order("learning_skills.user_id ASC").
+		Pluck("learning_skills.user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// ClaimDigest marks a user's digest for a week as processed
+// Returns false when the digest was already claimed (by this or another instance)
+func (r *RecommendationRepository) ClaimDigest(userID uint, weekStart time.Time) (*models.RecommendationDigest, bool, error) {
+	digest := &models.RecommendationDigest{
+		UserID:    userID,
+		WeekStart: weekStart,
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(digest)
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	return digest, result.RowsAffected == 1, nil
+}
+
+// ReleaseDigest deletes a claimed digest so a later run can retry it
+func (r *RecommendationRepository) ReleaseDigest(digestID uint) error {
+	return r.db.Delete(&models.RecommendationDigest{}, digestID).Error
+}
+
+// SetDigestMatchCount records how many new matches a digest contained
+func (r *RecommendationRepository) SetDigestMatchCount(digestID uint, count int) error {
+	return r.db.Model(&models.RecommendationDigest{}).
+		Where("id = ?", digestID).
+		Update("match_count", count).Error
+}
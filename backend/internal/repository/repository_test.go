@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// sqlRecorder is a GORM logger that keeps the SQL of every statement
+type sqlRecorder struct {
+	statements []string
+}
+
+func (r *sqlRecorder) LogMode(logger.LogLevel) logger.Interface      { return r }
+func (r *sqlRecorder) Info(context.Context, string, ...interface{})  {}
+func (r *sqlRecorder) Warn(context.Context, string, ...interface{})  {}
+func (r *sqlRecorder) Error(context.Context, string, ...interface{}) {}
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
+// last returns the most recent statement
+func (r *sqlRecorder) last(t *testing.T) string {
+	t.Helper()
+	if len(r.statements) == 0 {
+		t.Fatal("no SQL was recorded")
+	}
+	return r.statements[len(r.statements)-1]
+}
+
+// newDryRunDB opens a Postgres-dialect GORM handle that builds SQL without a database
+func newDryRunDB(t *testing.T) (*gorm.DB, *sqlRecorder) {
+	t.Helper()
+	recorder := &sqlRecorder{}
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1 user=test dbname=test sslmode=disable"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               recorder,
+	})
+	if err != nil {
+		t.Fatalf("failed to open dry-run database: %v", err)
+	}
+	return db, recorder
+}
+
+// orderByClause returns the ORDER BY clause of a statement, up to its LIMIT
+func orderByClause(t *testing.T, sql string) string {
+	t.Helper()
+	i := strings.LastIndex(sql, "ORDER BY ")
+	if i < 0 {
+		t.Fatalf("statement has no ORDER BY: %s", sql)
+	}
+	clause := sql[i+len("ORDER BY "):]
+	if j := strings.Index(clause, " LIMIT "); j >= 0 {
+		clause = clause[:j]
+	}
+	return clause
+}
+
+// checkDryRun fails the test on errors other than the one Scan reports in dry-run
+// mode, where the statement is still built and recorded
+func checkDryRun(t *testing.T, err error) {
+	t.Helper()
+	if err != nil && !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+		t.Fatalf("query failed: %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReputationReviewRow is one visible review with the context needed to weight it
+type ReputationReviewRow struct {
+	ReviewID            uint
+	RevieweeID          uint
+	Type                models.ReviewType
+	UserSkillID         *uint // Offer the session was booked on; only set for reviews of a teacher
+	Rating              int
+	CommunicationRating *int
+	PunctualityRating   *int
+	KnowledgeRating     *int
+	CreatedAt           time.Time
+	ReviewerSessions    int  // Sessions the reviewer completed in either role
+	ReviewerActive      bool // Reviewer account still exists and is active
+	FraudStatus         *models.FraudFlagStatus
+}
+
+// ReputationPrior holds platform-wide average ratings for one review type
+// Sub-rating averages are nil while no review has that sub-rating
+type ReputationPrior struct {
+	Type          models.ReviewType
+	Rating        float64
+	Communication *float64
+	Punctuality   *float64
+	Knowledge     *float64
+}
+
+// ReputationRepository handles database operations for reputation scores
+type ReputationRepository struct {
+	db *gorm.DB
+}
+
+// NewReputationRepository creates a new reputation repository
+func NewReputationRepository(db *gorm.DB) *ReputationRepository {
+	return &ReputationRepository{db: db}
+}
+
+// GetReviewRows gets the visible, published reviews received by the given users,
+// or all of them when userIDs is nil
+// Reviews on sessions confirmed as fraudulent are left out
+func (r *ReputationRepository) GetReviewRows(userIDs []uint) ([]ReputationReviewRow, error) {
+	var rows []ReputationReviewRow
+	query := r.db.Table("reviews").
+		Select(`reviews.id AS review_id, reviews.reviewee_id, reviews.type,
+			CASE WHEN reviews.type = ? THEN sessions.user_skill_id END AS user_skill_id,
+			reviews.rating, reviews.communication_rating, reviews.punctuality_rating, reviews.knowledge_rating,
+			reviews.created_at,
+			COALESCE(reviewers.total_sessions_as_teacher, 0) + COALESCE(reviewers.total_sessions_as_student, 0) AS reviewer_sessions,
+			COALESCE(reviewers.is_active AND reviewers.deleted_at IS NULL, false) AS reviewer_active,
+			fraud_flags.status AS fraud_status`, models.ReviewTypeTeacher).
+		Joins("JOIN sessions ON sessions.id = reviews.session_id").
+		Joins("LEFT JOIN users reviewers ON reviewers.id = reviews.reviewer_id").
+		Joins("LEFT JOIN fraud_flags ON fraud_flags.session_id = reviews.session_id AND fraud_flags.deleted_at IS NULL").
+		Where("reviews.is_hidden = ? AND reviews.published_at IS NOT NULL AND reviews.deleted_at IS NULL", false).
+		Where("(fraud_flags.status IS NULL OR fraud_flags.status <> ?)", models.FraudFlagConfirmed)
+	if userIDs != nil {
+		query = query.Where("reviews.reviewee_id IN ?", userIDs)
+	}
+	err := query.Order("reviews.id ASC").Scan(&rows).Error
+	return rows, err
+}
+
+// GetPriors gets the platform-wide average ratings per review type
+func (r *ReputationRepository) GetPriors() ([]ReputationPrior, error) {
+	var priors []ReputationPrior
+	err := r.db.Model(&models.Review{}).
+		Select(`type, AVG(rating)::float8 AS rating,
+			AVG(communication_rating)::float8 AS communication,
+			AVG(punctuality_rating)::float8 AS punctuality,
+			AVG(knowledge_rating)::float8 AS knowledge`).
+		Where("is_hidden = ? AND published_at IS NOT NULL", false).
+		Group("type").
+		Scan(&priors).Error
+	return priors, err
+}
+
+// ReplaceForUsers replaces the scores owned by the given users
+// Users whose reviews are all gone end up with no scores
+func (r *ReputationRepository) ReplaceForUsers(userIDs []uint, scores []models.ReputationScore) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id IN ?", userIDs).Delete(&models.ReputationScore{}).Error; err != nil {
+			return err
+		}
+		return upsertReputationScores(tx, scores)
+	})
+}
+
+// ReplaceAll replaces every stored score
+func (r *ReputationRepository) ReplaceAll(scores []models.ReputationScore) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.ReputationScore{}).Error; err != nil {
+			return err
+		}
+		return upsertReputationScores(tx, scores)
+	})
+}
+
+// GetByUser gets a user's role scores and offer scores, best first
+func (r *ReputationRepository) GetByUser(userID uint) ([]models.ReputationScore, error) {
+	var scores []models.ReputationScore
+	err := r.db.Where("user_id = ?", userID).
+		Order("subject_type ASC, score DESC").
+		Find(&scores).Error
+	return scores, err
+}
+
+// GetBySubject gets the score of one user role or offer
+func (r *ReputationRepository) GetBySubject(subjectType models.ReputationSubject, subjectID uint, role models.ReviewType) (*models.ReputationScore, error) {
+	var score models.ReputationScore
+	err := r.db.Where("subject_type = ? AND subject_id = ? AND role = ?", subjectType, subjectID, role).
+		First(&score).Error
+	if err != nil {
+		return nil, err
+	}
+	return &score, nil
+}
+
+// upsertReputationScores writes scores, overwriting any written concurrently for the same subject
+func upsertReputationScores(tx *gorm.DB, scores []models.ReputationScore) error {
+	if len(scores) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subject_type"}, {Name: "subject_id"}, {Name: "role"}},
+		UpdateAll: true,
+	}).CreateInBatches(scores, 500).Error
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReviewModerationRepository handles review reports, helpful votes, replies and moderator actions
+type ReviewModerationRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewModerationRepository creates a new review moderation repository
+func NewReviewModerationRepository(db *gorm.DB) *ReviewModerationRepository {
+	return &ReviewModerationRepository{db: db}
+}
+
+// CreateReport files a report and puts the review in the moderation queue
+// Returns false when the reporter already reported the review
+func (r *ReviewModerationRepository) CreateReport(report *models.ReviewReport) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		created = true
+		return tx.Model(&models.Review{}).Where("id = ?", report.ReviewID).Updates(map[string]interface{}{
+			"is_reported":  true,
+			"report_count": gorm.Expr("report_count + 1"),
+		}).Error
+	})
+	return created, err
+}
+
+// ListReported gets reviews waiting for a moderator, most reported first
+func (r *ReviewModerationRepository) ListReported(limit, offset int) ([]models.Review, int64, error) {
+	var reviews []models.Review
+	var total int64
+
+	query := r.db.Model(&models.Review{}).Where("is_reported = ?", true)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Reviewer").Preload("Reviewee").Preload("Reply").
+		Order("report_count DESC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&reviews).Error
+
+	return reviews, total, err
+}
+
+// ListReports gets the reports filed against the given reviews, oldest first
+// An empty status returns reports in every state
+func (r *ReviewModerationRepository) ListReports(reviewIDs []uint, status models.ReviewReportStatus) ([]models.ReviewReport, error) {
+	var reports []models.ReviewReport
+	if len(reviewIDs) == 0 {
+		return reports, nil
+	}
+
+	query := r.db.Where("review_id IN ?", reviewIDs)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Preload("Reporter").Order("created_at ASC").Find(&reports).Error
+	return reports, err
+}
+
+// AddHelpfulVote records a helpful vote and refreshes the review's helpful count
+// Returns false when the user had already voted
+func (r *ReviewModerationRepository) AddHelpfulVote(reviewID, userID uint) (bool, error) {
+	added := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ReviewHelpfulVote{
+			ReviewID: reviewID,
+			UserID:   userID,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		added = true
+		return refreshHelpfulCount(tx, reviewID)
+	})
+	return added, err
+}
+
+// RemoveHelpfulVote removes a user's helpful vote and refreshes the review's helpful count
+// Returns false when the user had not voted
+func (r *ReviewModerationRepository) RemoveHelpfulVote(reviewID, userID uint) (bool, error) {
+	removed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("review_id = ? AND user_id = ?", reviewID, userID).Delete(&models.ReviewHelpfulVote{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		removed = true
+		return refreshHelpfulCount(tx, reviewID)
+	})
+	return removed, err
+}
+
+// GetHelpfulCount gets a review's current helpful count
+func (r *ReviewModerationRepository) GetHelpfulCount(reviewID uint) (int, error) {
+	var count int
+	err := r.db.Model(&models.Review{}).Where("id = ?", reviewID).Pluck("helpful_count", &count).Error
+	return count, err
+}
+
+// GetReply gets the reviewee's response to a review
+func (r *ReviewModerationRepository) GetReply(reviewID uint) (*models.ReviewReply, error) {
+	var reply models.ReviewReply
+	if err := r.db.Where("review_id = ?", reviewID).First(&reply).Error; err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// SaveReply creates the response to a review or replaces its text
+func (r *ReviewModerationRepository) SaveReply(reply *models.ReviewReply) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "review_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"body", "updated_at"}),
+	}).Create(reply).Error
+}
+
+// DeleteReply deletes the response to a review
+func (r *ReviewModerationRepository) DeleteReply(reviewID uint) error {
+	return r.db.Where("review_id = ?", reviewID).Delete(&models.ReviewReply{}).Error
+}
+
+// SetHidden hides or restores a review and closes its pending reports
+//
+// Hiding marks the reports actioned, restoring dismisses them. Ratings fed by
+// the review are refreshed in the same transaction, so a hidden review drops
+// out of the aggregates immediately.
+func (r *ReviewModerationRepository) SetHidden(reviewID, adminID uint, hidden bool, reason string, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var review models.Review
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, reviewID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&review).Updates(map[string]interface{}{
+			"is_hidden":         hidden,
+			"is_reported":       false,
+			"report_count":      0,
+			"moderated_by":      adminID,
+			"moderated_at":      now,
+			"moderation_reason": reason,
+		}).Error; err != nil {
+			return err
+		}
+
+		status := models.ReviewReportDismissed
+		if hidden {
+			status = models.ReviewReportActioned
+		}
+		if err := resolveReviewReports(tx, reviewID, adminID, status, now); err != nil {
+			return err
+		}
+
+		return refreshReviewCounters(tx, &review)
+	})
+}
+
+// DeleteModerated records a moderator's deletion, closes the pending reports
+// as actioned and soft deletes the review
+func (r *ReviewModerationRepository) DeleteModerated(reviewID, adminID uint, reason string, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var review models.Review
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, reviewID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&review).Updates(map[string]interface{}{
+			"is_reported":       false,
+			"report_count":      0,
+			"moderated_by":      adminID,
+			"moderated_at":      now,
+			"moderation_reason": reason,
+		}).Error; err != nil {
+			return err
+		}
+		if err := resolveReviewReports(tx, reviewID, adminID, models.ReviewReportActioned, now); err != nil {
+			return err
+		}
+		if err := tx.Delete(&review).Error; err != nil {
+			return err
+		}
+
+		return refreshReviewCounters(tx, &review)
+	})
+}
+
+// resolveReviewReports closes a review's pending reports
+func resolveReviewReports(tx *gorm.DB, reviewID, adminID uint, status models.ReviewReportStatus, now time.Time) error {
+	return tx.Model(&models.ReviewReport{}).
+		Where("review_id = ? AND status = ?", reviewID, models.ReviewReportPending).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"resolved_by": adminID,
+			"resolved_at": now,
+		}).Error
+}
+
+// refreshHelpfulCount recounts a review's helpful votes
+func refreshHelpfulCount(tx *gorm.DB, reviewID uint) error {
+	return tx.Model(&models.Review{}).Where("id = ?", reviewID).
+		Update("helpful_count", gorm.Expr("(SELECT COUNT(*) FROM review_helpful_votes WHERE review_id = ?)", reviewID)).Error
+}
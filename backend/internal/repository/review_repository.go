@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"github.com/timebankingskill/backend/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ReviewRepository handles database operations for reviews
@@ -15,15 +19,77 @@ func NewReviewRepository(db *gorm.DB) *ReviewRepository {
 	return &ReviewRepository{db: db}
 }
 
-// Create creates a new review
+// Create creates a new, sealed review
+// When the other participant has already reviewed the session, both reviews are
+// published together and the ratings they feed are refreshed. The session row is
+// locked so two reviews arriving at once can't both stay sealed.
 func (r *ReviewRepository) Create(review *models.Review) error {
-	return r.db.Create(review).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var session models.Session
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&session, review.SessionID).Error; err != nil {
+			return err
+		}
+
+		review.PublishedAt = nil
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+
+		var counterpart models.Review
+		err := tx.Where("session_id = ? AND reviewer_id <> ?", review.SessionID, review.ReviewerID).First(&counterpart).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(&models.Review{}).
+			Where("session_id = ? AND published_at IS NULL", review.SessionID).
+			Update("published_at", now).Error; err != nil {
+			return err
+		}
+		review.PublishedAt = &now
+
+		if err := refreshReviewCounters(tx, review); err != nil {
+			return err
+		}
+		return refreshReviewCounters(tx, &counterpart)
+	})
+}
+
+// PublishExpired publishes sealed reviews on sessions that completed at or before cutoff
+// Returns the reviews it published; each review is published by exactly one caller
+func (r *ReviewRepository) PublishExpired(cutoff, now time.Time) ([]models.Review, error) {
+	var published []models.Review
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&published).
+			Clauses(clause.Returning{}).
+			Where("published_at IS NULL AND session_id IN (?)",
+				tx.Model(&models.Session{}).Select("id").Where("COALESCE(completed_at, updated_at) <= ?", cutoff)).
+			Update("published_at", now).Error; err != nil {
+			return err
+		}
+
+		for i := range published {
+			if err := refreshReviewCounters(tx, &published[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return published, nil
 }
 
 // GetByID gets a review by ID
 func (r *ReviewRepository) GetByID(id uint) (*models.Review, error) {
 	var review models.Review
-	err := r.db.Preload("Session").Preload("Reviewer").Preload("Reviewee").First(&review, id).Error
+	err := r.db.Preload("Session").Preload("Reviewer").Preload("Reviewee").Preload("Reply").First(&review, id).Error
 	return &review, err
 }
 
@@ -40,13 +106,14 @@ func (r *ReviewRepository) GetReviewsForUser(userID uint, limit, offset int) ([]
 	var total int64
 
 	// Count total reviews
-	if err := r.db.Model(&models.Review{}).Where("reviewee_id = ? AND is_hidden = ?", userID, false).Count(&total).Error; err != nil {
+	if err := r.db.Model(&models.Review{}).Where("reviewee_id = ? AND is_hidden = ? AND published_at IS NOT NULL", userID, false).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated reviews
-	err := r.db.Where("reviewee_id = ? AND is_hidden = ?", userID, false).
+	err := r.db.Where("reviewee_id = ? AND is_hidden = ? AND published_at IS NOT NULL", userID, false).
 		Preload("Reviewer").
+		Preload("Reply").
 		Order("created_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -68,6 +135,7 @@ func (r *ReviewRepository) GetReviewsByReviewer(reviewerID uint, limit, offset i
 	// Get paginated reviews
 	err := r.db.Where("reviewer_id = ?", reviewerID).
 		Preload("Reviewee").
+		Preload("Reply").
 		Order("created_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -82,25 +150,57 @@ func (r *ReviewRepository) GetReviewsForSession(sessionID uint) ([]models.Review
 	err := r.db.Where("session_id = ?", sessionID).
 		Preload("Reviewer").
 		Preload("Reviewee").
+		Preload("Reply").
 		Find(&reviews).Error
 	return reviews, err
 }
 
-// Update updates a review
+// Update updates a review and refreshes the ratings it feeds
 func (r *ReviewRepository) Update(review *models.Review) error {
-	return r.db.Save(review).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+		return refreshReviewCounters(tx, review)
+	})
 }
 
-// Delete deletes a review (soft delete)
+// Delete deletes a review (soft delete) and refreshes the ratings it fed
 func (r *ReviewRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Review{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var review models.Review
+		if err := tx.First(&review, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&review).Error; err != nil {
+			return err
+		}
+		return refreshReviewCounters(tx, &review)
+	})
+}
+
+// refreshReviewCounters refreshes the reviewee's role ratings and, for reviews
+// of a teacher, the rating of the teaching offer the session was booked on
+func refreshReviewCounters(tx *gorm.DB, review *models.Review) error {
+	if err := refreshUserCounters(tx, review.RevieweeID); err != nil {
+		return err
+	}
+	if review.Type != models.ReviewTypeTeacher {
+		return nil
+	}
+
+	var userSkillIDs []uint
+	if err := tx.Model(&models.Session{}).Where("id = ?", review.SessionID).Pluck("user_skill_id", &userSkillIDs).Error; err != nil {
+		return err
+	}
+	return refreshUserSkillCounters(tx, userSkillIDs...)
 }
 
 // GetAverageRatingForUser calculates average rating for a user
 func (r *ReviewRepository) GetAverageRatingForUser(userID uint) (float64, error) {
 	var avgRating float64
 	err := r.db.Model(&models.Review{}).
-		Where("reviewee_id = ? AND is_hidden = ?", userID, false).
+		Where("reviewee_id = ? AND is_hidden = ? AND published_at IS NOT NULL", userID, false).
 		Select("COALESCE(AVG(rating), 0)").
 		Scan(&avgRating).Error
 	return avgRating, err
@@ -110,11 +210,29 @@ func (r *ReviewRepository) GetAverageRatingForUser(userID uint) (float64, error)
 func (r *ReviewRepository) GetRatingCountForUser(userID uint) (int64, error) {
 	var count int64
 	err := r.db.Model(&models.Review{}).
-		Where("reviewee_id = ? AND is_hidden = ?", userID, false).
+		Where("reviewee_id = ? AND is_hidden = ? AND published_at IS NOT NULL", userID, false).
 		Count(&count).Error
 	return count, err
 }
 
+// ReviewRatingStats is the review count and average rating a user received in one role
+type ReviewRatingStats struct {
+	Type    models.ReviewType
+	Count   int64
+	Average float64
+}
+
+// GetRatingStatsForUser gets the visible review count and average rating per review type
+func (r *ReviewRepository) GetRatingStatsForUser(userID uint) ([]ReviewRatingStats, error) {
+	var stats []ReviewRatingStats
+	err := r.db.Model(&models.Review{}).
+		Select("type, COUNT(*) AS count, COALESCE(AVG(rating), 0)::float8 AS average").
+		Where("reviewee_id = ? AND is_hidden = ? AND published_at IS NOT NULL", userID, false).
+		Group("type").
+		Scan(&stats).Error
+	return stats, err
+}
+
 // GetReviewsForUserByType gets reviews for a user filtered by type (teacher/student)
 func (r *ReviewRepository) GetReviewsForUserByType(userID uint, reviewType models.ReviewType, limit, offset int) ([]models.Review, int64, error) {
 	var reviews []models.Review
@@ -122,14 +240,15 @@ func (r *ReviewRepository) GetReviewsForUserByType(userID uint, reviewType model
 
 	// Count total reviews
 	if err := r.db.Model(&models.Review{}).
-		Where("reviewee_id = ? AND type = ? AND is_hidden = ?", userID, reviewType, false).
+		Where("reviewee_id = ? AND type = ? AND is_hidden = ? AND published_at IS NOT NULL", userID, reviewType, false).
 		Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated reviews
-	err := r.db.Where("reviewee_id = ? AND type = ? AND is_hidden = ?", userID, reviewType, false).
+	err := r.db.Where("reviewee_id = ? AND type = ? AND is_hidden = ? AND published_at IS NOT NULL", userID, reviewType, false).
 		Preload("Reviewer").
+		Preload("Reply").
 		Order("created_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SavedSearchRepository handles database operations for saved marketplace searches
+type SavedSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewSavedSearchRepository creates a new saved search repository
+func NewSavedSearchRepository(db *gorm.DB) *SavedSearchRepository {
+	return &SavedSearchRepository{db: db}
+}
+
+// Create creates a saved search and records the offers it already matches as seen
+func (r *SavedSearchRepository) Create(search *models.SavedSearch, seenUserSkillIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(search).Error; err != nil {
+			return err
+		}
+		_, err := insertSavedSearchMatches(tx, search.ID, seenUserSkillIDs, false)
+		return err
+	})
+}
+
+// GetByID gets a saved search
+func (r *SavedSearchRepository) GetByID(id uint) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	if err := r.db.First(&search, id).Error; err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+// ListByUser gets a user's saved searches, newest first
+func (r *SavedSearchRepository) ListByUser(userID uint) ([]models.SavedSearch, error) {
+	var searches []models.SavedSearch
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&searches).Error
+	return searches, err
+}
+
+// CountByUser counts a user's saved searches
+func (r *SavedSearchRepository) CountByUser(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.SavedSearch{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Update saves a saved search's name, filters and alert state
+// When seenUserSkillIDs is not nil the search restarts from LastCheckedAt with
+// those offers recorded as seen, so changed filters or a resume don't replay old matches
+func (r *SavedSearchRepository) Update(search *models.SavedSearch, seenUserSkillIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(search).Select("name", "filters", "is_paused", "last_checked_at").Updates(search).Error; err != nil {
+			return err
+		}
+		_, err := insertSavedSearchMatches(tx, search.ID, seenUserSkillIDs, false)
+		return err
+	})
+}
+
+// Delete soft deletes a saved search and forgets the offers it has seen
+func (r *SavedSearchRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("saved_search_id = ?", id).Delete(&models.SavedSearchMatch{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.SavedSearch{}, id).Error
+	})
+}
+
+// ListActive gets saved searches that are not paused, for users who are still active
+func (r *SavedSearchRepository) ListActive() ([]models.SavedSearch, error) {
+	var searches []models.SavedSearch
+	err := r.db.
+		Joins("JOIN users ON users.id = saved_searches.user_id AND users.deleted_at IS NULL AND users.is_active = ?", true).
+		Where("saved_searches.is_paused = ?", false).
+		Order("saved_searches.id ASC").
+		Find(&searches).Error
+	return searches, err
+}
+
+// CompleteCheck records a check's matching offers and moves the search's watermark
+// from previous to now, in one transaction
+// Returns the offers the search had not seen before, and false (recording nothing) when
+// another run already checked the search or it was paused or changed meanwhile.
+// The new matches are added to the search's match count
+func (r *SavedSearchRepository) CompleteCheck(searchID uint, previous, now time.Time, userSkillIDs []uint) ([]uint, bool, error) {
+	var added []uint
+	claimed := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.SavedSearch{}).
+			Where("id = ? AND last_checked_at = ? AND is_paused = ?", searchID, previous, false).
+			Update("last_checked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return nil
+		}
+		claimed = true
+
+		var err error
+		if added, err = insertSavedSearchMatches(tx, searchID, userSkillIDs, true); err != nil {
+			return err
+		}
+		if len(added) == 0 {
+			return nil
+		}
+		return tx.Model(&models.SavedSearch{}).Where("id = ?", searchID).Updates(map[string]interface{}{
+			"match_count":     gorm.Expr("match_count + ?", len(added)),
+			"last_matched_at": now,
+		}).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return added, claimed, nil
+}
+
+// insertSavedSearchMatches records offers as seen by a search, skipping ones already recorded
+// Returns the IDs that were newly recorded
+func insertSavedSearchMatches(tx *gorm.DB, searchID uint, userSkillIDs []uint, notified bool) ([]uint, error) {
+	var added []uint
+	for _, userSkillID := range userSkillIDs {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.SavedSearchMatch{
+			SavedSearchID: searchID,
+			UserSkillID:   userSkillID,
+			Notified:      notified,
+		})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			added = append(added, userSkillID)
+		}
+	}
+	return added, nil
+}
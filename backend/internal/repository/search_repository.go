@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// searchHeadlineOptions controls ts_headline snippets; matches are wrapped in <mark>
+const searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=\" … \""
+
+// searchTrigramWeight scales trigram similarity relative to ts_rank_cd
+const searchTrigramWeight = 0.5
+
+// SearchHit is one document matching a search query
+type SearchHit struct {
+	Type      models.SearchResultType
+	ID        uint
+	Title     string
+	Snippet   string
+	Rank      float64
+	ParentID  *uint // Thread of a reply, skill of an offer
+	AuthorID  *uint // Author of a thread, reply or story; teacher of an offer
+	CreatedAt time.Time
+}
+
+// SearchTypeCount is the number of hits for one document type
+type SearchTypeCount struct {
+	Type  models.SearchResultType
+	Count int64
+}
+
+// searchDocument describes how one table is searched
+type searchDocument struct {
+	from      string // FROM clause (aliased d) including joins
+	where     string // Visibility conditions
+	title     string // Expression shown as the hit title
+	body      string // Text the snippet is cut from
+	trigram   string // Column with a trigram index for typo-tolerant matching
+	boost     string // Optional expression added to the rank
+	parentID  string
+	authorID  string
+	createdAt string
+}
+
+// searchDocuments maps each result type to its table definition
+var searchDocuments = map[models.SearchResultType]searchDocument{
+	models.SearchTypeSkill: {
+		from:      "skills d",
+		where:     "d.deleted_at IS NULL",
+		title:     "d.name",
+		body:      "d.description",
+		trigram:   "d.name",
+		parentID:  "NULL::bigint",
+		authorID:  "NULL::bigint",
+		createdAt: "d.created_at",
+	},
+	models.SearchTypeOffer: {
+		from:      "user_skills d JOIN skills s ON s.id = d.skill_id AND s.deleted_at IS NULL JOIN users u ON u.id = d.user_id AND u.deleted_at IS NULL AND u.is_active = true",
+		where:     "d.deleted_at IS NULL AND d.is_available = true",
+		title:     "s.name || ' — ' || u.full_name",
+		body:      "d.description",
+		trigram:   "d.description",
+		boost:     "CASE WHEN d.is_verified AND d.verification_expires_at > NOW() THEN 0.1 ELSE 0 END", // Verified teachers first among similar matches
+		parentID:  "d.skill_id",
+		authorID:  "d.user_id",
+		createdAt: "d.created_at",
+	},
+	models.SearchTypeThread: {
+		from:      "forum_threads d",
+		where:     "TRUE",
+		title:     "d.title",
+		body:      "d.content",
+		trigram:   "d.title",
+		parentID:  "NULL::bigint",
+		authorID:  "d.author_id",
+		createdAt: "d.created_at",
+	},
+	models.SearchTypeReply: {
+		from:      "forum_replies d JOIN forum_threads t ON t.id = d.thread_id",
+		where:     "TRUE",
+		title:     "t.title",
+		body:      "d.content",
+		trigram:   "d.content",
+		parentID:  "d.thread_id",
+		authorID:  "d.author_id",
+		createdAt: "d.created_at",
+	},
+	models.SearchTypeStory: {
+		from:      "success_stories d",
+		where:     "d.is_published = true",
+		title:     "d.title",
+		body:      "d.description",
+		trigram:   "d.title",
+		parentID:  "NULL::bigint",
+		authorID:  "d.user_id",
+		createdAt: "d.created_at",
+	},
+}
+
+// SearchRepository runs unified full-text search across skills, offers, forum and stories
+type SearchRepository struct {
+	db             *gorm.DB
+	trigramEnabled bool
+}
+
+// NewSearchRepository creates a new search repository
+// Typo tolerance is enabled only when the pg_trgm extension is installed
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	var enabled bool
+	if err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')").Scan(&enabled).Error; err != nil {
+		log.Printf("Failed to detect pg_trgm, typo-tolerant search disabled: %v", err)
+	}
+	return &SearchRepository{db: db, trigramEnabled: enabled}
+}
+
+// Search returns a page of hits across the given types, best match first
+func (r *SearchRepository) Search(term string, types []models.SearchResultType, limit, offset int) ([]SearchHit, error) {
+	var hits []SearchHit
+	union, args := r.searchUnion(term, types, true)
+	err := r.db.Raw(union+" ORDER BY rank DESC, created_at DESC, id DESC LIMIT ? OFFSET ?",
+		append(args, limit, offset)...).
+		Scan(&hits).Error
+	return hits, err
+}
+
+// CountByType counts hits per type for the same query
+func (r *SearchRepository) CountByType(term string, types []models.SearchResultType) ([]SearchTypeCount, error) {
+	var counts []SearchTypeCount
+	union, args := r.searchUnion(term, types, false)
+	err := r.db.Raw("SELECT type, COUNT(*) AS count FROM ("+union+") hits GROUP BY type", args...).
+		Scan(&counts).Error
+	return counts, err
+}
+
+// searchUnion builds one UNION ALL query over the requested document types
+// The query CTE parses the term once with every search language; a document
+// matches on its search_vector or, with pg_trgm, on word similarity of its
+// trigram column. Snippets are skipped when only counting.
+func (r *SearchRepository) searchUnion(term string, types []models.SearchResultType, withSnippet bool) (string, []interface{}) {
+	tsqueries := make([]string, len(models.SearchLanguages))
+	args := make([]interface{}, 0, len(models.SearchLanguages)+1)
+	for i, language := range models.SearchLanguages {
+		tsqueries[i] = fmt.Sprintf("websearch_to_tsquery('%s', ?)", language)
+		args = append(args, term)
+	}
+	args = append(args, term)
+
+	arms := make([]string, 0, len(types))
+	for _, t := range types {
+		doc, ok := searchDocuments[t]
+		if !ok {
+			continue
+		}
+		arms = append(arms, r.searchArm(t, doc, withSnippet))
+	}
+
+	return "WITH q AS (SELECT " + strings.Join(tsqueries, " || ") + " AS query, CAST(? AS text) AS term) " +
+		strings.Join(arms, " UNION ALL "), args
+}
+
+// searchArm builds the SELECT for one document type
+func (r *SearchRepository) searchArm(t models.SearchResultType, doc searchDocument, withSnippet bool) string {
+	rank := "ts_rank_cd(d.search_vector, q.query)"
+	match := "d.search_vector @@ q.query"
+	if r.trigramEnabled {
+		rank += fmt.Sprintf(" + %g * word_similarity(q.term, COALESCE(%s, ''))", searchTrigramWeight, doc.trigram)
+		match = fmt.Sprintf("(%s OR q.term <%% %s)", match, doc.trigram)
+	}
+	if doc.boost != "" {
+		rank += " + " + doc.boost
+	}
+
+	snippet := "''"
+	if withSnippet {
+		// Escape markup before highlighting so only <mark> tags reach clients
+		escaped := fmt.Sprintf("replace(replace(replace(COALESCE(%s, ''), '&', '&amp;'), '<', '&lt;'), '>', '&gt;')", doc.body)
+		snippet = fmt.Sprintf("ts_headline('%s', %s, q.query, '%s')",
+			models.SearchLanguages[0], escaped, strings.ReplaceAll(searchHeadlineOptions, "'", "''"))
+	}
+
+	return fmt.Sprintf(
+		"(SELECT '%s' AS type, d.id, %s AS title, %s AS snippet, %s AS rank, %s AS parent_id, %s AS author_id, %s AS created_at "+
+			"FROM %s CROSS JOIN q WHERE %s AND %s)",
+		t, doc.title, snippet, rank, doc.parentID, doc.authorID, doc.createdAt,
+		doc.from, doc.where, match)
+}
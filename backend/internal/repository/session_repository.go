@@ -35,6 +35,37 @@ func (r *SessionRepository) Update(session *models.Session) error {
 	return r.db.Save(session).Error
 }
 
+// UpdateCompletion updates a session entering or leaving the completed status
+// The teaching offer's and both parties' session counters are refreshed in the same transaction
+func (r *SessionRepository) UpdateCompletion(session *models.Session) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return saveCompletion(tx, session)
+	})
+}
+
+// HoldForReview completes a session whose credits are held for fraud review
+// The review queue flag is created in the same transaction, so a held session always has one
+func (r *SessionRepository) HoldForReview(session *models.Session, flag *models.FraudFlag) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveCompletion(tx, session); err != nil {
+			return err
+		}
+		flag.SessionID = session.ID
+		return tx.Create(flag).Error
+	})
+}
+
+// saveCompletion saves a session and refreshes the counters its completion status feeds
+func saveCompletion(tx *gorm.DB, session *models.Session) error {
+	if err := tx.Save(session).Error; err != nil {
+		return err
+	}
+	if err := refreshUserSkillCounters(tx, session.UserSkillID); err != nil {
+		return err
+	}
+	return refreshUserCounters(tx, session.TeacherID, session.StudentID)
+}
+
 // Delete soft deletes a session
 func (r *SessionRepository) Delete(id uint) error {
 	return r.db.Delete(&models.Session{}, id).Error
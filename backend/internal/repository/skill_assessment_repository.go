@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SkillAssessmentRepository handles database operations for placement assessments and attempts
+type SkillAssessmentRepository struct {
+	db *gorm.DB
+}
+
+// NewSkillAssessmentRepository creates a new skill assessment repository
+func NewSkillAssessmentRepository(db *gorm.DB) *SkillAssessmentRepository {
+	return &SkillAssessmentRepository{db: db}
+}
+
+// Create creates an assessment together with its questions
+func (r *SkillAssessmentRepository) Create(assessment *models.SkillAssessment) error {
+	return r.db.Create(assessment).Error
+}
+
+// GetByID gets an assessment with its skill and questions in order
+func (r *SkillAssessmentRepository) GetByID(id uint) (*models.SkillAssessment, error) {
+	var assessment models.SkillAssessment
+	err := r.db.Preload("Skill").
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		First(&assessment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &assessment, nil
+}
+
+// List gets assessments filtered by skill, author and publication, newest first
+func (r *SkillAssessmentRepository) List(skillID, authorID *uint, publishedOnly bool, limit, offset int) ([]models.SkillAssessment, int64, error) {
+	var assessments []models.SkillAssessment
+	var total int64
+
+	query := r.db.Model(&models.SkillAssessment{})
+	if skillID != nil {
+		query = query.Where("skill_id = ?", *skillID)
+	}
+	if authorID != nil {
+		query = query.Where("author_id = ?", *authorID)
+	}
+	if publishedOnly {
+		query = query.Where("is_published = ?", true)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Skill").
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&assessments).Error
+
+	return assessments, total, err
+}
+
+// Update saves an assessment's settings and, when questions is not nil, replaces its questions
+func (r *SkillAssessmentRepository) Update(assessment *models.SkillAssessment, questions []models.AssessmentQuestion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(assessment).Select(
+			"title", "description", "level", "time_limit_minutes", "pass_score", "retake_cooldown_hours",
+		).Updates(assessment).Error; err != nil {
+			return err
+		}
+		if questions == nil {
+			return nil
+		}
+
+		if err := tx.Where("assessment_id = ?", assessment.ID).Delete(&models.AssessmentQuestion{}).Error; err != nil {
+			return err
+		}
+		for i := range questions {
+			questions[i].AssessmentID = assessment.ID
+		}
+		if len(questions) > 0 {
+			if err := tx.Create(&questions).Error; err != nil {
+				return err
+			}
+		}
+		assessment.Questions = questions
+		return nil
+	})
+}
+
+// SetPublished publishes or unpublishes an assessment
+func (r *SkillAssessmentRepository) SetPublished(id uint, published bool) error {
+	return r.db.Model(&models.SkillAssessment{}).Where("id = ?", id).Update("is_published", published).Error
+}
+
+// Delete soft deletes an assessment
+func (r *SkillAssessmentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.SkillAssessment{}, id).Error
+}
+
+// CountOpenAttempts counts attempts of an assessment that can still be submitted
+func (r *SkillAssessmentRepository) CountOpenAttempts(assessmentID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.AssessmentAttempt{}).
+		Where("assessment_id = ? AND status = ? AND deadline > ?", assessmentID, models.AttemptInProgress, since).
+		Count(&count).Error
+	return count, err
+}
+
+// CreateAttempt creates a new attempt
+func (r *SkillAssessmentRepository) CreateAttempt(attempt *models.AssessmentAttempt) error {
+	return r.db.Create(attempt).Error
+}
+
+// GetAttempt gets an attempt with its assessment, skill and questions
+func (r *SkillAssessmentRepository) GetAttempt(id uint) (*models.AssessmentAttempt, error) {
+	var attempt models.AssessmentAttempt
+	err := r.db.Preload("Assessment.Skill").
+		Preload("Assessment.Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		First(&attempt, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// ListAttemptsByUser gets a user's attempts, newest first
+func (r *SkillAssessmentRepository) ListAttemptsByUser(userID uint, limit, offset int) ([]models.AssessmentAttempt, int64, error) {
+	var attempts []models.AssessmentAttempt
+	var total int64
+
+	query := r.db.Model(&models.AssessmentAttempt{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Assessment.Skill").
+		Order("started_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&attempts).Error
+
+	return attempts, total, err
+}
+
+// GetOpenAttempt gets the user's attempt of an assessment that is still running, if any
+func (r *SkillAssessmentRepository) GetOpenAttempt(assessmentID, userID uint, now time.Time) (*models.AssessmentAttempt, error) {
+	var attempt models.AssessmentAttempt
+	err := r.db.Where("assessment_id = ? AND user_id = ? AND status = ? AND deadline > ?",
+		assessmentID, userID, models.AttemptInProgress, now).
+		Order("started_at DESC").
+		First(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// GetLastFinishedAttempt gets the user's most recent submitted or expired attempt of an assessment
+func (r *SkillAssessmentRepository) GetLastFinishedAttempt(assessmentID, userID uint) (*models.AssessmentAttempt, error) {
+	var attempt models.AssessmentAttempt
+	err := r.db.Where("assessment_id = ? AND user_id = ? AND status IN ?",
+		assessmentID, userID, []models.AttemptStatus{models.AttemptSubmitted, models.AttemptExpired}).
+		Order("started_at DESC").
+		First(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// HasPassed checks if the user has already passed an assessment
+func (r *SkillAssessmentRepository) HasPassed(assessmentID, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.AssessmentAttempt{}).
+		Where("assessment_id = ? AND user_id = ? AND passed = ?", assessmentID, userID, true).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ExpireAttempts marks a user's in-progress attempts whose deadline passed before cutoff as expired
+func (r *SkillAssessmentRepository) ExpireAttempts(userID uint, cutoff time.Time) error {
+	return r.db.Model(&models.AssessmentAttempt{}).
+		Where("user_id = ? AND status = ? AND deadline <= ?", userID, models.AttemptInProgress, cutoff).
+		Update("status", models.AttemptExpired).Error
+}
+
+// FinishAttempt records an attempt's result and, when it passed, applies the placement level
+//
+// In one transaction the attempt is closed (only if still in progress), then a passed
+// level is recorded as:
+//   1. The assessed level of the user's teaching skill, if higher than before
+//   2. The placement level of the user's progress for a skill they learn, raising
+//      CurrentLevel with it
+//
+// Returns:
+//   - error: If the attempt was already finished or database error
+func (r *SkillAssessmentRepository) FinishAttempt(attempt *models.AssessmentAttempt, skillID uint, level models.SkillLevel) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current models.AssessmentAttempt
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, attempt.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != models.AttemptInProgress {
+			return errors.New("attempt is already finished")
+		}
+
+		if attempt.Passed {
+			applied, err := applyPlacementLevel(tx, attempt.UserID, skillID, level, *attempt.SubmittedAt)
+			if err != nil {
+				return err
+			}
+			attempt.LevelApplied = applied
+		}
+
+		return tx.Model(&current).Updates(map[string]interface{}{
+			"status":          attempt.Status,
+			"submitted_at":    attempt.SubmittedAt,
+			"answers":         attempt.Answers,
+			"correct_count":   attempt.CorrectCount,
+			"total_questions": attempt.TotalQuestions,
+			"score":           attempt.Score,
+			"passed":          attempt.Passed,
+			"level_applied":   attempt.LevelApplied,
+		}).Error
+	})
+}
+
+// applyPlacementLevel records a passed level on the user's teaching skill and learning progress
+// Returns whether anything was recorded
+func applyPlacementLevel(tx *gorm.DB, userID, skillID uint, level models.SkillLevel, at time.Time) (bool, error) {
+	applied := false
+
+	var userSkill models.UserSkill
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND skill_id = ?", userID, skillID).
+		First(&userSkill).Error
+	if err == nil {
+		if level.Rank() > userSkill.AssessedLevel.Rank() {
+			if err := tx.Model(&userSkill).Updates(map[string]interface{}{
+				"assessed_level": level,
+				"assessed_at":    at,
+			}).Error; err != nil {
+				return false, err
+			}
+		}
+		applied = true
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	var progress models.SkillProgress
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND skill_id = ?", userID, skillID).
+		First(&progress).Error
+	switch {
+	case err == nil:
+		updates := map[string]interface{}{"last_activity_at": at.UnixMilli()}
+		if level.Rank() > models.SkillLevel(progress.PlacementLevel).Rank() {
+			updates["placement_level"] = string(level)
+		}
+		if level.Rank() > models.SkillLevel(progress.CurrentLevel).Rank() {
+			updates["current_level"] = string(level)
+		}
+		if err := tx.Model(&progress).Updates(updates).Error; err != nil {
+			return false, err
+		}
+		applied = true
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// Learners without progress yet start at the placement level
+		var learning int64
+		if err := tx.Model(&models.LearningSkill{}).
+			Where("user_id = ? AND skill_id = ?", userID, skillID).
+			Count(&learning).Error; err != nil {
+			return false, err
+		}
+		if learning > 0 {
+			if err := tx.Create(&models.SkillProgress{
+				UserID:         userID,
+				SkillID:        skillID,
+				CurrentLevel:   string(level),
+				PlacementLevel: string(level),
+				LastActivityAt: at.UnixMilli(),
+			}).Error; err != nil {
+				return false, err
+			}
+			applied = true
+		}
+	default:
+		return false, err
+	}
+
+	return applied, nil
+}
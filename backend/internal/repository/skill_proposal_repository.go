@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// skillSimilarityThreshold is the pg_trgm similarity above which a skill is reported as a possible duplicate
+const skillSimilarityThreshold = 0.3
+
+// SkillProposalRepository handles database operations for user-proposed skills
+type SkillProposalRepository struct {
+	db             *gorm.DB
+	trigramEnabled bool
+}
+
+// NewSkillProposalRepository creates a new skill proposal repository
+// Fuzzy duplicate detection is enabled only when the pg_trgm extension is installed
+func NewSkillProposalRepository(db *gorm.DB) *SkillProposalRepository {
+	var enabled bool
+	if err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')").Scan(&enabled).Error; err != nil {
+		log.Printf("Failed to detect pg_trgm, fuzzy skill duplicate detection disabled: %v", err)
+	}
+	return &SkillProposalRepository{db: db, trigramEnabled: enabled}
+}
+
+// Create creates a new skill proposal
+func (r *SkillProposalRepository) Create(proposal *models.SkillProposal) error {
+	return r.db.Create(proposal).Error
+}
+
+// GetByID gets a proposal with its proposer and resulting skill
+func (r *SkillProposalRepository) GetByID(id uint) (*models.SkillProposal, error) {
+	var proposal models.SkillProposal
+	err := r.db.Preload("Proposer").Preload("Skill").First(&proposal, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+// List gets proposals filtered by status, oldest first so the queue is worked in order
+func (r *SkillProposalRepository) List(status models.SkillProposalStatus, limit, offset int) ([]models.SkillProposal, int64, error) {
+	var proposals []models.SkillProposal
+	var total int64
+
+	query := r.db.Model(&models.SkillProposal{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Proposer").Preload("Skill").
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&proposals).Error
+
+	return proposals, total, err
+}
+
+// ListByProposer gets a user's proposals, newest first
+func (r *SkillProposalRepository) ListByProposer(userID uint, limit, offset int) ([]models.SkillProposal, int64, error) {
+	var proposals []models.SkillProposal
+	var total int64
+
+	query := r.db.Model(&models.SkillProposal{}).Where("proposer_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Skill").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&proposals).Error
+
+	return proposals, total, err
+}
+
+// CountPendingByProposer counts a user's proposals still waiting for review
+func (r *SkillProposalRepository) CountPendingByProposer(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.SkillProposal{}).
+		Where("proposer_id = ? AND status = ?", userID, models.SkillProposalPending).
+		Count(&count).Error
+	return count, err
+}
+
+// FindPendingByName gets a pending proposal with the same normalized name
+func (r *SkillProposalRepository) FindPendingByName(normalized string) (*models.SkillProposal, error) {
+	var proposal models.SkillProposal
+	err := r.db.Where("normalized = ? AND status = ?", normalized, models.SkillProposalPending).
+		Order("created_at ASC").
+		First(&proposal).Error
+	if err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+// FindSimilarSkills gets existing skills whose name or an alias resembles the given name
+// With pg_trgm this catches typos ("Pyhton"); without it, only containment
+// in either direction ("Python" vs "Python for Data Science") is detected.
+// Containment is literal: LIKE wildcards in the name are escaped, and stored
+// names are matched with strpos so theirs aren't treated as patterns either.
+func (r *SkillProposalRepository) FindSimilarSkills(name string, limit int) ([]models.Skill, error) {
+	normalized := models.NormalizeSkillName(name)
+	pattern := containsPattern(normalized)
+
+	var skills []models.Skill
+	query := r.db.Model(&models.Skill{})
+	if r.trigramEnabled {
+		query = query.
+			Where(`similarity(lower(name), ?) > ? OR lower(name) LIKE ? ESCAPE '\' OR strpos(?, lower(name)) > 0
+				OR id IN (SELECT skill_id FROM skill_aliases WHERE similarity(normalized, ?) > ? OR strpos(?, normalized) > 0)`,
+				normalized, skillSimilarityThreshold, pattern, normalized,
+				normalized, skillSimilarityThreshold, normalized).
+			Order(clause.OrderBy{Expression: clause.Expr{SQL: "similarity(lower(name), ?) DESC", Vars: []interface{}{normalized}}})
+	} else {
+		query = query.
+			Where(`lower(name) LIKE ? ESCAPE '\' OR strpos(?, lower(name)) > 0
+				OR id IN (SELECT skill_id FROM skill_aliases WHERE normalized LIKE ? ESCAPE '\' OR strpos(?, normalized) > 0)`,
+				pattern, normalized, pattern, normalized).
+			Order("name ASC")
+	}
+
+	err := query.Limit(limit).Find(&skills).Error
+	return skills, err
+}
+
+// Approve resolves a pending proposal to a skill in a single transaction
+//
+// Creates the skill when it has no ID yet (otherwise the proposal is linked to
+// the existing skill), adds the optional alias, creates the proposer's teaching
+// offer or wishlist entry unless they already have one, and marks the proposal approved.
+func (r *SkillProposalRepository) Approve(proposalID, reviewerID uint, note string, skill *models.Skill, alias *models.SkillAlias) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var proposal models.SkillProposal
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&proposal, proposalID).Error; err != nil {
+			return err
+		}
+		if !proposal.IsPending() {
+			return errors.New("proposal is no longer pending")
+		}
+
+		if skill.ID == 0 {
+			if err := tx.Create(skill).Error; err != nil {
+				return err
+			}
+		}
+
+		if alias != nil {
+			alias.SkillID = skill.ID
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(alias).Error; err != nil {
+				return err
+			}
+		}
+
+		switch proposal.AttachAs {
+		case models.AttachTeach:
+			var existing int64
+			if err := tx.Model(&models.UserSkill{}).
+				Where("user_id = ? AND skill_id = ?", proposal.ProposerID, skill.ID).
+				Count(&existing).Error; err != nil {
+				return err
+			}
+			if existing == 0 {
+				if err := tx.Create(&models.UserSkill{
+					UserID:      proposal.ProposerID,
+					SkillID:     skill.ID,
+					Level:       proposal.Level,
+					Description: proposal.Description,
+					HourlyRate:  proposal.HourlyRate,
+					IsAvailable: true,
+				}).Error; err != nil {
+					return err
+				}
+			}
+		case models.AttachLearn:
+			var existing int64
+			if err := tx.Model(&models.LearningSkill{}).
+				Where("user_id = ? AND skill_id = ?", proposal.ProposerID, skill.ID).
+				Count(&existing).Error; err != nil {
+				return err
+			}
+			if existing == 0 {
+				if err := tx.Create(&models.LearningSkill{
+					UserID:       proposal.ProposerID,
+					SkillID:      skill.ID,
+					DesiredLevel: proposal.Level,
+					Priority:     proposal.Priority,
+				}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := refreshSkillCounters(tx, skill.ID); err != nil {
+			return err
+		}
+
+		return tx.Model(&proposal).Updates(map[string]interface{}{
+			"status":      models.SkillProposalApproved,
+			"skill_id":    skill.ID,
+			"reviewed_by": reviewerID,
+			"reviewed_at": time.Now(),
+			"review_note": note,
+		}).Error
+	})
+}
+
+// Reject marks a pending proposal as rejected
+func (r *SkillProposalRepository) Reject(proposalID, reviewerID uint, note string) error {
+	result := r.db.Model(&models.SkillProposal{}).
+		Where("id = ? AND status = ?", proposalID, models.SkillProposalPending).
+		Updates(map[string]interface{}{
+			"status":      models.SkillProposalRejected,
+			"reviewed_by": reviewerID,
+			"reviewed_at": time.Now(),
+			"review_note": note,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("proposal is no longer pending")
+	}
+	return nil
+}
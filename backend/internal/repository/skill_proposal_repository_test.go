@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindSimilarSkillsMatchesLiterally(t *testing.T) {
+	for _, trigram := range []bool{false, true} {
+		name := "plain"
+		if trigram {
+			name = "trigram"
+		}
+		t.Run(name, func(t *testing.T) {
+			db, recorder := newDryRunDB(t)
+			repo := &SkillProposalRepository{db: db, trigramEnabled: trigram}
+
+			_, err := repo.FindSimilarSkills(`C_Sharp 100%`, 5)
+			checkDryRun(t, err)
+
+			sql := recorder.last(t)
+			if !strings.Contains(sql, `LIKE '%c\_sharp 100\%%' ESCAPE '\'`) {
+				t.Errorf("name pattern should escape LIKE wildcards, got %s", sql)
+			}
+			if strings.Contains(sql, `LIKE '%' ||`) {
+				t.Errorf("stored names should not be used as LIKE patterns, got %s", sql)
+			}
+		})
+	}
+}
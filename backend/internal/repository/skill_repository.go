@@ -9,7 +9,7 @@ import (
 
 // SkillRepositoryInterface defines the contract for skill repository
 type SkillRepositoryInterface interface {
-	GetAllWithFilters(limit, offset int, category, search string, dayOfWeek *int, minRating *float64, location, sortBy string) ([]models.Skill, int64, error)
+	GetAllWithFilters(limit, offset int, category, search, tag string, dayOfWeek *int, minRating *float64, location, sortBy string) ([]models.Skill, int64, error)
 	GetByID(id uint) (*models.Skill, error)
 	Create(skill *models.Skill) error
 	Update(skill *models.Skill) error
@@ -79,7 +79,8 @@ func (r *SkillRepository) Create(skill *models.Skill) error {
 }
 
 // GetAllWithFilters returns skills with pagination and filters
-func (r *SkillRepository) GetAllWithFilters(limit, offset int, category, search string, dayOfWeek *int, minRating *float64, location, sortBy string) ([]models.Skill, int64, error) {
+// search also matches aliases and tags; tag keeps only skills carrying that tag
+func (r *SkillRepository) GetAllWithFilters(limit, offset int, category, search, tag string, dayOfWeek *int, minRating *float64, location, sortBy string) ([]models.Skill, int64, error) {
 	var skills []models.Skill
 	var total int64
 
@@ -97,7 +98,12 @@ func (r *SkillRepository) GetAllWithFilters(limit, offset int, category, search
 		query = query.Where("skills.category = ?", category)
 	}
 	if search != "" {
-		query = query.Where("skills.name ILIKE ? OR skills.description ILIKE ?", "%"+search+"%", "%"+search+"%")
+		query = query.Where("skills.name ILIKE ? OR skills.description ILIKE ? OR skills.tags @> jsonb_build_array(CAST(? AS text)) OR "+
+			"EXISTS (SELECT 1 FROM skill_aliases WHERE skill_aliases.skill_id = skills.id AND skill_aliases.alias ILIKE ?)",
+			"%"+search+"%", "%"+search+"%", models.NormalizeSkillName(search), "%"+search+"%")
+	}
+	if tag != "" {
+		query = query.Where("skills.tags @> jsonb_build_array(CAST(? AS text))", models.NormalizeSkillName(tag))
 	}
 	if dayOfWeek != nil {
 		query = query.Joins("JOIN availabilities ON availabilities.user_id = user_skills.user_id").
@@ -145,6 +151,33 @@ func (r *SkillRepository) GetByID(id uint) (*models.Skill, error) {
 	return &skill, nil
 }
 
+// GetByIDWithAliases finds a skill by ID with its aliases
+func (r *SkillRepository) GetByIDWithAliases(id uint) (*models.Skill, error) {
+	var skill models.Skill
+	err := r.db.Preload("Aliases", func(db *gorm.DB) *gorm.DB { return db.Order("alias ASC") }).
+		First(&skill, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &skill, nil
+}
+
+// FindByNameOrAlias finds the canonical skill whose name or alias matches name
+// Matching ignores case and repeated whitespace (see models.NormalizeSkillName)
+func (r *SkillRepository) FindByNameOrAlias(name string) (*models.Skill, error) {
+	normalized := models.NormalizeSkillName(name)
+
+	var skill models.Skill
+	err := r.db.
+		Where("lower(regexp_replace(btrim(name), '\\s+', ' ', 'g')) = ? OR id IN (SELECT skill_id FROM skill_aliases WHERE normalized = ?)",
+			normalized, normalized).
+		First(&skill).Error
+	if err != nil {
+		return nil, err
+	}
+	return &skill, nil
+}
+
 // Update updates an existing skill
 func (r *SkillRepository) Update(skill *models.Skill) error {
 	return r.db.Save(skill).Error
@@ -157,9 +190,14 @@ func (r *SkillRepository) Delete(id uint) error {
 
 // User Skills Methods
 
-// CreateUserSkill creates a new user skill
+// CreateUserSkill creates a new user skill and refreshes the skill's teacher count
 func (r *SkillRepository) CreateUserSkill(userSkill *models.UserSkill) error {
-	return r.db.Create(userSkill).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(userSkill).Error; err != nil {
+			return err
+		}
+		return refreshSkillCounters(tx, userSkill.SkillID)
+	})
 }
 
 // GetUserSkills returns all skills that user can teach
@@ -204,16 +242,26 @@ func (r *SkillRepository) GetTeachersBySkillID(skillID uint) ([]models.UserSkill
 	return userSkills, err
 }
 
-// DeleteUserSkill deletes user skill
+// DeleteUserSkill deletes user skill and refreshes the skill's teacher count
 func (r *SkillRepository) DeleteUserSkill(userID, skillID uint) error {
-	return r.db.Where("user_id = ? AND skill_id = ?", userID, skillID).Delete(&models.UserSkill{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND skill_id = ?", userID, skillID).Delete(&models.UserSkill{}).Error; err != nil {
+			return err
+		}
+		return refreshSkillCounters(tx, skillID)
+	})
 }
 
 // Learning Skills Methods
 
-// CreateLearningSkill adds skill to learning wishlist
+// CreateLearningSkill adds skill to learning wishlist and refreshes the skill's learner count
 func (r *SkillRepository) CreateLearningSkill(learningSkill *models.LearningSkill) error {
-	return r.db.Create(learningSkill).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(learningSkill).Error; err != nil {
+			return err
+		}
+		return refreshSkillCounters(tx, learningSkill.SkillID)
+	})
 }
 
 // GetLearningSkills returns user's learning wishlist
@@ -233,7 +281,12 @@ func (r *SkillRepository) GetLearningSkill(userID, skillID uint) (*models.Learni
 	return &learningSkill, nil
 }
 
-// DeleteLearningSkill removes skill from learning wishlist
+// DeleteLearningSkill removes skill from learning wishlist and refreshes the skill's learner count
 func (r *SkillRepository) DeleteLearningSkill(userID, skillID uint) error {
-	return r.db.Where("user_id = ? AND skill_id = ?", userID, skillID).Delete(&models.LearningSkill{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND skill_id = ?", userID, skillID).Delete(&models.LearningSkill{}).Error; err != nil {
+			return err
+		}
+		return refreshSkillCounters(tx, skillID)
+	})
 }
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SwapCandidate is a ring of offers where every learner wants the skill they receive
+// The owner of UserSkillIDs[i] teaches the owner of UserSkillIDs[i+1], and the
+// owner of the last offer teaches the owner of the first
+type SwapCandidate struct {
+	UserSkillIDs []uint
+	Priority     int // Sum of the learners' wishlist priorities
+}
+
+// swapPairRow is one scanned pair candidate
+type swapPairRow struct {
+	GiveID    uint
+	ReceiveID uint
+	Priority  int
+}
+
+// swapCycleRow is one scanned three-way candidate
+type swapCycleRow struct {
+	FirstID  uint
+	SecondID uint
+	ThirdID  uint
+	Priority int
+}
+
+// SkillSwapRepository handles database operations for skill swaps
+type SkillSwapRepository struct {
+	db *gorm.DB
+}
+
+// NewSkillSwapRepository creates a new skill swap repository
+func NewSkillSwapRepository(db *gorm.DB) *SkillSwapRepository {
+	return &SkillSwapRepository{db: db}
+}
+
+// FindPairs finds users who teach something userID wants to learn and want to
+// learn something userID teaches. Keeps the best pair of offers per partner.
+func (r *SkillSwapRepository) FindPairs(userID uint, limit int) ([]SwapCandidate, error) {
+	var rows []swapPairRow
+	err := r.db.Raw(`
+		SELECT give_id, receive_id, priority FROM (
+			SELECT DISTINCT ON (recv.user_id)
+				give.id AS give_id, recv.id AS receive_id, recv.user_id AS partner_id,
+				COALESCE(bl.priority, 0) + COALESCE(al.priority, 0) AS priority
+			FROM user_skills give
+			JOIN learning_skills bl ON bl.skill_id = give.skill_id AND bl.user_id <> give.user_id AND bl.deleted_at IS NULL
+			JOIN users ub ON ub.id = bl.user_id AND ub.deleted_at IS NULL AND ub.is_active = true
+			JOIN user_skills recv ON recv.user_id = bl.user_id AND recv.is_available = true AND recv.deleted_at IS NULL
+			JOIN learning_skills al ON al.user_id = give.user_id AND al.skill_id = recv.skill_id AND al.deleted_at IS NULL
+			WHERE give.user_id = ? AND give.is_available = true AND give.deleted_at IS NULL
+			ORDER BY recv.user_id, priority DESC, give.id, recv.id
+		) pairs
+		ORDER BY priority DESC, partner_id
+		LIMIT ?`,
+		userID, limit,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]SwapCandidate, len(rows))
+	for i, row := range rows {
+		candidates[i] = SwapCandidate{
+			UserSkillIDs: []uint{row.GiveID, row.ReceiveID},
+			Priority:     row.Priority,
+		}
+	}
+	return candidates, nil
+}
+
+// FindCycles finds three-way rings userID → B → C → userID where each user
+// teaches the next one something on their wishlist. Keeps the best set of
+// offers per (B, C) ordering.
+func (r *SkillSwapRepository) FindCycles(userID uint, limit int) ([]SwapCandidate, error) {
+	var rows []swapCycleRow
+	err := r.db.Raw(`
+		SELECT first_id, second_id, third_id, priority FROM (
+			SELECT DISTINCT ON (bl.user_id, cl.user_id)
+				ab.id AS first_id, bc.id AS second_id, ca.id AS third_id,
+				bl.user_id AS b_id, cl.user_id AS c_id,
+				COALESCE(bl.priority, 0) + COALESCE(cl.priority, 0) + COALESCE(al.priority, 0) AS priority
+			FROM user_skills ab
+			JOIN learning_skills bl ON bl.skill_id = ab.skill_id AND bl.user_id <> ab.user_id AND bl.deleted_at IS NULL
+			JOIN users ub ON ub.id = bl.user_id AND ub.deleted_at IS NULL AND ub.is_active = true
+			JOIN user_skills bc ON bc.user_id = bl.user_id AND bc.is_available = true AND bc.deleted_at IS NULL
+			JOIN learning_skills cl ON cl.skill_id = bc.skill_id AND cl.user_id NOT IN (ab.user_id, bl.user_id) AND cl.deleted_at IS NULL
+			JOIN users uc ON uc.id = cl.user_id AND uc.deleted_at IS NULL AND uc.is_active = true
+			JOIN user_skills ca ON ca.user_id = cl.user_id AND ca.is_available = true AND ca.deleted_at IS NULL
+			JOIN learning_skills al ON al.user_id = ab.user_id AND al.skill_id = ca.skill_id AND al.deleted_at IS NULL
+			WHERE ab.user_id = ? AND ab.is_available = true AND ab.deleted_at IS NULL
+			ORDER BY bl.user_id, cl.user_id, priority DESC, ab.id, bc.id, ca.id
+		) cycles
+		ORDER BY priority DESC, b_id, c_id
+		LIMIT ?`,
+		userID, limit,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]SwapCandidate, len(rows))
+	for i, row := range rows {
+		candidates[i] = SwapCandidate{
+			UserSkillIDs: []uint{row.FirstID, row.SecondID, row.ThirdID},
+			Priority:     row.Priority,
+		}
+	}
+	return candidates, nil
+}
+
+// GetUserSkillsByIDs gets offers with their skill and owner
+func (r *SkillSwapRepository) GetUserSkillsByIDs(ids []uint) ([]models.UserSkill, error) {
+	var userSkills []models.UserSkill
+	if len(ids) == 0 {
+		return userSkills, nil
+	}
+	err := r.db.Preload("Skill").Preload("User").
+		Where("id IN ?", ids).
+		Find(&userSkills).Error
+	return userSkills, err
+}
+
+// Create creates a swap with its legs and one pending session per leg
+// sessions[i] is booked for swap.Legs[i]; all rows are written in one transaction
+func (r *SkillSwapRepository) Create(swap *models.SkillSwap, sessions []*models.Session) error {
+	if len(sessions) != len(swap.Legs) {
+		return errors.New("every swap leg needs a session")
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(swap).Error; err != nil {
+			return err
+		}
+
+		for i, session := range sessions {
+			session.SkillSwapID = &swap.ID
+			if err := tx.Create(session).Error; err != nil {
+				return err
+			}
+
+			leg := &swap.Legs[i]
+			leg.SwapID = swap.ID
+			leg.SessionID = session.ID
+			if err := tx.Omit(clause.Associations).Create(leg).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetByID gets a swap with its legs, participants, offers and sessions
+func (r *SkillSwapRepository) GetByID(id uint) (*models.SkillSwap, error) {
+	var swap models.SkillSwap
+	err := r.db.
+		Preload("Legs", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("Legs.Teacher").
+		Preload("Legs.Student").
+		Preload("Legs.UserSkill.Skill").
+		Preload("Legs.Session").
+		First(&swap, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &swap, nil
+}
+
+// ListByUser gets swaps the user takes part in, newest first
+func (r *SkillSwapRepository) ListByUser(userID uint, status models.SkillSwapStatus, limit, offset int) ([]models.SkillSwap, int64, error) {
+	var swaps []models.SkillSwap
+	var total int64
+
+	query := r.db.Model(&models.SkillSwap{}).
+		Where("EXISTS (SELECT 1 FROM skill_swap_legs l WHERE l.swap_id = skill_swaps.id AND l.teacher_id = ?)", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Legs", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("Legs.Teacher").
+		Preload("Legs.Student").
+		Preload("Legs.UserSkill.Skill").
+		Preload("Legs.Session").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&swaps).Error
+
+	return swaps, total, err
+}
+
+// AcceptLeg records a participant's acceptance of a proposed swap
+// When the last participant accepts, the swap becomes accepted and its pending
+// sessions are approved in the same transaction
+//
+// Returns:
+//   - bool: Whether every participant has now accepted
+//   - error: If the swap is no longer proposed, the user already accepted, or database error
+func (r *SkillSwapRepository) AcceptLeg(swapID, teacherID uint) (bool, error) {
+	allAccepted := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var swap models.SkillSwap
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&swap, swapID).Error; err != nil {
+			return err
+		}
+		if swap.Status != models.SwapStatusProposed {
+			return errors.New("skill swap is no longer awaiting acceptance")
+		}
+
+		result := tx.Model(&models.SkillSwapLeg{}).
+			Where("swap_id = ? AND teacher_id = ? AND accepted = ?", swapID, teacherID, false).
+			Updates(map[string]interface{}{
+				"accepted":    true,
+				"accepted_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("you have already accepted this skill swap")
+		}
+
+		var pending int64
+		if err := tx.Model(&models.SkillSwapLeg{}).
+			Where("swap_id = ? AND accepted = ?", swapID, false).
+			Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending > 0 {
+			return nil
+		}
+
+		allAccepted = true
+		if err := tx.Model(&swap).Update("status", models.SwapStatusAccepted).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Session{}).
+			Where("skill_swap_id = ? AND status = ?", swapID, models.StatusPending).
+			Update("status", models.StatusApproved).Error
+	})
+
+	return allAccepted, err
+}
+
+// Close declines or cancels an open swap and moves its unstarted sessions to sessionStatus
+// Sessions already in progress or completed are left untouched
+func (r *SkillSwapRepository) Close(swapID, userID uint, status models.SkillSwapStatus, sessionStatus models.SessionStatus, reason string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.SkillSwap{}).
+			Where("id = ? AND status IN ?", swapID, []models.SkillSwapStatus{models.SwapStatusProposed, models.SwapStatusAccepted}).
+			Updates(map[string]interface{}{
+				"status":              status,
+				"cancelled_by":        userID,
+				"cancellation_reason": reason,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("skill swap is no longer open")
+		}
+
+		return tx.Model(&models.Session{}).
+			Where("skill_swap_id = ? AND status IN ?", swapID, []models.SessionStatus{models.StatusPending, models.StatusApproved}).
+			Updates(map[string]interface{}{
+				"status":              sessionStatus,
+				"cancelled_by":        userID,
+				"cancellation_reason": reason,
+			}).Error
+	})
+}
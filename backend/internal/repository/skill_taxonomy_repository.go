@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SkillMergeResult counts the rows a skill merge touched
+// Moved rows were re-pointed to the surviving skill; merged rows duplicated one
+// the user already had for the surviving skill and were folded into it
+type SkillMergeResult struct {
+	UserSkillsMoved      int64 `json:"user_skills_moved"`
+	UserSkillsMerged     int64 `json:"user_skills_merged"`
+	LearningSkillsMoved  int64 `json:"learning_skills_moved"`
+	LearningSkillsMerged int64 `json:"learning_skills_merged"`
+	EndorsementsMoved    int64 `json:"endorsements_moved"`
+	EndorsementsMerged   int64 `json:"endorsements_merged"`
+	ProgressMoved        int64 `json:"progress_moved"`
+	ProgressMerged       int64 `json:"progress_merged"`
+	ChildrenMoved        int64 `json:"children_moved"`
+	AliasesMoved         int64 `json:"aliases_moved"`
+	ProposalsMoved       int64 `json:"proposals_moved"`
+	AssessmentsMoved     int64 `json:"assessments_moved"`
+}
+
+// SkillTaxonomyRepository handles database operations for the skill hierarchy, aliases and merges
+type SkillTaxonomyRepository struct {
+	db *gorm.DB
+}
+
+// NewSkillTaxonomyRepository creates a new skill taxonomy repository
+func NewSkillTaxonomyRepository(db *gorm.DB) *SkillTaxonomyRepository {
+	return &SkillTaxonomyRepository{db: db}
+}
+
+// GetAllSkills gets every skill ordered by name, for building the tree
+func (r *SkillTaxonomyRepository) GetAllSkills() ([]models.Skill, error) {
+	var skills []models.Skill
+	err := r.db.Order("name ASC").Find(&skills).Error
+	return skills, err
+}
+
+// UpdateParent moves a skill under a new parent (nil for a root skill)
+func (r *SkillTaxonomyRepository) UpdateParent(skillID uint, parentID *uint) error {
+	return r.db.Model(&models.Skill{}).Where("id = ?", skillID).Update("parent_id", parentID).Error
+}
+
+// UpdateTags replaces a skill's tags
+func (r *SkillTaxonomyRepository) UpdateTags(skillID uint, tags models.JSONArray) error {
+	return r.db.Model(&models.Skill{}).Where("id = ?", skillID).Update("tags", tags).Error
+}
+
+// GetAliases gets a skill's aliases ordered alphabetically
+func (r *SkillTaxonomyRepository) GetAliases(skillID uint) ([]models.SkillAlias, error) {
+	var aliases []models.SkillAlias
+	err := r.db.Where("skill_id = ?", skillID).Order("alias ASC").Find(&aliases).Error
+	return aliases, err
+}
+
+// CreateAlias creates a new alias
+func (r *SkillTaxonomyRepository) CreateAlias(alias *models.SkillAlias) error {
+	return r.db.Create(alias).Error
+}
+
+// DeleteAlias deletes one of a skill's aliases
+func (r *SkillTaxonomyRepository) DeleteAlias(skillID, aliasID uint) error {
+	result := r.db.Where("id = ? AND skill_id = ?", aliasID, skillID).Delete(&models.SkillAlias{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// MergeSkills folds sourceID into targetID in a single transaction
+//
+// Re-points teaching offers, learning wishlists, endorsements, progress,
+// child skills, aliases, approved proposals and assessments to the target. Where a user already has a row for
+// the target, the duplicate is merged instead:
+//   - Teaching offers: sessions and swap legs move to the user's target offer, session counts add up
+//   - Wishlist entries: the higher priority is kept
+//   - Endorsements: the endorser's existing endorsement of the target wins
+//   - Progress: the furthest progress is kept, sessions and hours add up, milestones move
+//
+// The source name becomes an alias of the target, tags are unioned, the
+// target's teacher/learner counters are recomputed and the source is soft-deleted.
+func (r *SkillTaxonomyRepository) MergeSkills(sourceID, targetID uint) (*SkillMergeResult, error) {
+	result := &SkillMergeResult{}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var source, target models.Skill
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&source, sourceID).Error; err != nil {
+			return errors.New("source skill not found")
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&target, targetID).Error; err != nil {
+			return errors.New("target skill not found")
+		}
+
+		// Teaching offers
+		if err := tx.Exec(`
+			UPDATE sessions SET user_skill_id = t.id
+			FROM user_skills s
+			JOIN user_skills t ON t.user_id = s.user_id AND t.skill_id = ? AND t.deleted_at IS NULL
+			WHERE s.skill_id = ? AND s.deleted_at IS NULL AND sessions.user_skill_id = s.id`,
+			targetID, sourceID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`
+			UPDATE skill_swap_legs SET user_skill_id = t.id
+			FROM user_skills s
+			JOIN user_skills t ON t.user_id = s.user_id AND t.skill_id = ? AND t.deleted_at IS NULL
+			WHERE s.skill_id = ? AND s.deleted_at IS NULL AND skill_swap_legs.user_skill_id = s.id`,
+			targetID, sourceID).Error; err != nil {
+			return err
+		}
+		merged := tx.Exec(`
+			UPDATE user_skills s SET deleted_at = NOW(), skill_id = ?
+			WHERE s.skill_id = ? AND s.deleted_at IS NULL
+			  AND EXISTS (SELECT 1 FROM user_skills t WHERE t.user_id = s.user_id AND t.skill_id = ? AND t.deleted_at IS NULL)`,
+			targetID, sourceID, targetID)
+		if merged.Error != nil {
+			return merged.Error
+		}
+		result.UserSkillsMerged = merged.RowsAffected
+		moved := tx.Exec("UPDATE user_skills SET skill_id = ? WHERE skill_id = ?", targetID, sourceID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.UserSkillsMoved = moved.RowsAffected
+
+		// Merged offers now carry the sessions and reviews of both
+		var offerIDs []uint
+		if err := tx.Model(&models.UserSkill{}).Where("skill_id = ?", targetID).Pluck("id", &offerIDs).Error; err != nil {
+			return err
+		}
+		if err := refreshUserSkillCounters(tx, offerIDs...); err != nil {
+			return err
+		}
+
+		// Learning wishlists
+		if err := tx.Exec(`
+			UPDATE learning_skills t SET priority = GREATEST(t.priority, s.priority)
+			FROM learning_skills s
+			WHERE s.skill_id = ? AND s.deleted_at IS NULL
+			  AND t.skill_id = ? AND t.deleted_at IS NULL AND t.user_id = s.user_id`,
+			sourceID, targetID).Error; err != nil {
+			return err
+		}
+		merged = tx.Exec(`
+			UPDATE learning_skills s SET deleted_at = NOW(), skill_id = ?
+			WHERE s.skill_id = ? AND s.deleted_at IS NULL
+			  AND EXISTS (SELECT 1 FROM learning_skills t WHERE t.user_id = s.user_id AND t.skill_id = ? AND t.deleted_at IS NULL)`,
+			targetID, sourceID, targetID)
+		if merged.Error != nil {
+			return merged.Error
+		}
+		result.LearningSkillsMerged = merged.RowsAffected
+		moved = tx.Exec("UPDATE learning_skills SET skill_id = ? WHERE skill_id = ?", targetID, sourceID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.LearningSkillsMoved = moved.RowsAffected
+
+		// Endorsements
+		merged = tx.Exec(`
+			DELETE FROM endorsements s
+			WHERE s.skill_id = ?
+			  AND EXISTS (SELECT 1 FROM endorsements t WHERE t.skill_id = ? AND t.user_id = s.user_id AND t.endorser_id = s.endorser_id)`,
+			sourceID, targetID)
+		if merged.Error != nil {
+			return merged.Error
+		}
+		result.EndorsementsMerged = merged.RowsAffected
+		moved = tx.Exec("UPDATE endorsements SET skill_id = ? WHERE skill_id = ?", targetID, sourceID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.EndorsementsMoved = moved.RowsAffected
+
+		// Skill progress
+		if err := tx.Exec(`
+			UPDATE skill_progress t SET
+				progress_percentage = GREATEST(t.progress_percentage, s.progress_percentage),
+				sessions_completed = t.sessions_completed + s.sessions_completed,
+				total_hours_spent = t.total_hours_spent + s.total_hours_spent,
+				last_activity_at = GREATEST(t.last_activity_at, s.last_activity_at)
+			FROM skill_progress s
+			WHERE s.skill_id = ? AND t.skill_id = ? AND t.user_id = s.user_id`,
+			sourceID, targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`
+			UPDATE milestones SET skill_progress_id = t.id
+			FROM skill_progress s
+			JOIN skill_progress t ON t.user_id = s.user_id AND t.skill_id = ?
+			WHERE s.skill_id = ? AND milestones.skill_progress_id = s.id`,
+			targetID, sourceID).Error; err != nil {
+			return err
+		}
+		merged = tx.Exec(`
+			DELETE FROM skill_progress s
+			WHERE s.skill_id = ?
+			  AND EXISTS (SELECT 1 FROM skill_progress t WHERE t.skill_id = ? AND t.user_id = s.user_id)`,
+			sourceID, targetID)
+		if merged.Error != nil {
+			return merged.Error
+		}
+		result.ProgressMerged = merged.RowsAffected
+		moved = tx.Exec("UPDATE skill_progress SET skill_id = ? WHERE skill_id = ?", targetID, sourceID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.ProgressMoved = moved.RowsAffected
+
+		// Hierarchy: the target takes the source's place if it was its child
+		if target.ParentID != nil && *target.ParentID == sourceID {
+			target.ParentID = source.ParentID
+		}
+		moved = tx.Model(&models.Skill{}).
+			Where("parent_id = ? AND id <> ?", sourceID, targetID).
+			Update("parent_id", targetID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.ChildrenMoved = moved.RowsAffected
+
+		// Aliases: keep every spelling that used to reach the source
+		moved = tx.Model(&models.SkillAlias{}).Where("skill_id = ?", sourceID).Update("skill_id", targetID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.AliasesMoved = moved.RowsAffected
+		if models.NormalizeSkillName(source.Name) != models.NormalizeSkillName(target.Name) {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.SkillAlias{
+				SkillID:    targetID,
+				Alias:      source.Name,
+				Normalized: models.NormalizeSkillName(source.Name),
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		// Proposals approved as the source
+		moved = tx.Exec("UPDATE skill_proposals SET skill_id = ? WHERE skill_id = ?", targetID, sourceID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.ProposalsMoved = moved.RowsAffected
+
+		// Placement assessments
+		moved = tx.Exec("UPDATE skill_assessments SET skill_id = ? WHERE skill_id = ?", targetID, sourceID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.AssessmentsMoved = moved.RowsAffected
+
+		// Tags and counters
+		if target.Tags == nil {
+			target.Tags = models.JSONArray{}
+		}
+		seen := make(map[string]bool, len(target.Tags))
+		for _, tag := range target.Tags {
+			seen[tag] = true
+		}
+		for _, tag := range source.Tags {
+			if !seen[tag] {
+				target.Tags = append(target.Tags, tag)
+				seen[tag] = true
+			}
+		}
+
+		if err := tx.Model(&target).Updates(map[string]interface{}{
+			"parent_id": target.ParentID,
+			"tags":      target.Tags,
+		}).Error; err != nil {
+			return err
+		}
+		if err := refreshSkillCounters(tx, targetID); err != nil {
+			return err
+		}
+
+		return tx.Delete(&source).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// refreshUserVerifiedSQL sets users.is_verified from whether they hold any current skill verification
+const refreshUserVerifiedSQL = `UPDATE users SET is_verified = EXISTS (
+		SELECT 1 FROM user_skills
+		WHERE user_skills.user_id = users.id AND user_skills.deleted_at IS NULL
+		  AND user_skills.is_verified = true AND user_skills.verification_expires_at > ?
+	) WHERE users.id IN ?`
+
+// SkillVerificationRepository handles database operations for skill credential verification
+type SkillVerificationRepository struct {
+	db *gorm.DB
+}
+
+// NewSkillVerificationRepository creates a new skill verification repository
+func NewSkillVerificationRepository(db *gorm.DB) *SkillVerificationRepository {
+	return &SkillVerificationRepository{db: db}
+}
+
+// Create creates a new verification request
+func (r *SkillVerificationRepository) Create(verification *models.SkillVerification) error {
+	return r.db.Create(verification).Error
+}
+
+// GetByID gets a verification with its user skill and teacher
+func (r *SkillVerificationRepository) GetByID(id uint) (*models.SkillVerification, error) {
+	var verification models.SkillVerification
+	err := r.db.Preload("UserSkill.Skill").Preload("User").First(&verification, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// List gets verifications filtered by status and/or teacher, oldest first so the queue is worked in order
+func (r *SkillVerificationRepository) List(status models.VerificationStatus, userID *uint, limit, offset int) ([]models.SkillVerification, int64, error) {
+	var verifications []models.SkillVerification
+	var total int64
+
+	query := r.db.Model(&models.SkillVerification{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("UserSkill.Skill").Preload("User").
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&verifications).Error
+
+	return verifications, total, err
+}
+
+// ListByUser gets a teacher's verifications, newest first
+func (r *SkillVerificationRepository) ListByUser(userID uint) ([]models.SkillVerification, error) {
+	var verifications []models.SkillVerification
+	err := r.db.Preload("UserSkill.Skill").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&verifications).Error
+	return verifications, err
+}
+
+// HasPending checks if a user skill already has proof waiting for review
+func (r *SkillVerificationRepository) HasPending(userSkillID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.SkillVerification{}).
+		Where("user_skill_id = ? AND status = ?", userSkillID, models.VerificationPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Approve marks a pending verification approved and verifies its user skill until expiresAt
+// The user skill's proof fields are replaced with the reviewed proof and the
+// teacher's account-level verified flag is refreshed, all in one transaction
+func (r *SkillVerificationRepository) Approve(verificationID, reviewerID uint, note string, expiresAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var verification models.SkillVerification
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&verification, verificationID).Error; err != nil {
+			return err
+		}
+		if !verification.IsPending() {
+			return errors.New("verification is no longer pending")
+		}
+
+		now := time.Now()
+		if err := tx.Model(&verification).Updates(map[string]interface{}{
+			"status":      models.VerificationApproved,
+			"reviewed_by": reviewerID,
+			"reviewed_at": now,
+			"review_note": note,
+			"expires_at":  expiresAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		// A renewal replaces the verification it renews
+		if err := tx.Model(&models.SkillVerification{}).
+			Where("user_skill_id = ? AND status = ? AND id <> ?", verification.UserSkillID, models.VerificationApproved, verification.ID).
+			Update("status", models.VerificationExpired).Error; err != nil {
+			return err
+		}
+
+		skillUpdates := map[string]interface{}{
+			"is_verified":             true,
+			"verified_at":             now,
+			"verification_expires_at": expiresAt,
+			"proof_type":              verification.ProofType,
+		}
+		if verification.ExternalURL != "" {
+			skillUpdates["proof_url"] = verification.ExternalURL
+		}
+		result := tx.Model(&models.UserSkill{}).Where("id = ?", verification.UserSkillID).Updates(skillUpdates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("user skill no longer exists")
+		}
+
+		return tx.Exec(refreshUserVerifiedSQL, now, []uint{verification.UserID}).Error
+	})
+}
+
+// Reject marks a pending verification as rejected
+func (r *SkillVerificationRepository) Reject(verificationID, reviewerID uint, note string) error {
+	result := r.db.Model(&models.SkillVerification{}).
+		Where("id = ? AND status = ?", verificationID, models.VerificationPending).
+		Updates(map[string]interface{}{
+			"status":      models.VerificationRejected,
+			"reviewed_by": reviewerID,
+			"reviewed_at": time.Now(),
+			"review_note": note,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("verification is no longer pending")
+	}
+	return nil
+}
+
+// ExpireDue clears the verified flag of user skills whose verification has lapsed
+//
+// Approved verifications past their expiry are marked expired, the affected user
+// skills lose their flag and their teachers' account-level flag is refreshed.
+//
+// Returns:
+//   - []UserSkill: User skills that were unverified, with their skill loaded
+//   - error: If database error
+func (r *SkillVerificationRepository) ExpireDue(now time.Time) ([]models.UserSkill, error) {
+	var expired []models.UserSkill
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.SkillVerification{}).
+			Where("status = ? AND expires_at <= ?", models.VerificationApproved, now).
+			Update("status", models.VerificationExpired).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Skill").
+			Where("is_verified = ? AND (verification_expires_at IS NULL OR verification_expires_at <= ?)", true, now).
+			Find(&expired).Error; err != nil {
+			return err
+		}
+		if len(expired) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(expired))
+		userIDs := make([]uint, 0, len(expired))
+		seen := make(map[uint]bool, len(expired))
+		for i, userSkill := range expired {
+			ids[i] = userSkill.ID
+			if !seen[userSkill.UserID] {
+				seen[userSkill.UserID] = true
+				userIDs = append(userIDs, userSkill.UserID)
+			}
+		}
+
+		if err := tx.Model(&models.UserSkill{}).Where("id IN ?", ids).Update("is_verified", false).Error; err != nil {
+			return err
+		}
+		return tx.Exec(refreshUserVerifiedSQL, now, userIDs).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+// ClaimRenewalReminders claims approved verifications expiring before windowEnd that
+// have not been reminded yet and have no renewal in review
+// Each verification is claimed at most once, even with several workers running
+func (r *SkillVerificationRepository) ClaimRenewalReminders(now, windowEnd time.Time) ([]models.SkillVerification, error) {
+	var due []models.SkillVerification
+	err := r.db.Preload("UserSkill.Skill").
+		Where("status = ? AND reminder_sent_at IS NULL AND expires_at > ? AND expires_at <= ?",
+			models.VerificationApproved, now, windowEnd).
+		Where("NOT EXISTS (SELECT 1 FROM skill_verifications p WHERE p.user_skill_id = skill_verifications.user_skill_id AND p.status = ? AND p.deleted_at IS NULL)",
+			models.VerificationPending).
+		Find(&due).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := due[:0]
+	for _, verification := range due {
+		result := r.db.Model(&models.SkillVerification{}).
+			Where("id = ? AND reminder_sent_at IS NULL", verification.ID).
+			Update("reminder_sent_at", now)
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 1 {
+			claimed = append(claimed, verification)
+		}
+	}
+	return claimed, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StreakRepository handles database operations for activity streaks
+type StreakRepository struct {
+	db *gorm.DB
+}
+
+// NewStreakRepository creates a new streak repository
+func NewStreakRepository(db *gorm.DB) *StreakRepository {
+	return &StreakRepository{db: db}
+}
+
+// GetByUserID gets a user's streak
+func (r *StreakRepository) GetByUserID(userID uint) (*models.UserStreak, error) {
+	var streak models.UserStreak
+	if err := r.db.Where("user_id = ?", userID).First(&streak).Error; err != nil {
+		return nil, err
+	}
+	return &streak, nil
+}
+
+// GetSessionCompletionTimes gets when each of a user's completed sessions finished, in either role
+// Sessions held for fraud review don't count until they are cleared, and skill swap legs not at all
+func (r *StreakRepository) GetSessionCompletionTimes(userID uint) ([]time.Time, error) {
+	var times []time.Time
+	err := r.db.Model(&models.Session{}).
+		Where("(teacher_id = ? OR student_id = ?) AND status = ? AND fraud_hold = ? AND skill_swap_id IS NULL", userID, userID, models.StatusCompleted, false).
+		Pluck("COALESCE(completed_at, updated_at)", &times).Error
+	return times, err
+}
+
+// SaveStreak creates or replaces a user's streak counters, keeping the warning marker
+func (r *StreakRepository) SaveStreak(streak *models.UserStreak) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"current_weeks", "longest_weeks", "last_active_week", "total_active_weeks", "updated_at"}),
+	}).Create(streak).Error
+}
+
+// GetStreaksAtRisk gets streaks of at least minWeeks whose last active week is lastWeek,
+// i.e. that break unless the user completes a session this week, and that haven't been
+// warned about thisWeek yet
+func (r *StreakRepository) GetStreaksAtRisk(lastWeek, thisWeek time.Time, minWeeks int) ([]models.UserStreak, error) {
+	var streaks []models.UserStreak
+	err := r.db.Where("last_active_week = ? AND current_weeks >= ?", lastWeek, minWeeks).
+		Where("warned_for_week IS NULL OR warned_for_week <> ?", thisWeek).
+		Find(&streaks).Error
+	return streaks, err
+}
+
+// MarkWarned records that a streak-break warning was sent for a week
+func (r *StreakRepository) MarkWarned(id uint, week time.Time) error {
+	return r.db.Model(&models.UserStreak{}).Where("id = ?", id).Update("warned_for_week", week).Error
+}
+
+// ResetBrokenStreaks zeroes the current run of streaks last active before the cutoff week
+func (r *StreakRepository) ResetBrokenStreaks(cutoff time.Time) (int64, error) {
+	result := r.db.Model(&models.UserStreak{}).
+		Where("last_active_week < ? AND current_weeks > 0", cutoff).
+		Update("current_weeks", 0)
+	return result.RowsAffected, result.Error
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/timebankingskill/backend/internal/models"
 	"gorm.io/gorm"
 )
@@ -69,3 +71,105 @@ func (r *TransactionRepository) GetUserTransactionHistory(userID uint, limit, of
 
 	return transactions, total, err
 }
+
+// TransactionFilter holds optional filters for searching transactions
+// Zero values mean "no filter" for the corresponding field
+type TransactionFilter struct {
+	Types          []models.TransactionType
+	From           *time.Time
+	To             *time.Time
+	MinAmount      *float64 // Compared against ABS(amount)
+	MaxAmount      *float64 // Compared against ABS(amount)
+	SessionID      *uint
+	CounterpartyID *uint // Other participant of the linked session
+	Search         string
+}
+
+// TransactionCursor identifies the last row of a page for keyset pagination
+// Rows are ordered by (created_at DESC, id DESC) so the pair is unique and stable
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+// TransactionTypeTotal is the aggregated amount for one transaction type
+type TransactionTypeTotal struct {
+	Type   models.TransactionType
+	Count  int64
+	Credit float64
+	Debit  float64
+}
+
+// filteredUserTransactions builds the base query for a user's transactions with filters applied
+func (r *TransactionRepository) filteredUserTransactions(userID uint, filter TransactionFilter) *gorm.DB {
+	query := r.db.Model(&models.Transaction{}).Where("transactions.user_id = ?", userID)
+
+	if len(filter.Types) > 0 {
+		query = query.Where("transactions.type IN ?", filter.Types)
+	}
+	if filter.From != nil {
+		query = query.Where("transactions.created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("transactions.created_at <= ?", *filter.To)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("ABS(transactions.amount) >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("ABS(transactions.amount) <= ?", *filter.MaxAmount)
+	}
+	if filter.SessionID != nil {
+		query = query.Where("transactions.session_id = ?", *filter.SessionID)
+	}
+	if filter.CounterpartyID != nil {
+		// Counterparty is derived from the linked session: the participant that isn't the user
+		query = query.Where(
+			"transactions.session_id IN (SELECT id FROM sessions WHERE (teacher_id = ? AND student_id = ?) OR (student_id = ? AND teacher_id = ?))",
+			*filter.CounterpartyID, userID, *filter.CounterpartyID, userID,
+		)
+	}
+	if filter.Search != "" {
+		// Uses the GIN index idx_transactions_description_fts
+		query = query.Where(
+			"to_tsvector('simple', COALESCE(transactions.description, '')) @@ plainto_tsquery('simple', ?)",
+			filter.Search,
+		)
+	}
+
+	return query
+}
+
+// SearchUserTransactions returns a page of a user's transactions matching the filter
+// When cursor is set, keyset pagination is used and offset is ignored
+func (r *TransactionRepository) SearchUserTransactions(userID uint, filter TransactionFilter, cursor *TransactionCursor, limit, offset int) ([]models.Transaction, error) {
+	var transactions []models.Transaction
+
+	query := r.filteredUserTransactions(userID, filter)
+	if cursor != nil {
+		query = query.Where("(transactions.created_at, transactions.id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	} else if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.
+		Order("transactions.created_at DESC").
+		Order("transactions.id DESC").
+		Limit(limit).
+		Find(&transactions).Error
+
+	return transactions, err
+}
+
+// GetUserTransactionTotals aggregates the amounts of a user's transactions matching the filter
+func (r *TransactionRepository) GetUserTransactionTotals(userID uint, filter TransactionFilter) ([]TransactionTypeTotal, error) {
+	var totals []TransactionTypeTotal
+	err := r.filteredUserTransactions(userID, filter).
+		Select("transactions.type AS type, " +
+			"COUNT(*) AS count, " +
+			"COALESCE(SUM(CASE WHEN transactions.amount > 0 THEN transactions.amount ELSE 0 END), 0) AS credit, " +
+			"COALESCE(SUM(CASE WHEN transactions.amount < 0 THEN -transactions.amount ELSE 0 END), 0) AS debit").
+		Group("transactions.type").
+		Scan(&totals).Error
+	return totals, err
+}
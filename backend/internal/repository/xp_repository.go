@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// XPRepository handles database operations for experience points
+type XPRepository struct {
+	db *gorm.DB
+}
+
+// NewXPRepository creates a new XP repository
+func NewXPRepository(db *gorm.DB) *XPRepository {
+	return &XPRepository{db: db}
+}
+
+// GetRules gets every XP rule
+func (r *XPRepository) GetRules() ([]models.XPRule, error) {
+	var rules []models.XPRule
+	err := r.db.Order("id ASC").Find(&rules).Error
+	return rules, err
+}
+
+// GetRule gets the rule of an action
+func (r *XPRepository) GetRule(action models.XPAction) (*models.XPRule, error) {
+	var rule models.XPRule
+	if err := r.db.Where("action = ?", action).First(&rule).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// SaveRule creates or updates an XP rule
+func (r *XPRepository) SaveRule(rule *models.XPRule) error {
+	return r.db.Save(rule).Error
+}
+
+// AwardXP records an XP award and adds it to the user's total
+// The award is skipped (nil event) when the source already awarded this action to the
+// user. With a positive dailyCap, XP the action earned the user since dayStart counts
+// against the cap and the award is reduced to what is left of it; a fully capped
+// award is still recorded, with 0 points, so the history shows it.
+// Returns the event and the user's total before it.
+func (r *XPRepository) AwardXP(userID uint, action models.XPAction, sourceType string, sourceID uint, points, dailyCap int, dayStart time.Time) (*models.XPEvent, int, error) {
+	var event *models.XPEvent
+	var totalBefore int
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the user's total so concurrent awards see each other's daily XP
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.UserXP{UserID: userID}).Error; err != nil {
+			return err
+		}
+		var total models.UserXP
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", userID).First(&total).Error; err != nil {
+			return err
+		}
+		totalBefore = total.TotalXP
+
+		var existing int64
+		if err := tx.Model(&models.XPEvent{}).
+			Where("user_id = ? AND action = ? AND source_type = ? AND source_id = ?", userID, action, sourceType, sourceID).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return nil
+		}
+
+		var earnedToday int
+		if dailyCap > 0 {
+			if err := tx.Model(&models.XPEvent{}).
+				Select("COALESCE(SUM(points), 0)").
+				Where("user_id = ? AND action = ? AND created_at >= ?", userID, action, dayStart).
+				Scan(&earnedToday).Error; err != nil {
+				return err
+			}
+		}
+		awarded := cappedXP(points, dailyCap, earnedToday)
+
+		event = &models.XPEvent{
+			UserID:     userID,
+			Action:     action,
+			SourceType: sourceType,
+			SourceID:   sourceID,
+			Points:     awarded,
+			BasePoints: points,
+			Capped:     awarded < points,
+			TotalAfter: total.TotalXP + awarded,
+		}
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		if awarded == 0 {
+			return nil
+		}
+		return tx.Model(&total).Update("total_xp", gorm.Expr("total_xp + ?", awarded)).Error
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return event, totalBefore, nil
+}
+
+// cappedXP is how much of points can be awarded once earnedToday XP of the action's
+// dailyCap is used; a cap of 0 means unlimited
+func cappedXP(points, dailyCap, earnedToday int) int {
+	if dailyCap <= 0 {
+		return points
+	}
+	return min(points, max(dailyCap-earnedToday, 0))
+}
+
+// GetUserXP gets a user's XP total
+func (r *XPRepository) GetUserXP(userID uint) (*models.UserXP, error) {
+	var total models.UserXP
+	if err := r.db.Where("user_id = ?", userID).First(&total).Error; err != nil {
+		return nil, err
+	}
+	return &total, nil
+}
+
+// GetEarnedSince sums the XP a user earned per action since a time
+func (r *XPRepository) GetEarnedSince(userID uint, since time.Time) (map[models.XPAction]int, error) {
+	var rows []struct {
+		Action models.XPAction
+		Points int
+	}
+	err := r.db.Model(&models.XPEvent{}).
+		Select("action, COALESCE(SUM(points), 0) AS points").
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Group("action").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	earned := make(map[models.XPAction]int, len(rows))
+	for _, row := range rows {
+		earned[row.Action] = row.Points
+	}
+	return earned, nil
+}
+
+// GetEvents gets a page of a user's XP history, newest first
+// An empty action matches every action; nil bounds leave that side of [since, until) open
+func (r *XPRepository) GetEvents(userID uint, action models.XPAction, since, until *time.Time, limit, offset int) ([]models.XPEvent, int64, error) {
+	var events []models.XPEvent
+	var total int64
+
+	query := r.db.Model(&models.XPEvent{}).Where("user_id = ?", userID)
+	if action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if since != nil {
+		query = query.Where("created_at >= ?", *since)
+	}
+	if until != nil {
+		query = query.Where("created_at < ?", *until)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error
+	return events, total, err
+}
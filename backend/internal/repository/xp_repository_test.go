@@ -0,0 +1,26 @@
+package repository
+
+import "testing"
+
+func TestCappedXP(t *testing.T) {
+	tests := []struct {
+		name                          string
+		points, dailyCap, earnedToday int
+		want                          int
+	}{
+		{"uncapped", 50, 0, 1000, 50},
+		{"under the cap", 10, 50, 20, 10},
+		{"reaching the cap exactly", 10, 50, 40, 10},
+		{"partly over the cap", 10, 50, 45, 5},
+		{"cap already used", 10, 50, 50, 0},
+		{"cap lowered below today's XP", 10, 50, 80, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cappedXP(tt.points, tt.dailyCap, tt.earnedToday); got != tt.want {
+				t.Errorf("cappedXP(%d, %d, %d) = %d, want %d", tt.points, tt.dailyCap, tt.earnedToday, got, tt.want)
+			}
+		})
+	}
+}
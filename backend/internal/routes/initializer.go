@@ -1,8 +1,10 @@
 package routes
 
 import (
+	"github.com/gin-gonic/gin"
 	"github.com/timebankingskill/backend/internal/config"
 	"github.com/timebankingskill/backend/internal/handler"
+	"github.com/timebankingskill/backend/internal/middleware"
 	"github.com/timebankingskill/backend/internal/repository"
 	"github.com/timebankingskill/backend/internal/service"
 	"github.com/timebankingskill/backend/internal/websocket"
@@ -24,6 +26,28 @@ func InitializeAdminHandler(db *gorm.DB) *handler.AdminHandler {
 	return handler.NewAdminHandler(adminService)
 }
 
+// InitializeAdminAuth initializes the admin account check used by admin-only routes
+func InitializeAdminAuth(db *gorm.DB) gin.HandlerFunc {
+	adminRepo := repository.NewAdminRepository(db)
+	return middleware.AdminMiddleware(adminRepo)
+}
+
+// InitializeIdempotency initializes the Idempotency-Key middleware for credit-moving endpoints
+func InitializeIdempotency(db *gorm.DB, cfg *config.Config) gin.HandlerFunc {
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
+	return middleware.NewIdempotency(idempotencyRepo, cfg.Server.IdempotencyTTL).Middleware()
+}
+
+// InitializeCreditAdjustmentHandler initializes credit adjustment handler with dependencies
+func InitializeCreditAdjustmentHandler(db *gorm.DB, cfg *config.Config) *handler.CreditAdjustmentHandler {
+	adjustmentRepo := repository.NewCreditAdjustmentRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	notificationService := service.NewNotificationService(notificationRepo, userRepo)
+	adjustmentService := service.NewCreditAdjustmentService(adjustmentRepo, userRepo, notificationService, cfg.Finance.AdjustmentApprovalThreshold)
+	return handler.NewCreditAdjustmentHandler(adjustmentService)
+}
+
 // InitializeSkillHandler initializes skill handler with dependencies
 func InitializeSkillHandler(db *gorm.DB) *handler.SkillHandler {
 	skillRepo := repository.NewSkillRepository(db)
@@ -52,37 +76,59 @@ func InitializeTransactionHandler(db *gorm.DB) *handler.TransactionHandler {
 }
 
 // InitializeSessionHandler initializes session handler with dependencies
-func InitializeSessionHandler(db *gorm.DB) *handler.SessionHandler {
+func InitializeSessionHandler(db *gorm.DB, cfg *config.Config) *handler.SessionHandler {
+	return handler.NewSessionHandler(newSessionService(db, cfg))
+}
+
+// InitializeFraudHandler initializes fraud review handler with dependencies
+func InitializeFraudHandler(db *gorm.DB, cfg *config.Config) *handler.FraudHandler {
+	fraudRepo := repository.NewFraudRepository(db)
+	fraudService := service.NewFraudService(fraudRepo, cfg.Fraud)
+	return handler.NewFraudHandler(fraudService, newSessionService(db, cfg))
+}
+
+// newSessionService builds the session service shared by session and fraud handlers
+func newSessionService(db *gorm.DB, cfg *config.Config) *service.SessionService {
 	sessionRepo := repository.NewSessionRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	skillRepo := repository.NewSkillRepository(db)
 	transactionRepo := repository.NewTransactionRepository(db)
 	notificationRepo := repository.NewNotificationRepository(db)
 	notificationService := service.NewNotificationService(notificationRepo, userRepo)
-	sessionService := service.NewSessionService(sessionRepo, userRepo, skillRepo, transactionRepo, notificationService)
-	return handler.NewSessionHandler(sessionService)
+	fraudRepo := repository.NewFraudRepository(db)
+	fraudService := service.NewFraudService(fraudRepo, cfg.Fraud)
+	return service.NewSessionService(sessionRepo, userRepo, skillRepo, transactionRepo, notificationService, fraudService,
+		newBadgeService(db, cfg), newStreakService(db, cfg), newChallengeService(db, cfg), newXPService(db, cfg))
 }
 
 // InitializeReviewHandler initializes review handler with dependencies
-func InitializeReviewHandler(db *gorm.DB) *handler.ReviewHandler {
+// Starts the worker that publishes sealed reviews once their blind window closes
+func InitializeReviewHandler(db *gorm.DB, cfg *config.Config) *handler.ReviewHandler {
 	reviewRepo := repository.NewReviewRepository(db)
 	sessionRepo := repository.NewSessionRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	notificationRepo := repository.NewNotificationRepository(db)
 	notificationService := service.NewNotificationService(notificationRepo, userRepo)
-	reviewService := service.NewReviewService(reviewRepo, sessionRepo, userRepo, notificationService)
+	reputationService := service.NewReputationService(repository.NewReputationRepository(db), cfg.Reputation)
+	reviewService := service.NewReviewService(reviewRepo, sessionRepo, userRepo, notificationService, reputationService, newBadgeService(db, cfg), newXPService(db, cfg), cfg.Review)
+	reviewService.StartReleaseWorker()
 	return handler.NewReviewHandler(reviewService)
 }
 
 // InitializeBadgeHandler initializes badge handler with dependencies
-func InitializeBadgeHandler(db *gorm.DB) *handler.BadgeHandler {
+func InitializeBadgeHandler(db *gorm.DB, cfg *config.Config) *handler.BadgeHandler {
+	return handler.NewBadgeHandler(newBadgeService(db, cfg))
+}
+
+// newBadgeService builds the badge service shared by the badge handler and the
+// session and review services, which feed it badge progress events
+func newBadgeService(db *gorm.DB, cfg *config.Config) *service.BadgeService {
 	badgeRepo := repository.NewBadgeRepository(db)
 	userRepo := repository.NewUserRepository(db)
-	sessionRepo := repository.NewSessionRepository(db)
 	notificationRepo := repository.NewNotificationRepository(db)
 	notificationService := service.NewNotificationService(notificationRepo, userRepo)
-	badgeService := service.NewBadgeService(badgeRepo, userRepo, sessionRepo, notificationService)
-	return handler.NewBadgeHandler(badgeService)
+	ruleService := service.NewBadgeRuleService(repository.NewBadgeMetricRepository(db))
+	return service.NewBadgeService(badgeRepo, userRepo, ruleService, notificationService, cfg.Badge)
 }
 
 // InitializeNotificationHandler initializes notification handler with dependencies
@@ -94,12 +140,12 @@ func InitializeNotificationHandler(db *gorm.DB) *handler.NotificationHandler {
 }
 
 // InitializeForumHandler initializes forum handler with dependencies
-func InitializeForumHandler(db *gorm.DB) *handler.ForumHandler {
+func InitializeForumHandler(db *gorm.DB, cfg *config.Config) *handler.ForumHandler {
 	forumRepo := repository.NewForumRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	notificationRepo := repository.NewNotificationRepository(db)
 	notificationService := service.NewNotificationService(notificationRepo, userRepo)
-	forumService := service.NewForumServiceWithNotification(forumRepo, userRepo, notificationService)
+	forumService := service.NewForumServiceWithNotification(forumRepo, userRepo, notificationService, newXPService(db, cfg))
 	return handler.NewForumHandler(forumService)
 }
 
@@ -114,13 +160,13 @@ func InitializeStoryHandler(db *gorm.DB) *handler.StoryHandler {
 }
 
 // InitializeEndorsementHandler initializes endorsement handler with dependencies
-func InitializeEndorsementHandler(db *gorm.DB) *handler.EndorsementHandler {
+func InitializeEndorsementHandler(db *gorm.DB, cfg *config.Config) *handler.EndorsementHandler {
 	endorsementRepo := repository.NewEndorsementRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	skillRepo := repository.NewSkillRepository(db)
 	notificationRepo := repository.NewNotificationRepository(db)
 	notificationService := service.NewNotificationService(notificationRepo, userRepo)
-	endorsementService := service.NewEndorsementServiceWithNotification(endorsementRepo, userRepo, skillRepo, notificationService)
+	endorsementService := service.NewEndorsementServiceWithNotification(endorsementRepo, userRepo, skillRepo, notificationService, newXPService(db, cfg))
 	return handler.NewEndorsementHandler(endorsementService)
 }
 
@@ -192,3 +238,201 @@ func InitializeAvailabilityHandler(db *gorm.DB) *handler.AvailabilityHandler {
 	availabilityService := service.NewAvailabilityService(availabilityRepo)
 	return handler.NewAvailabilityHandler(availabilityService)
 }
+
+// InitializeAuditRecorder initializes the middleware exposing the audit recorder to handlers
+func InitializeAuditRecorder(db *gorm.DB) gin.HandlerFunc {
+	auditService := service.NewAuditService(repository.NewAuditRepository(db))
+	return func(c *gin.Context) {
+		c.Set(service.AuditRecorderKey, auditService)
+		c.Next()
+	}
+}
+
+// InitializeAuditHandler initializes audit handler with dependencies
+func InitializeAuditHandler(db *gorm.DB) *handler.AuditHandler {
+	auditRepo := repository.NewAuditRepository(db)
+	auditService := service.NewAuditService(auditRepo)
+	return handler.NewAuditHandler(auditService)
+}
+
+// InitializeMarketplaceHandler initializes marketplace handler with dependencies
+func InitializeMarketplaceHandler(db *gorm.DB) *handler.MarketplaceHandler {
+	marketplaceRepo := repository.NewMarketplaceRepository(db)
+	marketplaceService := service.NewMarketplaceService(marketplaceRepo)
+	return handler.NewMarketplaceHandler(marketplaceService)
+}
+
+// InitializeSearchHandler initializes search handler with dependencies
+func InitializeSearchHandler(db *gorm.DB) *handler.SearchHandler {
+	searchRepo := repository.NewSearchRepository(db)
+	searchService := service.NewSearchService(searchRepo)
+	return handler.NewSearchHandler(searchService)
+}
+
+// InitializeRecommendationHandler initializes recommendation handler with dependencies
+// Starts the weekly "new matches" digest when enabled
+func InitializeRecommendationHandler(db *gorm.DB, cfg *config.Config) *handler.RecommendationHandler {
+	skillRepo := repository.NewSkillRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	availabilityRepo := repository.NewAvailabilityRepository(db)
+	marketplaceRepo := repository.NewMarketplaceRepository(db)
+	recommendationRepo := repository.NewRecommendationRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	notificationService := service.NewNotificationService(notificationRepo, userRepo)
+	recommendationService := service.NewRecommendationService(
+		skillRepo, userRepo, availabilityRepo, marketplaceRepo, recommendationRepo, notificationService,
+	)
+	if cfg.Recommendation.DigestEnabled {
+		recommendationService.StartWeeklyDigest()
+	}
+	return handler.NewRecommendationHandler(recommendationService)
+}
+
+// InitializeSkillSwapHandler initializes skill swap handler with dependencies
+func InitializeSkillSwapHandler(db *gorm.DB) *handler.SkillSwapHandler {
+	swapRepo := repository.NewSkillSwapRepository(db)
+	skillRepo := repository.NewSkillRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	notificationService := service.NewNotificationService(notificationRepo, userRepo)
+	swapService := service.NewSkillSwapService(swapRepo, skillRepo, sessionRepo, notificationService)
+	return handler.NewSkillSwapHandler(swapService)
+}
+
+// InitializeSkillTaxonomyHandler initializes skill taxonomy handler with dependencies
+func InitializeSkillTaxonomyHandler(db *gorm.DB) *handler.SkillTaxonomyHandler {
+	taxonomyRepo := repository.NewSkillTaxonomyRepository(db)
+	skillRepo := repository.NewSkillRepository(db)
+	taxonomyService := service.NewSkillTaxonomyService(taxonomyRepo, skillRepo)
+	return handler.NewSkillTaxonomyHandler(taxonomyService)
+}
+
+// InitializeSkillProposalHandler initializes skill proposal handler with dependencies
+func InitializeSkillProposalHandler(db *gorm.DB) *handler.SkillProposalHandler {
+	proposalRepo := repository.NewSkillProposalRepository(db)
+	skillRepo := repository.NewSkillRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	notificationService := service.NewNotificationService(notificationRepo, userRepo)
+	proposalService := service.NewSkillProposalService(proposalRepo, skillRepo, notificationService)
+	return handler.NewSkillProposalHandler(proposalService)
+}
+
+// InitializeSkillVerificationHandler initializes skill verification handler with dependencies
+// Starts the background job that expires lapsed verifications and sends renewal reminders
+func InitializeSkillVerificationHandler(db *gorm.DB, cfg *config.Config) *handler.SkillVerificationHandler {
+	verificationRepo := repository.NewSkillVerificationRepository(db)
+	skillRepo := repository.NewSkillRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	notificationService := service.NewNotificationService(notificationRepo, userRepo)
+	verificationService := service.NewSkillVerificationService(verificationRepo, skillRepo, notificationService, cfg.Verification)
+	verificationService.StartExpiryWorker()
+	return handler.NewSkillVerificationHandler(verificationService)
+}
+
+// InitializeSkillAssessmentHandler initializes skill assessment handler with dependencies
+func InitializeSkillAssessmentHandler(db *gorm.DB) *handler.SkillAssessmentHandler {
+	assessmentRepo := repository.NewSkillAssessmentRepository(db)
+	skillRepo := repository.NewSkillRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	notificationService := service.NewNotificationService(notificationRepo, userRepo)
+	assessmentService := service.NewSkillAssessmentService(assessmentRepo, skillRepo, notificationService)
+	return handler.NewSkillAssessmentHandler(assessmentService)
+}
+
+// InitializeSavedSearchHandler initializes saved search handler with dependencies
+// Starts the new-match alert job when enabled
+func InitializeSavedSearchHandler(db *gorm.DB, cfg *config.Config) *handler.SavedSearchHandler {
+	savedSearchRepo := repository.NewSavedSearchRepository(db)
+	marketplaceRepo := repository.NewMarketplaceRepository(db)
+	marketplaceService := service.NewMarketplaceService(marketplaceRepo)
+	userRepo := repository.NewUserRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	notificationService := service.NewNotificationService(notificationRepo, userRepo)
+	savedSearchService := service.NewSavedSearchService(
+		savedSearchRepo, marketplaceRepo, marketplaceService, notificationService, cfg.SavedSearch,
+	)
+	if cfg.SavedSearch.AlertsEnabled {
+		savedSearchService.StartAlertWorker()
+	}
+	return handler.NewSavedSearchHandler(savedSearchService)
+}
+
+// InitializeReputationHandler initializes reputation handler with dependencies
+// Starts the periodic full recompute when enabled
+func InitializeReputationHandler(db *gorm.DB, cfg *config.Config) *handler.ReputationHandler {
+	reputationRepo := repository.NewReputationRepository(db)
+	reputationService := service.NewReputationService(reputationRepo, cfg.Reputation)
+	if cfg.Reputation.RecomputeEnabled {
+		reputationService.StartRecomputeJob()
+	}
+	return handler.NewReputationHandler(reputationService)
+}
+
+// InitializeReviewModerationHandler initializes review moderation handler with dependencies
+func InitializeReviewModerationHandler(db *gorm.DB, cfg *config.Config) *handler.ReviewModerationHandler {
+	reviewRepo := repository.NewReviewRepository(db)
+	moderationRepo := repository.NewReviewModerationRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	notificationService := service.NewNotificationService(notificationRepo, userRepo)
+	reputationService := service.NewReputationService(repository.NewReputationRepository(db), cfg.Reputation)
+	moderationService := service.NewReviewModerationService(reviewRepo, moderationRepo, notificationService, reputationService)
+	return handler.NewReviewModerationHandler(moderationService)
+}
+
+// InitializeLeaderboardHandler initializes leaderboard handler with dependencies
+// Starts the periodic snapshot refresh when enabled
+func InitializeLeaderboardHandler(db *gorm.DB, cfg *config.Config) *handler.LeaderboardHandler {
+	leaderboardRepo := repository.NewLeaderboardRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	leaderboardService := service.NewLeaderboardService(leaderboardRepo, userRepo, cfg.Leaderboard)
+	if cfg.Leaderboard.RefreshEnabled {
+		leaderboardService.StartRefreshJob()
+	}
+	return handler.NewLeaderboardHandler(leaderboardService)
+}
+
+// InitializeChallengeHandler initializes challenge and streak handler with dependencies
+// Starts the challenge progress job and the streak-break warning job
+func InitializeChallengeHandler(db *gorm.DB, cfg *config.Config) *handler.ChallengeHandler {
+	challengeService := newChallengeService(db, cfg)
+	streakService := newStreakService(db, cfg)
+	if cfg.Challenge.ProgressEnabled {
+		challengeService.StartProgressJob()
+	}
+	if cfg.Streak.WarningEnabled {
+		streakService.StartWarningJob()
+	}
+	return handler.NewChallengeHandler(challengeService, streakService)
+}
+
+// InitializeXPHandler initializes XP and level handler with dependencies
+func InitializeXPHandler(db *gorm.DB, cfg *config.Config) *handler.XPHandler {
+	return handler.NewXPHandler(newXPService(db, cfg))
+}
+
+// newXPService builds the XP service shared by every service that awards XP
+func newXPService(db *gorm.DB, cfg *config.Config) *service.XPService {
+	userRepo := repository.NewUserRepository(db)
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), userRepo)
+	return service.NewXPService(repository.NewXPRepository(db), notificationService, cfg.XP)
+}
+
+// newStreakService builds the streak service shared by session and challenge handlers
+func newStreakService(db *gorm.DB, cfg *config.Config) *service.StreakService {
+	userRepo := repository.NewUserRepository(db)
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), userRepo)
+	return service.NewStreakService(repository.NewStreakRepository(db), notificationService, cfg.Streak)
+}
+
+// newChallengeService builds the challenge service shared by session and challenge handlers
+func newChallengeService(db *gorm.DB, cfg *config.Config) *service.ChallengeService {
+	userRepo := repository.NewUserRepository(db)
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), userRepo)
+	return service.NewChallengeService(repository.NewChallengeRepository(db), repository.NewBadgeRepository(db),
+		newBadgeService(db, cfg), notificationService, cfg.Challenge)
+}
@@ -25,19 +25,41 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB, cfg *config.Config) {
 	skillHandler := InitializeSkillHandler(db)
 	userHandler := InitializeUserHandler(db)
 	transactionHandler := InitializeTransactionHandler(db)
-	sessionHandler := InitializeSessionHandler(db)
-	reviewHandler := InitializeReviewHandler(db)
-	badgeHandler := InitializeBadgeHandler(db)
+	sessionHandler := InitializeSessionHandler(db, cfg)
+	reviewHandler := InitializeReviewHandler(db, cfg)
+	badgeHandler := InitializeBadgeHandler(db, cfg)
 	notificationHandler := InitializeNotificationHandler(db)
-	forumHandler := InitializeForumHandler(db)
+	forumHandler := InitializeForumHandler(db, cfg)
 	storyHandler := InitializeStoryHandler(db)
-	endorsementHandler := InitializeEndorsementHandler(db)
+	endorsementHandler := InitializeEndorsementHandler(db, cfg)
 	videoSessionHandler := InitializeVideoSessionHandler(db, cfg)
 	sharedFileHandler := InitializeSharedFileHandler(db)
 	whiteboardHandler := InitializeWhiteboardHandler(db)
 	progressHandler := InitializeSkillProgressHandler(db)
 	analyticsHandler := InitializeAnalyticsHandler(db)
 	availabilityHandler := InitializeAvailabilityHandler(db)
+	creditAdjustmentHandler := InitializeCreditAdjustmentHandler(db, cfg)
+	fraudHandler := InitializeFraudHandler(db, cfg)
+	auditHandler := InitializeAuditHandler(db)
+	marketplaceHandler := InitializeMarketplaceHandler(db)
+	searchHandler := InitializeSearchHandler(db)
+	recommendationHandler := InitializeRecommendationHandler(db, cfg)
+	skillSwapHandler := InitializeSkillSwapHandler(db)
+	skillTaxonomyHandler := InitializeSkillTaxonomyHandler(db)
+	skillProposalHandler := InitializeSkillProposalHandler(db)
+	skillVerificationHandler := InitializeSkillVerificationHandler(db, cfg)
+	skillAssessmentHandler := InitializeSkillAssessmentHandler(db)
+	savedSearchHandler := InitializeSavedSearchHandler(db, cfg)
+	reputationHandler := InitializeReputationHandler(db, cfg)
+	leaderboardHandler := InitializeLeaderboardHandler(db, cfg)
+	challengeHandler := InitializeChallengeHandler(db, cfg)
+	xpHandler := InitializeXPHandler(db, cfg)
+	reviewModerationHandler := InitializeReviewModerationHandler(db, cfg)
+	adminAuth := InitializeAdminAuth(db)
+	idempotent := InitializeIdempotency(db, cfg)
+
+	// Audit recorder (services record mutations through handler audit scopes)
+	router.Use(InitializeAuditRecorder(db))
 
 	// WebSocket endpoints (before auth middleware)
 	router.GET("/api/v1/ws/whiteboard/:sessionId", func(c *gin.Context) {
@@ -116,16 +138,157 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB, cfg *config.Config) {
 			admin.GET("/profile", middleware.AuthMiddleware(), adminHandler.GetProfile) // GET /api/v1/admin/profile
 			admin.PUT("/profile", middleware.AuthMiddleware(), adminHandler.UpdateProfile) // PUT /api/v1/admin/profile
 			admin.POST("/change-password", middleware.AuthMiddleware(), adminHandler.ChangePassword) // POST /api/v1/admin/change-password
+
+			// Credit adjustments (maker-checker above the approval threshold)
+			adminCredits := admin.Group("/credits", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("manage_finance"))
+			{
+				adminCredits.POST("/adjustments", idempotent, creditAdjustmentHandler.CreateAdjustment)              // POST /api/v1/admin/credits/adjustments
+				adminCredits.GET("/adjustments", creditAdjustmentHandler.ListAdjustments)                            // GET /api/v1/admin/credits/adjustments?status=pending
+				adminCredits.GET("/adjustments/:id", creditAdjustmentHandler.GetAdjustment)                          // GET /api/v1/admin/credits/adjustments/1
+				adminCredits.POST("/adjustments/:id/approve", idempotent, creditAdjustmentHandler.ApproveAdjustment) // POST /api/v1/admin/credits/adjustments/1/approve
+				adminCredits.POST("/adjustments/:id/reject", idempotent, creditAdjustmentHandler.RejectAdjustment)   // POST /api/v1/admin/credits/adjustments/1/reject
+				adminCredits.GET("/audit", creditAdjustmentHandler.GetAuditTrail)                                    // GET /api/v1/admin/credits/audit
+			}
+
+			// Fraud review queue (credits held until cleared)
+			adminFraud := admin.Group("/fraud", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("manage_sessions"))
+			{
+				adminFraud.GET("/flags", fraudHandler.ListFlags)                            // GET /api/v1/admin/fraud/flags?status=pending
+				adminFraud.GET("/flags/:id", fraudHandler.GetFlag)                          // GET /api/v1/admin/fraud/flags/1
+				adminFraud.POST("/flags/:id/clear", idempotent, fraudHandler.ClearFlag)     // POST /api/v1/admin/fraud/flags/1/clear
+				adminFraud.POST("/flags/:id/confirm", idempotent, fraudHandler.ConfirmFlag) // POST /api/v1/admin/fraud/flags/1/confirm
+				adminFraud.GET("/pairs", fraudHandler.GetSuspiciousPairs)                   // GET /api/v1/admin/fraud/pairs?limit=20
+				adminFraud.GET("/pairs/:userA/:userB", fraudHandler.ScorePair)              // GET /api/v1/admin/fraud/pairs/1/2
+			}
+
+			// Audit log (immutable record of service-layer mutations)
+			adminAudit := admin.Group("/audit", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("view_reports"))
+			{
+				adminAudit.GET("/events", auditHandler.ListEvents) // GET /api/v1/admin/audit/events?entity=reviews&from=2024-01-01T00:00:00Z
+			}
+
+			// Skill taxonomy (hierarchy, tags, aliases, merging duplicates)
+			adminTaxonomy := admin.Group("/skills", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("manage_content"))
+			{
+				adminTaxonomy.PUT("/:id/parent", skillTaxonomyHandler.SetParent)                // PUT /api/v1/admin/skills/1/parent
+				adminTaxonomy.PUT("/:id/tags", skillTaxonomyHandler.SetTags)                    // PUT /api/v1/admin/skills/1/tags
+				adminTaxonomy.GET("/:id/aliases", skillTaxonomyHandler.GetAliases)              // GET /api/v1/admin/skills/1/aliases
+				adminTaxonomy.POST("/:id/aliases", skillTaxonomyHandler.AddAlias)               // POST /api/v1/admin/skills/1/aliases
+				adminTaxonomy.DELETE("/:id/aliases/:aliasId", skillTaxonomyHandler.DeleteAlias) // DELETE /api/v1/admin/skills/1/aliases/2
+				adminTaxonomy.POST("/:id/merge", idempotent, skillTaxonomyHandler.MergeSkill)   // POST /api/v1/admin/skills/1/merge
+			}
+
+			// Skill proposal moderation queue
+			adminProposals := admin.Group("/skill-proposals", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("manage_content"))
+			{
+				adminProposals.GET("", skillProposalHandler.ListProposals)                            // GET /api/v1/admin/skill-proposals?status=pending
+				adminProposals.GET("/:id", skillProposalHandler.GetProposal)                          // GET /api/v1/admin/skill-proposals/1
+				adminProposals.POST("/:id/approve", idempotent, skillProposalHandler.ApproveProposal) // POST /api/v1/admin/skill-proposals/1/approve
+				adminProposals.POST("/:id/reject", idempotent, skillProposalHandler.RejectProposal)   // POST /api/v1/admin/skill-proposals/1/reject
+			}
+
+			// Skill credential verification queue
+			adminVerifications := admin.Group("/verifications", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("manage_content"))
+			{
+				adminVerifications.GET("", skillVerificationHandler.ListVerifications)                            // GET /api/v1/admin/verifications?status=pending
+				adminVerifications.GET("/:id", skillVerificationHandler.GetVerification)                          // GET /api/v1/admin/verifications/1
+				adminVerifications.GET("/:id/proof", skillVerificationHandler.GetProofFile)                       // GET /api/v1/admin/verifications/1/proof
+				adminVerifications.POST("/:id/approve", idempotent, skillVerificationHandler.ApproveVerification) // POST /api/v1/admin/verifications/1/approve
+				adminVerifications.POST("/:id/reject", idempotent, skillVerificationHandler.RejectVerification)   // POST /api/v1/admin/verifications/1/reject
+			}
+
+			// Skill placement assessments
+			adminAssessments := admin.Group("/assessments", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("manage_content"))
+			{
+				adminAssessments.GET("", skillAssessmentHandler.AdminListAssessments)                    // GET /api/v1/admin/assessments?skill_id=1
+				adminAssessments.GET("/:id", skillAssessmentHandler.AdminGetAssessment)                  // GET /api/v1/admin/assessments/1
+				adminAssessments.POST("", idempotent, skillAssessmentHandler.AdminCreateAssessment)      // POST /api/v1/admin/assessments
+				adminAssessments.PUT("/:id", skillAssessmentHandler.AdminUpdateAssessment)               // PUT /api/v1/admin/assessments/1
+				adminAssessments.DELETE("/:id", skillAssessmentHandler.AdminDeleteAssessment)            // DELETE /api/v1/admin/assessments/1
+				adminAssessments.POST("/:id/publish", skillAssessmentHandler.AdminPublishAssessment)     // POST /api/v1/admin/assessments/1/publish
+				adminAssessments.POST("/:id/unpublish", skillAssessmentHandler.AdminUnpublishAssessment) // POST /api/v1/admin/assessments/1/unpublish
+			}
+
+			// Review moderation
+			adminReviews := admin.Group("/reviews", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("manage_content"))
+			{
+				adminReviews.GET("/reported", reviewModerationHandler.GetModerationQueue)            // GET /api/v1/admin/reviews/reported
+				adminReviews.GET("/:id", reviewModerationHandler.GetModerationItem)                  // GET /api/v1/admin/reviews/1
+				adminReviews.POST("/:id/hide", idempotent, reviewModerationHandler.HideReview)       // POST /api/v1/admin/reviews/1/hide
+				adminReviews.POST("/:id/restore", idempotent, reviewModerationHandler.RestoreReview) // POST /api/v1/admin/reviews/1/restore
+				adminReviews.DELETE("/:id", reviewModerationHandler.DeleteReview)                    // DELETE /api/v1/admin/reviews/1
+			}
+
+			// Reputation scores
+			adminReputation := admin.Group("/reputation", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("manage_content"))
+			{
+				adminReputation.POST("/recompute", idempotent, reputationHandler.RecomputeAll) // POST /api/v1/admin/reputation/recompute
+			}
+
+			// Leaderboard seasons
+			adminLeaderboard := admin.Group("/leaderboard", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("manage_content"))
+			{
+				adminLeaderboard.POST("/seasons", idempotent, leaderboardHandler.CreateSeason) // POST /api/v1/admin/leaderboard/seasons
+				adminLeaderboard.PUT("/seasons/:id", leaderboardHandler.UpdateSeason)          // PUT /api/v1/admin/leaderboard/seasons/1
+				adminLeaderboard.DELETE("/seasons/:id", leaderboardHandler.DeleteSeason)       // DELETE /api/v1/admin/leaderboard/seasons/1 - Only seasons that haven't started
+				adminLeaderboard.POST("/refresh", idempotent, leaderboardHandler.RefreshAll)   // POST /api/v1/admin/leaderboard/refresh
+			}
+
+			// Badge management
+			adminBadges := admin.Group("/badges", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("manage_content"))
+			{
+				adminBadges.GET("", badgeHandler.ListBadgesForAdmin)                          // GET /api/v1/admin/badges
+				adminBadges.GET("/metrics", badgeHandler.GetBadgeMetrics)                     // GET /api/v1/admin/badges/metrics - Metrics requirements can use
+				adminBadges.POST("", idempotent, badgeHandler.CreateBadge)                    // POST /api/v1/admin/badges
+				adminBadges.PUT("/:id", badgeHandler.UpdateBadge)                             // PUT /api/v1/admin/badges/1
+				adminBadges.DELETE("/:id", badgeHandler.DeleteBadge)                          // DELETE /api/v1/admin/badges/1 - Only badges nobody holds
+				adminBadges.PUT("/:id/icon", badgeHandler.UploadBadgeIcon)                    // PUT /api/v1/admin/badges/1/icon (multipart)
+				adminBadges.POST("/:id/activate", idempotent, badgeHandler.ActivateBadge)     // POST /api/v1/admin/badges/1/activate
+				adminBadges.POST("/:id/deactivate", idempotent, badgeHandler.DeactivateBadge) // POST /api/v1/admin/badges/1/deactivate
+				adminBadges.POST("/:id/evaluate", idempotent, badgeHandler.StartAwardRun)     // POST /api/v1/admin/badges/1/evaluate - Award to users who qualify
+				adminBadges.GET("/:id/evaluate", badgeHandler.GetAwardRun)                    // GET /api/v1/admin/badges/1/evaluate - Latest evaluation progress
+				adminBadges.POST("/:id/revoke", idempotent, badgeHandler.RevokeBadge)         // POST /api/v1/admin/badges/1/revoke
+			}
+
+			// Community challenges
+			adminChallenges := admin.Group("/challenges", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("manage_content"))
+			{
+				adminChallenges.GET("", challengeHandler.ListChallengesForAdmin)               // GET /api/v1/admin/challenges?status=running
+				adminChallenges.POST("", idempotent, challengeHandler.CreateChallenge)         // POST /api/v1/admin/challenges
+				adminChallenges.PUT("/:id", challengeHandler.UpdateChallenge)                  // PUT /api/v1/admin/challenges/1
+				adminChallenges.DELETE("/:id", challengeHandler.DeleteChallenge)               // DELETE /api/v1/admin/challenges/1 - Only challenges nobody joined
+				adminChallenges.GET("/:id/participants", challengeHandler.ListParticipants)    // GET /api/v1/admin/challenges/1/participants
+				adminChallenges.POST("/refresh", idempotent, challengeHandler.RefreshProgress) // POST /api/v1/admin/challenges/refresh
+			}
+
+			// Experience points
+			adminXP := admin.Group("/xp", middleware.AuthMiddleware(), adminAuth, middleware.RequireAdminPermission("manage_content"))
+			{
+				adminXP.GET("/rules", xpHandler.ListRules)                    // GET /api/v1/admin/xp/rules
+				adminXP.PUT("/rules/:action", xpHandler.UpdateRule)           // PUT /api/v1/admin/xp/rules/review_written
+				adminXP.GET("/users/:id/history", xpHandler.GetUserXPHistory) // GET /api/v1/admin/xp/users/1/history?action=session_completed
+			}
 		}
 
 		// Public Skills routes
 		skills := v1.Group("/skills")
 		{
-			skills.GET("", skillHandler.GetSkills)                     // GET /api/v1/skills?limit=10&page=1&category=&search=
+			skills.GET("", skillHandler.GetSkills)                     // GET /api/v1/skills?limit=10&page=1&category=&search=&tag=
+			skills.GET("/tree", skillTaxonomyHandler.GetTree)          // GET /api/v1/skills/tree
+			skills.GET("/resolve", skillTaxonomyHandler.ResolveSkill)  // GET /api/v1/skills/resolve?name=JS
 			skills.GET("/:id/teachers", skillHandler.GetSkillTeachers) // GET /api/v1/skills/1/teachers
 			skills.GET("/:id", skillHandler.GetSkillByID)              // GET /api/v1/skills/1
 		}
 
+		// Public unified search (skills, offers, forum, stories)
+		v1.GET("/search", searchHandler.Search) // GET /api/v1/search?q=kalkulus&types=skill,thread
+
+		// Public Marketplace (teacher-level offers)
+		marketplace := v1.Group("/marketplace")
+		{
+			marketplace.GET("/offers", marketplaceHandler.SearchOffers) // GET /api/v1/marketplace/offers?q=calculus&level=advanced&mode=online&sort=rating
+		}
+
 		// Public User profiles
 		publicUsers := v1.Group("/users")
 		{
@@ -134,6 +297,8 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB, cfg *config.Config) {
 			publicUsers.GET("/:id/reviews", reviewHandler.GetUserReviews)          // GET /api/v1/users/1/reviews
 			publicUsers.GET("/:id/reviews/:type", reviewHandler.GetUserReviewsByType) // GET /api/v1/users/1/reviews/teacher
 			publicUsers.GET("/:id/rating-summary", reviewHandler.GetUserRatingSummary) // GET /api/v1/users/1/rating-summary
+			publicUsers.GET("/:id/reputation", reputationHandler.GetUserReputation) // GET /api/v1/users/1/reputation
+			publicUsers.GET("/:id/xp", xpHandler.GetUserXP) // GET /api/v1/users/1/xp - Level and total XP
 			publicUsers.GET("/:id/availability", availabilityHandler.GetUserAvailability) // GET /api/v1/users/1/availability
 			publicUsers.GET("/:id/availability/check", availabilityHandler.CheckAvailability) // GET /api/v1/users/1/availability/check?day=1&time=14:00
 		}
@@ -141,18 +306,23 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB, cfg *config.Config) {
 		// Public Badges
 		badges := v1.Group("/badges")
 		{
-			badges.GET("", badgeHandler.GetAllBadges)           // GET /api/v1/badges
-			badges.GET("/:id", badgeHandler.GetBadge)           // GET /api/v1/badges/1
+			badges.GET("", badgeHandler.GetAllBadges)          // GET /api/v1/badges
+			badges.GET("/:id", badgeHandler.GetBadge)          // GET /api/v1/badges/1
+			badges.GET("/:id/icon", badgeHandler.GetBadgeIcon) // GET /api/v1/badges/1/icon - Uploaded icon
 		}
 
 		// Public Leaderboards
 		leaderboards := v1.Group("/leaderboard")
 		{
-			leaderboards.GET("/badges", badgeHandler.GetBadgeLeaderboard)       // GET /api/v1/leaderboard/badges
-			leaderboards.GET("/rarity", badgeHandler.GetRarityLeaderboard)      // GET /api/v1/leaderboard/rarity
-			leaderboards.GET("/sessions", badgeHandler.GetSessionLeaderboard)   // GET /api/v1/leaderboard/sessions
-			leaderboards.GET("/rating", badgeHandler.GetRatingLeaderboard)      // GET /api/v1/leaderboard/rating
-			leaderboards.GET("/credits", badgeHandler.GetCreditLeaderboard)     // GET /api/v1/leaderboard/credits
+			leaderboards.GET("/seasons", leaderboardHandler.ListSeasons)  // GET /api/v1/leaderboard/seasons
+			leaderboards.GET("/:type", leaderboardHandler.GetLeaderboard) // GET /api/v1/leaderboard/sessions?period=weekly&scope=school&value=sma+1 - badges, rarity, sessions, rating, credits
+		}
+
+		// Public Challenges
+		challenges := v1.Group("/challenges")
+		{
+			challenges.GET("", challengeHandler.ListChallenges)   // GET /api/v1/challenges?status=running
+			challenges.GET("/:id", challengeHandler.GetChallenge) // GET /api/v1/challenges/1
 		}
 
 		// Protected routes (require authentication)
@@ -174,10 +344,16 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB, cfg *config.Config) {
 				user.PUT("/skills/:skillId", skillHandler.UpdateUserSkill)    // PUT /api/v1/user/skills/1
 				user.DELETE("/skills/:skillId", skillHandler.DeleteUserSkill) // DELETE /api/v1/user/skills/1
 
+				// Skill Verification (proof of credentials, reviewed by moderators)
+				user.POST("/skills/:skillId/verification", skillVerificationHandler.SubmitVerification) // POST /api/v1/user/skills/1/verification
+				user.GET("/verifications", skillVerificationHandler.GetUserVerifications)               // GET /api/v1/user/verifications
+				user.GET("/verifications/:id/proof", skillVerificationHandler.GetUserProofFile)         // GET /api/v1/user/verifications/1/proof
+
 				// Learning Skills Management
 				user.POST("/learning-skills", skillHandler.AddLearningSkill)               // POST /api/v1/user/learning-skills
 				user.GET("/learning-skills", skillHandler.GetLearningSkills)               // GET /api/v1/user/learning-skills
 				user.DELETE("/learning-skills/:skillId", skillHandler.DeleteLearningSkill) // DELETE /api/v1/user/learning-skills/1
+				user.GET("/recommendations", recommendationHandler.GetRecommendations)     // GET /api/v1/user/recommendations?limit=5
 
 				// Transaction Management
 				user.GET("/transactions", transactionHandler.GetUserTransactions)    // GET /api/v1/user/transactions
@@ -204,17 +380,17 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB, cfg *config.Config) {
 			// Sessions routes
 			sessions := protected.Group("/sessions")
 			{
-				sessions.POST("", sessionHandler.BookSession)                    // POST /api/v1/sessions - Book a session
-				sessions.GET("", sessionHandler.GetUserSessions)                 // GET /api/v1/sessions - Get user's sessions
-				sessions.GET("/upcoming", sessionHandler.GetUpcomingSessions)    // GET /api/v1/sessions/upcoming
-				sessions.GET("/pending", sessionHandler.GetPendingRequests)      // GET /api/v1/sessions/pending - Teacher's pending requests
-				sessions.GET("/:id", sessionHandler.GetSession)                  // GET /api/v1/sessions/:id
-				sessions.POST("/:id/approve", sessionHandler.ApproveSession)     // POST /api/v1/sessions/:id/approve
-				sessions.POST("/:id/reject", sessionHandler.RejectSession)       // POST /api/v1/sessions/:id/reject
-				sessions.POST("/:id/checkin", sessionHandler.CheckIn)            // POST /api/v1/sessions/:id/checkin - Check in for session
-				sessions.POST("/:id/start", sessionHandler.StartSession)         // POST /api/v1/sessions/:id/start (legacy)
-				sessions.POST("/:id/complete", sessionHandler.ConfirmCompletion) // POST /api/v1/sessions/:id/complete
-				sessions.POST("/:id/cancel", sessionHandler.CancelSession)       // POST /api/v1/sessions/:id/cancel
+				sessions.POST("", idempotent, sessionHandler.BookSession)                    // POST /api/v1/sessions - Book a session
+				sessions.GET("", sessionHandler.GetUserSessions)                             // GET /api/v1/sessions - Get user's sessions
+				sessions.GET("/upcoming", sessionHandler.GetUpcomingSessions)                // GET /api/v1/sessions/upcoming
+				sessions.GET("/pending", sessionHandler.GetPendingRequests)                  // GET /api/v1/sessions/pending - Teacher's pending requests
+				sessions.GET("/:id", sessionHandler.GetSession)                              // GET /api/v1/sessions/:id
+				sessions.POST("/:id/approve", idempotent, sessionHandler.ApproveSession)     // POST /api/v1/sessions/:id/approve
+				sessions.POST("/:id/reject", sessionHandler.RejectSession)                   // POST /api/v1/sessions/:id/reject
+				sessions.POST("/:id/checkin", sessionHandler.CheckIn)                        // POST /api/v1/sessions/:id/checkin - Check in for session
+				sessions.POST("/:id/start", sessionHandler.StartSession)                     // POST /api/v1/sessions/:id/start (legacy)
+				sessions.POST("/:id/complete", idempotent, sessionHandler.ConfirmCompletion) // POST /api/v1/sessions/:id/complete
+				sessions.POST("/:id/cancel", idempotent, sessionHandler.CancelSession)       // POST /api/v1/sessions/:id/cancel
 
 				// Video session routes
 				sessions.POST("/:id/video/start", videoSessionHandler.StartVideoSession)     // POST /api/v1/sessions/:id/video/start
@@ -235,6 +411,60 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB, cfg *config.Config) {
 				sessions.DELETE("/:id/whiteboard", whiteboardHandler.DeleteWhiteboard)       // DELETE /api/v1/sessions/:id/whiteboard
 			}
 
+			// Skill swap routes (reciprocal sessions that move no credits)
+			swaps := protected.Group("/swaps")
+			{
+				swaps.GET("/matches", skillSwapHandler.FindSwaps)        // GET /api/v1/swaps/matches?limit=10
+				swaps.POST("", idempotent, skillSwapHandler.ProposeSwap) // POST /api/v1/swaps - Propose a swap
+				swaps.GET("", skillSwapHandler.GetUserSwaps)             // GET /api/v1/swaps - Get user's swaps
+				swaps.GET("/:id", skillSwapHandler.GetSwap)              // GET /api/v1/swaps/:id
+				swaps.POST("/:id/accept", skillSwapHandler.AcceptSwap)   // POST /api/v1/swaps/:id/accept
+				swaps.POST("/:id/decline", skillSwapHandler.DeclineSwap) // POST /api/v1/swaps/:id/decline
+				swaps.POST("/:id/cancel", skillSwapHandler.CancelSwap)   // POST /api/v1/swaps/:id/cancel
+			}
+
+			// Skill proposals (new skills suggested by users, reviewed by admins)
+			skillProposals := protected.Group("/skill-proposals")
+			{
+				skillProposals.POST("", idempotent, skillProposalHandler.ProposeSkill) // POST /api/v1/skill-proposals - Propose a skill
+				skillProposals.GET("", skillProposalHandler.GetUserProposals)          // GET /api/v1/skill-proposals - Get user's proposals
+			}
+
+			// Skill placement assessments (authored by verified teachers, taken by anyone)
+			assessments := protected.Group("/assessments")
+			{
+				assessments.GET("", skillAssessmentHandler.ListAssessments)                        // GET /api/v1/assessments?skill_id=1 - Published assessments
+				assessments.GET("/authored", skillAssessmentHandler.GetAuthoredAssessments)        // GET /api/v1/assessments/authored - Teacher's own assessments
+				assessments.GET("/authored/:id", skillAssessmentHandler.GetAuthoredAssessment)     // GET /api/v1/assessments/authored/1 - With answers
+				assessments.GET("/:id", skillAssessmentHandler.GetAssessment)                      // GET /api/v1/assessments/1
+				assessments.POST("", idempotent, skillAssessmentHandler.CreateAssessment)          // POST /api/v1/assessments - Author an assessment
+				assessments.PUT("/:id", skillAssessmentHandler.UpdateAssessment)                   // PUT /api/v1/assessments/1
+				assessments.DELETE("/:id", skillAssessmentHandler.DeleteAssessment)                // DELETE /api/v1/assessments/1
+				assessments.POST("/:id/publish", skillAssessmentHandler.PublishAssessment)         // POST /api/v1/assessments/1/publish
+				assessments.POST("/:id/unpublish", skillAssessmentHandler.UnpublishAssessment)     // POST /api/v1/assessments/1/unpublish
+				assessments.POST("/:id/attempts", idempotent, skillAssessmentHandler.StartAttempt) // POST /api/v1/assessments/1/attempts - Start or resume an attempt
+			}
+
+			attempts := protected.Group("/assessment-attempts")
+			{
+				attempts.GET("", skillAssessmentHandler.GetUserAttempts)                       // GET /api/v1/assessment-attempts - User's attempts
+				attempts.GET("/:id", skillAssessmentHandler.GetAttempt)                        // GET /api/v1/assessment-attempts/1
+				attempts.POST("/:id/submit", idempotent, skillAssessmentHandler.SubmitAttempt) // POST /api/v1/assessment-attempts/1/submit - Submit answers for scoring
+			}
+
+			// Saved marketplace searches (alerts for new matching teachers)
+			savedSearches := protected.Group("/saved-searches")
+			{
+				savedSearches.POST("", idempotent, savedSearchHandler.CreateSavedSearch)    // POST /api/v1/saved-searches - Save a search
+				savedSearches.GET("", savedSearchHandler.GetSavedSearches)                  // GET /api/v1/saved-searches - User's saved searches
+				savedSearches.GET("/:id", savedSearchHandler.GetSavedSearch)                // GET /api/v1/saved-searches/1
+				savedSearches.GET("/:id/results", savedSearchHandler.GetSavedSearchResults) // GET /api/v1/saved-searches/1/results - Run the search now
+				savedSearches.PUT("/:id", savedSearchHandler.UpdateSavedSearch)             // PUT /api/v1/saved-searches/1
+				savedSearches.POST("/:id/pause", savedSearchHandler.PauseSavedSearch)       // POST /api/v1/saved-searches/1/pause
+				savedSearches.POST("/:id/resume", savedSearchHandler.ResumeSavedSearch)     // POST /api/v1/saved-searches/1/resume
+				savedSearches.DELETE("/:id", savedSearchHandler.DeleteSavedSearch)          // DELETE /api/v1/saved-searches/1
+			}
+
 			// Progress Tracking routes
 			progress := protected.Group("/user/skills")
 			{
@@ -260,19 +490,47 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB, cfg *config.Config) {
 			// Reviews routes
 			reviews := protected.Group("/reviews")
 			{
-				reviews.POST("", reviewHandler.CreateReview)              // POST /api/v1/reviews - Create a review
-				reviews.GET("/:id", reviewHandler.GetReview)              // GET /api/v1/reviews/:id - Get a review
-				reviews.PUT("/:id", reviewHandler.UpdateReview)           // PUT /api/v1/reviews/:id - Update a review
-				reviews.DELETE("/:id", reviewHandler.DeleteReview)        // DELETE /api/v1/reviews/:id - Delete a review
+				reviews.POST("", reviewHandler.CreateReview)                                  // POST /api/v1/reviews - Create a review
+				reviews.GET("/:id", reviewHandler.GetReview)                                  // GET /api/v1/reviews/:id - Get a review
+				reviews.PUT("/:id", reviewHandler.UpdateReview)                               // PUT /api/v1/reviews/:id - Update a review
+				reviews.DELETE("/:id", reviewHandler.DeleteReview)                            // DELETE /api/v1/reviews/:id - Delete a review
+				reviews.POST("/:id/report", idempotent, reviewModerationHandler.ReportReview) // POST /api/v1/reviews/:id/report - Report to moderators
+				reviews.POST("/:id/helpful", reviewModerationHandler.MarkHelpful)             // POST /api/v1/reviews/:id/helpful - One vote per user
+				reviews.DELETE("/:id/helpful", reviewModerationHandler.UnmarkHelpful)         // DELETE /api/v1/reviews/:id/helpful
+				reviews.PUT("/:id/reply", reviewModerationHandler.SaveReply)                  // PUT /api/v1/reviews/:id/reply - Reviewee's public response
+				reviews.DELETE("/:id/reply", reviewModerationHandler.DeleteReply)             // DELETE /api/v1/reviews/:id/reply
 			}
 
 			// User Badges routes
 			userBadges := protected.Group("/user/badges")
 			{
-				userBadges.GET("", badgeHandler.GetUserBadges)                    // GET /api/v1/user/badges
-				userBadges.GET("/:type", badgeHandler.GetUserBadgesByType)        // GET /api/v1/user/badges/achievement
-				userBadges.POST("/check", badgeHandler.CheckAndAwardBadges)       // POST /api/v1/user/badges/check
-				userBadges.POST("/:id/pin", badgeHandler.PinBadge)                // POST /api/v1/user/badges/1/pin
+				userBadges.GET("", badgeHandler.GetUserBadges)              // GET /api/v1/user/badges
+				userBadges.GET("/progress", badgeHandler.GetBadgeProgress)  // GET /api/v1/user/badges/progress - Closest badges to unlock
+				userBadges.GET("/:type", badgeHandler.GetUserBadgesByType)  // GET /api/v1/user/badges/achievement
+				userBadges.POST("/check", badgeHandler.CheckAndAwardBadges) // POST /api/v1/user/badges/check
+				userBadges.POST("/:id/pin", badgeHandler.PinBadge)          // POST /api/v1/user/badges/1/pin
+			}
+
+			// User leaderboard standing
+			userLeaderboard := protected.Group("/user/leaderboard")
+			{
+				userLeaderboard.GET("/:type/rank", leaderboardHandler.GetMyRank) // GET /api/v1/user/leaderboard/sessions/rank?period=monthly&scope=location
+			}
+
+			// User streak and challenges
+			userChallenges := protected.Group("/user")
+			{
+				userChallenges.GET("/streak", challengeHandler.GetMyStreak)                             // GET /api/v1/user/streak
+				userChallenges.GET("/challenges", challengeHandler.GetMyChallenges)                     // GET /api/v1/user/challenges
+				userChallenges.POST("/challenges/:id/join", idempotent, challengeHandler.JoinChallenge) // POST /api/v1/user/challenges/1/join
+				userChallenges.DELETE("/challenges/:id/join", challengeHandler.LeaveChallenge)          // DELETE /api/v1/user/challenges/1/join
+			}
+
+			// User experience points
+			userXP := protected.Group("/user/xp")
+			{
+				userXP.GET("", xpHandler.GetMyXP)                // GET /api/v1/user/xp
+				userXP.GET("/history", xpHandler.GetMyXPHistory) // GET /api/v1/user/xp/history?action=review_written&from=2026-03-01&to=2026-03-31
 			}
 
 			// Notifications routes
@@ -290,14 +548,15 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB, cfg *config.Config) {
 			// Forum routes
 			forum := protected.Group("/forum")
 			{
-				forum.GET("/categories", forumHandler.GetCategories)                          // GET /api/v1/forum/categories
-				forum.POST("/threads", forumHandler.CreateThread)                             // POST /api/v1/forum/threads
-				forum.GET("/threads/:id", forumHandler.GetThread)                             // GET /api/v1/forum/threads/:id
-				forum.GET("/categories/:id/threads", forumHandler.GetThreadsByCategory)       // GET /api/v1/forum/categories/:id/threads
-				forum.PUT("/threads/:id", forumHandler.UpdateThread)                          // PUT /api/v1/forum/threads/:id
-				forum.POST("/replies", forumHandler.CreateReply)                              // POST /api/v1/forum/replies
-				forum.GET("/threads/:id/replies", forumHandler.GetReplies)                    // GET /api/v1/forum/threads/:id/replies
-				forum.DELETE("/replies/:id", forumHandler.DeleteReply)                        // DELETE /api/v1/forum/replies/:id
+				forum.GET("/categories", forumHandler.GetCategories)                         // GET /api/v1/forum/categories
+				forum.POST("/threads", forumHandler.CreateThread)                            // POST /api/v1/forum/threads
+				forum.GET("/threads/:id", forumHandler.GetThread)                            // GET /api/v1/forum/threads/:id
+				forum.GET("/categories/:id/threads", forumHandler.GetThreadsByCategory)      // GET /api/v1/forum/categories/:id/threads
+				forum.PUT("/threads/:id", forumHandler.UpdateThread)                         // PUT /api/v1/forum/threads/:id
+				forum.POST("/replies", forumHandler.CreateReply)                             // POST /api/v1/forum/replies
+				forum.GET("/threads/:id/replies", forumHandler.GetReplies)                   // GET /api/v1/forum/threads/:id/replies
+				forum.POST("/threads/:id/replies/:replyId/accept", forumHandler.AcceptReply) // POST /api/v1/forum/threads/:id/replies/:replyId/accept
+				forum.DELETE("/replies/:id", forumHandler.DeleteReply)                       // DELETE /api/v1/forum/replies/:id
 			}
 
 			// Stories routes
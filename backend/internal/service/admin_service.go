@@ -13,6 +13,7 @@ import (
 // AdminService handles admin business logic
 type AdminService struct {
 	adminRepo *repository.AdminRepository
+	audit     *AuditScope
 }
 
 // NewAdminService creates new admin service
@@ -22,6 +23,13 @@ func NewAdminService(adminRepo *repository.AdminRepository) *AdminService {
 	}
 }
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *AdminService) WithAudit(audit *AuditScope) *AdminService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
 // Register registers a new admin (only super_admin can do this)
 func (s *AdminService) Register(req dto.AdminRegisterRequest) (*dto.AdminLoginResponse, error) {
 	// Check if admin already exists
@@ -48,9 +56,10 @@ func (s *AdminService) Register(req dto.AdminRegisterRequest) (*dto.AdminLoginRe
 	if err := s.adminRepo.Create(&admin); err != nil {
 		return nil, fmt.Errorf("failed to create admin: %w", err)
 	}
+	s.audit.Record(models.AuditActionCreate, "admins", admin.ID, nil, &admin)
 
 	// Generate token
-	token, err := utils.GenerateToken(admin.ID, admin.Email)
+	token, err := utils.GenerateAdminToken(admin.ID, admin.Email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
@@ -90,7 +99,7 @@ func (s *AdminService) Login(req dto.AdminLoginRequest) (*dto.AdminLoginResponse
 	s.adminRepo.UpdateLastLogin(admin.ID)
 
 	// Generate token
-	token, err := utils.GenerateToken(admin.ID, admin.Email)
+	token, err := utils.GenerateAdminToken(admin.ID, admin.Email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
@@ -133,6 +142,7 @@ func (s *AdminService) UpdateProfile(adminID uint, req dto.AdminUpdateRequest) (
 	if err != nil {
 		return nil, errors.New("admin not found")
 	}
+	before := *admin
 
 	// Update fields
 	if req.FullName != "" {
@@ -150,6 +160,7 @@ func (s *AdminService) UpdateProfile(adminID uint, req dto.AdminUpdateRequest) (
 	if err := s.adminRepo.Update(admin); err != nil {
 		return nil, fmt.Errorf("failed to update admin: %w", err)
 	}
+	s.audit.Record(models.AuditActionUpdate, "admins", adminID, &before, admin)
 
 	return &dto.AdminProfile{
 		ID:        admin.ID,
@@ -182,5 +193,11 @@ func (s *AdminService) ChangePassword(adminID uint, req dto.AdminChangePasswordR
 
 	// Update password
 	admin.Password = string(hashedPassword)
-	return s.adminRepo.Update(admin)
+	if err := s.adminRepo.Update(admin); err != nil {
+		return err
+	}
+
+	// Password hashes are never written to the audit log, only the fact of the change
+	s.audit.Record(models.AuditActionUpdate, "admins", adminID, nil, map[string]interface{}{"password": "changed"})
+	return nil
 }
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"reflect"
+
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+)
+
+// AuditRecorderKey is the gin context key holding the *AuditService for a request
+const AuditRecorderKey = "audit_recorder"
+
+// auditIgnoredFields are bookkeeping columns left out of change diffs
+var auditIgnoredFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"deleted_at": true,
+}
+
+// AuditChange is the before/after value of a single changed field
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditService records and queries the immutable audit log
+type AuditService struct {
+	auditRepo *repository.AuditRepository
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(auditRepo *repository.AuditRepository) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// AuditScope identifies the actor and request behind a set of mutations
+// Services receive a scope through their WithAudit method and call Record
+// after each successful mutation. A nil scope records nothing.
+type AuditScope struct {
+	recorder  *AuditService
+	ActorType models.AuditActorType
+	ActorID   uint
+	IP        string
+	RequestID string
+}
+
+// Scope creates an audit scope for one request
+func (s *AuditService) Scope(actorType models.AuditActorType, actorID uint, ip, requestID string) *AuditScope {
+	return &AuditScope{
+		recorder:  s,
+		ActorType: actorType,
+		ActorID:   actorID,
+		IP:        ip,
+		RequestID: requestID,
+	}
+}
+
+// WithActor returns a copy of the scope attributed to another actor of the same type
+// Used where the actor only exists once the mutation succeeds, such as registration
+func (a *AuditScope) WithActor(actorID uint) *AuditScope {
+	if a == nil {
+		return nil
+	}
+	scoped := *a
+	scoped.ActorID = actorID
+	return &scoped
+}
+
+// Record appends an audit event for a mutation
+// before is nil for creates and after is nil for deletes. Both are
+// snapshotted through their JSON form, so fields tagged json:"-"
+// (passwords, internal flags) never reach the audit log.
+// Failures are logged and never fail the mutation itself.
+func (a *AuditScope) Record(action models.AuditAction, entity string, entityID uint, before, after interface{}) {
+	if a == nil || a.recorder == nil {
+		return
+	}
+
+	changes, err := json.Marshal(diffAuditSnapshots(before, after))
+	if err != nil {
+		log.Printf("Failed to encode audit changes for %s %d: %v", entity, entityID, err)
+		return
+	}
+
+	event := &models.AuditEvent{
+		ActorType: a.ActorType,
+		ActorID:   a.ActorID,
+		Action:    action,
+		Entity:    entity,
+		EntityID:  entityID,
+		Changes:   string(changes),
+		IP:        a.IP,
+		RequestID: a.RequestID,
+	}
+	if err := a.recorder.auditRepo.Create(event); err != nil {
+		log.Printf("Failed to record audit event for %s %d: %v", entity, entityID, err)
+	}
+}
+
+// ListEvents queries the audit log
+//
+// Parameters:
+//   - req: Optional filters by actor, entity, action, request and time range
+//
+// Returns:
+//   - []AuditEvent: Matching events, newest first
+//   - int64: Total number of matching events
+//   - error: If the filter is invalid or database error
+func (s *AuditService) ListEvents(req *dto.AuditEventQuery) ([]models.AuditEvent, int64, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 50
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	actorType := models.AuditActorType(req.ActorType)
+	switch actorType {
+	case "", models.AuditActorUser, models.AuditActorAdmin, models.AuditActorSystem:
+	default:
+		return nil, 0, errors.New("invalid actor type")
+	}
+
+	action := models.AuditAction(req.Action)
+	switch action {
+	case "", models.AuditActionCreate, models.AuditActionUpdate, models.AuditActionDelete:
+	default:
+		return nil, 0, errors.New("invalid action")
+	}
+
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		return nil, 0, errors.New("from must be before to")
+	}
+
+	filter := repository.AuditFilter{
+		ActorType: actorType,
+		ActorID:   req.ActorID,
+		Entity:    req.Entity,
+		EntityID:  req.EntityID,
+		Action:    action,
+		RequestID: req.RequestID,
+		From:      req.From,
+		To:        req.To,
+	}
+
+	return s.auditRepo.List(filter, req.Limit, req.Offset)
+}
+
+// diffAuditSnapshots returns the scalar fields that differ between two snapshots
+// Nested objects and arrays (preloaded relationships) are skipped
+func diffAuditSnapshots(before, after interface{}) map[string]AuditChange {
+	beforeFields := auditSnapshot(before)
+	afterFields := auditSnapshot(after)
+
+	changes := map[string]AuditChange{}
+	for key, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[key]) {
+			changes[key] = AuditChange{Before: value, After: afterFields[key]}
+		}
+	}
+	for key, value := range afterFields {
+		if _, seen := beforeFields[key]; !seen && value != nil {
+			changes[key] = AuditChange{Before: nil, After: value}
+		}
+	}
+	return changes
+}
+
+// auditSnapshot flattens a value to its scalar JSON fields
+func auditSnapshot(value interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil()) {
+		return fields
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fields
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return fields
+	}
+
+	for key, field := range decoded {
+		if auditIgnoredFields[key] {
+			continue
+		}
+		switch field.(type) {
+		case map[string]interface{}, []interface{}:
+			continue
+		}
+		fields[key] = field
+	}
+	return fields
+}
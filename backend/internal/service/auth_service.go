@@ -14,6 +14,7 @@ import (
 type AuthService struct {
   userRepo        *repository.UserRepository
   transactionRepo *repository.TransactionRepository
+  audit           *AuditScope
 }
 
 // NewAuthService creates a new auth service
@@ -24,6 +25,13 @@ func NewAuthService(userRepo *repository.UserRepository, transactionRepo *reposi
   }
 }
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *AuthService) WithAudit(audit *AuditScope) *AuthService {
+  scoped := *s
+  scoped.audit = audit
+  return &scoped
+}
+
 // Register registers a new user account in the system
 // Creates user profile, grants welcome bonus credits, and generates JWT token
 //
@@ -103,6 +111,8 @@ func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, err
   if err := s.userRepo.Create(user); err != nil {
     return nil, errors.New("failed to create user")
   }
+  // The request is unauthenticated, so the new user is recorded as the actor
+  s.audit.WithActor(user.ID).Record(models.AuditActionCreate, "users", user.ID, nil, user)
 
   // Create initial transaction for welcome bonus
   transaction := &models.Transaction{
@@ -4,12 +4,14 @@ import (
 	"errors"
 
 	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
 	"github.com/timebankingskill/backend/internal/repository"
 )
 
 // AvailabilityService handles availability business logic
 type AvailabilityService struct {
 	availabilityRepo *repository.AvailabilityRepository
+	audit            *AuditScope
 }
 
 // NewAvailabilityService creates a new availability service
@@ -19,6 +21,13 @@ func NewAvailabilityService(availabilityRepo *repository.AvailabilityRepository)
 	}
 }
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *AvailabilityService) WithAudit(audit *AuditScope) *AvailabilityService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
 // GetUserAvailability gets all availability slots for a user
 func (s *AvailabilityService) GetUserAvailability(userID uint) (*dto.UserAvailabilityResponse, error) {
 	availabilities, err := s.availabilityRepo.GetUserAvailability(userID)
@@ -45,6 +54,11 @@ func (s *AvailabilityService) SetUserAvailability(userID uint, req *dto.SetAvail
 		}
 	}
 
+	previous, err := s.availabilityRepo.GetUserAvailability(userID)
+	if err != nil {
+		return nil, errors.New("failed to fetch availability")
+	}
+
 	// Convert request to models
 	availabilities := dto.MapRequestToAvailabilities(userID, req)
 
@@ -52,6 +66,8 @@ func (s *AvailabilityService) SetUserAvailability(userID uint, req *dto.SetAvail
 	if err := s.availabilityRepo.SetUserAvailability(userID, availabilities); err != nil {
 		return nil, errors.New("failed to set availability")
 	}
+	s.audit.Record(models.AuditActionUpdate, "availabilities", userID,
+		availabilityAuditSnapshot(previous), availabilityAuditSnapshot(availabilities))
 
 	// Fetch updated availability
 	return s.GetUserAvailability(userID)
@@ -82,5 +98,30 @@ func (s *AvailabilityService) CheckUserAvailability(userID uint, dayOfWeek int,
 
 // ClearUserAvailability removes all availability for a user
 func (s *AvailabilityService) ClearUserAvailability(userID uint) error {
-	return s.availabilityRepo.DeleteUserAvailability(userID)
+	previous, err := s.availabilityRepo.GetUserAvailability(userID)
+	if err != nil {
+		return errors.New("failed to fetch availability")
+	}
+
+	if err := s.availabilityRepo.DeleteUserAvailability(userID); err != nil {
+		return err
+	}
+	s.audit.Record(models.AuditActionDelete, "availabilities", userID, availabilityAuditSnapshot(previous), nil)
+	return nil
+}
+
+// availabilityAuditSnapshot summarizes a weekly schedule as scalar fields for the audit log
+// Each day maps to its slots joined as "HH:MM-HH:MM" ranges
+func availabilityAuditSnapshot(availabilities []models.Availability) map[string]interface{} {
+	snapshot := map[string]interface{}{}
+	for i := range availabilities {
+		a := &availabilities[i]
+		day := a.DayName()
+		slot := a.StartTime + "-" + a.EndTime
+		if existing, ok := snapshot[day]; ok {
+			slot = existing.(string) + ", " + slot
+		}
+		snapshot[day] = slot
+	}
+	return snapshot
 }
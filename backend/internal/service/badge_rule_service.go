@@ -0,0 +1,240 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+)
+
+// badgeMetricResolver reads one metric for a user; since is nil for all-time values
+type badgeMetricResolver func(e *badgeEvaluation, since *time.Time) (float64, error)
+
+// badgeMetricResolvers implements every metric in the models badge metric registry
+// All-time session and rating metrics come from the user's maintained counters;
+// credit metrics are summed from the transaction ledger
+var badgeMetricResolvers = map[string]badgeMetricResolver{
+	models.BadgeMetricSessions: func(e *badgeEvaluation, since *time.Time) (float64, error) {
+		if since == nil {
+			return float64(e.user.TotalSessionsAsTeacher + e.user.TotalSessionsAsStudent), nil
+		}
+		count, err := e.metricRepo.CountCompletedSessions(e.user.ID, repository.BadgeRoleAny, since)
+		return float64(count), err
+	},
+	models.BadgeMetricSessionsAsTeacher: func(e *badgeEvaluation, since *time.Time) (float64, error) {
+		if since == nil {
+			return float64(e.user.TotalSessionsAsTeacher), nil
+		}
+		count, err := e.metricRepo.CountCompletedSessions(e.user.ID, repository.BadgeRoleTeacher, since)
+		return float64(count), err
+	},
+	models.BadgeMetricSessionsAsStudent: func(e *badgeEvaluation, since *time.Time) (float64, error) {
+		if since == nil {
+			return float64(e.user.TotalSessionsAsStudent), nil
+		}
+		count, err := e.metricRepo.CountCompletedSessions(e.user.ID, repository.BadgeRoleStudent, since)
+		return float64(count), err
+	},
+	models.BadgeMetricHoursTaught: func(e *badgeEvaluation, since *time.Time) (float64, error) {
+		return e.metricRepo.SumSessionHours(e.user.ID, repository.BadgeRoleTeacher, since)
+	},
+	models.BadgeMetricHoursLearned: func(e *badgeEvaluation, since *time.Time) (float64, error) {
+		return e.metricRepo.SumSessionHours(e.user.ID, repository.BadgeRoleStudent, since)
+	},
+	models.BadgeMetricUniqueSkills: func(e *badgeEvaluation, since *time.Time) (float64, error) {
+		count, err := e.metricRepo.CountSkillsTaught(e.user.ID, since)
+		return float64(count), err
+	},
+	models.BadgeMetricRating: func(e *badgeEvaluation, _ *time.Time) (float64, error) {
+		return (e.user.AverageRatingAsTeacher + e.user.AverageRatingAsStudent) / 2, nil
+	},
+	models.BadgeMetricTeacherRating: func(e *badgeEvaluation, _ *time.Time) (float64, error) {
+		return e.user.AverageRatingAsTeacher, nil
+	},
+	models.BadgeMetricStudentRating: func(e *badgeEvaluation, _ *time.Time) (float64, error) {
+		return e.user.AverageRatingAsStudent, nil
+	},
+	models.BadgeMetricReviewsReceived: func(e *badgeEvaluation, since *time.Time) (float64, error) {
+		count, err := e.metricRepo.CountReviewsReceived(e.user.ID, since)
+		return float64(count), err
+	},
+	models.BadgeMetricTotalEarned: func(e *badgeEvaluation, since *time.Time) (float64, error) {
+		return e.metricRepo.SumTransactions(e.user.ID, models.TransactionEarned, since)
+	},
+	models.BadgeMetricTotalSpent: func(e *badgeEvaluation, since *time.Time) (float64, error) {
+		return e.metricRepo.SumTransactions(e.user.ID, models.TransactionSpent, since)
+	},
+	models.BadgeMetricBadgesEarned: func(e *badgeEvaluation, since *time.Time) (float64, error) {
+		count, err := e.metricRepo.CountBadgesEarned(e.user.ID, since)
+		return float64(count), err
+	},
+	models.BadgeMetricAccountAgeDays: func(e *badgeEvaluation, _ *time.Time) (float64, error) {
+		return float64(int(e.now.Sub(e.user.CreatedAt).Hours() / 24)), nil
+	},
+}
+
+// BadgeRuleService evaluates declarative badge requirements against a user's activity
+type BadgeRuleService struct {
+	metricRepo *repository.BadgeMetricRepository
+}
+
+// NewBadgeRuleService creates a new badge rule service
+func NewBadgeRuleService(metricRepo *repository.BadgeMetricRepository) *BadgeRuleService {
+	return &BadgeRuleService{metricRepo: metricRepo}
+}
+
+// badgeEvaluation evaluates rules for one user at one point in time
+// Metric values are memoized, so checking many badges reads each metric once
+type badgeEvaluation struct {
+	metricRepo *repository.BadgeMetricRepository
+	user       *models.User
+	now        time.Time
+	values     map[string]float64
+}
+
+// newEvaluation starts evaluating rules for a user
+func (s *BadgeRuleService) newEvaluation(user *models.User, now time.Time) *badgeEvaluation {
+	return &badgeEvaluation{
+		metricRepo: s.metricRepo,
+		user:       user,
+		now:        now,
+		values:     map[string]float64{},
+	}
+}
+
+// satisfies reports whether the user meets a rule
+// all needs every child, any needs one, not inverts its child
+func (e *badgeEvaluation) satisfies(rule *models.BadgeRule) (bool, error) {
+	switch {
+	case rule.All != nil:
+		for i := range rule.All {
+			ok, err := e.satisfies(&rule.All[i])
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case rule.Any != nil:
+		for i := range rule.Any {
+			ok, err := e.satisfies(&rule.Any[i])
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case rule.Not != nil:
+		ok, err := e.satisfies(rule.Not)
+		return !ok && err == nil, err
+	}
+
+	value, err := e.metric(rule.Metric, rule.WindowDays)
+	if err != nil {
+		return false, err
+	}
+	return rule.Compare(value), nil
+}
+
+// metric reads a metric, counted over the last windowDays days when windowDays > 0
+func (e *badgeEvaluation) metric(name string, windowDays int) (float64, error) {
+	key := fmt.Sprintf("%s/%d", name, windowDays)
+	if value, ok := e.values[key]; ok {
+		return value, nil
+	}
+
+	resolve, ok := badgeMetricResolvers[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown metric %q", name)
+	}
+
+	var since *time.Time
+	if windowDays > 0 {
+		start := e.now.AddDate(0, 0, -windowDays)
+		since = &start
+	}
+
+	value, err := resolve(e, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read metric %s: %w", name, err)
+	}
+	e.values[key] = value
+	return value, nil
+}
+
+// badgeProgressCeiling caps the progress of a requirement that isn't met yet,
+// e.g. a strict > comparison sitting exactly on its value
+const badgeProgressCeiling = 0.99
+
+// badgeRuleProgress is how far a user is towards meeting a rule
+type badgeRuleProgress struct {
+	Fraction   float64 // 0-1; 1 only when the rule is met
+	Metric     string  // Requirement furthest from being met
+	Current    float64
+	Target     float64
+	WindowDays int
+}
+
+// progress measures how close the user is to meeting a rule
+// A >= or > requirement earns partial credit towards its value, other comparisons
+// are met or not. all averages its children and reports the one furthest from
+// being met, any reports its closest child, not is met or not.
+func (e *badgeEvaluation) progress(rule *models.BadgeRule) (badgeRuleProgress, error) {
+	switch {
+	case rule.All != nil || rule.Any != nil:
+		children := rule.All
+		if rule.Any != nil {
+			children = rule.Any
+		}
+
+		var picked badgeRuleProgress
+		sum := 0.0
+		for i := range children {
+			child, err := e.progress(&children[i])
+			if err != nil {
+				return badgeRuleProgress{}, err
+			}
+			sum += child.Fraction
+			furthest := rule.All != nil && child.Fraction < picked.Fraction
+			closest := rule.Any != nil && child.Fraction > picked.Fraction
+			if i == 0 || furthest || closest {
+				picked = child
+			}
+		}
+		if rule.All != nil {
+			picked.Fraction = sum / float64(len(children))
+		}
+		return picked, nil
+	case rule.Not != nil:
+		child, err := e.progress(rule.Not)
+		if err != nil {
+			return badgeRuleProgress{}, err
+		}
+		child.Fraction = 0
+		if met, err := e.satisfies(rule); err != nil {
+			return badgeRuleProgress{}, err
+		} else if met {
+			child.Fraction = 1
+		}
+		return child, nil
+	}
+
+	value, err := e.metric(rule.Metric, rule.WindowDays)
+	if err != nil {
+		return badgeRuleProgress{}, err
+	}
+
+	result := badgeRuleProgress{
+		Metric:     rule.Metric,
+		Current:    value,
+		Target:     rule.Value,
+		WindowDays: rule.WindowDays,
+	}
+	switch {
+	case rule.Compare(value):
+		result.Fraction = 1
+	case (rule.Op == models.BadgeOpGTE || rule.Op == models.BadgeOpGT) && rule.Value > 0:
+		result.Fraction = math.Min(math.Max(value/rule.Value, 0), badgeProgressCeiling)
+	}
+	return result, nil
+}
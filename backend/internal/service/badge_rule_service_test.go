@@ -0,0 +1,119 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/models"
+)
+
+// newTestEvaluation evaluates rules against a user's maintained counters only;
+// windowed metric values can be preset in values, keyed "metric/windowDays"
+func newTestEvaluation(values map[string]float64) *badgeEvaluation {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	user := &models.User{
+		TotalSessionsAsTeacher: 8,
+		TotalSessionsAsStudent: 4,
+		AverageRatingAsTeacher: 4.8,
+		AverageRatingAsStudent: 4.2,
+	}
+	user.CreatedAt = now.AddDate(0, 0, -45)
+
+	e := (&BadgeRuleService{}).newEvaluation(user, now)
+	for key, value := range values {
+		e.values[key] = value
+	}
+	return e
+}
+
+func mustParseBadgeRule(t *testing.T, raw string) *models.BadgeRule {
+	t.Helper()
+	rule, err := models.ParseBadgeRequirements(raw)
+	if err != nil {
+		t.Fatalf("ParseBadgeRequirements(%s): %v", raw, err)
+	}
+	return rule
+}
+
+func TestBadgeEvaluationSatisfies(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want bool
+	}{
+		{"all-time sessions", `{"sessions": 12}`, true},
+		{"too few sessions", `{"sessions": 13}`, false},
+		{"rating averages both roles", `{"metric": "rating", "op": "==", "value": 4.5}`, true},
+		{"account age", `{"metric": "account_age_days", "op": ">", "value": 44}`, true},
+		{"all needs every child", `{"all": [{"metric": "sessions_as_teacher", "value": 8}, {"metric": "student_rating", "value": 4.5}]}`, false},
+		{"any needs one child", `{"any": [{"metric": "sessions_as_teacher", "value": 9}, {"metric": "teacher_rating", "value": 4.5}]}`, true},
+		{"not inverts", `{"not": {"metric": "sessions_as_student", "op": "<", "value": 5}}`, false},
+		{"windowed value", `{"metric": "sessions", "value": 3, "window_days": 30}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEvaluation(map[string]float64{"sessions/30": 3})
+			got, err := e.satisfies(mustParseBadgeRule(t, tt.raw))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("satisfies(%s) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBadgeEvaluationProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		fraction float64
+		metric   string
+	}{
+		{"met", `{"sessions": 10}`, 1, models.BadgeMetricSessions},
+		{"partial credit", `{"sessions_as_teacher": 16}`, 0.5, models.BadgeMetricSessionsAsTeacher},
+		{"strict comparison on its value is capped", `{"metric": "sessions_as_teacher", "op": ">", "value": 8}`, badgeProgressCeiling, models.BadgeMetricSessionsAsTeacher},
+		{"other comparisons are met or not", `{"metric": "sessions_as_student", "op": "<=", "value": 3}`, 0, models.BadgeMetricSessionsAsStudent},
+		{"all averages and reports the furthest", `{"all": [{"metric": "sessions", "value": 12}, {"metric": "sessions_as_student", "value": 16}]}`, 0.625, models.BadgeMetricSessionsAsStudent},
+		{"any reports the closest", `{"any": [{"metric": "sessions", "value": 24}, {"metric": "sessions_as_student", "value": 16}]}`, 0.5, models.BadgeMetricSessions},
+		{"not is met or not", `{"not": {"metric": "sessions", "value": 100}}`, 1, models.BadgeMetricSessions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress, err := newTestEvaluation(nil).progress(mustParseBadgeRule(t, tt.raw))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(progress.Fraction-tt.fraction) > 1e-9 {
+				t.Errorf("Fraction = %v, want %v", progress.Fraction, tt.fraction)
+			}
+			if progress.Metric != tt.metric {
+				t.Errorf("Metric = %q, want %q", progress.Metric, tt.metric)
+			}
+		})
+	}
+}
+
+func TestBadgeEvaluationMemoizesMetrics(t *testing.T) {
+	e := newTestEvaluation(nil)
+	if _, err := e.metric(models.BadgeMetricSessions, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	e.user.TotalSessionsAsTeacher = 100
+	value, err := e.metric(models.BadgeMetricSessions, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value != 12 {
+		t.Errorf("second read = %v, want the memoized 12", value)
+	}
+
+	if _, err := e.metric("karma", 0); err == nil {
+		t.Error("unknown metric should fail")
+	}
+}
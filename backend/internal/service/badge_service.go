@@ -1,11 +1,23 @@
 package service
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"sort"
-
+	"io"
+	"log"
+	"math"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/config"
 	"github.com/timebankingskill/backend/internal/dto"
 	"github.com/timebankingskill/backend/internal/models"
 	"github.com/timebankingskill/backend/internal/repository"
@@ -15,25 +27,36 @@ import (
 type BadgeService struct {
 	badgeRepo           *repository.BadgeRepository
 	userRepo            *repository.UserRepository
-	sessionRepo         *repository.SessionRepository
+	ruleService         *BadgeRuleService
 	notificationService *NotificationService
+	config              config.BadgeConfig
+	audit               *AuditScope
 }
 
 // NewBadgeService creates a new badge service
 func NewBadgeService(
 	badgeRepo *repository.BadgeRepository,
 	userRepo *repository.UserRepository,
-	sessionRepo *repository.SessionRepository,
+	ruleService *BadgeRuleService,
 	notificationService *NotificationService,
+	cfg config.BadgeConfig,
 ) *BadgeService {
 	return &BadgeService{
 		badgeRepo:           badgeRepo,
 		userRepo:            userRepo,
-		sessionRepo:         sessionRepo,
+		ruleService:         ruleService,
 		notificationService: notificationService,
+		config:              cfg,
 	}
 }
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *BadgeService) WithAudit(audit *AuditScope) *BadgeService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
 // GetAllBadges gets all available badges
 func (s *BadgeService) GetAllBadges() ([]dto.BadgeResponse, error) {
 	badges, err := s.badgeRepo.GetAllBadges()
@@ -85,25 +108,55 @@ func (s *BadgeService) GetUserBadgesByType(userID uint, badgeType string) ([]dto
 	return dto.MapUserBadgesToResponse(userBadges), nil
 }
 
+// BadgeEvent is a domain event that can move a user towards badges
+type BadgeEvent string
+
+const (
+	BadgeEventSessionCompleted BadgeEvent = "session_completed" // A session the user took part in completed
+	BadgeEventReviewReceived   BadgeEvent = "review_received"   // A review of the user was published
+	BadgeEventCreditsEarned    BadgeEvent = "credits_earned"    // Held session credits were released to the user
+)
+
+// badgeEventMetrics lists the metrics each event can change
+var badgeEventMetrics = map[BadgeEvent][]string{
+	BadgeEventSessionCompleted: {
+		models.BadgeMetricSessions, models.BadgeMetricSessionsAsTeacher, models.BadgeMetricSessionsAsStudent,
+		models.BadgeMetricHoursTaught, models.BadgeMetricHoursLearned, models.BadgeMetricUniqueSkills,
+		models.BadgeMetricTotalEarned, models.BadgeMetricTotalSpent,
+	},
+	BadgeEventReviewReceived: {
+		models.BadgeMetricRating, models.BadgeMetricTeacherRating, models.BadgeMetricStudentRating,
+		models.BadgeMetricReviewsReceived,
+	},
+	BadgeEventCreditsEarned: {
+		models.BadgeMetricTotalEarned, models.BadgeMetricTotalSpent,
+	},
+}
+
+// badgeMatcher selects which unearned badges an evaluation pass re-checks
+type badgeMatcher func(badge *models.Badge, rule *models.BadgeRule) bool
+
 // CheckAndAwardBadges checks if user qualifies for any badges and awards them
-// This function is called after session completion to automatically award earned badges
-// Performance: O(n*m) where n = number of badges, m = average requirement checks
-// Should be called asynchronously to avoid blocking session completion
+// Re-evaluates every unearned badge and refreshes the user's progress towards
+// the ones still out of reach
+// Performance: O(n*m) where n = number of badges, m = distinct metrics; each metric
+// is read once per pass and shared across badges
 //
 // Algorithm:
 // 1. Fetch user profile with all stats
-// 2. Fetch all available badges
+// 2. Fetch the active badges the user hasn't earned
 // 3. For each badge:
-//    a. Check if user already has badge (skip if yes)
-//    b. Parse badge requirements JSON
-//    c. Check if user meets ALL requirements
-//    d. If qualified: award badge and grant bonus credits
-// 4. Return list of newly awarded badges
+//    a. Parse badge requirements into a rule tree (see models.BadgeRule)
+//    b. Measure the user's progress against the rule
+//    c. If met: award badge and grant bonus credits
+//    d. Otherwise: save progress towards the badge
+// 4. Repeat for badges that count earned badges while new ones were awarded
+// 5. Return list of newly awarded badges
 //
 // Side Effects:
 //   - Updates user credit balance if bonus credits awarded
 //   - Creates UserBadge records in database
-//   - May update user profile stats
+//   - Creates or updates BadgeProgress records
 //
 // Parameters:
 //   - userID: User to check and award badges for
@@ -116,68 +169,194 @@ func (s *BadgeService) GetUserBadgesByType(userID uint, badgeType string) ([]dto
 //   awarded, err := badgeService.CheckAndAwardBadges(userID)
 //   // Automatically awards badges user qualifies for
 func (s *BadgeService) CheckAndAwardBadges(userID uint) ([]dto.UserBadgeResponse, error) {
-	awardedBadges := []models.UserBadge{}
+	awardedBadges, err := s.evaluateBadges(userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return dto.MapUserBadgesToResponse(awardedBadges), nil
+}
+
+// HandleEvent refreshes a user's progress after a domain event and awards any
+// badges it completes. Only badges whose requirements use a metric the event
+// changes are re-evaluated.
+// Failures are only logged; the next event or progress read repairs them
+func (s *BadgeService) HandleEvent(userID uint, event BadgeEvent) {
+	metrics := map[string]bool{}
+	for _, metric := range badgeEventMetrics[event] {
+		metrics[metric] = true
+	}
+
+	_, err := s.evaluateBadges(userID, func(_ *models.Badge, rule *models.BadgeRule) bool {
+		for _, metric := range rule.Metrics() {
+			if metrics[metric] {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		log.Printf("Failed to update badge progress for user %d after %s: %v", userID, event, err)
+	}
+}
+
+// GetBadgeProgress lists the user's closest badges to unlock
+// Badges the user has no progress record for yet, and badges whose requirements
+// depend on time windows or account age, are re-evaluated on read since no
+// event announces their change
+func (s *BadgeService) GetBadgeProgress(userID uint, limit int) ([]dto.BadgeProgressResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	trackedIDs, err := s.badgeRepo.GetTrackedBadgeIDs(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch badge progress: %w", err)
+	}
+	tracked := map[uint]bool{}
+	for _, id := range trackedIDs {
+		tracked[id] = true
+	}
+
+	_, err = s.evaluateBadges(userID, func(badge *models.Badge, rule *models.BadgeRule) bool {
+		return !tracked[badge.ID] || rule.IsTimeRelative()
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	// Fetch user with current stats (teaching hours, learning hours, ratings, etc)
+	progress, err := s.badgeRepo.GetBadgeProgress(userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch badge progress: %w", err)
+	}
+	return dto.MapBadgeProgressToResponse(progress), nil
+}
+
+// evaluateBadges re-checks the user's unearned badges selected by match (all when nil)
+// Badges that count earned badges are re-checked while the pass awards new ones
+func (s *BadgeService) evaluateBadges(userID uint, match badgeMatcher) ([]models.UserBadge, error) {
+	// Fetch user with current stats (session counts, ratings, etc)
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
 		return nil, errors.New("user not found")
 	}
 
-	// Fetch all available badges in system
-	allBadges, err := s.badgeRepo.GetAllBadges()
+	awarded, err := s.evaluateBadgePass(user, match)
 	if err != nil {
 		return nil, err
 	}
 
-	// Iterate through all badges and check qualification
-	for _, badge := range allBadges {
-		// Skip if user already has this badge (prevent duplicate awards)
-		hasIt, _ := s.badgeRepo.HasUserBadge(userID, badge.ID)
-		if hasIt {
-			continue
+	newlyAwarded := awarded
+	for len(newlyAwarded) > 0 {
+		newlyAwarded, err = s.evaluateBadgePass(user, func(_ *models.Badge, rule *models.BadgeRule) bool {
+			for _, metric := range rule.Metrics() {
+				if metric == models.BadgeMetricBadgesEarned {
+					return true
+				}
+			}
+			return false
+		})
+		if err != nil {
+			return nil, err
 		}
+		awarded = append(awarded, newlyAwarded...)
+	}
+
+	return awarded, nil
+}
+
+// evaluateBadgePass measures the user against the selected unearned badges once,
+// awarding the badges that are met and saving progress towards the rest
+func (s *BadgeService) evaluateBadgePass(user *models.User, match badgeMatcher) ([]models.UserBadge, error) {
+	badges, err := s.badgeRepo.GetUnearnedBadges(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Metric values are shared by every badge checked in this pass
+	evaluation := s.ruleService.newEvaluation(user, time.Now())
 
-		// Parse badge requirements from JSON string
+	awarded := []models.UserBadge{}
+	var progress []models.BadgeProgress
+	for i := range badges {
+		badge := &badges[i]
+
+		// Parse badge requirements into a rule tree
 		// Requirements define what user must achieve to earn badge
-		var requirements map[string]interface{}
-		if err := json.Unmarshal([]byte(badge.Requirements), &requirements); err != nil {
-			// Skip badge if requirements are malformed
+		rule, err := models.ParseBadgeRequirements(badge.Requirements)
+		if err != nil {
+			// Never award a badge whose requirements can't be evaluated
+			log.Printf("Skipping badge %d (%s): %v", badge.ID, badge.Name, err)
+			continue
+		}
+		if match != nil && !match(badge, rule) {
 			continue
 		}
 
-		// Check if user meets ALL badge requirements
-		if s.qualifiesForBadge(user, requirements) {
-			// Award badge to user
-			userBadge, err := s.badgeRepo.AwardBadge(userID, badge.ID)
-			if err == nil {
-				// Grant bonus credits if badge has bonus
-				// This incentivizes users to earn badges
-				if badge.BonusCredits > 0 {
-					user.CreditBalance += badge.BonusCredits
-					_ = s.userRepo.Update(user)
-				}
-				awardedBadges = append(awardedBadges, *userBadge)
-
-				// Send badge achievement notification
-				notificationData := map[string]interface{}{
-					"badgeID":   badge.ID,
-					"badgeName": badge.Name,
-					"rarity":    badge.Rarity,
-					"bonus":     badge.BonusCredits,
-				}
-				_, _ = s.notificationService.CreateNotification(
-					userID,
-					models.NotificationTypeAchievement,
-					"Badge Unlocked! 🏆",
-					fmt.Sprintf("You unlocked the %s badge! Rarity: %d/5", badge.Name, badge.Rarity),
-					notificationData,
-				)
+		result, err := evaluation.progress(rule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate badge %s: %w", badge.Name, err)
+		}
+
+		if result.Fraction >= 1 {
+			if userBadge := s.awardBadge(user, badge); userBadge != nil {
+				awarded = append(awarded, *userBadge)
 			}
+			continue
 		}
+
+		progress = append(progress, models.BadgeProgress{
+			UserID:     user.ID,
+			BadgeID:    badge.ID,
+			Metric:     result.Metric,
+			Current:    result.Current,
+			Target:     result.Target,
+			WindowDays: result.WindowDays,
+			Percent:    math.Round(result.Fraction*1000) / 10,
+		})
 	}
 
-	return dto.MapUserBadgesToResponse(awardedBadges), nil
+	if err := s.badgeRepo.SaveBadgeProgress(progress); err != nil {
+		return nil, fmt.Errorf("failed to save badge progress: %w", err)
+	}
+	return awarded, nil
+}
+
+// awardBadge awards a badge, grants its bonus credits and notifies the user
+// Returns nil when the badge could not be awarded
+func (s *BadgeService) awardBadge(user *models.User, badge *models.Badge) *models.UserBadge {
+	// Award badge to user; bonus credits are paid through the ledger in the same transaction
+	// This incentivizes users to earn badges
+	userBadge, err := s.badgeRepo.AwardBadge(user.ID, badge)
+	if err != nil {
+		log.Printf("Failed to award badge %d to user %d: %v", badge.ID, user.ID, err)
+		return nil
+	}
+
+	// Send badge achievement notification
+	notificationData := map[string]interface{}{
+		"badgeID":   badge.ID,
+		"badgeName": badge.Name,
+		"rarity":    badge.Rarity,
+		"bonus":     badge.BonusCredits,
+	}
+	_, _ = s.notificationService.CreateNotification(
+		user.ID,
+		models.NotificationTypeAchievement,
+		"Badge Unlocked! 🏆",
+		fmt.Sprintf("You unlocked the %s badge! Rarity: %d/5", badge.Name, badge.Rarity),
+		notificationData,
+	)
+
+	return userBadge
+}
+
+// GrantBadge awards a badge outside the rule engine, e.g. as a challenge reward
+// Users who already hold the badge are skipped; returns nil when nothing was awarded
+func (s *BadgeService) GrantBadge(userID uint, badge *models.Badge) *models.UserBadge {
+	if has, err := s.badgeRepo.HasUserBadge(userID, badge.ID); err != nil || has {
+		return nil
+	}
+	return s.awardBadge(&models.User{ID: userID}, badge)
 }
 
 // PinBadge pins or unpins a badge for a user
@@ -200,407 +379,448 @@ func (s *BadgeService) PinBadge(userID, badgeID uint, isPinned bool) error {
 	return nil
 }
 
-// qualifiesForBadge checks if user meets badge requirements
-// This is a private method that evaluates badge qualification criteria
-// Performance: O(1) - constant time lookup and comparison
-// Logic: AND operation - ALL requirements must be met to qualify
-// Extensibility: New requirements can be added without breaking existing logic
-//
-// Supported Requirements (optional - any can be omitted):
-//   - "sessions": Total sessions (teaching + learning) required
-//   - "teaching_sessions": Minimum teaching sessions required
-//   - "learning_sessions": Minimum learning sessions required
-//   - "rating": Minimum average rating required (0-5 scale)
-//   - "credits_earned": Minimum credits earned from teaching required
-//
-// If a requirement is not specified in the badge, it's skipped (no check)
-// This allows flexible badge definitions
-//
-// Parameters:
-//   - user: User to check (must have stats pre-loaded)
-//   - requirements: Map of requirement keys to values (parsed from JSON)
-//
-// Returns:
-//   - true: User meets ALL specified requirements
-//   - false: User fails at least one requirement
-//
-// Example:
-//   requirements := map[string]interface{}{
-//     "teaching_sessions": 20.0,
-//     "rating": 4.5,
-//   }
-//   qualifies := qualifiesForBadge(user, requirements)
-func (s *BadgeService) qualifiesForBadge(user *models.User, requirements map[string]interface{}) bool {
-	// TOTAL SESSIONS CHECK: Verify combined teaching + learning sessions
-	// Used for badges like "Dedicated Participant" (10+ total sessions)
-	// Represents overall engagement in the platform
-	if sessionsReq, ok := requirements["sessions"].(float64); ok {
-		totalSessions := user.TotalSessionsAsTeacher + user.TotalSessionsAsStudent
-		if totalSessions < int(sessionsReq) {
-			return false // Doesn't meet minimum total sessions
-		}
-	}
+// badgeIconExtensions lists the image types accepted as badge icons
+// SVG is left out because it can carry scripts and icons are served from the API origin
+var badgeIconExtensions = map[string]bool{
+	".png":  true,
+	".jpg":  true,
+	".jpeg": true,
+	".webp": true,
+	".gif":  true,
+}
 
-	// TEACHING SESSIONS CHECK: Verify teaching experience
-	// Used for badges like "Dedicated Teacher" (20+ teaching sessions)
-	// Represents commitment to helping others
-	if teachingReq, ok := requirements["teaching_sessions"].(float64); ok {
-		if user.TotalSessionsAsTeacher < int(teachingReq) {
-			return false // Doesn't meet minimum teaching sessions
-		}
+// badgeAwardBatchSize is how many users a retroactive award run loads at a time
+const badgeAwardBatchSize = 200
+
+// badgeAwardRun tracks a retroactive award run of one badge
+type badgeAwardRun struct {
+	mu     sync.Mutex
+	result dto.BadgeAwardRunResponse
+}
+
+// snapshot copies the run's current counters
+func (r *badgeAwardRun) snapshot() *dto.BadgeAwardRunResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := r.result
+	return &result
+}
+
+// badgeAwardRuns holds the latest retroactive run per badge, shared by every badge service
+var (
+	badgeAwardRunsMu sync.Mutex
+	badgeAwardRuns   = map[uint]*badgeAwardRun{}
+)
+
+// ListBadgesForAdmin gets every badge, including inactive ones
+func (s *BadgeService) ListBadgesForAdmin() ([]dto.BadgeResponse, error) {
+	badges, err := s.badgeRepo.ListAllBadges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch badges: %w", err)
 	}
+	return dto.MapBadgesToResponse(badges), nil
+}
 
-	// LEARNING SESSIONS CHECK: Verify learning experience
-	// Used for badges like "Knowledge Seeker" (10+ learning sessions)
-	// Represents commitment to personal growth
-	if learningReq, ok := requirements["learning_sessions"].(float64); ok {
-		if user.TotalSessionsAsStudent < int(learningReq) {
-			return false // Doesn't meet minimum learning sessions
-		}
+// CreateBadge creates a badge after validating its requirements
+// An active badge is evaluated against every existing user in the background
+func (s *BadgeService) CreateBadge(req *dto.CreateBadgeRequest) (*dto.BadgeResponse, error) {
+	if err := s.checkBadgeName(req.Name, 0); err != nil {
+		return nil, err
+	}
+	requirements, err := normalizeBadgeRequirements(req.Requirements)
+	if err != nil {
+		return nil, err
 	}
 
-	// RATING CHECK: Verify quality of teaching/learning
-	// Calculates average of teacher and student ratings: (teacher_rating + student_rating) / 2
-	// Used for badges like "Top Tutor" (4.8+ rating)
-	// Represents user's reputation and quality
-	if ratingReq, ok := requirements["rating"].(float64); ok {
-		avgRating := (user.AverageRatingAsTeacher + user.AverageRatingAsStudent) / 2
-		if avgRating < ratingReq {
-			return false // Doesn't meet minimum rating threshold
-		}
+	badge := &models.Badge{
+		Name:         strings.TrimSpace(req.Name),
+		Description:  req.Description,
+		Icon:         req.Icon,
+		Type:         models.BadgeType(req.Type),
+		Requirements: requirements,
+		BonusCredits: req.BonusCredits,
+		Rarity:       req.Rarity,
+		Color:        req.Color,
+		IsActive:     req.IsActive == nil || *req.IsActive,
+		DisplayOrder: req.DisplayOrder,
+	}
+	if badge.Rarity == 0 {
+		badge.Rarity = 1
 	}
 
-	// CREDITS EARNED CHECK: Verify teaching productivity
-	// Used for badges like "Platinum Teacher" (100+ hours taught)
-	// Represents total time credits earned from teaching
-	if creditsReq, ok := requirements["credits_earned"].(float64); ok {
-		if int(user.TotalEarned) < int(creditsReq) {
-			return false // Doesn't meet minimum credits earned
+	if err := s.badgeRepo.CreateBadge(badge); err != nil {
+		return nil, fmt.Errorf("failed to create badge: %w", err)
+	}
+	// GORM skips false for columns with a default, so store inactive explicitly
+	if !badge.IsActive {
+		if err := s.badgeRepo.SetBadgeActive(badge.ID, false); err != nil {
+			return nil, fmt.Errorf("failed to create badge: %w", err)
 		}
 	}
+	s.audit.Record(models.AuditActionCreate, "badges", badge.ID, nil, badge)
 
-	// All requirements met - user qualifies for this badge
-	return true
-}
-
-// GetBadgeLeaderboard gets top users by badge count
-func (s *BadgeService) GetBadgeLeaderboard(limit int) ([]dto.LeaderboardEntry, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 10
+	if badge.IsActive {
+		s.startAwardRun(badge)
 	}
+	return dto.MapBadgeToResponse(badge), nil
+}
 
-	results, err := s.badgeRepo.GetBadgeLeaderboard(limit)
+// UpdateBadge edits a badge
+// Changing the requirements of an active badge re-evaluates every user who doesn't have it;
+// users who already earned it keep it
+func (s *BadgeService) UpdateBadge(id uint, req *dto.UpdateBadgeRequest) (*dto.BadgeResponse, error) {
+	badge, err := s.badgeRepo.GetBadgeByID(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch leaderboard: %w", err)
+		return nil, errors.New("badge not found")
 	}
+	before := *badge
 
-	var leaderboard []dto.LeaderboardEntry
-	for _, result := range results {
-		userID := uint(result["user_id"].(float64))
-		badgeCount := int(result["badge_count"].(int64))
-
-		user, err := s.userRepo.GetByID(userID)
+	if req.Name != nil {
+		if err := s.checkBadgeName(*req.Name, badge.ID); err != nil {
+			return nil, err
+		}
+		badge.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		badge.Description = *req.Description
+	}
+	if req.Icon != nil {
+		badge.Icon = *req.Icon
+	}
+	if req.Type != nil {
+		badge.Type = models.BadgeType(*req.Type)
+	}
+	requirementsChanged := false
+	if len(req.Requirements) > 0 && string(req.Requirements) != "null" {
+		requirements, err := normalizeBadgeRequirements(req.Requirements)
 		if err != nil {
-			continue
+			return nil, err
 		}
-
-		leaderboard = append(leaderboard, dto.LeaderboardEntry{
-			UserID:    userID,
-			Username:  user.Username,
-			FullName:  user.FullName,
-			Avatar:    user.Avatar,
-			Score:     badgeCount,
-			ScoreType: "badges",
-		})
+		requirementsChanged = requirements != badge.Requirements
+		badge.Requirements = requirements
+	}
+	if req.BonusCredits != nil {
+		badge.BonusCredits = *req.BonusCredits
+	}
+	if req.Rarity != nil {
+		badge.Rarity = *req.Rarity
+	}
+	if req.Color != nil {
+		badge.Color = *req.Color
+	}
+	if req.DisplayOrder != nil {
+		badge.DisplayOrder = *req.DisplayOrder
 	}
 
-	return leaderboard, nil
-}
+	if err := s.badgeRepo.UpdateBadge(badge); err != nil {
+		return nil, fmt.Errorf("failed to update badge: %w", err)
+	}
+	s.audit.Record(models.AuditActionUpdate, "badges", badge.ID, before, badge)
 
-// GetRarityLeaderboard gets top users by badge rarity
-func (s *BadgeService) GetRarityLeaderboard(limit int) ([]dto.LeaderboardEntry, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 10
+	if requirementsChanged && badge.IsActive {
+		s.startAwardRun(badge)
 	}
+	return dto.MapBadgeToResponse(badge), nil
+}
 
-	results, err := s.badgeRepo.GetRarityLeaderboard(limit)
+// DeleteBadge deletes a badge nobody holds
+// Badges that were awarded are deactivated instead so holders keep them
+func (s *BadgeService) DeleteBadge(id uint) error {
+	badge, err := s.badgeRepo.GetBadgeByID(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch leaderboard: %w", err)
+		return errors.New("badge not found")
+	}
+	if badge.TotalAwarded > 0 {
+		return errors.New("badge has been awarded; deactivate it instead")
 	}
 
-	var leaderboard []dto.LeaderboardEntry
-	for _, result := range results {
-		userID := uint(result["user_id"].(float64))
-		totalRarity := int(result["total_rarity"].(int64))
+	if err := s.badgeRepo.DeleteBadge(id); err != nil {
+		return fmt.Errorf("failed to delete badge: %w", err)
+	}
+	if badge.IconPath != "" {
+		_ = os.Remove(badge.IconPath)
+	}
+	s.audit.Record(models.AuditActionDelete, "badges", badge.ID, badge, nil)
+	return nil
+}
 
-		user, err := s.userRepo.GetByID(userID)
-		if err != nil {
-			continue
+// SetBadgeActive activates or deactivates a badge
+// Deactivated badges stay with their holders but can't be earned; activating a badge
+// evaluates it against every user who doesn't have it
+func (s *BadgeService) SetBadgeActive(id uint, active bool) (*dto.BadgeResponse, error) {
+	badge, err := s.badgeRepo.GetBadgeByID(id)
+	if err != nil {
+		return nil, errors.New("badge not found")
+	}
+	if badge.IsActive == active {
+		return dto.MapBadgeToResponse(badge), nil
+	}
+	if active {
+		if _, err := models.ParseBadgeRequirements(badge.Requirements); err != nil {
+			return nil, fmt.Errorf("invalid requirements: %w", err)
 		}
+	}
 
-		leaderboard = append(leaderboard, dto.LeaderboardEntry{
-			UserID:    userID,
-			Username:  user.Username,
-			FullName:  user.FullName,
-			Avatar:    user.Avatar,
-			Score:     totalRarity,
-			ScoreType: "rarity",
-		})
+	before := *badge
+	if err := s.badgeRepo.SetBadgeActive(id, active); err != nil {
+		return nil, fmt.Errorf("failed to update badge: %w", err)
 	}
+	badge.IsActive = active
+	s.audit.Record(models.AuditActionUpdate, "badges", badge.ID, before, badge)
 
-	return leaderboard, nil
+	if active {
+		s.startAwardRun(badge)
+	}
+	return dto.MapBadgeToResponse(badge), nil
 }
 
-// GetSessionLeaderboard retrieves top users ranked by total session count (teaching + learning)
-// Performance: O(n log n) due to sort.Slice
-// Caching: Results should be cached for 5-10 minutes to reduce database load
-//
-// Algorithm:
-// 1. Fetch all users from database
-// 2. Count sessions for each user (teaching + learning)
-// 3. Filter users with at least 1 session
-// 4. Sort by total session count (descending)
-// 5. Return top N entries
-//
-// Parameters:
-//   - limit: Maximum number of entries (clamped to 1-100, default 10)
-//
-// Returns:
-//   - []LeaderboardEntry: Sorted leaderboard entries
-//   - error: If database fetch fails
-//
-// Example:
-//   leaderboard, err := badgeService.GetSessionLeaderboard(10)
-//   // Returns top 10 users by session count
-func (s *BadgeService) GetSessionLeaderboard(limit int) ([]dto.LeaderboardEntry, error) {
-	// Validate and normalize limit parameter
-	if limit <= 0 || limit > 100 {
-		limit = 10
+// UploadBadgeIcon stores an uploaded image as the badge's icon
+// The badge's icon then points at the public icon endpoint
+func (s *BadgeService) UploadBadgeIcon(id uint, file *multipart.FileHeader) (*dto.BadgeResponse, error) {
+	badge, err := s.badgeRepo.GetBadgeByID(id)
+	if err != nil {
+		return nil, errors.New("badge not found")
 	}
+	before := *badge
 
-	// Fetch all users from database
-	users, err := s.userRepo.GetAll()
+	path, err := s.saveIconFile(file)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch users: %w", err)
-	}
-
-	// Helper struct to hold user with their session count
-	type userSessionCount struct {
-		User  models.User
-		Count int64
-	}
-	var userCounts []userSessionCount
-
-	// Count total sessions for each user (teaching + learning)
-	// Only include users with at least 1 session to reduce leaderboard noise
-	for _, user := range users {
-		// Count sessions where user is the teacher
-		teacherCount, _ := s.sessionRepo.CountUserSessionsAsTeacher(user.ID)
-		// Count sessions where user is the student/learner
-		studentCount, _ := s.sessionRepo.CountUserSessionsAsStudent(user.ID)
-		totalCount := teacherCount + studentCount
-
-		// Only include users with activity
-		if totalCount > 0 {
-			userCounts = append(userCounts, userSessionCount{
-				User:  user,
-				Count: totalCount,
-			})
-		}
+		return nil, err
 	}
 
-	// Sort by session count in descending order using efficient sort.Slice
-	// Time complexity: O(n log n) instead of O(n²) with bubble sort
-	sort.Slice(userCounts, func(i, j int) bool {
-		return userCounts[i].Count > userCounts[j].Count
-	})
+	badge.IconPath = path
+	badge.Icon = fmt.Sprintf("/api/v1/badges/%d/icon", badge.ID)
+	if err := s.badgeRepo.UpdateBadge(badge); err != nil {
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("failed to update badge: %w", err)
+	}
+	if before.IconPath != "" {
+		_ = os.Remove(before.IconPath)
+	}
+	s.audit.Record(models.AuditActionUpdate, "badges", badge.ID, before, badge)
 
-	// Build leaderboard response with top N entries
-	var leaderboard []dto.LeaderboardEntry
-	for i, uc := range userCounts {
-		// Stop after reaching limit
-		if i >= limit {
-			break
-		}
-		leaderboard = append(leaderboard, dto.LeaderboardEntry{
-			UserID:    uc.User.ID,
-			Username:  uc.User.Username,
-			FullName:  uc.User.FullName,
-			Avatar:    uc.User.Avatar,
-			Score:     int(uc.Count),
-			ScoreType: "sessions",
-		})
+	return dto.MapBadgeToResponse(badge), nil
+}
+
+// GetBadgeIconPath gets the stored file of a badge's uploaded icon
+func (s *BadgeService) GetBadgeIconPath(id uint) (string, error) {
+	badge, err := s.badgeRepo.GetBadgeByID(id)
+	if err != nil {
+		return "", errors.New("badge not found")
+	}
+	if badge.IconPath == "" {
+		return "", errors.New("badge icon not found")
 	}
+	return badge.IconPath, nil
+}
 
-	return leaderboard, nil
+// StartAwardRun evaluates a badge against every user who doesn't have it, in the background
+// Only one run per badge happens at a time; starting another returns the one in progress
+func (s *BadgeService) StartAwardRun(id uint) (*dto.BadgeAwardRunResponse, error) {
+	badge, err := s.badgeRepo.GetBadgeByID(id)
+	if err != nil {
+		return nil, errors.New("badge not found")
+	}
+	if !badge.IsActive {
+		return nil, errors.New("inactive badges can't be awarded")
+	}
+	if _, err := models.ParseBadgeRequirements(badge.Requirements); err != nil {
+		return nil, fmt.Errorf("invalid requirements: %w", err)
+	}
+	return s.startAwardRun(badge).snapshot(), nil
 }
 
-// GetRatingLeaderboard retrieves top users ranked by average rating (teacher + student average)
-// Performance: O(n log n) due to sort.Slice
-// Caching: Results should be cached for 5-10 minutes to reduce computation
-//
-// Algorithm:
-// 1. Fetch all users from database
-// 2. Calculate average rating for each user: (teacherRating + studentRating) / 2
-// 3. Filter users with at least some rating (> 0)
-// 4. Sort by average rating (descending)
-// 5. Return top N entries
-//
-// Rating Calculation:
-//   - Combines both teaching and learning ratings equally
-//   - Provides balanced view of user quality
-//   - Only includes users with at least one rating
-//
-// Parameters:
-//   - limit: Maximum number of entries (clamped to 1-100, default 10)
-//
-// Returns:
-//   - []LeaderboardEntry: Sorted leaderboard entries (score stored as int: 450 = 4.5 stars)
-//   - error: If database fetch fails
-//
-// Example:
-//   leaderboard, err := badgeService.GetRatingLeaderboard(10)
-//   // Returns top 10 users by average rating
-func (s *BadgeService) GetRatingLeaderboard(limit int) ([]dto.LeaderboardEntry, error) {
-	// Validate and normalize limit parameter
-	if limit <= 0 || limit > 100 {
-		limit = 10
+// GetAwardRun gets the latest retroactive award run of a badge
+func (s *BadgeService) GetAwardRun(id uint) (*dto.BadgeAwardRunResponse, error) {
+	badgeAwardRunsMu.Lock()
+	run, ok := badgeAwardRuns[id]
+	badgeAwardRunsMu.Unlock()
+	if !ok {
+		return nil, errors.New("award run not found")
 	}
+	return run.snapshot(), nil
+}
 
-	// Fetch all users from database
-	users, err := s.userRepo.GetAll()
+// RevokeBadge takes a badge away from a user and, unless the bonus is kept,
+// claws back its bonus credits through the ledger
+// The revoked badge isn't awarded to the user again
+func (s *BadgeService) RevokeBadge(adminID, badgeID uint, req *dto.RevokeBadgeRequest) (*dto.RevokeBadgeResponse, error) {
+	badge, err := s.badgeRepo.GetBadgeByID(badgeID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch users: %w", err)
-	}
-
-	// Helper struct to hold user with their calculated average rating
-	type userRating struct {
-		User      models.User
-		AvgRating float64
-	}
-	var userRatings []userRating
-
-	// Calculate average rating for each user
-	// Average combines both teaching and learning ratings equally
-	for _, user := range users {
-		// Calculate average: (teacher_rating + student_rating) / 2
-		// This gives balanced view of user quality in both roles
-		avgRating := (user.AverageRatingAsTeacher + user.AverageRatingAsStudent) / 2
-		// Only include users with at least some rating
-		if avgRating > 0 {
-			userRatings = append(userRatings, userRating{
-				User:      user,
-				AvgRating: avgRating,
-			})
-		}
+		return nil, errors.New("badge not found")
 	}
 
-	// Sort by average rating in descending order using efficient sort.Slice
-	// Time complexity: O(n log n) instead of O(n²) with bubble sort
-	sort.Slice(userRatings, func(i, j int) bool {
-		return userRatings[i].AvgRating > userRatings[j].AvgRating
-	})
+	userBadge, ledger, err := s.badgeRepo.RevokeBadge(req.UserID, badgeID, adminID, req.Reason, !req.KeepBonus)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(models.AuditActionDelete, "user_badges", userBadge.ID, userBadge, nil)
+
+	result := &dto.RevokeBadgeResponse{
+		UserID:    req.UserID,
+		BadgeID:   badgeID,
+		RevokedAt: time.Now().Format(time.RFC3339),
+	}
+	message := fmt.Sprintf("Your %s badge was revoked: %s", badge.Name, req.Reason)
+	if ledger != nil {
+		result.ClawedBack = -ledger.Amount
+		result.TransactionID = &ledger.ID
+		message = fmt.Sprintf("Your %s badge was revoked and %.2f bonus credits were taken back: %s", badge.Name, result.ClawedBack, req.Reason)
+	}
+
+	_, _ = s.notificationService.CreateNotification(
+		req.UserID,
+		models.NotificationTypeAchievement,
+		"Badge Revoked",
+		message,
+		map[string]interface{}{
+			"badgeID":    badgeID,
+			"badgeName":  badge.Name,
+			"clawedBack": result.ClawedBack,
+		},
+	)
+
+	return result, nil
+}
 
+// startAwardRun starts a retroactive award run of the badge unless one is in progress
+func (s *BadgeService) startAwardRun(badge *models.Badge) *badgeAwardRun {
+	badgeAwardRunsMu.Lock()
+	if run, ok := badgeAwardRuns[badge.ID]; ok && run.snapshot().Running {
+		badgeAwardRunsMu.Unlock()
+		return run
+	}
+	run := &badgeAwardRun{result: dto.BadgeAwardRunResponse{
+		BadgeID:   badge.ID,
+		Running:   true,
+		StartedAt: time.Now().Format(time.RFC3339),
+	}}
+	badgeAwardRuns[badge.ID] = run
+	badgeAwardRunsMu.Unlock()
+
+	go func() {
+		if err := s.awardRetroactively(badge.ID, run); err != nil {
+			log.Printf("Retroactive award of badge %d stopped: %v", badge.ID, err)
+		}
+		result := run.snapshot()
+		log.Printf("Retroactive award of badge %d (%s): %d users evaluated, %d awarded",
+			badge.ID, badge.Name, result.Evaluated, result.Awarded)
+	}()
+	return run
+}
+
+// awardRetroactively walks every active user without the badge in ID order,
+// awarding it to those who meet its requirements and saving progress for the rest
+func (s *BadgeService) awardRetroactively(badgeID uint, run *badgeAwardRun) error {
+	defer func() {
+		run.mu.Lock()
+		run.result.Running = false
+		run.mu.Unlock()
+	}()
+
+	match := func(badge *models.Badge, _ *models.BadgeRule) bool { return badge.ID == badgeID }
 
-	// Build leaderboard response with top N entries
-	var leaderboard []dto.LeaderboardEntry
-	for i, ur := range userRatings {
-		// Stop after reaching limit
-		if i >= limit {
-			break
+	var afterID uint
+	for {
+		userIDs, err := s.badgeRepo.GetUserIDsWithoutBadge(badgeID, afterID, badgeAwardBatchSize)
+		if err != nil {
+			return err
 		}
-		leaderboard = append(leaderboard, dto.LeaderboardEntry{
-			UserID:    ur.User.ID,
-			Username:  ur.User.Username,
-			FullName:  ur.User.FullName,
-			Avatar:    ur.User.Avatar,
-			// Store rating as integer: multiply by 100 (e.g., 4.5 stars = 450)
-			// This avoids floating-point precision issues in JSON
-			Score:     int(ur.AvgRating * 100),
-			ScoreType: "rating",
-		})
+		if len(userIDs) == 0 {
+			return nil
+		}
+
+		for _, userID := range userIDs {
+			awarded, err := s.evaluateBadges(userID, match)
+			if err != nil {
+				log.Printf("Failed to evaluate badge %d for user %d: %v", badgeID, userID, err)
+			}
+
+			earned := false
+			for _, userBadge := range awarded {
+				earned = earned || userBadge.BadgeID == badgeID
+			}
+			run.mu.Lock()
+			run.result.Evaluated++
+			if earned {
+				run.result.Awarded++
+			}
+			run.mu.Unlock()
+		}
+		afterID = userIDs[len(userIDs)-1]
 	}
+}
 
-	return leaderboard, nil
+// checkBadgeName rejects a name another badge uses, including deleted badges
+func (s *BadgeService) checkBadgeName(name string, badgeID uint) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("badge name is required")
+	}
+	existing, err := s.badgeRepo.GetBadgeByName(strings.TrimSpace(name))
+	if err == nil && existing.ID != badgeID {
+		return errors.New("badge name already in use")
+	}
+	return nil
 }
 
-// GetCreditLeaderboard retrieves top users ranked by total credits earned from teaching
-// Performance: O(n log n) due to sort.Slice
-// Caching: Results should be cached for 5-10 minutes to reduce computation
-//
-// Algorithm:
-// 1. Fetch all users from database
-// 2. Filter users with at least 1 credit earned
-// 3. Sort by total credits earned (descending)
-// 4. Return top N entries
-//
-// Credits Earned:
-//   - Represents total time credits user has earned from teaching
-//   - Higher value indicates more teaching experience and productivity
-//   - Only includes completed sessions
-//
-// Parameters:
-//   - limit: Maximum number of entries (clamped to 1-100, default 10)
-//
-// Returns:
-//   - []LeaderboardEntry: Sorted leaderboard entries
-//   - error: If database fetch fails
-//
-// Example:
-//   leaderboard, err := badgeService.GetCreditLeaderboard(10)
-//   // Returns top 10 users by credits earned
-func (s *BadgeService) GetCreditLeaderboard(limit int) ([]dto.LeaderboardEntry, error) {
-	// Validate and normalize limit parameter
-	if limit <= 0 || limit > 100 {
-		limit = 10
+// saveIconFile writes an uploaded badge icon under the icon directory with a random name
+func (s *BadgeService) saveIconFile(file *multipart.FileHeader) (string, error) {
+	maxSize := int64(s.config.MaxIconSizeKB) * 1024
+	if file.Size > maxSize {
+		return "", fmt.Errorf("icon exceeds %dKB limit", s.config.MaxIconSizeKB)
 	}
 
-	// Fetch all users from database
-	users, err := s.userRepo.GetAll()
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch users: %w", err)
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if !badgeIconExtensions[ext] {
+		return "", errors.New("icon must be an image (png, jpg, webp, gif)")
 	}
 
-	// Helper struct to hold user with their total credits earned
-	type userCredit struct {
-		User   models.User
-		Credit int
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
 	}
-	var userCredits []userCredit
 
-	// Collect users with at least some credits earned
-	// Only include active teachers to reduce leaderboard noise
-	for _, user := range users {
-		if user.TotalEarned > 0 {
-			userCredits = append(userCredits, userCredit{
-				User:   user,
-				Credit: int(user.TotalEarned),
-			})
-		}
+	if err := os.MkdirAll(s.config.IconDir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to store icon: %w", err)
 	}
+	path := filepath.Join(s.config.IconDir, hex.EncodeToString(buf)+ext)
 
-	// Sort by credits earned in descending order using efficient sort.Slice
-	// Time complexity: O(n log n) instead of O(n²) with bubble sort
-	sort.Slice(userCredits, func(i, j int) bool {
-		return userCredits[i].Credit > userCredits[j].Credit
-	})
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
 
-	// Build leaderboard response with top N entries
-	var leaderboard []dto.LeaderboardEntry
-	for i, uc := range userCredits {
-		// Stop after reaching limit
-		if i >= limit {
-			break
-		}
-		leaderboard = append(leaderboard, dto.LeaderboardEntry{
-			UserID:    uc.User.ID,
-			Username:  uc.User.Username,
-			FullName:  uc.User.FullName,
-			Avatar:    uc.User.Avatar,
-			Score:     uc.Credit,
-			ScoreType: "credits",
-		})
+	dst, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", fmt.Errorf("failed to store icon: %w", err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		_ = os.Remove(path)
+		return "", fmt.Errorf("failed to store icon: %w", err)
 	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(path)
+		return "", fmt.Errorf("failed to store icon: %w", err)
+	}
+
+	return path, nil
+}
+
+// normalizeBadgeRequirements validates requirements JSON and compacts it for storage
+func normalizeBadgeRequirements(raw json.RawMessage) (string, error) {
+	if _, err := models.ParseBadgeRequirements(string(raw)); err != nil {
+		return "", fmt.Errorf("invalid requirements: %w", err)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return "", fmt.Errorf("invalid requirements: %w", err)
+	}
+	return compact.String(), nil
+}
 
-	return leaderboard, nil
+// GetBadgeMetrics lists the metrics badge requirements can use
+func (s *BadgeService) GetBadgeMetrics() []models.BadgeMetric {
+	return models.BadgeMetrics()
 }
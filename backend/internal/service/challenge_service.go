@@ -0,0 +1,469 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/config"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+)
+
+// challengeRefreshMu keeps progress runs from overlapping
+var challengeRefreshMu sync.Mutex
+
+// ChallengeService handles community challenges
+type ChallengeService struct {
+	challengeRepo       *repository.ChallengeRepository
+	badgeRepo           *repository.BadgeRepository
+	badgeService        *BadgeService
+	notificationService *NotificationService
+	config              config.ChallengeConfig
+	audit               *AuditScope
+}
+
+// NewChallengeService creates a new challenge service
+func NewChallengeService(
+	challengeRepo *repository.ChallengeRepository,
+	badgeRepo *repository.BadgeRepository,
+	badgeService *BadgeService,
+	notificationService *NotificationService,
+	cfg config.ChallengeConfig,
+) *ChallengeService {
+	return &ChallengeService{
+		challengeRepo:       challengeRepo,
+		badgeRepo:           badgeRepo,
+		badgeService:        badgeService,
+		notificationService: notificationService,
+		config:              cfg,
+	}
+}
+
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *ChallengeService) WithAudit(audit *AuditScope) *ChallengeService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
+// ListChallenges lists the challenges users can see
+func (s *ChallengeService) ListChallenges(query *dto.ChallengeQuery) ([]dto.ChallengeResponse, int64, error) {
+	return s.listChallenges(query, true)
+}
+
+// ListChallengesForAdmin lists every challenge, including switched off ones
+func (s *ChallengeService) ListChallengesForAdmin(query *dto.ChallengeQuery) ([]dto.ChallengeResponse, int64, error) {
+	return s.listChallenges(query, false)
+}
+
+func (s *ChallengeService) listChallenges(query *dto.ChallengeQuery, activeOnly bool) ([]dto.ChallengeResponse, int64, error) {
+	limit, offset := challengePage(query.Limit, query.Offset)
+	now := time.Now()
+
+	challenges, total, err := s.challengeRepo.List(query.Status, now, activeOnly, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch challenges: %w", err)
+	}
+	return dto.MapChallengesToResponse(challenges, now), total, nil
+}
+
+// GetChallenge gets a challenge users can see
+func (s *ChallengeService) GetChallenge(id uint) (*dto.ChallengeResponse, error) {
+	challenge, err := s.challengeRepo.GetByID(id)
+	if err != nil || !challenge.IsActive {
+		return nil, errors.New("challenge not found")
+	}
+	return dto.MapChallengeToResponse(challenge, time.Now()), nil
+}
+
+// CreateChallenge creates a challenge
+func (s *ChallengeService) CreateChallenge(adminID uint, req *dto.CreateChallengeRequest) (*dto.ChallengeResponse, error) {
+	challenge := &models.Challenge{
+		Title:         strings.TrimSpace(req.Title),
+		Description:   req.Description,
+		Metric:        models.ChallengeMetric(req.Metric),
+		SkillCategory: models.SkillCategory(req.SkillCategory),
+		Target:        req.Target,
+		StartsAt:      req.StartsAt.UTC(),
+		EndsAt:        req.EndsAt.UTC(),
+		RewardCredits: req.RewardCredits,
+		RewardBadgeID: req.RewardBadgeID,
+		IsActive:      true,
+		CreatedBy:     adminID,
+	}
+	if err := s.validateChallenge(challenge); err != nil {
+		return nil, err
+	}
+	if !challenge.EndsAt.After(time.Now()) {
+		return nil, errors.New("challenge must end in the future")
+	}
+
+	if err := s.challengeRepo.Create(challenge); err != nil {
+		return nil, fmt.Errorf("failed to create challenge: %w", err)
+	}
+	s.audit.Record(models.AuditActionCreate, "challenges", challenge.ID, nil, challenge)
+	return s.reloadChallenge(challenge)
+}
+
+// UpdateChallenge edits a challenge that hasn't ended
+// Once a challenge is running its goal, start and rewards are fixed, so
+// participants who already finished were held to the same terms
+func (s *ChallengeService) UpdateChallenge(id uint, req *dto.UpdateChallengeRequest) (*dto.ChallengeResponse, error) {
+	challenge, err := s.challengeRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("challenge not found")
+	}
+	now := time.Now()
+	if !now.Before(challenge.EndsAt) {
+		return nil, errors.New("ended challenges can't be changed")
+	}
+	before := *challenge
+	started := !now.Before(challenge.StartsAt)
+
+	if started && (req.Metric != nil || req.SkillCategory != nil || req.Target != nil ||
+		req.StartsAt != nil || req.RewardCredits != nil || req.RewardBadgeID != nil) {
+		return nil, errors.New("only the title, description, end and active flag of a running challenge can be changed")
+	}
+
+	if req.Title != nil {
+		challenge.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Description != nil {
+		challenge.Description = *req.Description
+	}
+	if req.Metric != nil {
+		challenge.Metric = models.ChallengeMetric(*req.Metric)
+	}
+	if req.SkillCategory != nil {
+		challenge.SkillCategory = models.SkillCategory(*req.SkillCategory)
+	}
+	if req.Target != nil {
+		challenge.Target = *req.Target
+	}
+	if req.StartsAt != nil {
+		challenge.StartsAt = req.StartsAt.UTC()
+	}
+	if req.EndsAt != nil {
+		if !req.EndsAt.After(now) {
+			return nil, errors.New("challenge must end in the future")
+		}
+		challenge.EndsAt = req.EndsAt.UTC()
+	}
+	if req.RewardCredits != nil {
+		challenge.RewardCredits = *req.RewardCredits
+	}
+	if req.RewardBadgeID != nil {
+		challenge.RewardBadgeID = req.RewardBadgeID
+		if *req.RewardBadgeID == 0 {
+			challenge.RewardBadgeID = nil
+		}
+		challenge.RewardBadge = nil
+	}
+	if req.IsActive != nil {
+		challenge.IsActive = *req.IsActive
+	}
+
+	if err := s.validateChallenge(challenge); err != nil {
+		return nil, err
+	}
+	if err := s.challengeRepo.Update(challenge); err != nil {
+		return nil, fmt.Errorf("failed to update challenge: %w", err)
+	}
+	s.audit.Record(models.AuditActionUpdate, "challenges", challenge.ID, before, challenge)
+	return s.reloadChallenge(challenge)
+}
+
+// DeleteChallenge deletes a challenge nobody joined
+// Challenges with participants are switched off instead so their history is kept
+func (s *ChallengeService) DeleteChallenge(id uint) error {
+	challenge, err := s.challengeRepo.GetByID(id)
+	if err != nil {
+		return errors.New("challenge not found")
+	}
+	if challenge.ParticipantCount > 0 {
+		return errors.New("challenge has participants; deactivate it instead")
+	}
+
+	if err := s.challengeRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete challenge: %w", err)
+	}
+	s.audit.Record(models.AuditActionDelete, "challenges", challenge.ID, challenge, nil)
+	return nil
+}
+
+// ListParticipants lists a challenge's participants, furthest along first
+func (s *ChallengeService) ListParticipants(id uint, limit, offset int) ([]dto.ChallengeParticipationResponse, int64, error) {
+	challenge, err := s.challengeRepo.GetByID(id)
+	if err != nil {
+		return nil, 0, errors.New("challenge not found")
+	}
+	limit, offset = challengePage(limit, offset)
+
+	participants, total, err := s.challengeRepo.ListParticipants(id, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch participants: %w", err)
+	}
+
+	now := time.Now()
+	responses := make([]dto.ChallengeParticipationResponse, len(participants))
+	for i := range participants {
+		responses[i] = *dto.MapChallengeParticipantToResponse(&participants[i], challenge.Target, now)
+	}
+	return responses, total, nil
+}
+
+// JoinChallenge enrols a user in a challenge that hasn't ended
+// Sessions completed since the challenge started count, including those before joining
+func (s *ChallengeService) JoinChallenge(userID, id uint) (*dto.ChallengeParticipationResponse, error) {
+	challenge, err := s.challengeRepo.GetByID(id)
+	if err != nil || !challenge.IsActive {
+		return nil, errors.New("challenge not found")
+	}
+	now := time.Now()
+	if !now.Before(challenge.EndsAt) {
+		return nil, errors.New("challenge has ended")
+	}
+
+	participant := &models.ChallengeParticipant{ChallengeID: id, UserID: userID}
+	if err := s.challengeRepo.AddParticipant(participant); err != nil {
+		return nil, err
+	}
+
+	if challenge.IsRunning(now) {
+		if _, err := s.evaluateParticipant(participant, challenge); err != nil {
+			log.Printf("Failed to evaluate challenge %d for user %d: %v", id, userID, err)
+		}
+		if updated, err := s.challengeRepo.GetParticipant(id, userID); err == nil {
+			participant = updated
+		}
+	}
+
+	participant.Challenge = *challenge
+	return dto.MapChallengeParticipantToResponse(participant, challenge.Target, now), nil
+}
+
+// LeaveChallenge withdraws a user from a challenge they haven't completed
+func (s *ChallengeService) LeaveChallenge(userID, id uint) error {
+	challenge, err := s.challengeRepo.GetByID(id)
+	if err != nil {
+		return errors.New("challenge not found")
+	}
+	if !time.Now().Before(challenge.EndsAt) {
+		return errors.New("challenge has ended")
+	}
+	return s.challengeRepo.RemoveParticipant(id, userID)
+}
+
+// GetUserChallenges lists every challenge a user joined with their progress
+func (s *ChallengeService) GetUserChallenges(userID uint) ([]dto.ChallengeParticipationResponse, error) {
+	participants, err := s.challengeRepo.ListUserParticipations(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch challenges: %w", err)
+	}
+
+	now := time.Now()
+	responses := make([]dto.ChallengeParticipationResponse, len(participants))
+	for i := range participants {
+		responses[i] = *dto.MapChallengeParticipantToResponse(&participants[i], 0, now)
+	}
+	return responses, nil
+}
+
+// RecordActivity updates the running challenges of users who just completed a session
+// Failures are logged rather than returned so they never fail the session flow
+func (s *ChallengeService) RecordActivity(userIDs ...uint) {
+	if s == nil {
+		return
+	}
+	now := time.Now()
+	for _, userID := range userIDs {
+		participants, err := s.challengeRepo.GetOpenParticipations(userID, now)
+		if err != nil {
+			log.Printf("Failed to fetch challenges of user %d: %v", userID, err)
+			continue
+		}
+		for i := range participants {
+			if _, err := s.evaluateParticipant(&participants[i], &participants[i].Challenge); err != nil {
+				log.Printf("Failed to evaluate challenge %d for user %d: %v", participants[i].ChallengeID, userID, err)
+			}
+		}
+	}
+}
+
+// StartProgressJob periodically recomputes challenge progress
+func (s *ChallengeService) StartProgressJob() {
+	go func() {
+		ticker := time.NewTicker(s.config.ProgressInterval)
+		defer ticker.Stop()
+
+		for {
+			if result, err := s.RefreshProgress(time.Now()); err != nil {
+				log.Printf("Failed to refresh challenge progress: %v", err)
+			} else if result.Completed > 0 || result.Finalized > 0 {
+				log.Printf("Refreshed challenge progress: %d completed, %d challenges settled",
+					result.Completed, result.Finalized)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// RefreshProgress recomputes the progress of every unfinished participant in
+// challenges that have started, catching activity the session hooks missed
+// Challenges that ended are evaluated one last time and then settled.
+func (s *ChallengeService) RefreshProgress(now time.Time) (*dto.ChallengeRefreshResponse, error) {
+	challengeRefreshMu.Lock()
+	defer challengeRefreshMu.Unlock()
+
+	challenges, err := s.challengeRepo.GetChallengesToEvaluate(now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch challenges: %w", err)
+	}
+
+	result := &dto.ChallengeRefreshResponse{}
+	for i := range challenges {
+		challenge := &challenges[i]
+
+		var afterID uint
+		for {
+			participants, err := s.challengeRepo.GetOpenParticipants(challenge.ID, afterID, 200)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch participants: %w", err)
+			}
+			if len(participants) == 0 {
+				break
+			}
+			for j := range participants {
+				completed, err := s.evaluateParticipant(&participants[j], challenge)
+				if err != nil {
+					log.Printf("Failed to evaluate challenge %d for user %d: %v", challenge.ID, participants[j].UserID, err)
+					continue
+				}
+				result.Evaluated++
+				if completed {
+					result.Completed++
+				}
+			}
+			afterID = participants[len(participants)-1].ID
+		}
+
+		if !now.Before(challenge.EndsAt) {
+			if err := s.challengeRepo.MarkFinalized(challenge.ID, now); err != nil {
+				return nil, fmt.Errorf("failed to settle challenge %d: %w", challenge.ID, err)
+			}
+			result.Finalized++
+		}
+	}
+	return result, nil
+}
+
+// evaluateParticipant recomputes a participant's progress and rewards them once they
+// reach the target. Returns whether this call completed the challenge.
+func (s *ChallengeService) evaluateParticipant(participant *models.ChallengeParticipant, challenge *models.Challenge) (bool, error) {
+	progress, err := s.challengeRepo.ComputeProgress(participant.UserID, challenge.Metric, challenge.SkillCategory, challenge.StartsAt, challenge.EndsAt)
+	if err != nil {
+		return false, err
+	}
+	if progress < challenge.Target {
+		if progress == participant.Progress {
+			return false, nil
+		}
+		return false, s.challengeRepo.UpdateProgress(participant.ID, progress)
+	}
+
+	completed, ledger, err := s.challengeRepo.CompleteParticipant(participant, challenge, progress)
+	if err != nil || !completed {
+		return false, err
+	}
+	s.rewardParticipant(participant.UserID, challenge, ledger)
+	return true, nil
+}
+
+// rewardParticipant awards a completed challenge's badge and notifies the user
+// Credits were already paid when the completion was recorded
+func (s *ChallengeService) rewardParticipant(userID uint, challenge *models.Challenge, ledger *models.Transaction) {
+	if challenge.RewardBadge != nil && s.badgeService != nil {
+		s.badgeService.GrantBadge(userID, challenge.RewardBadge)
+	}
+
+	message := fmt.Sprintf("You completed the \"%s\" challenge!", challenge.Title)
+	data := map[string]interface{}{
+		"challengeID":    challenge.ID,
+		"challengeTitle": challenge.Title,
+	}
+	if ledger != nil {
+		message += fmt.Sprintf(" %.1f credits have been added to your balance.", ledger.Amount)
+		data["credits"] = ledger.Amount
+		data["transactionID"] = ledger.ID
+	}
+	_, _ = s.notificationService.CreateNotification(
+		userID,
+		models.NotificationTypeAchievement,
+		"Challenge Complete! 🎯",
+		message,
+		data,
+	)
+}
+
+// validateChallenge checks a challenge's goal, window and rewards
+func (s *ChallengeService) validateChallenge(challenge *models.Challenge) error {
+	if challenge.Title == "" {
+		return errors.New("title is required")
+	}
+	if !isValidChallengeMetric(challenge.Metric) {
+		return fmt.Errorf("unknown metric %q", challenge.Metric)
+	}
+	if challenge.SkillCategory != "" && !isValidSkillCategory(challenge.SkillCategory) {
+		return fmt.Errorf("unknown skill category %q", challenge.SkillCategory)
+	}
+	if challenge.Target <= 0 {
+		return errors.New("target must be positive")
+	}
+	if !challenge.EndsAt.After(challenge.StartsAt) {
+		return errors.New("challenge must end after it starts")
+	}
+	if challenge.RewardCredits < 0 {
+		return errors.New("reward credits can't be negative")
+	}
+	if challenge.RewardBadgeID != nil {
+		badge, err := s.badgeRepo.GetBadgeByID(*challenge.RewardBadgeID)
+		if err != nil || !badge.IsActive {
+			return errors.New("reward badge not found")
+		}
+	}
+	return nil
+}
+
+// reloadChallenge reads a saved challenge back with its reward badge
+func (s *ChallengeService) reloadChallenge(challenge *models.Challenge) (*dto.ChallengeResponse, error) {
+	if saved, err := s.challengeRepo.GetByID(challenge.ID); err == nil {
+		challenge = saved
+	}
+	return dto.MapChallengeToResponse(challenge, time.Now()), nil
+}
+
+// isValidChallengeMetric reports whether challenges can count the metric
+func isValidChallengeMetric(metric models.ChallengeMetric) bool {
+	for _, m := range models.ChallengeMetrics {
+		if m == metric {
+			return true
+		}
+	}
+	return false
+}
+
+// challengePage clamps a page of challenges or participants
+func challengePage(limit, offset int) (int, int) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
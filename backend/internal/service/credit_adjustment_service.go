@@ -0,0 +1,228 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CreditAdjustmentService handles admin credit grants and deductions
+// Implements maker-checker: adjustments whose absolute amount exceeds the
+// approval threshold stay pending until a different admin approves them
+type CreditAdjustmentService struct {
+	adjustmentRepo      *repository.CreditAdjustmentRepository
+	userRepo            *repository.UserRepository
+	notificationService *NotificationService
+	approvalThreshold   float64
+	audit               *AuditScope
+}
+
+// NewCreditAdjustmentService creates a new credit adjustment service
+func NewCreditAdjustmentService(
+	adjustmentRepo *repository.CreditAdjustmentRepository,
+	userRepo *repository.UserRepository,
+	notificationService *NotificationService,
+	approvalThreshold float64,
+) *CreditAdjustmentService {
+	return &CreditAdjustmentService{
+		adjustmentRepo:      adjustmentRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		approvalThreshold:   approvalThreshold,
+	}
+}
+
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *CreditAdjustmentService) WithAudit(audit *AuditScope) *CreditAdjustmentService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
+// RequestAdjustment creates a credit adjustment on behalf of an admin
+//
+// Flow:
+//   1. Validates target user exists and amount is non-zero
+//   2. Records the adjustment with a mandatory reason
+//   3. If |amount| <= threshold: applies immediately
+//   4. Otherwise: leaves it pending for a second admin
+//
+// Parameters:
+//   - adminID: Admin creating the adjustment (the "maker")
+//   - req: Target user, signed amount and reason
+//
+// Returns:
+//   - *CreditAdjustment: Created adjustment (applied or pending)
+//   - error: If validation fails or balance would go negative
+func (s *CreditAdjustmentService) RequestAdjustment(adminID uint, req *dto.CreateCreditAdjustmentRequest) (*models.CreditAdjustment, error) {
+	if req.Amount == 0 || math.IsNaN(req.Amount) || math.IsInf(req.Amount, 0) {
+		return nil, errors.New("amount must be a non-zero number")
+	}
+
+	if _, err := s.userRepo.GetByID(req.UserID); err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	adjustment := &models.CreditAdjustment{
+		UserID:           req.UserID,
+		Amount:           req.Amount,
+		Reason:           req.Reason,
+		Status:           models.AdjustmentPending,
+		RequestedBy:      adminID,
+		RequiresApproval: math.Abs(req.Amount) > s.approvalThreshold,
+	}
+
+	if err := s.adjustmentRepo.Create(adjustment); err != nil {
+		return nil, fmt.Errorf("failed to create adjustment: %w", err)
+	}
+
+	// Small adjustments take effect immediately
+	if !adjustment.RequiresApproval {
+		if err := s.apply(adjustment.ID, nil, adminID, ""); err != nil {
+			_ = s.adjustmentRepo.Reject(adjustment.ID, adminID, "auto-apply failed: "+err.Error())
+			return nil, err
+		}
+	}
+
+	created, err := s.adjustmentRepo.GetByID(adjustment.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(models.AuditActionCreate, "credit_adjustments", created.ID, nil, created)
+	return created, nil
+}
+
+// ApproveAdjustment approves and applies a pending adjustment
+// The approving admin must differ from the admin who requested it
+func (s *CreditAdjustmentService) ApproveAdjustment(adminID, adjustmentID uint, note string) (*models.CreditAdjustment, error) {
+	adjustment, err := s.getPending(adjustmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if adjustment.RequestedBy == adminID {
+		return nil, errors.New("adjustment must be approved by a different admin")
+	}
+
+	if err := s.apply(adjustment.ID, &adminID, adminID, note); err != nil {
+		return nil, err
+	}
+
+	return s.recordReview(adjustment)
+}
+
+// RejectAdjustment rejects a pending adjustment without moving credits
+// Requesters may withdraw their own adjustment by rejecting it
+func (s *CreditAdjustmentService) RejectAdjustment(adminID, adjustmentID uint, note string) (*models.CreditAdjustment, error) {
+	adjustment, err := s.getPending(adjustmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.adjustmentRepo.Reject(adjustment.ID, adminID, note); err != nil {
+		return nil, err
+	}
+
+	return s.recordReview(adjustment)
+}
+
+// GetAdjustment gets an adjustment with its audit trail
+func (s *CreditAdjustmentService) GetAdjustment(adjustmentID uint) (*models.CreditAdjustment, error) {
+	adjustment, err := s.adjustmentRepo.GetByID(adjustmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("adjustment not found")
+		}
+		return nil, err
+	}
+	return adjustment, nil
+}
+
+// ListAdjustments lists adjustments filtered by status and/or user
+func (s *CreditAdjustmentService) ListAdjustments(status string, userID *uint, limit, offset int) ([]models.CreditAdjustment, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	adjustmentStatus := models.AdjustmentStatus(status)
+	switch adjustmentStatus {
+	case "", models.AdjustmentPending, models.AdjustmentApplied, models.AdjustmentRejected:
+	default:
+		return nil, 0, errors.New("invalid adjustment status")
+	}
+
+	return s.adjustmentRepo.List(adjustmentStatus, userID, limit, offset)
+}
+
+// GetAuditTrail lists adjustment audit events, optionally for one admin
+func (s *CreditAdjustmentService) GetAuditTrail(adminID *uint, limit, offset int) ([]models.CreditAdjustmentEvent, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.adjustmentRepo.ListEvents(adminID, limit, offset)
+}
+
+// getPending loads an adjustment and verifies it is still pending
+func (s *CreditAdjustmentService) getPending(adjustmentID uint) (*models.CreditAdjustment, error) {
+	adjustment, err := s.GetAdjustment(adjustmentID)
+	if err != nil {
+		return nil, err
+	}
+	if !adjustment.IsPending() {
+		return nil, fmt.Errorf("adjustment is already %s", adjustment.Status)
+	}
+	return adjustment, nil
+}
+
+// recordReview reloads a reviewed adjustment and records the status change
+func (s *CreditAdjustmentService) recordReview(before *models.CreditAdjustment) (*models.CreditAdjustment, error) {
+	after, err := s.adjustmentRepo.GetByID(before.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(models.AuditActionUpdate, "credit_adjustments", after.ID, before, after)
+	return after, nil
+}
+
+// apply moves the credits and notifies the affected user
+func (s *CreditAdjustmentService) apply(adjustmentID uint, reviewerID *uint, actorID uint, note string) error {
+	ledger, err := s.adjustmentRepo.Apply(adjustmentID, reviewerID, actorID, note)
+	if err != nil {
+		return err
+	}
+
+	title := "Credits Added by Admin"
+	message := fmt.Sprintf("An admin added %.1f credits to your balance", ledger.Amount)
+	if ledger.Amount < 0 {
+		title = "Credits Deducted by Admin"
+		message = fmt.Sprintf("An admin deducted %.1f credits from your balance", -ledger.Amount)
+	}
+
+	notificationData := map[string]interface{}{
+		"amount":        ledger.Amount,
+		"adjustmentID":  adjustmentID,
+		"transactionID": ledger.ID,
+		"description":   ledger.Description,
+	}
+	_, _ = s.notificationService.CreateNotification(
+		ledger.UserID,
+		models.NotificationTypeCredit,
+		title,
+		message,
+		notificationData,
+	)
+
+	return nil
+}
@@ -15,6 +15,8 @@ type EndorsementService struct {
 	userRepo             *repository.UserRepository
 	skillRepo            *repository.SkillRepository
 	notificationService  *NotificationService
+	xpService            *XPService
+	audit                *AuditScope
 }
 
 // NewEndorsementService creates a new endorsement service
@@ -37,15 +39,24 @@ func NewEndorsementServiceWithNotification(
 	userRepo *repository.UserRepository,
 	skillRepo *repository.SkillRepository,
 	notificationService *NotificationService,
+	xpService *XPService,
 ) *EndorsementService {
 	return &EndorsementService{
 		endorsementRepo:     endorsementRepo,
 		userRepo:            userRepo,
 		skillRepo:           skillRepo,
 		notificationService: notificationService,
+		xpService:           xpService,
 	}
 }
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *EndorsementService) WithAudit(audit *AuditScope) *EndorsementService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
 // CreateEndorsement creates a new endorsement
 func (s *EndorsementService) CreateEndorsement(endorserID uint, req *dto.CreateEndorsementRequest) (*models.Endorsement, error) {
 	// Validate endorser exists
@@ -85,6 +96,11 @@ func (s *EndorsementService) CreateEndorsement(endorserID uint, req *dto.CreateE
 	if err := s.endorsementRepo.CreateEndorsement(endorsement); err != nil {
 		return nil, fmt.Errorf("failed to create endorsement: %w", err)
 	}
+	s.audit.Record(models.AuditActionCreate, "endorsements", endorsement.ID, nil, endorsement)
+
+	// XP: The endorsed user earns XP once per endorser, so removing and repeating
+	// an endorsement earns nothing more
+	s.xpService.Award(endorsement.UserID, models.XPEndorsementReceived, "endorser", endorsement.EndorserID)
 
 	return s.endorsementRepo.GetEndorsementByID(endorsement.ID)
 }
@@ -131,7 +147,11 @@ func (s *EndorsementService) DeleteEndorsement(endorsementID, endorserID uint) e
 		return errors.New("unauthorized")
 	}
 
-	return s.endorsementRepo.DeleteEndorsement(endorsementID)
+	if err := s.endorsementRepo.DeleteEndorsement(endorsementID); err != nil {
+		return err
+	}
+	s.audit.Record(models.AuditActionDelete, "endorsements", endorsement.ID, endorsement, nil)
+	return nil
 }
 
 // GetTopEndorsedSkills gets the most endorsed skills
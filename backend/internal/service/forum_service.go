@@ -14,6 +14,8 @@ type ForumService struct {
 	forumRepo            *repository.ForumRepository
 	userRepo             *repository.UserRepository
 	notificationService  *NotificationService
+	xpService            *XPService
+	audit                *AuditScope
 }
 
 // NewForumService creates a new forum service
@@ -33,14 +35,23 @@ func NewForumServiceWithNotification(
 	forumRepo *repository.ForumRepository,
 	userRepo *repository.UserRepository,
 	notificationService *NotificationService,
+	xpService *XPService,
 ) *ForumService {
 	return &ForumService{
 		forumRepo:           forumRepo,
 		userRepo:            userRepo,
 		notificationService: notificationService,
+		xpService:           xpService,
 	}
 }
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *ForumService) WithAudit(audit *AuditScope) *ForumService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
 // ===== CATEGORY OPERATIONS =====
 
 // GetAllCategories gets all forum categories
@@ -75,6 +86,7 @@ func (s *ForumService) CreateThread(userID uint, req *dto.CreateThreadRequest) (
 	if err := s.forumRepo.CreateThread(thread); err != nil {
 		return nil, fmt.Errorf("failed to create thread: %w", err)
 	}
+	s.audit.Record(models.AuditActionCreate, "forum_threads", thread.ID, nil, thread)
 
 	return s.forumRepo.GetThreadByID(thread.ID)
 }
@@ -128,6 +140,7 @@ func (s *ForumService) UpdateThread(threadID, userID uint, req *dto.UpdateThread
 		return nil, errors.New("unauthorized")
 	}
 
+	before := *thread
 	thread.Title = req.Title
 	thread.Content = req.Content
 	thread.Tags = req.Tags
@@ -135,6 +148,7 @@ func (s *ForumService) UpdateThread(threadID, userID uint, req *dto.UpdateThread
 	if err := s.forumRepo.UpdateThread(thread); err != nil {
 		return nil, fmt.Errorf("failed to update thread: %w", err)
 	}
+	s.audit.Record(models.AuditActionUpdate, "forum_threads", thread.ID, &before, thread)
 
 	return thread, nil
 }
@@ -164,6 +178,57 @@ func (s *ForumService) CreateReply(userID uint, req *dto.CreateReplyRequest) (*m
 	if err := s.forumRepo.CreateReply(reply); err != nil {
 		return nil, fmt.Errorf("failed to create reply: %w", err)
 	}
+	s.audit.Record(models.AuditActionCreate, "forum_replies", reply.ID, nil, reply)
+
+	return reply, nil
+}
+
+// AcceptReply lets a thread's author accept a reply as the answer
+// Accepting another reply moves the mark; XP is awarded once per accepted reply,
+// and authors can't accept their own replies
+func (s *ForumService) AcceptReply(threadID, replyID, userID uint) (*models.ForumReply, error) {
+	thread, err := s.forumRepo.GetThreadByID(threadID)
+	if err != nil {
+		return nil, errors.New("thread not found")
+	}
+	if thread.AuthorID != userID {
+		return nil, errors.New("unauthorized")
+	}
+
+	reply, err := s.forumRepo.GetReplyByID(replyID)
+	if err != nil || reply.ThreadID != threadID {
+		return nil, errors.New("reply not found")
+	}
+	if reply.AuthorID == userID {
+		return nil, errors.New("you can't accept your own reply")
+	}
+	if reply.IsAccepted {
+		return reply, nil
+	}
+
+	before := *thread
+	if err := s.forumRepo.AcceptReply(threadID, replyID); err != nil {
+		return nil, fmt.Errorf("failed to accept reply: %w", err)
+	}
+	thread.AcceptedReplyID = &reply.ID
+	reply.IsAccepted = true
+	s.audit.Record(models.AuditActionUpdate, "forum_threads", thread.ID, &before, thread)
+
+	// XP: The reply's author earns XP for the accepted answer
+	s.xpService.Award(reply.AuthorID, models.XPForumAnswerAccepted, "forum_reply", reply.ID)
+
+	if s.notificationService != nil {
+		_, _ = s.notificationService.CreateNotification(
+			reply.AuthorID,
+			models.NotificationTypeSocial,
+			"Answer Accepted",
+			fmt.Sprintf("Your reply to \"%s\" was accepted as the answer", thread.Title),
+			map[string]interface{}{
+				"threadID": thread.ID,
+				"replyID":  reply.ID,
+			},
+		)
+	}
 
 	return reply, nil
 }
@@ -187,6 +252,7 @@ func (s *ForumService) DeleteReply(replyID, userID uint) error {
 	if err := s.forumRepo.DeleteReply(replyID); err != nil {
 		return fmt.Errorf("failed to delete reply: %w", err)
 	}
+	s.audit.Record(models.AuditActionDelete, "forum_replies", replyID, nil, nil)
 
 	return nil
 }
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/config"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Signal weights; a score is the capped sum of the weights of triggered signals
+const (
+	fraudWeightRepeatedPair  = 0.25
+	fraudWeightShortDuration = 0.35
+	fraudWeightSameIP        = 0.35
+	fraudWeightReciprocal    = 0.2
+	fraudWeightBookingLoop   = 0.2
+)
+
+// FraudAssessment is the result of scoring a session or a user pair
+type FraudAssessment struct {
+	Score   float64                        `json:"score"`
+	Flagged bool                           `json:"flagged"`
+	Signals map[models.FraudSignal]float64 `json:"signals"` // Signal -> contribution to score
+}
+
+// PairAssessment is a fraud assessment for a pair of users
+type PairAssessment struct {
+	UserA uint                  `json:"user_a"`
+	UserB uint                  `json:"user_b"`
+	Stats *repository.PairStats `json:"stats"`
+	FraudAssessment
+}
+
+// FraudService detects credit farming between users
+// Scores sessions at completion time and pairs of users on demand
+type FraudService struct {
+	fraudRepo *repository.FraudRepository
+	config    config.FraudConfig
+}
+
+// NewFraudService creates a new fraud service
+func NewFraudService(fraudRepo *repository.FraudRepository, cfg config.FraudConfig) *FraudService {
+	return &FraudService{
+		fraudRepo: fraudRepo,
+		config:    cfg,
+	}
+}
+
+// ScoreSession scores a session that is being completed
+//
+// Signals:
+//   - short_duration: StartedAt→CompletedAt below ShortSessionRatio of booked duration
+//   - same_ip: teacher and student checked in from the same IP
+//   - repeated_pair: teacher already taught this student RepeatedPairThreshold times in the window
+//   - reciprocal: student also taught the teacher in the window
+//   - booking_loop: credits cycle back to the student through a third user
+//
+// Parameters:
+//   - session: Session with CompletedAt set (not yet persisted as completed)
+//
+// Returns:
+//   - *FraudAssessment: Score, triggered signals and whether it crosses FlagThreshold
+//   - error: If history lookup fails
+func (s *FraudService) ScoreSession(session *models.Session) (*FraudAssessment, error) {
+	assessment := &FraudAssessment{Signals: map[models.FraudSignal]float64{}}
+	since := s.windowStart()
+
+	if session.StartedAt != nil && session.CompletedAt != nil && session.Duration > 0 {
+		actual := session.CompletedAt.Sub(*session.StartedAt).Hours()
+		if actual < session.Duration*s.config.ShortSessionRatio {
+			assessment.Signals[models.FraudSignalShortDuration] = fraudWeightShortDuration
+		}
+	}
+
+	if session.TeacherCheckInIP != "" && session.TeacherCheckInIP == session.StudentCheckInIP {
+		assessment.Signals[models.FraudSignalSameIP] = fraudWeightSameIP
+	}
+
+	repeated, err := s.fraudRepo.CountDirectedSessions(session.TeacherID, session.StudentID, since)
+	if err != nil {
+		return nil, err
+	}
+	if repeated >= int64(s.config.RepeatedPairThreshold) {
+		assessment.Signals[models.FraudSignalRepeatedPair] = fraudWeightRepeatedPair
+	}
+
+	reverse, err := s.fraudRepo.CountDirectedSessions(session.StudentID, session.TeacherID, since)
+	if err != nil {
+		return nil, err
+	}
+	if reverse > 0 {
+		assessment.Signals[models.FraudSignalReciprocal] = fraudWeightReciprocal
+	}
+
+	loops, err := s.fraudRepo.CountBookingLoops(session.TeacherID, session.StudentID, since)
+	if err != nil {
+		return nil, err
+	}
+	if loops > 0 {
+		assessment.Signals[models.FraudSignalBookingLoop] = fraudWeightBookingLoop
+	}
+
+	s.finalize(assessment)
+	return assessment, nil
+}
+
+// ScorePair scores the completed session history between two users
+// Ratio-based signals scale with the share of affected sessions
+func (s *FraudService) ScorePair(userA, userB uint) (*PairAssessment, error) {
+	if userA == userB {
+		return nil, errors.New("a pair needs two different users")
+	}
+
+	since := s.windowStart()
+	stats, err := s.fraudRepo.GetPairStats(userA, userB, since, s.config.ShortSessionRatio)
+	if err != nil {
+		return nil, err
+	}
+
+	pair := &PairAssessment{
+		UserA:           userA,
+		UserB:           userB,
+		Stats:           stats,
+		FraudAssessment: FraudAssessment{Signals: map[models.FraudSignal]float64{}},
+	}
+
+	if stats.Sessions == 0 {
+		return pair, nil
+	}
+
+	if stats.Sessions >= int64(s.config.RepeatedPairThreshold) {
+		pair.Signals[models.FraudSignalRepeatedPair] = fraudWeightRepeatedPair
+	}
+	if stats.ShortSessions > 0 {
+		pair.Signals[models.FraudSignalShortDuration] = fraudWeightShortDuration * float64(stats.ShortSessions) / float64(stats.Sessions)
+	}
+	if stats.SameIPSessions > 0 {
+		pair.Signals[models.FraudSignalSameIP] = fraudWeightSameIP * float64(stats.SameIPSessions) / float64(stats.Sessions)
+	}
+	if stats.ATaught > 0 && stats.BTaught > 0 {
+		pair.Signals[models.FraudSignalReciprocal] = fraudWeightReciprocal
+	}
+
+	loopsAB, err := s.fraudRepo.CountBookingLoops(userA, userB, since)
+	if err != nil {
+		return nil, err
+	}
+	loopsBA, err := s.fraudRepo.CountBookingLoops(userB, userA, since)
+	if err != nil {
+		return nil, err
+	}
+	if loopsAB+loopsBA > 0 {
+		pair.Signals[models.FraudSignalBookingLoop] = fraudWeightBookingLoop
+	}
+
+	s.finalize(&pair.FraudAssessment)
+	return pair, nil
+}
+
+// GetSuspiciousPairs scores the most active pairs in the window, riskiest first
+func (s *FraudService) GetSuspiciousPairs(limit int) ([]PairAssessment, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	minSessions := int64(s.config.RepeatedPairThreshold)
+	if minSessions < 1 {
+		minSessions = 1
+	}
+
+	pairs, err := s.fraudRepo.GetFrequentPairs(s.windowStart(), minSessions, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	assessments := make([]PairAssessment, 0, len(pairs))
+	for _, p := range pairs {
+		pair, err := s.ScorePair(p.UserA, p.UserB)
+		if err != nil {
+			return nil, err
+		}
+		assessments = append(assessments, *pair)
+	}
+
+	sort.SliceStable(assessments, func(i, j int) bool {
+		return assessments[i].Score > assessments[j].Score
+	})
+
+	return assessments, nil
+}
+
+// NewFlag builds the review queue entry for a held session
+// It is created together with the session's completion, see SessionRepository.HoldForReview
+func (s *FraudService) NewFlag(session *models.Session, assessment *FraudAssessment) *models.FraudFlag {
+	signals, _ := json.Marshal(assessment.Signals)
+
+	return &models.FraudFlag{
+		SessionID: session.ID,
+		TeacherID: session.TeacherID,
+		StudentID: session.StudentID,
+		Score:     assessment.Score,
+		Signals:   string(signals),
+		Status:    models.FraudFlagPending,
+	}
+}
+
+// GetFlag gets a fraud flag by ID
+func (s *FraudService) GetFlag(flagID uint) (*models.FraudFlag, error) {
+	flag, err := s.fraudRepo.GetFlagByID(flagID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("fraud flag not found")
+		}
+		return nil, err
+	}
+	return flag, nil
+}
+
+// ListFlags lists the review queue filtered by status
+func (s *FraudService) ListFlags(status string, limit, offset int) ([]models.FraudFlag, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	flagStatus := models.FraudFlagStatus(status)
+	switch flagStatus {
+	case "", models.FraudFlagPending, models.FraudFlagCleared, models.FraudFlagConfirmed:
+	default:
+		return nil, 0, errors.New("invalid flag status")
+	}
+
+	return s.fraudRepo.ListFlags(flagStatus, limit, offset)
+}
+
+// ResolveFlag records the admin decision on a pending flag and settles its held session
+func (s *FraudService) ResolveFlag(flagID, adminID uint, status models.FraudFlagStatus, note string) (*models.FraudFlag, *models.Session, error) {
+	flag, session, err := s.fraudRepo.ResolveFlag(flagID, adminID, status, note)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, errors.New("fraud flag not found")
+	}
+	return flag, session, err
+}
+
+// windowStart returns the beginning of the history window
+func (s *FraudService) windowStart() time.Time {
+	return time.Now().AddDate(0, 0, -s.config.WindowDays)
+}
+
+// finalize caps the score and applies the flag threshold
+func (s *FraudService) finalize(assessment *FraudAssessment) {
+	total := 0.0
+	for _, weight := range assessment.Signals {
+		total += weight
+	}
+	assessment.Score = math.Round(math.Min(total, 1)*100) / 100
+	assessment.Flagged = assessment.Score >= s.config.FlagThreshold
+}
@@ -0,0 +1,627 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/config"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+)
+
+// leaderboardAllTimeKey is the period key of all-time leaderboards
+const leaderboardAllTimeKey = "all"
+
+// leaderboardRefreshMu keeps the background job and admin-triggered refreshes from overlapping
+var leaderboardRefreshMu sync.Mutex
+
+// LeaderboardService computes leaderboard snapshots and serves leaderboards from them
+//
+// Every leaderboard type is computed for all time, the current week and month, and the
+// active season, each split into global, school, location and (for session-based
+// types) skill category standings. Reads never compute scores, so they stay fast.
+type LeaderboardService struct {
+	leaderboardRepo *repository.LeaderboardRepository
+	userRepo        *repository.UserRepository
+	config          config.LeaderboardConfig
+	audit           *AuditScope
+}
+
+// NewLeaderboardService creates a new leaderboard service
+func NewLeaderboardService(
+	leaderboardRepo *repository.LeaderboardRepository,
+	userRepo *repository.UserRepository,
+	cfg config.LeaderboardConfig,
+) *LeaderboardService {
+	return &LeaderboardService{
+		leaderboardRepo: leaderboardRepo,
+		userRepo:        userRepo,
+		config:          cfg,
+	}
+}
+
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *LeaderboardService) WithAudit(audit *AuditScope) *LeaderboardService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
+// leaderboardPeriodRef identifies the period a leaderboard request resolved to
+type leaderboardPeriodRef struct {
+	Period models.LeaderboardPeriod
+	Key    string
+	Season *models.LeaderboardSeason
+}
+
+// GetLeaderboard gets a page of a leaderboard from its latest snapshot
+func (s *LeaderboardService) GetLeaderboard(boardType string, query *dto.LeaderboardQuery) (*dto.LeaderboardResponse, error) {
+	lbType, err := parseLeaderboardType(boardType)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := s.resolvePeriod(query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	scope, scopeValue, err := resolveLeaderboardScope(lbType, query.Scope, query.Value, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	offset := query.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	snapshots, total, err := s.leaderboardRepo.GetSnapshots(lbType, ref.Period, ref.Key, scope, scopeValue, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch leaderboard: %w", err)
+	}
+
+	response := &dto.LeaderboardResponse{
+		Type:       string(lbType),
+		Period:     string(ref.Period),
+		PeriodKey:  ref.Key,
+		Scope:      string(scope),
+		ScopeValue: scopeValue,
+		Entries:    make([]dto.LeaderboardEntry, 0, len(snapshots)),
+		Total:      int(total),
+		UpdatedAt:  s.computedAt(ref),
+	}
+	if ref.Season != nil {
+		response.Season = dto.MapLeaderboardSeasonToResponse(ref.Season)
+	}
+	for _, snapshot := range snapshots {
+		response.Entries = append(response.Entries, dto.LeaderboardEntry{
+			UserID:    snapshot.UserID,
+			Username:  snapshot.User.Username,
+			FullName:  snapshot.User.FullName,
+			Avatar:    snapshot.User.Avatar,
+			Score:     leaderboardScore(lbType, snapshot.Score),
+			ScoreType: string(lbType),
+			Rank:      snapshot.Rank,
+		})
+	}
+	return response, nil
+}
+
+// GetMyRank gets the user's standing on a leaderboard
+// School and location scopes default to the user's own school and location
+func (s *LeaderboardService) GetMyRank(userID uint, boardType string, query *dto.LeaderboardQuery) (*dto.LeaderboardRankResponse, error) {
+	lbType, err := parseLeaderboardType(boardType)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	ref, err := s.resolvePeriod(query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	scope, scopeValue, err := resolveLeaderboardScope(lbType, query.Scope, query.Value, user)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.leaderboardRepo.CountSnapshots(lbType, ref.Period, ref.Key, scope, scopeValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch leaderboard: %w", err)
+	}
+
+	response := &dto.LeaderboardRankResponse{
+		Type:       string(lbType),
+		Period:     string(ref.Period),
+		PeriodKey:  ref.Key,
+		Scope:      string(scope),
+		ScopeValue: scopeValue,
+		Total:      int(total),
+		UpdatedAt:  s.computedAt(ref),
+	}
+
+	snapshot, err := s.leaderboardRepo.GetUserSnapshot(lbType, ref.Period, ref.Key, scope, scopeValue, userID)
+	if err != nil {
+		// No activity in the period
+		return response, nil
+	}
+	response.Ranked = true
+	response.Rank = snapshot.Rank
+	response.Score = leaderboardScore(lbType, snapshot.Score)
+	if total > 0 {
+		response.TopPercent = math.Max(math.Round(float64(snapshot.Rank)/float64(total)*1000)/10, 0.1)
+	}
+	return response, nil
+}
+
+// ListSeasons gets every season, most recent first
+func (s *LeaderboardService) ListSeasons() ([]dto.LeaderboardSeasonResponse, error) {
+	seasons, err := s.leaderboardRepo.ListSeasons()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch seasons: %w", err)
+	}
+	return dto.MapLeaderboardSeasonsToResponse(seasons), nil
+}
+
+// CreateSeason schedules a season
+// Seasons can't overlap; one that has already started becomes active on the next refresh
+func (s *LeaderboardService) CreateSeason(adminID uint, req *dto.CreateLeaderboardSeasonRequest) (*dto.LeaderboardSeasonResponse, error) {
+	season := &models.LeaderboardSeason{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		StartsAt:    req.StartsAt.UTC(),
+		EndsAt:      req.EndsAt.UTC(),
+		Status:      models.SeasonUpcoming,
+		CreatedBy:   adminID,
+	}
+	if err := s.validateSeason(season, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.leaderboardRepo.CreateSeason(season); err != nil {
+		return nil, fmt.Errorf("failed to create season: %w", err)
+	}
+	s.audit.Record(models.AuditActionCreate, "leaderboard_seasons", season.ID, nil, season)
+	return dto.MapLeaderboardSeasonToResponse(season), nil
+}
+
+// UpdateSeason edits a season that hasn't been archived
+// A running season keeps its start; its end can be moved but not into the past
+func (s *LeaderboardService) UpdateSeason(id uint, req *dto.UpdateLeaderboardSeasonRequest) (*dto.LeaderboardSeasonResponse, error) {
+	season, err := s.leaderboardRepo.GetSeasonByID(id)
+	if err != nil {
+		return nil, errors.New("season not found")
+	}
+	if season.Status == models.SeasonArchived {
+		return nil, errors.New("archived seasons can't be changed")
+	}
+	before := *season
+
+	if req.Name != nil {
+		season.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		season.Description = *req.Description
+	}
+	if req.StartsAt != nil {
+		if season.Status == models.SeasonActive && !req.StartsAt.Equal(season.StartsAt) {
+			return nil, errors.New("the start of a running season can't be moved")
+		}
+		season.StartsAt = req.StartsAt.UTC()
+	}
+	if req.EndsAt != nil {
+		season.EndsAt = req.EndsAt.UTC()
+	}
+	if err := s.validateSeason(season, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.leaderboardRepo.UpdateSeason(season); err != nil {
+		return nil, fmt.Errorf("failed to update season: %w", err)
+	}
+	s.audit.Record(models.AuditActionUpdate, "leaderboard_seasons", season.ID, before, season)
+	return dto.MapLeaderboardSeasonToResponse(season), nil
+}
+
+// DeleteSeason deletes a season that hasn't started
+func (s *LeaderboardService) DeleteSeason(id uint) error {
+	season, err := s.leaderboardRepo.GetSeasonByID(id)
+	if err != nil {
+		return errors.New("season not found")
+	}
+	if season.Status != models.SeasonUpcoming || !time.Now().Before(season.StartsAt) {
+		return errors.New("only seasons that haven't started can be deleted")
+	}
+
+	if err := s.leaderboardRepo.DeleteSeason(id); err != nil {
+		return fmt.Errorf("failed to delete season: %w", err)
+	}
+	s.audit.Record(models.AuditActionDelete, "leaderboard_seasons", season.ID, season, nil)
+	return nil
+}
+
+// StartRefreshJob recomputes every leaderboard in the background
+// The first run happens at startup so the leaderboards are filled right away
+func (s *LeaderboardService) StartRefreshJob() {
+	go func() {
+		ticker := time.NewTicker(s.config.RefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			if result, err := s.RefreshAll(time.Now()); err != nil {
+				log.Printf("Failed to refresh leaderboards: %v", err)
+			} else if result.SeasonsStarted > 0 || result.SeasonsArchived > 0 {
+				log.Printf("Refreshed leaderboards: %d seasons started, %d archived",
+					result.SeasonsStarted, result.SeasonsArchived)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// RefreshAll recomputes every leaderboard snapshot
+//
+// Flow:
+//  1. Starts seasons whose start has passed and archives those that ended,
+//     freezing their final standings
+//  2. Recomputes the all-time, current week, current month and active season standings
+//  3. Finalises the previous week and month once, the first refresh after they end
+//  4. Drops weekly and monthly history older than HistoryDays
+func (s *LeaderboardService) RefreshAll(now time.Time) (*dto.LeaderboardRefreshResponse, error) {
+	leaderboardRefreshMu.Lock()
+	defer leaderboardRefreshMu.Unlock()
+
+	now = now.UTC()
+	result := &dto.LeaderboardRefreshResponse{ComputedAt: now.Format(time.RFC3339)}
+
+	seasons, err := s.leaderboardRepo.GetUnarchivedSeasons(now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch seasons: %w", err)
+	}
+	for i := range seasons {
+		if err := s.refreshSeason(&seasons[i], now, result); err != nil {
+			return nil, err
+		}
+	}
+
+	count, err := s.computeBoards(models.LeaderboardAllTime, leaderboardAllTimeKey, nil, nil, now)
+	if err != nil {
+		return nil, err
+	}
+	result.Snapshots += count
+
+	windows := []struct {
+		period models.LeaderboardPeriod
+		start  func(time.Time) time.Time
+		next   func(time.Time) time.Time
+		key    func(time.Time) string
+	}{
+		{models.LeaderboardWeekly, leaderboardWeekStart, func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }, leaderboardWeekKey},
+		{models.LeaderboardMonthly, leaderboardMonthStart, func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }, leaderboardMonthKey},
+	}
+	for _, window := range windows {
+		start := window.start(now)
+		end := window.next(start)
+		count, err := s.computeBoards(window.period, window.key(start), &start, &end, now)
+		if err != nil {
+			return nil, err
+		}
+		result.Snapshots += count
+
+		// The previous period is final once computed after it ended
+		prevStart := window.start(start.Add(-time.Nanosecond))
+		prevKey := window.key(prevStart)
+		computedAt, err := s.leaderboardRepo.GetComputedAt(window.period, prevKey)
+		if err != nil {
+			return nil, err
+		}
+		if computedAt == nil || computedAt.Before(start) {
+			count, err := s.computeBoards(window.period, prevKey, &prevStart, &start, now)
+			if err != nil {
+				return nil, err
+			}
+			result.Snapshots += count
+		}
+	}
+
+	if s.config.HistoryDays > 0 {
+		if _, err := s.leaderboardRepo.DeleteSnapshotsBefore(now.AddDate(0, 0, -s.config.HistoryDays)); err != nil {
+			return nil, fmt.Errorf("failed to prune leaderboard history: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// refreshSeason starts a season, recomputes its standings and archives it once it ended
+func (s *LeaderboardService) refreshSeason(season *models.LeaderboardSeason, now time.Time, result *dto.LeaderboardRefreshResponse) error {
+	if season.Status == models.SeasonUpcoming {
+		season.Status = models.SeasonActive
+		result.SeasonsStarted++
+	}
+
+	start, end := season.StartsAt, season.EndsAt
+	count, err := s.computeBoards(models.LeaderboardSeasonal, strconv.FormatUint(uint64(season.ID), 10), &start, &end, now)
+	if err != nil {
+		return err
+	}
+	result.Snapshots += count
+
+	if !now.Before(season.EndsAt) {
+		season.Status = models.SeasonArchived
+		season.ArchivedAt = &now
+		result.SeasonsArchived++
+	}
+	if err := s.leaderboardRepo.UpdateSeason(season); err != nil {
+		return fmt.Errorf("failed to update season %d: %w", season.ID, err)
+	}
+	return nil
+}
+
+// computeBoards recomputes every leaderboard type for one period, returning the snapshots written
+func (s *LeaderboardService) computeBoards(period models.LeaderboardPeriod, key string, since, until *time.Time, now time.Time) (int, error) {
+	written := 0
+	for _, lbType := range models.LeaderboardTypes {
+		rows, err := s.leaderboardRepo.GetScores(lbType, since, until, false, s.config.MinRatingReviews)
+		if err != nil {
+			return written, fmt.Errorf("failed to compute %s %s leaderboard: %w", period, lbType, err)
+		}
+
+		snapshots := rankLeaderboard(lbType, period, key, models.LeaderboardGlobal, rows, now,
+			func(repository.LeaderboardScoreRow) string { return "" })
+		snapshots = append(snapshots, rankLeaderboard(lbType, period, key, models.LeaderboardSchool, rows, now,
+			func(row repository.LeaderboardScoreRow) string { return normalizeScopeValue(row.School) })...)
+		snapshots = append(snapshots, rankLeaderboard(lbType, period, key, models.LeaderboardLocation, rows, now,
+			func(row repository.LeaderboardScoreRow) string { return normalizeScopeValue(row.Location) })...)
+
+		if lbType.SupportsCategory() {
+			categoryRows, err := s.leaderboardRepo.GetScores(lbType, since, until, true, s.config.MinRatingReviews)
+			if err != nil {
+				return written, fmt.Errorf("failed to compute %s %s leaderboard: %w", period, lbType, err)
+			}
+			snapshots = append(snapshots, rankLeaderboard(lbType, period, key, models.LeaderboardCategory, categoryRows, now,
+				func(row repository.LeaderboardScoreRow) string { return row.Category })...)
+		}
+
+		if err := s.leaderboardRepo.ReplaceSnapshots(lbType, period, key, snapshots); err != nil {
+			return written, fmt.Errorf("failed to save %s %s leaderboard: %w", period, lbType, err)
+		}
+		written += len(snapshots)
+	}
+	return written, nil
+}
+
+// rankLeaderboard ranks scores within each scope value returned by group
+// Users with no score, or no value to group by, are left out. Tied scores share
+// a rank and the next rank is skipped (1, 2, 2, 4).
+func rankLeaderboard(
+	lbType models.LeaderboardType,
+	period models.LeaderboardPeriod,
+	key string,
+	scope models.LeaderboardScope,
+	rows []repository.LeaderboardScoreRow,
+	now time.Time,
+	group func(repository.LeaderboardScoreRow) string,
+) []models.LeaderboardSnapshot {
+	groups := map[string][]repository.LeaderboardScoreRow{}
+	for _, row := range rows {
+		value := group(row)
+		if row.Score <= 0 || (scope != models.LeaderboardGlobal && value == "") {
+			continue
+		}
+		row.Score = math.Round(row.Score*100) / 100
+		groups[value] = append(groups[value], row)
+	}
+
+	var snapshots []models.LeaderboardSnapshot
+	for value, members := range groups {
+		sort.Slice(members, func(i, j int) bool {
+			if members[i].Score != members[j].Score {
+				return members[i].Score > members[j].Score
+			}
+			return members[i].UserID < members[j].UserID
+		})
+
+		rank := 0
+		for i, member := range members {
+			if i == 0 || member.Score != members[i-1].Score {
+				rank = i + 1
+			}
+			snapshots = append(snapshots, models.LeaderboardSnapshot{
+				Type:       lbType,
+				Period:     period,
+				PeriodKey:  key,
+				Scope:      scope,
+				ScopeValue: value,
+				UserID:     member.UserID,
+				Rank:       rank,
+				Score:      member.Score,
+				ComputedAt: now,
+			})
+		}
+	}
+	return snapshots
+}
+
+// resolvePeriod turns a request's period, key and season into the snapshot period to read
+func (s *LeaderboardService) resolvePeriod(query *dto.LeaderboardQuery, now time.Time) (*leaderboardPeriodRef, error) {
+	now = now.UTC()
+	switch models.LeaderboardPeriod(query.Period) {
+	case "", models.LeaderboardAllTime:
+		return &leaderboardPeriodRef{Period: models.LeaderboardAllTime, Key: leaderboardAllTimeKey}, nil
+	case models.LeaderboardWeekly:
+		if query.Key == "" {
+			return &leaderboardPeriodRef{Period: models.LeaderboardWeekly, Key: leaderboardWeekKey(now)}, nil
+		}
+		if _, err := parseLeaderboardWeekKey(query.Key); err != nil {
+			return nil, err
+		}
+		return &leaderboardPeriodRef{Period: models.LeaderboardWeekly, Key: query.Key}, nil
+	case models.LeaderboardMonthly:
+		if query.Key == "" {
+			return &leaderboardPeriodRef{Period: models.LeaderboardMonthly, Key: leaderboardMonthKey(now)}, nil
+		}
+		if _, err := time.Parse("2006-01", query.Key); err != nil {
+			return nil, errors.New("month must look like 2026-02")
+		}
+		return &leaderboardPeriodRef{Period: models.LeaderboardMonthly, Key: query.Key}, nil
+	case models.LeaderboardSeasonal:
+		var season *models.LeaderboardSeason
+		var err error
+		if query.SeasonID != 0 {
+			season, err = s.leaderboardRepo.GetSeasonByID(query.SeasonID)
+			if err != nil {
+				return nil, errors.New("season not found")
+			}
+		} else if season, err = s.leaderboardRepo.GetActiveSeason(); err != nil {
+			return nil, errors.New("no season is running")
+		}
+		return &leaderboardPeriodRef{
+			Period: models.LeaderboardSeasonal,
+			Key:    strconv.FormatUint(uint64(season.ID), 10),
+			Season: season,
+		}, nil
+	}
+	return nil, errors.New("invalid leaderboard period")
+}
+
+// computedAt formats when a period's standings were last computed, or "" if never
+func (s *LeaderboardService) computedAt(ref *leaderboardPeriodRef) string {
+	computedAt, err := s.leaderboardRepo.GetComputedAt(ref.Period, ref.Key)
+	if err != nil || computedAt == nil {
+		return ""
+	}
+	return computedAt.UTC().Format(time.RFC3339)
+}
+
+// validateSeason checks a season's name and window
+func (s *LeaderboardService) validateSeason(season *models.LeaderboardSeason, now time.Time) error {
+	if season.Name == "" {
+		return errors.New("season name is required")
+	}
+	if !season.EndsAt.After(season.StartsAt.Add(24 * time.Hour)) {
+		return errors.New("a season must last more than a day")
+	}
+	if !season.EndsAt.After(now) {
+		return errors.New("a season must end in the future")
+	}
+
+	overlapping, err := s.leaderboardRepo.CountOverlappingSeasons(season.StartsAt, season.EndsAt, season.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check seasons: %w", err)
+	}
+	if overlapping > 0 {
+		return errors.New("season overlaps another season")
+	}
+	return nil
+}
+
+// parseLeaderboardType validates a leaderboard type
+func parseLeaderboardType(boardType string) (models.LeaderboardType, error) {
+	for _, lbType := range models.LeaderboardTypes {
+		if string(lbType) == boardType {
+			return lbType, nil
+		}
+	}
+	return "", errors.New("invalid leaderboard type")
+}
+
+// resolveLeaderboardScope validates a scope and its value
+// When user is set, school and location default to the user's own
+func resolveLeaderboardScope(lbType models.LeaderboardType, scope, value string, user *models.User) (models.LeaderboardScope, string, error) {
+	value = normalizeScopeValue(value)
+	switch models.LeaderboardScope(scope) {
+	case "", models.LeaderboardGlobal:
+		return models.LeaderboardGlobal, "", nil
+	case models.LeaderboardSchool:
+		if value == "" && user != nil {
+			value = normalizeScopeValue(user.School)
+		}
+		if value == "" {
+			return "", "", errors.New("a school is required for the school scope")
+		}
+		return models.LeaderboardSchool, value, nil
+	case models.LeaderboardLocation:
+		if value == "" && user != nil {
+			value = normalizeScopeValue(user.Location)
+		}
+		if value == "" {
+			return "", "", errors.New("a location is required for the location scope")
+		}
+		return models.LeaderboardLocation, value, nil
+	case models.LeaderboardCategory:
+		if !lbType.SupportsCategory() {
+			return "", "", fmt.Errorf("the %s leaderboard can't be split by skill category", lbType)
+		}
+		if !isValidSkillCategory(models.SkillCategory(value)) {
+			return "", "", errors.New("invalid skill category")
+		}
+		return models.LeaderboardCategory, value, nil
+	}
+	return "", "", errors.New("invalid leaderboard scope")
+}
+
+// normalizeScopeValue makes school and location names match regardless of case and spacing
+func normalizeScopeValue(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+// leaderboardScore converts a stored score to the integer shown on the leaderboard
+// Ratings are multiplied by 100 (4.5 stars = 450) to keep their precision
+func leaderboardScore(lbType models.LeaderboardType, score float64) int {
+	if lbType == models.LeaderboardRating {
+		return int(math.Round(score * 100))
+	}
+	return int(math.Round(score))
+}
+
+// leaderboardWeekStart gets the Monday 00:00 UTC starting t's ISO week
+func leaderboardWeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7 // Days since Monday
+	return day.AddDate(0, 0, -offset)
+}
+
+// leaderboardMonthStart gets the first day of t's month, 00:00 UTC
+func leaderboardMonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// leaderboardWeekKey formats t's ISO week, e.g. "2026-W07"
+func leaderboardWeekKey(t time.Time) string {
+	year, week := t.UTC().ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// leaderboardMonthKey formats t's month, e.g. "2026-02"
+func leaderboardMonthKey(t time.Time) string {
+	return t.UTC().Format("2006-01")
+}
+
+// parseLeaderboardWeekKey parses an ISO week key into the Monday it starts on
+func parseLeaderboardWeekKey(key string) (time.Time, error) {
+	var year, week int
+	if _, err := fmt.Sscanf(key, "%d-W%d", &year, &week); err != nil || week < 1 || week > 53 {
+		return time.Time{}, errors.New("week must look like 2026-W07")
+	}
+	// January 4th is always in week 1
+	start := leaderboardWeekStart(time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)).AddDate(0, 0, (week-1)*7)
+	if leaderboardWeekKey(start) != fmt.Sprintf("%d-W%02d", year, week) {
+		return time.Time{}, errors.New("week must look like 2026-W07")
+	}
+	return start, nil
+}
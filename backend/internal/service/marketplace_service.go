@@ -0,0 +1,242 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+)
+
+// maxOfferFacetValues caps open-ended facets (location, school)
+const maxOfferFacetValues = 10
+
+// MarketplaceService handles teacher offer search
+// Unlike the skill catalogue, results are concrete tutors (user skills)
+type MarketplaceService struct {
+	marketplaceRepo *repository.MarketplaceRepository
+}
+
+// NewMarketplaceService creates a new marketplace service
+func NewMarketplaceService(marketplaceRepo *repository.MarketplaceRepository) *MarketplaceService {
+	return &MarketplaceService{marketplaceRepo: marketplaceRepo}
+}
+
+// SearchOffers searches teacher offers with filters, sorting and facet counts
+//
+// Parameters:
+//   - req: Optional filters, sort order and page
+//
+// Returns:
+//   - *OfferSearchResponse: Page of offers, total and facet counts
+//   - error: If a filter is invalid or database error
+func (s *MarketplaceService) SearchOffers(req *dto.OfferSearchRequest) (*dto.OfferSearchResponse, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	filter, err := BuildOfferFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sortBy := req.Sort
+	switch sortBy {
+	case "":
+		sortBy = repository.OfferSortRelevance
+	case repository.OfferSortRelevance, repository.OfferSortRating, repository.OfferSortPriceAsc,
+		repository.OfferSortPriceDesc, repository.OfferSortPopularity:
+	default:
+		return nil, fmt.Errorf("invalid sort: %s", sortBy)
+	}
+
+	rows, total, err := s.marketplaceRepo.SearchOffers(filter, sortBy, limit, (page-1)*limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search offers: %w", err)
+	}
+
+	facets, err := s.getFacets(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count facets: %w", err)
+	}
+
+	offers := make([]dto.OfferResponse, len(rows))
+	for i, row := range rows {
+		offers[i] = toOfferResponse(row)
+	}
+
+	return &dto.OfferSearchResponse{
+		Offers: offers,
+		Total:  total,
+		Page:   page,
+		Limit:  limit,
+		Facets: *facets,
+	}, nil
+}
+
+// getFacets counts offers per value of every filter
+func (s *MarketplaceService) getFacets(filter repository.OfferFilter) (*dto.OfferFacets, error) {
+	var facets dto.OfferFacets
+	var err error
+
+	if facets.Level, err = s.facetValues(s.marketplaceRepo.GetOfferLevelFacet(filter)); err != nil {
+		return nil, err
+	}
+	if facets.Price, err = s.facetValues(s.marketplaceRepo.GetOfferPriceFacet(filter)); err != nil {
+		return nil, err
+	}
+	if facets.Day, err = s.facetValues(s.marketplaceRepo.GetOfferDayFacet(filter)); err != nil {
+		return nil, err
+	}
+	if facets.Location, err = s.facetValues(s.marketplaceRepo.GetOfferFacetCounts(filter, repository.OfferFacetLocation, "users.location", maxOfferFacetValues)); err != nil {
+		return nil, err
+	}
+	if facets.School, err = s.facetValues(s.marketplaceRepo.GetOfferFacetCounts(filter, repository.OfferFacetSchool, "users.school", maxOfferFacetValues)); err != nil {
+		return nil, err
+	}
+	if facets.Grade, err = s.facetValues(s.marketplaceRepo.GetOfferFacetCounts(filter, repository.OfferFacetGrade, "users.grade", 0)); err != nil {
+		return nil, err
+	}
+
+	if facets.Verified, err = s.marketplaceRepo.GetOfferVerifiedFacet(filter); err != nil {
+		return nil, err
+	}
+
+	mode, err := s.marketplaceRepo.GetOfferModeFacet(filter)
+	if err != nil {
+		return nil, err
+	}
+	facets.Mode = []dto.FacetValue{
+		{Value: repository.OfferModeOnline, Count: mode.Online},
+		{Value: repository.OfferModeOffline, Count: mode.Offline},
+	}
+
+	rating, err := s.marketplaceRepo.GetOfferRatingFacet(filter)
+	if err != nil {
+		return nil, err
+	}
+	facets.MinRating = []dto.FacetValue{
+		{Value: "4.5", Count: rating.AtLeast45},
+		{Value: "4", Count: rating.AtLeast4},
+		{Value: "3.5", Count: rating.AtLeast35},
+		{Value: "3", Count: rating.AtLeast3},
+	}
+
+	return &facets, nil
+}
+
+// facetValues converts repository facet counts to response values
+func (s *MarketplaceService) facetValues(counts []repository.FacetCount, err error) ([]dto.FacetValue, error) {
+	if err != nil {
+		return nil, err
+	}
+	values := make([]dto.FacetValue, len(counts))
+	for i, c := range counts {
+		values[i] = dto.FacetValue{Value: c.Value, Count: c.Count}
+	}
+	return values, nil
+}
+
+// BuildOfferFilter validates an offer search request and converts it to a repository filter
+func BuildOfferFilter(req *dto.OfferSearchRequest) (repository.OfferFilter, error) {
+	filter := repository.OfferFilter{
+		Search:    strings.TrimSpace(req.Search),
+		SkillID:   req.SkillID,
+		Category:  strings.TrimSpace(req.Category),
+		MinRate:   req.MinRate,
+		MaxRate:   req.MaxRate,
+		Mode:      req.Mode,
+		Location:  strings.TrimSpace(req.Location),
+		MinRating: req.MinRating,
+		DayOfWeek: req.Day,
+		Time:      strings.TrimSpace(req.Time),
+		School:    strings.TrimSpace(req.School),
+		Grade:     strings.TrimSpace(req.Grade),
+		Verified:  req.Verified,
+	}
+
+	// Levels may be repeated (?level=beginner&level=expert) or comma-separated (?level=beginner,expert)
+	for _, raw := range req.Levels {
+		for _, l := range strings.Split(raw, ",") {
+			l = strings.TrimSpace(l)
+			if l == "" {
+				continue
+			}
+			if !isValidSkillLevel(models.SkillLevel(l)) {
+				return filter, fmt.Errorf("invalid level: %s", l)
+			}
+			filter.Levels = append(filter.Levels, models.SkillLevel(l))
+		}
+	}
+
+	switch filter.Mode {
+	case "", repository.OfferModeOnline, repository.OfferModeOffline:
+	default:
+		return filter, errors.New("mode must be online or offline")
+	}
+
+	if filter.MinRate != nil && filter.MaxRate != nil && *filter.MinRate > *filter.MaxRate {
+		return filter, errors.New("min_rate must not exceed max_rate")
+	}
+	if filter.MinRating != nil && (*filter.MinRating < 0 || *filter.MinRating > 5) {
+		return filter, errors.New("min_rating must be between 0 and 5")
+	}
+	if filter.DayOfWeek != nil && (*filter.DayOfWeek < 0 || *filter.DayOfWeek > 6) {
+		return filter, errors.New("day must be between 0 (Sunday) and 6 (Saturday)")
+	}
+	if filter.Time != "" {
+		if _, err := time.Parse("15:04", filter.Time); err != nil {
+			return filter, errors.New("time must be in HH:MM format")
+		}
+	}
+
+	return filter, nil
+}
+
+// isValidSkillLevel checks that a skill level is one of the known constants
+func isValidSkillLevel(level models.SkillLevel) bool {
+	switch level {
+	case models.LevelBeginner, models.LevelIntermediate, models.LevelAdvanced, models.LevelExpert:
+		return true
+	}
+	return false
+}
+
+// toOfferResponse converts an offer row to its response DTO
+func toOfferResponse(row repository.OfferRow) dto.OfferResponse {
+	return dto.OfferResponse{
+		UserSkillID:       row.UserSkillID,
+		SkillID:           row.SkillID,
+		SkillName:         row.SkillName,
+		SkillCategory:     string(row.SkillCategory),
+		Level:             string(row.Level),
+		Description:       row.Description,
+		YearsOfExperience: row.YearsOfExperience,
+		HourlyRate:        row.HourlyRate,
+		OnlineOnly:        row.OnlineOnly,
+		OfflineOnly:       row.OfflineOnly,
+		TotalSessions:     row.TotalSessions,
+		Rating:            row.Rating,
+		ReviewCount:       row.ReviewCount,
+		BayesianRating:    row.BayesianRating,
+		ReputationScore:   row.ReputationScore,
+		IsVerified:        row.IsVerified,
+		Teacher: dto.OfferTeacher{
+			ID:       row.UserID,
+			FullName: row.FullName,
+			Username: row.Username,
+			Avatar:   row.Avatar,
+			Location: row.Location,
+			School:   row.School,
+			Grade:    row.Grade,
+		},
+	}
+}
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+)
+
+// Component weights; a recommendation score is the weighted sum of 0-1 components
+const (
+	recommendWeightSkillMatch   = 0.30
+	recommendWeightLevelGap     = 0.15
+	recommendWeightRating       = 0.20
+	recommendWeightAvailability = 0.15
+	recommendWeightLocation     = 0.10
+	recommendWeightPrice        = 0.10
+)
+
+const (
+	// recommendCategoryMatch is the skill match of a related skill in the same category
+	recommendCategoryMatch = 0.4
+	// recommendRatingPrior and recommendRatingPriorWeight smooth ratings with few reviews
+	recommendRatingPrior       = 3.5
+	recommendRatingPriorWeight = 5
+	// recommendOverlapTarget is the weekly overlap (minutes) that scores full availability
+	recommendOverlapTarget = 120
+	// maxRecommendationCandidates caps offers scored per request
+	maxRecommendationCandidates = 500
+	// recommendDigestInterval is how often the digest job checks for unsent weekly digests
+	recommendDigestInterval = time.Hour
+)
+
+// skillLevelRank orders skill levels for level gap scoring
+var skillLevelRank = map[models.SkillLevel]int{
+	models.LevelBeginner:     1,
+	models.LevelIntermediate: 2,
+	models.LevelAdvanced:     3,
+	models.LevelExpert:       4,
+}
+
+// RecommendationService matches learners' learning skills with teacher offers
+type RecommendationService struct {
+	skillRepo           *repository.SkillRepository
+	userRepo            *repository.UserRepository
+	availabilityRepo    *repository.AvailabilityRepository
+	marketplaceRepo     *repository.MarketplaceRepository
+	recommendationRepo  *repository.RecommendationRepository
+	notificationService *NotificationService
+}
+
+// NewRecommendationService creates a new recommendation service
+func NewRecommendationService(
+	skillRepo *repository.SkillRepository,
+	userRepo *repository.UserRepository,
+	availabilityRepo *repository.AvailabilityRepository,
+	marketplaceRepo *repository.MarketplaceRepository,
+	recommendationRepo *repository.RecommendationRepository,
+	notificationService *NotificationService,
+) *RecommendationService {
+	return &RecommendationService{
+		skillRepo:           skillRepo,
+		userRepo:            userRepo,
+		availabilityRepo:    availabilityRepo,
+		marketplaceRepo:     marketplaceRepo,
+		recommendationRepo:  recommendationRepo,
+		notificationService: notificationService,
+	}
+}
+
+// GetRecommendations suggests teachers for each of a user's learning skills
+//
+// Scoring (weights):
+//   - Skill match (0.30): exact skill, or a related skill in the same category
+//   - Level gap (0.15): teachers above the desired level score best
+//   - Rating (0.20): offer review average smoothed toward 3.5 over 5 reviews
+//   - Availability (0.15): weekly minutes overlapping the learner's slots
+//   - Location (0.10): same location, or teacher offers online sessions
+//   - Price (0.10): cheaper offers score higher, unaffordable ones are penalized
+//
+// Parameters:
+//   - userID: Learner
+//   - limit: Teachers per learning skill (default 5, max 20)
+//
+// Returns:
+//   - *RecommendationsResponse: Groups ordered by learning skill priority
+//   - error: If user not found or database error
+func (s *RecommendationService) GetRecommendations(userID uint, limit int) (*dto.RecommendationsResponse, error) {
+	if limit <= 0 || limit > 20 {
+		limit = 5
+	}
+
+	groups, err := s.match(userID, nil, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.RecommendationsResponse{Groups: groups}, nil
+}
+
+// match scores candidate offers for every learning skill of a user
+// When since is set, only offers created after it are considered
+func (s *RecommendationService) match(userID uint, since *time.Time, limit int) ([]dto.RecommendationGroup, error) {
+	learner, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	learningSkills, err := s.skillRepo.GetLearningSkills(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get learning skills: %w", err)
+	}
+	groups := []dto.RecommendationGroup{}
+	if len(learningSkills) == 0 {
+		return groups, nil
+	}
+
+	sort.SliceStable(learningSkills, func(i, j int) bool {
+		return learningSkills[i].Priority > learningSkills[j].Priority
+	})
+
+	filter := repository.OfferFilter{
+		ExcludeUserID: &userID,
+		CreatedAfter:  since,
+	}
+	seenCategory := map[string]bool{}
+	for _, ls := range learningSkills {
+		filter.SkillIDs = append(filter.SkillIDs, ls.SkillID)
+		category := string(ls.Skill.Category)
+		if category != "" && !seenCategory[category] {
+			seenCategory[category] = true
+			filter.Categories = append(filter.Categories, category)
+		}
+	}
+
+	candidates, err := s.marketplaceRepo.ListOffers(filter, maxRecommendationCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candidate offers: %w", err)
+	}
+
+	userIDs := []uint{userID}
+	for _, c := range candidates {
+		userIDs = append(userIDs, c.UserID)
+	}
+	slots, err := s.availabilityRepo.GetAvailabilityForUsers(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+	slotsByUser := map[uint][]models.Availability{}
+	for _, slot := range slots {
+		slotsByUser[slot.UserID] = append(slotsByUser[slot.UserID], slot)
+	}
+
+	availableBalance := learner.CreditBalance - learner.CreditHeld
+
+	for _, ls := range learningSkills {
+		group := dto.RecommendationGroup{
+			LearningSkillID: ls.ID,
+			SkillID:         ls.SkillID,
+			SkillName:       ls.Skill.Name,
+			DesiredLevel:    string(ls.DesiredLevel),
+			Priority:        ls.Priority,
+			Teachers:        []dto.TeacherRecommendation{},
+		}
+
+		for _, offer := range candidates {
+			skillMatch := 0.0
+			switch {
+			case offer.SkillID == ls.SkillID:
+				skillMatch = 1
+			case ls.Skill.Category != "" && offer.SkillCategory == ls.Skill.Category:
+				skillMatch = recommendCategoryMatch
+			default:
+				continue
+			}
+
+			group.Teachers = append(group.Teachers, s.score(
+				offer, ls, skillMatch, learner, availableBalance,
+				slotsByUser[userID], slotsByUser[offer.UserID],
+			))
+		}
+
+		sort.SliceStable(group.Teachers, func(i, j int) bool {
+			return group.Teachers[i].Score.Total > group.Teachers[j].Score.Total
+		})
+		if len(group.Teachers) > limit {
+			group.Teachers = group.Teachers[:limit]
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// score computes the weighted score of one offer for one learning skill
+func (s *RecommendationService) score(
+	offer repository.OfferRow,
+	learning models.LearningSkill,
+	skillMatch float64,
+	learner *models.User,
+	availableBalance float64,
+	learnerSlots, teacherSlots []models.Availability,
+) dto.TeacherRecommendation {
+	var reasons []string
+	if skillMatch == 1 {
+		reasons = append(reasons, "Teaches "+offer.SkillName)
+	} else {
+		reasons = append(reasons, fmt.Sprintf("Teaches related skill %s", offer.SkillName))
+	}
+
+	// Level gap: one or more levels above the desired level is ideal
+	levelGap := 0.8
+	if desired, ok := skillLevelRank[learning.DesiredLevel]; ok {
+		teacher := skillLevelRank[offer.Level]
+		switch {
+		case teacher > desired:
+			levelGap = 1
+			reasons = append(reasons, fmt.Sprintf("%s level, above your goal", offer.Level))
+		case teacher == desired:
+			levelGap = 0.7
+		default:
+			levelGap = 0.2
+		}
+	}
+
+	// Rating: Bayesian average so one 5-star review doesn't beat an established tutor
+	smoothed := (offer.Rating*float64(offer.ReviewCount) + recommendRatingPrior*recommendRatingPriorWeight) /
+		(float64(offer.ReviewCount) + recommendRatingPriorWeight)
+	rating := smoothed / 5
+	if offer.ReviewCount > 0 && offer.Rating >= 4.5 {
+		reasons = append(reasons, fmt.Sprintf("Rated %.1f from %d reviews", offer.Rating, offer.ReviewCount))
+	}
+
+	// Availability: weekly overlap between learner and teacher slots
+	availability := 0.5 // Neutral when the learner hasn't set a schedule
+	if len(learnerSlots) > 0 {
+		overlap := availabilityOverlapMinutes(learnerSlots, teacherSlots)
+		availability = math.Min(1, float64(overlap)/recommendOverlapTarget)
+		if overlap > 0 {
+			reasons = append(reasons, fmt.Sprintf("%d hours/week of shared availability", int(math.Ceil(float64(overlap)/60))))
+		}
+	}
+
+	// Location: same place works for any mode; otherwise the teacher must teach online
+	location := 0.1
+	sameLocation := learner.Location != "" && strings.EqualFold(strings.TrimSpace(learner.Location), strings.TrimSpace(offer.Location))
+	switch {
+	case sameLocation:
+		location = 1
+		reasons = append(reasons, "Located in "+offer.Location)
+	case !offer.OfflineOnly:
+		location = 0.8
+	}
+
+	// Price: rates above 5 credits/hour score zero, unaffordable offers are halved
+	price := math.Max(0, math.Min(1, 1-(offer.HourlyRate-1)/4))
+	if offer.HourlyRate > availableBalance {
+		price *= 0.5
+	}
+
+	total := recommendWeightSkillMatch*skillMatch +
+		recommendWeightLevelGap*levelGap +
+		recommendWeightRating*rating +
+		recommendWeightAvailability*availability +
+		recommendWeightLocation*location +
+		recommendWeightPrice*price
+
+	return dto.TeacherRecommendation{
+		Offer: toOfferResponse(offer),
+		Score: dto.RecommendationScore{
+			Total:        roundScore(total),
+			SkillMatch:   roundScore(skillMatch),
+			LevelGap:     roundScore(levelGap),
+			Rating:       roundScore(rating),
+			Availability: roundScore(availability),
+			Location:     roundScore(location),
+			Price:        roundScore(price),
+		},
+		Reasons: reasons,
+	}
+}
+
+// StartWeeklyDigest runs the weekly "new matches" digest in the background
+// The job checks hourly; each user is notified at most once per week
+func (s *RecommendationService) StartWeeklyDigest() {
+	go func() {
+		ticker := time.NewTicker(recommendDigestInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if sent, err := s.SendWeeklyDigests(time.Now()); err != nil {
+				log.Printf("Failed to send recommendation digests: %v", err)
+			} else if sent > 0 {
+				log.Printf("Sent %d recommendation digests", sent)
+			}
+		}
+	}()
+}
+
+// SendWeeklyDigests notifies learners about teacher offers added in the past week
+// A digest that fails to match or send is released so the next run retries it
+//
+// Flow:
+//  1. Claims each learner's digest for the current week (skips already claimed)
+//  2. Matches only offers created in the 7 days before now
+//  3. Sends one notification summarizing the new matches, if any
+//
+// Returns:
+//   - int: Number of notifications sent
+//   - error: If learners cannot be listed
+func (s *RecommendationService) SendWeeklyDigests(now time.Time) (int, error) {
+	learnerIDs, err := s.recommendationRepo.GetLearnerIDs()
+	if err != nil {
+		return 0, err
+	}
+
+	weekStart := startOfWeek(now)
+	since := now.AddDate(0, 0, -7)
+	sent := 0
+
+	for _, userID := range learnerIDs {
+		digest, claimed, err := s.recommendationRepo.ClaimDigest(userID, weekStart)
+		if err != nil {
+			log.Printf("Failed to claim recommendation digest for user %d: %v", userID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		groups, err := s.match(userID, &since, maxRecommendationCandidates)
+		if err != nil {
+			log.Printf("Failed to match new teachers for user %d: %v", userID, err)
+			s.releaseDigest(digest)
+			continue
+		}
+
+		offers := map[uint]bool{}
+		var skillNames []string
+		for _, group := range groups {
+			if len(group.Teachers) == 0 {
+				continue
+			}
+			skillNames = append(skillNames, group.SkillName)
+			for _, t := range group.Teachers {
+				offers[t.Offer.UserSkillID] = true
+			}
+		}
+
+		if err := s.recommendationRepo.SetDigestMatchCount(digest.ID, len(offers)); err != nil {
+			log.Printf("Failed to record recommendation digest for user %d: %v", userID, err)
+		}
+		if len(offers) == 0 {
+			continue
+		}
+
+		notificationData := map[string]interface{}{
+			"matchCount": len(offers),
+			"skills":     skillNames,
+			"weekStart":  weekStart,
+		}
+		if _, err := s.notificationService.CreateNotification(
+			userID,
+			models.NotificationTypeSocial,
+			"New Teacher Matches",
+			fmt.Sprintf("%d new teachers match skills you want to learn: %s", len(offers), strings.Join(skillNames, ", ")),
+			notificationData,
+		); err != nil {
+			log.Printf("Failed to send recommendation digest to user %d: %v", userID, err)
+			s.releaseDigest(digest)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// releaseDigest gives up a claimed digest so the next run retries it
+func (s *RecommendationService) releaseDigest(digest *models.RecommendationDigest) {
+	if err := s.recommendationRepo.ReleaseDigest(digest.ID); err != nil {
+		log.Printf("Failed to release recommendation digest %d: %v", digest.ID, err)
+	}
+}
+
+// availabilityOverlapMinutes sums weekly minutes where two schedules overlap
+func availabilityOverlapMinutes(a, b []models.Availability) int {
+	total := 0
+	for _, x := range a {
+		for _, y := range b {
+			if x.DayOfWeek != y.DayOfWeek {
+				continue
+			}
+			start := max(clockMinutes(x.StartTime), clockMinutes(y.StartTime))
+			end := min(clockMinutes(x.EndTime), clockMinutes(y.EndTime))
+			if end > start {
+				total += end - start
+			}
+		}
+	}
+	return total
+}
+
+// clockMinutes converts "HH:MM" to minutes since midnight (0 if malformed)
+func clockMinutes(clock string) int {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0
+	}
+	return t.Hour()*60 + t.Minute()
+}
+
+// startOfWeek returns Monday 00:00 UTC of the week containing t
+func startOfWeek(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7 // Days since Monday
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// roundScore rounds a score to two decimals
+func roundScore(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/config"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+)
+
+const (
+	reputationPositiveRating     = 4    // Ratings at or above this count as positive for the Wilson score
+	reputationWilsonZ            = 1.96 // 95% confidence
+	reputationSubRatingShare     = 0.4  // Share of a review's value taken from its sub-ratings, when present
+	reputationMinRecencyWeight   = 0.1  // Old reviews never stop counting entirely
+	reputationCredibleSessions   = 10   // Completed sessions for a reviewer to count fully
+	reputationInactiveReviewer   = 0.5  // Weight multiplier for reviewers who left or were deactivated
+	reputationPendingFraudWeight = 0.5  // Weight multiplier while the session's fraud flag is undecided
+	reputationBayesianShare      = 0.7  // Share of the composite score from the Bayesian rating; the rest is Wilson
+	reputationFallbackPrior      = 3.0  // Prior rating before the platform has any reviews
+)
+
+// ReputationService computes reputation scores from reviews
+type ReputationService struct {
+	reputationRepo *repository.ReputationRepository
+	config         config.ReputationConfig
+}
+
+// NewReputationService creates a new reputation service
+func NewReputationService(reputationRepo *repository.ReputationRepository, cfg config.ReputationConfig) *ReputationService {
+	return &ReputationService{
+		reputationRepo: reputationRepo,
+		config:         cfg,
+	}
+}
+
+// reputationKey identifies one score being accumulated
+type reputationKey struct {
+	subjectType models.ReputationSubject
+	subjectID   uint
+	role        models.ReviewType
+}
+
+// reputationAccumulator sums weighted review values for one subject
+type reputationAccumulator struct {
+	userID         uint
+	count          int
+	ratingSum      int
+	weight         float64
+	valueSum       float64
+	positiveWeight float64
+	subWeight      [3]float64 // Communication, punctuality, knowledge
+	subSum         [3]float64
+}
+
+// GetUserReputation gets a user's stored reputation scores
+func (s *ReputationService) GetUserReputation(userID uint) (*dto.UserReputationResponse, error) {
+	scores, err := s.reputationRepo.GetByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.UserReputationResponse{
+		UserID: userID,
+		Offers: make([]dto.OfferReputationResponse, 0),
+	}
+	for i := range scores {
+		score := &scores[i]
+		switch {
+		case score.SubjectType == models.ReputationSubjectUserSkill:
+			response.Offers = append(response.Offers, dto.OfferReputationResponse{
+				UserSkillID:             score.SubjectID,
+				ReputationScoreResponse: *dto.ToReputationScoreResponse(score),
+			})
+		case score.Role == models.ReviewTypeTeacher:
+			response.Teacher = dto.ToReputationScoreResponse(score)
+		case score.Role == models.ReviewTypeStudent:
+			response.Student = dto.ToReputationScoreResponse(score)
+		}
+	}
+	return response, nil
+}
+
+// RecomputeForUsers rebuilds the scores of the given users and their teaching offers
+// Called after a review they received is created, edited, hidden or deleted
+func (s *ReputationService) RecomputeForUsers(userIDs ...uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	rows, err := s.reputationRepo.GetReviewRows(userIDs)
+	if err != nil {
+		return err
+	}
+	priors, err := s.reputationRepo.GetPriors()
+	if err != nil {
+		return err
+	}
+
+	return s.reputationRepo.ReplaceForUsers(userIDs, s.computeScores(rows, priors, time.Now()))
+}
+
+// RecomputeAll rebuilds every reputation score
+// Needed periodically because review weights decay with age and reviewer credibility changes
+//
+// Returns:
+//   - int: Number of scores stored
+//   - error: If reviews cannot be loaded or scores cannot be saved
+func (s *ReputationService) RecomputeAll(now time.Time) (int, error) {
+	rows, err := s.reputationRepo.GetReviewRows(nil)
+	if err != nil {
+		return 0, err
+	}
+	priors, err := s.reputationRepo.GetPriors()
+	if err != nil {
+		return 0, err
+	}
+
+	scores := s.computeScores(rows, priors, now)
+	if err := s.reputationRepo.ReplaceAll(scores); err != nil {
+		return 0, err
+	}
+	return len(scores), nil
+}
+
+// StartRecomputeJob rebuilds every reputation score in the background
+// The first run happens at startup so new deployments get scores right away
+func (s *ReputationService) StartRecomputeJob() {
+	go func() {
+		ticker := time.NewTicker(s.config.RecomputeInterval)
+		defer ticker.Stop()
+
+		for {
+			if count, err := s.RecomputeAll(time.Now()); err != nil {
+				log.Printf("Failed to recompute reputation scores: %v", err)
+			} else {
+				log.Printf("Recomputed %d reputation scores", count)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// computeScores turns reviews into one score per user role and one per teaching offer
+//
+// Scoring:
+//   1. Each review's value blends the overall rating with its sub-ratings
+//   2. Each review is weighted by recency (exponential decay) and reviewer credibility
+//      (completed sessions, account status, pending fraud flags)
+//   3. The Bayesian rating shrinks the weighted mean towards the platform average by
+//      PriorWeight reviews' worth, so a single 5-star review can't beat 200 averaging 4.9
+//   4. The Wilson score is the lower bound of the weighted share of 4-5 star reviews
+//   5. The composite score (0-100) combines both for ranking
+func (s *ReputationService) computeScores(rows []repository.ReputationReviewRow, priors []repository.ReputationPrior, now time.Time) []models.ReputationScore {
+	priorByType := make(map[models.ReviewType]repository.ReputationPrior, len(priors))
+	for _, prior := range priors {
+		priorByType[prior.Type] = prior
+	}
+
+	accumulators := make(map[reputationKey]*reputationAccumulator)
+	var order []reputationKey
+	add := func(key reputationKey, userID uint, row *repository.ReputationReviewRow, weight float64) {
+		acc, ok := accumulators[key]
+		if !ok {
+			acc = &reputationAccumulator{userID: userID}
+			accumulators[key] = acc
+			order = append(order, key)
+		}
+		acc.add(row, weight)
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		weight := s.reviewWeight(row, now)
+		add(reputationKey{models.ReputationSubjectUser, row.RevieweeID, row.Type}, row.RevieweeID, row, weight)
+		if row.UserSkillID != nil {
+			add(reputationKey{models.ReputationSubjectUserSkill, *row.UserSkillID, row.Type}, row.RevieweeID, row, weight)
+		}
+	}
+
+	scores := make([]models.ReputationScore, 0, len(order))
+	for _, key := range order {
+		prior, ok := priorByType[key.role]
+		if !ok {
+			prior = repository.ReputationPrior{Type: key.role, Rating: reputationFallbackPrior}
+		}
+		scores = append(scores, accumulators[key].score(key, prior, s.config.PriorWeight, now))
+	}
+	return scores
+}
+
+// reviewWeight weights a review by its age and how credible its reviewer is
+func (s *ReputationService) reviewWeight(row *repository.ReputationReviewRow, now time.Time) float64 {
+	weight := 1.0
+
+	if halfLife := float64(s.config.RecencyHalfLifeDays); halfLife > 0 {
+		ageDays := math.Max(0, now.Sub(row.CreatedAt).Hours()/24)
+		weight = math.Max(reputationMinRecencyWeight, math.Pow(0.5, ageDays/halfLife))
+	}
+
+	credibility := 0.5 + 0.5*math.Min(1, float64(row.ReviewerSessions)/reputationCredibleSessions)
+	if !row.ReviewerActive {
+		credibility *= reputationInactiveReviewer
+	}
+	if row.FraudStatus != nil && *row.FraudStatus == models.FraudFlagPending {
+		credibility *= reputationPendingFraudWeight
+	}
+
+	return weight * credibility
+}
+
+// add adds one weighted review
+func (a *reputationAccumulator) add(row *repository.ReputationReviewRow, weight float64) {
+	a.count++
+	a.ratingSum += row.Rating
+	a.weight += weight
+
+	value := float64(row.Rating)
+	var subCount int
+	var subTotal float64
+	for i, sub := range []*int{row.CommunicationRating, row.PunctualityRating, row.KnowledgeRating} {
+		if sub == nil {
+			continue
+		}
+		a.subWeight[i] += weight
+		a.subSum[i] += weight * float64(*sub)
+		subCount++
+		subTotal += float64(*sub)
+	}
+	if subCount > 0 {
+		value = (1-reputationSubRatingShare)*value + reputationSubRatingShare*subTotal/float64(subCount)
+	}
+	a.valueSum += weight * value
+
+	if row.Rating >= reputationPositiveRating {
+		a.positiveWeight += weight
+	}
+}
+
+// score turns the accumulated reviews into a stored score
+func (a *reputationAccumulator) score(key reputationKey, prior repository.ReputationPrior, priorWeight float64, now time.Time) models.ReputationScore {
+	bayesian := bayesianAverage(a.valueSum, a.weight, prior.Rating, priorWeight)
+	wilson := wilsonLowerBound(a.positiveWeight, a.weight)
+
+	subPriors := []*float64{prior.Communication, prior.Punctuality, prior.Knowledge}
+	var subScores [3]float64
+	for i := range subScores {
+		if a.subWeight[i] == 0 {
+			continue
+		}
+		subPrior := prior.Rating
+		if subPriors[i] != nil {
+			subPrior = *subPriors[i]
+		}
+		subScores[i] = roundScore(bayesianAverage(a.subSum[i], a.subWeight[i], subPrior, priorWeight))
+	}
+
+	composite := 100 * (reputationBayesianShare*(bayesian-1)/4 + (1-reputationBayesianShare)*wilson)
+
+	return models.ReputationScore{
+		SubjectType:        key.subjectType,
+		SubjectID:          key.subjectID,
+		Role:               key.role,
+		UserID:             a.userID,
+		ReviewCount:        a.count,
+		EffectiveReviews:   roundScore(a.weight),
+		AverageRating:      roundScore(float64(a.ratingSum) / float64(a.count)),
+		BayesianRating:     roundScore(bayesian),
+		WilsonScore:        math.Round(wilson*10000) / 10000,
+		CommunicationScore: subScores[0],
+		PunctualityScore:   subScores[1],
+		KnowledgeScore:     subScores[2],
+		Score:              roundScore(math.Max(0, composite)),
+		ComputedAt:         now,
+	}
+}
+
+// bayesianAverage is the weighted mean shrunk towards prior by priorWeight observations
+func bayesianAverage(sum, weight, prior, priorWeight float64) float64 {
+	if weight+priorWeight <= 0 {
+		return prior
+	}
+	return (priorWeight*prior + sum) / (priorWeight + weight)
+}
+
+// wilsonLowerBound is the lower bound of the Wilson score interval for positive/total
+func wilsonLowerBound(positive, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	p := positive / total
+	z2 := reputationWilsonZ * reputationWilsonZ
+	centre := p + z2/(2*total)
+	margin := reputationWilsonZ * math.Sqrt(p*(1-p)/total+z2/(4*total*total))
+	return math.Max(0, (centre-margin)/(1+z2/total))
+}
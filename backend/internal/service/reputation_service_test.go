@@ -0,0 +1,169 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/config"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestBayesianAverage(t *testing.T) {
+	tests := []struct {
+		name                            string
+		sum, weight, prior, priorWeight float64
+		want                            float64
+	}{
+		{"no reviews keeps the prior", 0, 0, 3.8, 5, 3.8},
+		{"no prior weight is the plain mean", 9, 2, 3.8, 0, 4.5},
+		{"shrinks towards the prior", 5, 1, 4, 5, 25.0 / 6},
+		{"nothing at all", 0, 0, 3.8, 0, 3.8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bayesianAverage(tt.sum, tt.weight, tt.prior, tt.priorWeight); !approxEqual(got, tt.want) {
+				t.Errorf("bayesianAverage = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWilsonLowerBound(t *testing.T) {
+	tests := []struct {
+		positive, total float64
+		want            float64
+	}{
+		{0, 0, 0},
+		{0, 5, 0},
+		{1, 1, 0.20654329147389294},
+		{9, 10, 0.5958436145024278},
+		{90, 100, 0.8256326956323347},
+	}
+
+	for _, tt := range tests {
+		if got := wilsonLowerBound(tt.positive, tt.total); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("wilsonLowerBound(%v, %v) = %v, want %v", tt.positive, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestReviewWeight(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	pending := models.FraudFlagPending
+	cleared := models.FraudFlagCleared
+	s := &ReputationService{config: config.ReputationConfig{RecencyHalfLifeDays: 90}}
+
+	tests := []struct {
+		name string
+		row  repository.ReputationReviewRow
+		want float64
+	}{
+		{"new review by a credible reviewer", repository.ReputationReviewRow{CreatedAt: now, ReviewerSessions: 12, ReviewerActive: true}, 1},
+		{"one half-life old", repository.ReputationReviewRow{CreatedAt: now.AddDate(0, 0, -90), ReviewerSessions: 10, ReviewerActive: true}, 0.5},
+		{"old reviews keep a floor", repository.ReputationReviewRow{CreatedAt: now.AddDate(-5, 0, 0), ReviewerSessions: 10, ReviewerActive: true}, reputationMinRecencyWeight},
+		{"reviewer without sessions", repository.ReputationReviewRow{CreatedAt: now, ReviewerActive: true}, 0.5},
+		{"halfway credible reviewer", repository.ReputationReviewRow{CreatedAt: now, ReviewerSessions: 5, ReviewerActive: true}, 0.75},
+		{"inactive reviewer", repository.ReputationReviewRow{CreatedAt: now, ReviewerSessions: 10}, reputationInactiveReviewer},
+		{"pending fraud flag", repository.ReputationReviewRow{CreatedAt: now, ReviewerSessions: 10, ReviewerActive: true, FraudStatus: &pending}, reputationPendingFraudWeight},
+		{"cleared fraud flag", repository.ReputationReviewRow{CreatedAt: now, ReviewerSessions: 10, ReviewerActive: true, FraudStatus: &cleared}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.reviewWeight(&tt.row, now); !approxEqual(got, tt.want) {
+				t.Errorf("reviewWeight = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("no half-life disables decay", func(t *testing.T) {
+		s := &ReputationService{}
+		row := repository.ReputationReviewRow{CreatedAt: now.AddDate(-5, 0, 0), ReviewerSessions: 10, ReviewerActive: true}
+		if got := s.reviewWeight(&row, now); got != 1 {
+			t.Errorf("reviewWeight = %v, want 1", got)
+		}
+	})
+}
+
+func TestComputeScores(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	s := &ReputationService{config: config.ReputationConfig{PriorWeight: 5}}
+	priors := []repository.ReputationPrior{{Type: models.ReviewTypeTeacher, Rating: 4}}
+
+	review := func(revieweeID uint, rating int) repository.ReputationReviewRow {
+		return repository.ReputationReviewRow{
+			RevieweeID:       revieweeID,
+			Type:             models.ReviewTypeTeacher,
+			Rating:           rating,
+			CreatedAt:        now,
+			ReviewerSessions: 10,
+			ReviewerActive:   true,
+		}
+	}
+
+	t.Run("one perfect review doesn't beat many near-perfect ones", func(t *testing.T) {
+		rows := []repository.ReputationReviewRow{review(1, 5)}
+		for i := 0; i < 200; i++ {
+			rating := 5
+			if i%10 == 0 {
+				rating = 4
+			}
+			rows = append(rows, review(2, rating))
+		}
+
+		scores := s.computeScores(rows, priors, now)
+		if len(scores) != 2 {
+			t.Fatalf("got %d scores, want 2", len(scores))
+		}
+		single, many := scores[0], scores[1]
+		if single.AverageRating <= many.AverageRating {
+			t.Fatalf("test setup: single review average %v should beat %v", single.AverageRating, many.AverageRating)
+		}
+		if single.BayesianRating >= many.BayesianRating || single.Score >= many.Score {
+			t.Errorf("single review scored %v (%v), 200 reviews %v (%v)", single.Score, single.BayesianRating, many.Score, many.BayesianRating)
+		}
+	})
+
+	t.Run("offer scores and sub-ratings", func(t *testing.T) {
+		offerID := uint(7)
+		communication := 3
+		row := review(1, 5)
+		row.UserSkillID = &offerID
+		row.CommunicationRating = &communication
+
+		scores := s.computeScores([]repository.ReputationReviewRow{row}, priors, now)
+		if len(scores) != 2 {
+			t.Fatalf("got %d scores, want a user and an offer score", len(scores))
+		}
+		user, offer := scores[0], scores[1]
+		if user.SubjectType != models.ReputationSubjectUser || offer.SubjectType != models.ReputationSubjectUserSkill || offer.SubjectID != offerID || offer.UserID != 1 {
+			t.Errorf("unexpected subjects: %+v, %+v", user, offer)
+		}
+
+		// The review's value is 60% overall rating and 40% sub-ratings: 0.6*5 + 0.4*3 = 4.2
+		if want := roundScore((5*4 + 4.2) / 6); user.BayesianRating != want {
+			t.Errorf("BayesianRating = %v, want %v", user.BayesianRating, want)
+		}
+		// The communication sub-score falls back to the overall prior
+		if want := roundScore((5*4 + 3.0) / 6); user.CommunicationScore != want {
+			t.Errorf("CommunicationScore = %v, want %v", user.CommunicationScore, want)
+		}
+		if user.PunctualityScore != 0 || user.KnowledgeScore != 0 {
+			t.Errorf("sub-scores without ratings should stay 0, got %v and %v", user.PunctualityScore, user.KnowledgeScore)
+		}
+	})
+
+	t.Run("falls back to the default prior", func(t *testing.T) {
+		scores := s.computeScores([]repository.ReputationReviewRow{review(1, 5)}, nil, now)
+		if want := roundScore((5*reputationFallbackPrior + 5) / 6); scores[0].BayesianRating != want {
+			t.Errorf("BayesianRating = %v, want %v", scores[0].BayesianRating, want)
+		}
+	})
+}
@@ -0,0 +1,377 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+)
+
+// ReviewModerationService handles review reports, helpful votes, reviewee replies
+// and the moderator queue
+type ReviewModerationService struct {
+	reviewRepo          *repository.ReviewRepository
+	moderationRepo      *repository.ReviewModerationRepository
+	notificationService *NotificationService
+	reputationService   *ReputationService
+	audit               *AuditScope
+}
+
+// NewReviewModerationService creates a new review moderation service
+func NewReviewModerationService(
+	reviewRepo *repository.ReviewRepository,
+	moderationRepo *repository.ReviewModerationRepository,
+	notificationService *NotificationService,
+	reputationService *ReputationService,
+) *ReviewModerationService {
+	return &ReviewModerationService{
+		reviewRepo:          reviewRepo,
+		moderationRepo:      moderationRepo,
+		notificationService: notificationService,
+		reputationService:   reputationService,
+	}
+}
+
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *ReviewModerationService) WithAudit(audit *AuditScope) *ReviewModerationService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
+// ReportReview reports a visible review to moderators
+// Each user can report a review once; reviewers can't report their own reviews
+func (s *ReviewModerationService) ReportReview(userID, reviewID uint, req *dto.ReportReviewRequest) (*models.ReviewReport, error) {
+	review, err := s.getVisibleReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.ReviewerID == userID {
+		return nil, errors.New("you cannot report your own review")
+	}
+
+	details := strings.TrimSpace(req.Details)
+	reason := models.ReviewReportReason(req.Reason)
+	if reason == models.ReviewReportOther && details == "" {
+		return nil, errors.New("details are required when the reason is other")
+	}
+
+	report := &models.ReviewReport{
+		ReviewID:   reviewID,
+		ReporterID: userID,
+		Reason:     reason,
+		Details:    details,
+		Status:     models.ReviewReportPending,
+	}
+	created, err := s.moderationRepo.CreateReport(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to report review: %w", err)
+	}
+	if !created {
+		return nil, errors.New("you have already reported this review")
+	}
+	s.audit.Record(models.AuditActionCreate, "review_reports", report.ID, nil, report)
+
+	return report, nil
+}
+
+// MarkHelpful records the user's helpful vote on a visible review
+// Voting again is a no-op; reviewers can't vote on their own reviews
+func (s *ReviewModerationService) MarkHelpful(userID, reviewID uint) (*dto.ReviewHelpfulResponse, error) {
+	review, err := s.getVisibleReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.ReviewerID == userID {
+		return nil, errors.New("you cannot vote on your own review")
+	}
+
+	if _, err := s.moderationRepo.AddHelpfulVote(reviewID, userID); err != nil {
+		return nil, fmt.Errorf("failed to record vote: %w", err)
+	}
+	return s.helpfulResponse(reviewID, true)
+}
+
+// UnmarkHelpful removes the user's helpful vote
+func (s *ReviewModerationService) UnmarkHelpful(userID, reviewID uint) (*dto.ReviewHelpfulResponse, error) {
+	if _, err := s.getVisibleReview(reviewID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.moderationRepo.RemoveHelpfulVote(reviewID, userID); err != nil {
+		return nil, fmt.Errorf("failed to remove vote: %w", err)
+	}
+	return s.helpfulResponse(reviewID, false)
+}
+
+// SaveReply creates or edits the reviewee's public response to a review
+// Only the reviewee can respond, and only once per review; the reviewer is
+// notified when the response is first posted
+func (s *ReviewModerationService) SaveReply(userID, reviewID uint, req *dto.ReviewReplyRequest) (*dto.ReviewReplyResponse, error) {
+	review, err := s.getVisibleReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.RevieweeID != userID {
+		return nil, errors.New("only the reviewed user can respond to this review")
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, errors.New("response cannot be empty")
+	}
+
+	before, _ := s.moderationRepo.GetReply(reviewID)
+	if err := s.moderationRepo.SaveReply(&models.ReviewReply{
+		ReviewID: reviewID,
+		AuthorID: userID,
+		Body:     body,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save response: %w", err)
+	}
+
+	reply, err := s.moderationRepo.GetReply(reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if before == nil {
+		s.audit.Record(models.AuditActionCreate, "review_replies", reply.ID, nil, reply)
+		_, _ = s.notificationService.CreateNotification(
+			review.ReviewerID,
+			models.NotificationTypeReview,
+			"Response To Your Review",
+			fmt.Sprintf("%s responded to your review", review.Reviewee.FullName),
+			map[string]interface{}{
+				"review_id": review.ID,
+			},
+		)
+	} else {
+		s.audit.Record(models.AuditActionUpdate, "review_replies", reply.ID, before, reply)
+	}
+
+	return dto.MapReviewReplyToResponse(reply), nil
+}
+
+// DeleteReply deletes the reviewee's response to a review
+func (s *ReviewModerationService) DeleteReply(userID, reviewID uint) error {
+	reply, err := s.moderationRepo.GetReply(reviewID)
+	if err != nil {
+		return errors.New("response not found")
+	}
+	if reply.AuthorID != userID {
+		return errors.New("you can only delete your own response")
+	}
+
+	if err := s.moderationRepo.DeleteReply(reviewID); err != nil {
+		return err
+	}
+	s.audit.Record(models.AuditActionDelete, "review_replies", reply.ID, reply, nil)
+	return nil
+}
+
+// GetModerationQueue lists reported reviews with their pending reports, most reported first
+func (s *ReviewModerationService) GetModerationQueue(limit, offset int) (*dto.ReviewModerationQueueResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	reviews, total, err := s.moderationRepo.ListReported(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewIDs := make([]uint, 0, len(reviews))
+	for _, review := range reviews {
+		reviewIDs = append(reviewIDs, review.ID)
+	}
+	reports, err := s.moderationRepo.ListReports(reviewIDs, models.ReviewReportPending)
+	if err != nil {
+		return nil, err
+	}
+	reportsByReview := make(map[uint][]models.ReviewReport)
+	for _, report := range reports {
+		reportsByReview[report.ReviewID] = append(reportsByReview[report.ReviewID], report)
+	}
+
+	response := &dto.ReviewModerationQueueResponse{
+		Items:  make([]dto.ReviewModerationItem, 0, len(reviews)),
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+	for i := range reviews {
+		response.Items = append(response.Items, dto.MapReviewToModerationItem(&reviews[i], reportsByReview[reviews[i].ID]))
+	}
+	return response, nil
+}
+
+// GetModerationItem gets a review with its full report history, including hidden reviews
+func (s *ReviewModerationService) GetModerationItem(reviewID uint) (*dto.ReviewModerationItem, error) {
+	review, err := s.getReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := s.moderationRepo.ListReports([]uint{reviewID}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	item := dto.MapReviewToModerationItem(review, reports)
+	return &item, nil
+}
+
+// HideReview hides a review from listings and rating aggregates
+// Pending reports are marked actioned and the reviewer is told why
+func (s *ReviewModerationService) HideReview(adminID, reviewID uint, reason string) (*dto.ReviewModerationItem, error) {
+	review, err := s.getReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.IsHidden {
+		return nil, errors.New("review is already hidden")
+	}
+
+	return s.setHidden(adminID, review, true, reason)
+}
+
+// RestoreReview makes a review visible again, or keeps a reported review visible
+// Pending reports are dismissed
+func (s *ReviewModerationService) RestoreReview(adminID, reviewID uint, reason string) (*dto.ReviewModerationItem, error) {
+	review, err := s.getReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if !review.IsHidden && !review.IsReported {
+		return nil, errors.New("review is neither hidden nor reported")
+	}
+
+	return s.setHidden(adminID, review, false, reason)
+}
+
+// DeleteReview deletes a review as a moderator
+// Pending reports are marked actioned and the reviewer is told why
+func (s *ReviewModerationService) DeleteReview(adminID, reviewID uint, reason string) error {
+	review, err := s.getReview(reviewID)
+	if err != nil {
+		return err
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.New("reason is required")
+	}
+
+	if err := s.moderationRepo.DeleteModerated(reviewID, adminID, reason, time.Now()); err != nil {
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+	s.audit.Record(models.AuditActionDelete, "reviews", reviewID, review, nil)
+	s.refreshReputation(review.RevieweeID)
+
+	_, _ = s.notificationService.CreateNotification(
+		review.ReviewerID,
+		models.NotificationTypeReview,
+		"Review Removed",
+		fmt.Sprintf("Your review of %s was removed by a moderator: %s", review.Reviewee.FullName, reason),
+		map[string]interface{}{
+			"review_id": review.ID,
+		},
+	)
+
+	return nil
+}
+
+// setHidden applies a hide or restore decision and notifies the reviewer
+func (s *ReviewModerationService) setHidden(adminID uint, review *models.Review, hidden bool, reason string) (*dto.ReviewModerationItem, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	if err := s.moderationRepo.SetHidden(review.ID, adminID, hidden, reason, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to moderate review: %w", err)
+	}
+
+	updated, err := s.getReview(review.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(models.AuditActionUpdate, "reviews", review.ID, review, updated)
+
+	reports, err := s.moderationRepo.ListReports([]uint{review.ID}, "")
+	if err != nil {
+		return nil, err
+	}
+	item := dto.MapReviewToModerationItem(updated, reports)
+
+	if hidden != review.IsHidden {
+		s.refreshReputation(review.RevieweeID)
+
+		title, verb := "Review Hidden", "hidden"
+		if !hidden {
+			title, verb = "Review Restored", "restored"
+		}
+		_, _ = s.notificationService.CreateNotification(
+			review.ReviewerID,
+			models.NotificationTypeReview,
+			title,
+			fmt.Sprintf("Your review of %s was %s by a moderator: %s", review.Reviewee.FullName, verb, reason),
+			map[string]interface{}{
+				"review_id": review.ID,
+			},
+		)
+	}
+
+	return &item, nil
+}
+
+// refreshReputation rebuilds a reviewee's reputation scores after moderation
+// Failures are only logged; the periodic recompute repairs them
+func (s *ReviewModerationService) refreshReputation(revieweeID uint) {
+	if err := s.reputationService.RecomputeForUsers(revieweeID); err != nil {
+		log.Printf("Failed to refresh reputation for user %d: %v", revieweeID, err)
+	}
+}
+
+// helpfulResponse reports a review's helpful count after a vote change
+func (s *ReviewModerationService) helpfulResponse(reviewID uint, voted bool) (*dto.ReviewHelpfulResponse, error) {
+	count, err := s.moderationRepo.GetHelpfulCount(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ReviewHelpfulResponse{
+		ReviewID:     reviewID,
+		HelpfulCount: count,
+		Voted:        voted,
+	}, nil
+}
+
+// getReview loads a review, mapping a missing row to "review not found"
+func (s *ReviewModerationService) getReview(reviewID uint) (*models.Review, error) {
+	review, err := s.reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return nil, errors.New("review not found")
+	}
+	return review, nil
+}
+
+// getVisibleReview loads a review users can interact with; hidden and sealed reviews read as missing
+func (s *ReviewModerationService) getVisibleReview(reviewID uint) (*models.Review, error) {
+	review, err := s.getReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.IsHidden || !review.IsPublished() {
+		return nil, errors.New("review not found")
+	}
+	return review, nil
+}
@@ -3,7 +3,10 @@ package service
 import (
 	"errors"
 	"fmt"
+	"log"
+	"time"
 
+	"github.com/timebankingskill/backend/internal/config"
 	"github.com/timebankingskill/backend/internal/dto"
 	"github.com/timebankingskill/backend/internal/models"
 	"github.com/timebankingskill/backend/internal/repository"
@@ -15,34 +18,59 @@ type ReviewService struct {
 	sessionRepo         *repository.SessionRepository
 	userRepo            *repository.UserRepository
 	notificationService *NotificationService
+	reputationService   *ReputationService
+	badgeService        *BadgeService
+	xpService           *XPService
+	config              config.ReviewConfig
+	audit               *AuditScope
 }
 
+// reviewReleaseInterval is how often sealed reviews past their window are checked
+const reviewReleaseInterval = time.Hour
+
 // NewReviewService creates a new review service
 func NewReviewService(
 	reviewRepo *repository.ReviewRepository,
 	sessionRepo *repository.SessionRepository,
 	userRepo *repository.UserRepository,
 	notificationService *NotificationService,
+	reputationService *ReputationService,
+	badgeService *BadgeService,
+	xpService *XPService,
+	cfg config.ReviewConfig,
 ) *ReviewService {
 	return &ReviewService{
 		reviewRepo:          reviewRepo,
 		sessionRepo:         sessionRepo,
 		userRepo:            userRepo,
 		notificationService: notificationService,
+		reputationService:   reputationService,
+		badgeService:        badgeService,
+		xpService:           xpService,
+		config:              cfg,
 	}
 }
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *ReviewService) WithAudit(audit *AuditScope) *ReviewService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
 // CreateReview creates a new review for a completed session
-// Implements bidirectional review system: both teacher and student can review each other
+// Implements bidirectional, double-blind review system: both teacher and student can
+// review each other, and neither sees the other's review until both are in or the
+// review window closes, so ratings can't be retaliatory
 //
 // Review Flow:
 //   1. Validates rating is within 1-5 range
-//   2. Validates session exists and is completed
+//   2. Validates session exists, is completed and its review window is still open
 //   3. Determines review type (teacher reviewing student OR student reviewing teacher)
 //   4. Prevents duplicate reviews from same reviewer
-//   5. Creates review record with ratings and optional comment
-//   6. Updates user's average rating statistics
-//   7. Sends notification to reviewee
+//   5. Creates the review sealed; if the other party already reviewed, both are published
+//   6. Updates rating statistics and reputation scores for published reviews
+//   7. Notifies the reviewee (without the rating while sealed)
 //
 // Review Types:
 //   - ReviewTypeTeacher: Student reviewing the teacher
@@ -88,6 +116,12 @@ func (s *ReviewService) CreateReview(reviewerID uint, req *dto.CreateReviewReque
 		return nil, errors.New("can only review completed sessions")
 	}
 
+	// WINDOW CHECK: Reviews are only accepted until the blind window closes,
+	// after which any sealed review on the session has been published
+	if time.Now().After(s.reviewDeadline(session)) {
+		return nil, errors.New("the review window for this session has closed")
+	}
+
 	// ROLE DETERMINATION: Identify reviewer role and who is being reviewed
 	// Teacher reviewing student vs Student reviewing teacher
 	var reviewType models.ReviewType
@@ -130,6 +164,14 @@ func (s *ReviewService) CreateReview(reviewerID uint, req *dto.CreateReviewReque
 	if err := s.reviewRepo.Create(review); err != nil {
 		return nil, fmt.Errorf("failed to create review: %w", err)
 	}
+	s.audit.Record(models.AuditActionCreate, "reviews", review.ID, nil, review)
+
+	// XP: Writing the review earns XP whether or not it's published yet
+	// Keyed on the session so deleting and rewriting the review earns nothing more
+	// Skill swap legs earn no XP, like their completion
+	if session.SkillSwapID == nil {
+		s.xpService.Award(reviewerID, models.XPReviewWritten, "session", session.ID)
+	}
 
 	// RELOAD: Fetch review with relationships for response
 	review, err = s.reviewRepo.GetByID(review.ID)
@@ -137,30 +179,40 @@ func (s *ReviewService) CreateReview(reviewerID uint, req *dto.CreateReviewReque
 		return nil, err
 	}
 
-	// Send review notification to reviewee
-	reviewer, _ := s.userRepo.GetByID(reviewerID)
-	notificationData := map[string]interface{}{
-		"reviewID":   review.ID,
-		"rating":     req.Rating,
-		"reviewerName": reviewer.FullName,
+	if review.IsPublished() {
+		// Both parties are in: release both reviews
+		reviews, err := s.reviewRepo.GetReviewsForSession(review.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		s.announcePublished(reviews)
+	} else {
+		// Tell the reviewee a review is waiting without revealing it
+		_, _ = s.notificationService.CreateNotification(
+			revieweeID,
+			models.NotificationTypeReview,
+			"You Have A New Review",
+			fmt.Sprintf("%s reviewed your session. Leave your review to see theirs; both are revealed together within %d days.",
+				review.Reviewer.FullName, s.config.BlindWindowDays),
+			map[string]interface{}{
+				"sessionID": review.SessionID,
+			},
+		)
 	}
-	_, _ = s.notificationService.CreateNotification(
-		revieweeID,
-		models.NotificationTypeReview,
-		"New Review Received! ⭐",
-		fmt.Sprintf("%s gave you a %d-star review", reviewer.FullName, req.Rating),
-		notificationData,
-	)
 
 	return dto.MapReviewToResponse(review), nil
 }
 
 // GetReview gets a specific review by ID
-func (s *ReviewService) GetReview(id uint) (*dto.ReviewResponse, error) {
+// A sealed review is only visible to its reviewer until it is published
+func (s *ReviewService) GetReview(id, viewerID uint) (*dto.ReviewResponse, error) {
 	review, err := s.reviewRepo.GetByID(id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get review: %w", err)
 	}
+	if !review.IsPublished() && review.ReviewerID != viewerID {
+		return nil, errors.New("review not found")
+	}
 	return dto.MapReviewToResponse(review), nil
 }
 
@@ -211,47 +263,49 @@ func (s *ReviewService) GetReviewsForUserByType(userID uint, reviewType string,
 }
 
 // GetUserRatingSummary gets comprehensive rating summary for a user
-// Calculates overall rating and breaks down by role (as teacher vs as student)
+// Breaks ratings down by role (as teacher vs as student) and includes the
+// reputation scores, which weigh review volume, recency and reviewer credibility
 func (s *ReviewService) GetUserRatingSummary(userID uint) (map[string]interface{}, error) {
-	// FETCH OVERALL RATING: Get average rating across all reviews
-	avgRating, err := s.reviewRepo.GetAverageRatingForUser(userID)
+	// FETCH ROLE STATS: Count and average visible reviews per role in one query
+	stats, err := s.reviewRepo.GetRatingStatsForUser(userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get average rating: %w", err)
-	}
-
-	// FETCH TOTAL COUNT: Get total number of reviews received
-	count, err := s.reviewRepo.GetRatingCountForUser(userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get rating count: %w", err)
+		return nil, fmt.Errorf("failed to get rating stats: %w", err)
+	}
+
+	var count, teacherCount, studentCount int64
+	var ratingSum, avgTeacherRating, avgStudentRating float64
+	for _, stat := range stats {
+		count += stat.Count
+		ratingSum += stat.Average * float64(stat.Count)
+		switch stat.Type {
+		case models.ReviewTypeTeacher:
+			teacherCount, avgTeacherRating = stat.Count, stat.Average
+		case models.ReviewTypeStudent:
+			studentCount, avgStudentRating = stat.Count, stat.Average
+		}
 	}
 
-	// FETCH TEACHER REVIEWS: Get all reviews where user was teaching
-	// These are reviews from students about the user's teaching ability
-	teacherReviews, _, err := s.reviewRepo.GetReviewsForUserByType(userID, models.ReviewTypeTeacher, 1000, 0)
-	if err != nil {
-		return nil, err
+	avgRating := 0.0
+	if count > 0 {
+		avgRating = ratingSum / float64(count)
 	}
 
-	// FETCH STUDENT REVIEWS: Get all reviews where user was learning
-	// These are reviews from teachers about the user's learning behavior
-	studentReviews, _, err := s.reviewRepo.GetReviewsForUserByType(userID, models.ReviewTypeStudent, 1000, 0)
+	// FETCH REPUTATION: Stored Bayesian/Wilson scores per role
+	reputation, err := s.reputationService.GetUserReputation(userID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get reputation: %w", err)
 	}
 
-	// CALCULATE ROLE-BASED AVERAGES: Compute separate ratings for each role
-	// This allows users to see how they're perceived as a teacher vs learner
-	avgTeacherRating := calculateAverageRating(teacherReviews)
-	avgStudentRating := calculateAverageRating(studentReviews)
-
 	// BUILD RESPONSE: Return comprehensive rating breakdown
 	return map[string]interface{}{
-		"average_rating":          avgRating,          // Overall average rating
-		"total_reviews":           count,              // Total reviews received
-		"average_teacher_rating":  avgTeacherRating,   // Average rating as teacher
-		"teacher_review_count":    len(teacherReviews), // Number of teaching reviews
-		"average_student_rating":  avgStudentRating,   // Average rating as student
-		"student_review_count":    len(studentReviews), // Number of student reviews
+		"average_rating":         avgRating,          // Overall average rating
+		"total_reviews":          count,              // Total reviews received
+		"average_teacher_rating": avgTeacherRating,   // Average rating as teacher
+		"teacher_review_count":   teacherCount,       // Number of teaching reviews
+		"average_student_rating": avgStudentRating,   // Average rating as student
+		"student_review_count":   studentCount,       // Number of student reviews
+		"teacher_reputation":     reputation.Teacher, // Reputation as teacher, null without reviews
+		"student_reputation":     reputation.Student, // Reputation as student, null without reviews
 	}, nil
 }
 
@@ -268,6 +322,12 @@ func (s *ReviewService) UpdateReview(reviewID, userID uint, req *dto.UpdateRevie
 		return nil, errors.New("you can only edit your own reviews")
 	}
 
+	// Published reviews are locked so neither party can react to the other's review
+	if review.IsPublished() {
+		return nil, errors.New("published reviews can no longer be edited")
+	}
+	before := *review
+
 	// Update fields
 	if req.Rating > 0 {
 		if req.Rating < 1 || req.Rating > 5 {
@@ -300,6 +360,8 @@ func (s *ReviewService) UpdateReview(reviewID, userID uint, req *dto.UpdateRevie
 	if err := s.reviewRepo.Update(review); err != nil {
 		return nil, fmt.Errorf("failed to update review: %w", err)
 	}
+	s.audit.Record(models.AuditActionUpdate, "reviews", reviewID, &before, review)
+	s.refreshReputation(review.RevieweeID)
 
 	// Reload
 	review, err = s.reviewRepo.GetByID(reviewID)
@@ -311,6 +373,7 @@ func (s *ReviewService) UpdateReview(reviewID, userID uint, req *dto.UpdateRevie
 }
 
 // DeleteReview deletes a review (soft delete)
+// Reviewers can only delete their reviews while they are still sealed
 func (s *ReviewService) DeleteReview(reviewID, userID uint) error {
 	// Get review
 	review, err := s.reviewRepo.GetByID(reviewID)
@@ -323,20 +386,99 @@ func (s *ReviewService) DeleteReview(reviewID, userID uint) error {
 		return errors.New("you can only delete your own reviews")
 	}
 
+	// Published reviews are locked like edits; deleting one and writing a new review
+	// would let the reviewer react to the counterpart's now-visible review
+	if review.IsPublished() {
+		return errors.New("published reviews can no longer be deleted")
+	}
+
 	// Delete
-	return s.reviewRepo.Delete(reviewID)
+	if err := s.reviewRepo.Delete(reviewID); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionDelete, "reviews", reviewID, review, nil)
+	s.refreshReputation(review.RevieweeID)
+	return nil
+}
+
+// ReleaseExpiredReviews publishes sealed reviews whose blind window has closed
+// Returns the number of reviews published
+func (s *ReviewService) ReleaseExpiredReviews(now time.Time) (int, error) {
+	cutoff := now.AddDate(0, 0, -s.config.BlindWindowDays)
+	published, err := s.reviewRepo.PublishExpired(cutoff, now)
+	if err != nil {
+		return 0, err
+	}
+	s.announcePublished(published)
+	return len(published), nil
+}
+
+// StartReleaseWorker periodically publishes sealed reviews past their blind window
+func (s *ReviewService) StartReleaseWorker() {
+	go func() {
+		ticker := time.NewTicker(reviewReleaseInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			count, err := s.ReleaseExpiredReviews(time.Now())
+			if err != nil {
+				log.Printf("Failed to release sealed reviews: %v", err)
+				continue
+			}
+			if count > 0 {
+				log.Printf("Released %d sealed reviews", count)
+			}
+		}
+	}()
 }
 
-// Helper function to calculate average rating from reviews
-func calculateAverageRating(reviews []models.Review) float64 {
-	if len(reviews) == 0 {
-		return 0
+// reviewDeadline is when a session's blind window closes
+func (s *ReviewService) reviewDeadline(session *models.Session) time.Time {
+	completedAt := session.UpdatedAt
+	if session.CompletedAt != nil {
+		completedAt = *session.CompletedAt
 	}
+	return completedAt.AddDate(0, 0, s.config.BlindWindowDays)
+}
 
-	sum := 0
+// announcePublished refreshes the reviewees' reputation and badge progress and
+// tells them their reviews are visible
+func (s *ReviewService) announcePublished(reviews []models.Review) {
 	for _, review := range reviews {
-		sum += review.Rating
+		if !review.IsPublished() {
+			continue
+		}
+		s.refreshReputation(review.RevieweeID)
+		if s.badgeService != nil {
+			s.badgeService.HandleEvent(review.RevieweeID, BadgeEventReviewReceived)
+		}
+
+		reviewerName := review.Reviewer.FullName
+		if reviewerName == "" {
+			if reviewer, err := s.userRepo.GetByID(review.ReviewerID); err == nil {
+				reviewerName = reviewer.FullName
+			}
+		}
+
+		_, _ = s.notificationService.CreateNotification(
+			review.RevieweeID,
+			models.NotificationTypeReview,
+			"New Review Received! ⭐",
+			fmt.Sprintf("%s gave you a %d-star review", reviewerName, review.Rating),
+			map[string]interface{}{
+				"reviewID":     review.ID,
+				"rating":       review.Rating,
+				"reviewerName": reviewerName,
+			},
+		)
 	}
+}
 
-	return float64(sum) / float64(len(reviews))
+// refreshReputation rebuilds a reviewee's reputation scores after one of their reviews changed
+// Failures are only logged; the periodic recompute repairs them
+func (s *ReviewService) refreshReputation(revieweeID uint) {
+	if err := s.reputationService.RecomputeForUsers(revieweeID); err != nil {
+		log.Printf("Failed to refresh reputation for user %d: %v", revieweeID, err)
+	}
 }
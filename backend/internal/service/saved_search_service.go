@@ -0,0 +1,414 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/config"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxSavedSearchesPerUser caps how many searches a user can save
+	maxSavedSearchesPerUser = 10
+	// maxSavedSearchBaseline caps the existing matches recorded as seen when a search is saved
+	maxSavedSearchBaseline = 1000
+	// savedSearchPageSize is how many changed offers are examined per query during a check
+	savedSearchPageSize = 200
+	// savedSearchAlertNames is how many teacher names an alert lists
+	savedSearchAlertNames = 3
+)
+
+// SavedSearchService handles saved marketplace searches and their new-match alerts
+// A saved search stores the same filters as the offer search endpoint. A background
+// job re-runs active searches against offers created or updated since the last
+// check and notifies the user about teachers the search had not seen before.
+type SavedSearchService struct {
+	savedSearchRepo     *repository.SavedSearchRepository
+	marketplaceRepo     *repository.MarketplaceRepository
+	marketplaceService  *MarketplaceService
+	notificationService *NotificationService
+	config              config.SavedSearchConfig
+	audit               *AuditScope
+}
+
+// NewSavedSearchService creates a new saved search service
+func NewSavedSearchService(
+	savedSearchRepo *repository.SavedSearchRepository,
+	marketplaceRepo *repository.MarketplaceRepository,
+	marketplaceService *MarketplaceService,
+	notificationService *NotificationService,
+	cfg config.SavedSearchConfig,
+) *SavedSearchService {
+	return &SavedSearchService{
+		savedSearchRepo:     savedSearchRepo,
+		marketplaceRepo:     marketplaceRepo,
+		marketplaceService:  marketplaceService,
+		notificationService: notificationService,
+		config:              cfg,
+	}
+}
+
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *SavedSearchService) WithAudit(audit *AuditScope) *SavedSearchService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
+// CreateSavedSearch saves a search; offers that already match are not alerted
+func (s *SavedSearchService) CreateSavedSearch(userID uint, req *dto.CreateSavedSearchRequest) (*dto.SavedSearchResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	count, err := s.savedSearchRepo.CountByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxSavedSearchesPerUser {
+		return nil, fmt.Errorf("you can save at most %d searches", maxSavedSearchesPerUser)
+	}
+
+	filters, seen, err := s.prepareFilters(userID, &req.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	search := &models.SavedSearch{
+		UserID:        userID,
+		Name:          name,
+		Filters:       filters,
+		LastCheckedAt: time.Now(),
+	}
+	if err := s.savedSearchRepo.Create(search, seen); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(models.AuditActionCreate, "saved_searches", search.ID, nil, search)
+	return toSavedSearchResponse(search), nil
+}
+
+// GetSavedSearches lists the user's saved searches
+func (s *SavedSearchService) GetSavedSearches(userID uint) ([]dto.SavedSearchResponse, error) {
+	searches, err := s.savedSearchRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.SavedSearchResponse, len(searches))
+	for i := range searches {
+		responses[i] = *toSavedSearchResponse(&searches[i])
+	}
+	return responses, nil
+}
+
+// GetSavedSearch gets one of the user's saved searches
+func (s *SavedSearchService) GetSavedSearch(userID, searchID uint) (*dto.SavedSearchResponse, error) {
+	search, err := s.getOwned(userID, searchID)
+	if err != nil {
+		return nil, err
+	}
+	return toSavedSearchResponse(search), nil
+}
+
+// UpdateSavedSearch renames a saved search and/or replaces its filters
+// New filters restart alerts from now, treating current matches as seen
+func (s *SavedSearchService) UpdateSavedSearch(userID, searchID uint, req *dto.UpdateSavedSearchRequest) (*dto.SavedSearchResponse, error) {
+	search, err := s.getOwned(userID, searchID)
+	if err != nil {
+		return nil, err
+	}
+	before := *search
+
+	if req.Name != nil {
+		search.Name = strings.TrimSpace(*req.Name)
+		if search.Name == "" {
+			return nil, errors.New("name is required")
+		}
+	}
+
+	var seen []uint
+	if req.Filters != nil {
+		if search.Filters, seen, err = s.prepareFilters(userID, req.Filters); err != nil {
+			return nil, err
+		}
+		search.LastCheckedAt = time.Now()
+	}
+
+	if err := s.savedSearchRepo.Update(search, seen); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(models.AuditActionUpdate, "saved_searches", search.ID, &before, search)
+	return toSavedSearchResponse(search), nil
+}
+
+// SetPaused pauses or resumes a saved search's alerts
+// Resuming restarts alerts from now so offers added while paused don't arrive in a burst
+func (s *SavedSearchService) SetPaused(userID, searchID uint, paused bool) (*dto.SavedSearchResponse, error) {
+	search, err := s.getOwned(userID, searchID)
+	if err != nil {
+		return nil, err
+	}
+	if search.IsPaused == paused {
+		return toSavedSearchResponse(search), nil
+	}
+	before := *search
+
+	var seen []uint
+	if !paused {
+		var req dto.OfferSearchRequest
+		if err := json.Unmarshal(search.Filters, &req); err != nil {
+			return nil, errors.New("saved search filters are invalid")
+		}
+		if _, seen, err = s.prepareFilters(userID, &req); err != nil {
+			return nil, err
+		}
+		search.LastCheckedAt = time.Now()
+	}
+	search.IsPaused = paused
+
+	if err := s.savedSearchRepo.Update(search, seen); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(models.AuditActionUpdate, "saved_searches", search.ID, &before, search)
+	return toSavedSearchResponse(search), nil
+}
+
+// DeleteSavedSearch deletes one of the user's saved searches
+func (s *SavedSearchService) DeleteSavedSearch(userID, searchID uint) error {
+	search, err := s.getOwned(userID, searchID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.savedSearchRepo.Delete(search.ID); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionDelete, "saved_searches", search.ID, search, nil)
+	return nil
+}
+
+// GetSavedSearchResults runs a saved search against the marketplace now
+func (s *SavedSearchService) GetSavedSearchResults(userID, searchID uint, page, limit int) (*dto.OfferSearchResponse, error) {
+	search, err := s.getOwned(userID, searchID)
+	if err != nil {
+		return nil, err
+	}
+
+	var req dto.OfferSearchRequest
+	if err := json.Unmarshal(search.Filters, &req); err != nil {
+		return nil, errors.New("saved search filters are invalid")
+	}
+	req.Page = page
+	req.Limit = limit
+
+	return s.marketplaceService.SearchOffers(&req)
+}
+
+// StartAlertWorker checks saved searches for new matches in the background
+func (s *SavedSearchService) StartAlertWorker() {
+	go func() {
+		ticker := time.NewTicker(s.config.CheckInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if sent, err := s.CheckSavedSearches(time.Now()); err != nil {
+				log.Printf("Failed to check saved searches: %v", err)
+			} else if sent > 0 {
+				log.Printf("Sent %d saved search alerts", sent)
+			}
+		}
+	}()
+}
+
+// CheckSavedSearches notifies users about offers that newly match their saved searches
+// A search that fails to match or record keeps its watermark, so the next run retries it
+//
+// Flow:
+//  1. Matches every offer created or updated since the search's watermark, page by page
+//  2. Records the matches and moves the watermark to now in one transaction, keeping
+//     only offers the search had not seen (skips searches another instance already checked)
+//  3. Sends one notification per search with new matches
+//
+// Returns:
+//   - int: Number of notifications sent
+//   - error: If saved searches cannot be listed
+func (s *SavedSearchService) CheckSavedSearches(now time.Time) (int, error) {
+	searches, err := s.savedSearchRepo.ListActive()
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range searches {
+		search := &searches[i]
+
+		var req dto.OfferSearchRequest
+		if err := json.Unmarshal(search.Filters, &req); err != nil {
+			log.Printf("Skipping saved search %d with invalid filters: %v", search.ID, err)
+			continue
+		}
+		filter, err := BuildOfferFilter(&req)
+		if err != nil {
+			log.Printf("Skipping saved search %d with invalid filters: %v", search.ID, err)
+			continue
+		}
+		filter.ExcludeUserID = &search.UserID
+		filter.ChangedAfter = &search.LastCheckedAt
+
+		ids, names, err := s.listMatches(filter)
+		if err != nil {
+			log.Printf("Failed to match saved search %d: %v", search.ID, err)
+			continue
+		}
+
+		added, claimed, err := s.savedSearchRepo.CompleteCheck(search.ID, search.LastCheckedAt, now, ids)
+		if err != nil {
+			log.Printf("Failed to record saved search %d matches: %v", search.ID, err)
+			continue
+		}
+		if !claimed || len(added) == 0 {
+			continue
+		}
+
+		teachers := make([]string, 0, savedSearchAlertNames)
+		for _, id := range added {
+			if len(teachers) == savedSearchAlertNames {
+				break
+			}
+			teachers = append(teachers, names[id])
+		}
+		message := fmt.Sprintf("%d new teachers match your saved search \"%s\": %s", len(added), search.Name, strings.Join(teachers, ", "))
+		if len(added) == 1 {
+			message = fmt.Sprintf("%s now matches your saved search \"%s\"", teachers[0], search.Name)
+		} else if len(added) > len(teachers) {
+			message += fmt.Sprintf(" and %d more", len(added)-len(teachers))
+		}
+
+		_, _ = s.notificationService.CreateNotification(
+			search.UserID,
+			models.NotificationTypeSocial,
+			"New Teachers For Your Search",
+			message,
+			map[string]interface{}{
+				"savedSearchID": search.ID,
+				"matchCount":    len(added),
+				"userSkillIDs":  added,
+			},
+		)
+		sent++
+	}
+
+	return sent, nil
+}
+
+// listMatches pages through every offer matching the filter
+// Returns the offer (user skill) IDs in ID order and each offer's teacher name
+func (s *SavedSearchService) listMatches(filter repository.OfferFilter) ([]uint, map[uint]string, error) {
+	var ids []uint
+	names := map[uint]string{}
+	var afterID uint
+	for {
+		offers, err := s.marketplaceRepo.ListOffersAfter(filter, afterID, savedSearchPageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, offer := range offers {
+			ids = append(ids, offer.UserSkillID)
+			names[offer.UserSkillID] = offer.FullName
+		}
+		if len(offers) < savedSearchPageSize {
+			return ids, names, nil
+		}
+		afterID = offers[len(offers)-1].UserSkillID
+	}
+}
+
+// prepareFilters validates search filters and lists the offers they already match
+// Returns the filters as stored and the current matches to record as seen
+func (s *SavedSearchService) prepareFilters(userID uint, req *dto.OfferSearchRequest) (datatypes.JSON, []uint, error) {
+	filter, err := BuildOfferFilter(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !hasOfferCriteria(filter) {
+		return nil, nil, errors.New("a saved search needs at least one filter")
+	}
+	if req.Sort != "" {
+		switch req.Sort {
+		case repository.OfferSortRelevance, repository.OfferSortRating, repository.OfferSortPriceAsc,
+			repository.OfferSortPriceDesc, repository.OfferSortPopularity:
+		default:
+			return nil, nil, fmt.Errorf("invalid sort: %s", req.Sort)
+		}
+	}
+
+	filters, err := json.Marshal(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter.ExcludeUserID = &userID
+	offers, err := s.marketplaceRepo.ListOffers(filter, maxSavedSearchBaseline)
+	if err != nil {
+		return nil, nil, err
+	}
+	seen := make([]uint, len(offers))
+	for i, offer := range offers {
+		seen[i] = offer.UserSkillID
+	}
+
+	return datatypes.JSON(filters), seen, nil
+}
+
+// getOwned loads a saved search, mapping a missing or foreign row to "saved search not found"
+func (s *SavedSearchService) getOwned(userID, searchID uint) (*models.SavedSearch, error) {
+	search, err := s.savedSearchRepo.GetByID(searchID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("saved search not found")
+		}
+		return nil, err
+	}
+	if search.UserID != userID {
+		return nil, errors.New("saved search not found")
+	}
+	return search, nil
+}
+
+// hasOfferCriteria checks that a filter narrows the marketplace at all
+func hasOfferCriteria(filter repository.OfferFilter) bool {
+	return filter.Search != "" || filter.SkillID != nil || filter.Category != "" || len(filter.Levels) > 0 ||
+		filter.MinRate != nil || filter.MaxRate != nil || filter.Mode != "" || filter.Location != "" ||
+		filter.MinRating != nil || filter.DayOfWeek != nil || filter.Time != "" || filter.School != "" ||
+		filter.Grade != "" || filter.Verified
+}
+
+// toSavedSearchResponse converts a saved search to its response DTO
+func toSavedSearchResponse(search *models.SavedSearch) *dto.SavedSearchResponse {
+	response := &dto.SavedSearchResponse{
+		ID:            search.ID,
+		Name:          search.Name,
+		IsPaused:      search.IsPaused,
+		LastCheckedAt: search.LastCheckedAt,
+		LastMatchedAt: search.LastMatchedAt,
+		MatchCount:    search.MatchCount,
+		CreatedAt:     search.CreatedAt,
+		UpdatedAt:     search.UpdatedAt,
+	}
+	_ = json.Unmarshal(search.Filters, &response.Filters)
+	return response
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+)
+
+const (
+	minSearchQueryLength = 2
+	maxSearchQueryLength = 200
+)
+
+// SearchService handles unified full-text search
+// Covers skills, teacher offers, forum threads and replies, and success stories
+type SearchService struct {
+	searchRepo *repository.SearchRepository
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(searchRepo *repository.SearchRepository) *SearchService {
+	return &SearchService{searchRepo: searchRepo}
+}
+
+// Search runs a ranked search across the requested document types
+//
+// Matching:
+//   - Full-text: query parsed with websearch syntax ("quoted phrases", -exclude, or)
+//     in every search language, matched against weighted search vectors
+//   - Typo tolerance: word similarity on titles (bodies for replies and offers) via pg_trgm when installed
+//
+// Parameters:
+//   - req: Query text, optional types filter and page
+//
+// Returns:
+//   - *SearchResponse: Ranked hits with highlighted snippets and per-type counts
+//   - error: If the query or a type is invalid, or database error
+func (s *SearchService) Search(req *dto.SearchRequest) (*dto.SearchResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	length := utf8.RuneCountInString(query)
+	if length < minSearchQueryLength {
+		return nil, fmt.Errorf("query must be at least %d characters", minSearchQueryLength)
+	}
+	if length > maxSearchQueryLength {
+		return nil, fmt.Errorf("query must be at most %d characters", maxSearchQueryLength)
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 50 {
+		limit = 20
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	types, err := parseSearchTypes(req.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, err := s.searchRepo.Search(query, types, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	typeCounts, err := s.searchRepo.CountByType(query, types)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	response := &dto.SearchResponse{
+		Query:   query,
+		Results: make([]dto.SearchResult, len(hits)),
+		Counts:  make(map[string]int64, len(types)),
+		Limit:   limit,
+		Offset:  offset,
+	}
+	for _, t := range types {
+		response.Counts[string(t)] = 0
+	}
+	for _, c := range typeCounts {
+		response.Counts[string(c.Type)] = c.Count
+		response.Total += c.Count
+	}
+	for i, hit := range hits {
+		response.Results[i] = dto.SearchResult{
+			Type:      string(hit.Type),
+			ID:        hit.ID,
+			Title:     hit.Title,
+			Snippet:   hit.Snippet,
+			Rank:      hit.Rank,
+			ParentID:  hit.ParentID,
+			AuthorID:  hit.AuthorID,
+			CreatedAt: hit.CreatedAt,
+		}
+	}
+
+	return response, nil
+}
+
+// parseSearchTypes validates the requested types, defaulting to all
+// Types may be repeated (?types=skill&types=story) or comma-separated (?types=skill,story)
+func parseSearchTypes(raw []string) ([]models.SearchResultType, error) {
+	seen := map[models.SearchResultType]bool{}
+	var types []models.SearchResultType
+
+	for _, value := range raw {
+		for _, t := range strings.Split(value, ",") {
+			t = strings.TrimSpace(t)
+			if t == "" {
+				continue
+			}
+			searchType := models.SearchResultType(t)
+			if !isValidSearchType(searchType) {
+				return nil, fmt.Errorf("invalid search type: %s", t)
+			}
+			if !seen[searchType] {
+				seen[searchType] = true
+				types = append(types, searchType)
+			}
+		}
+	}
+
+	if len(types) == 0 {
+		types = models.SearchTypes
+	}
+	return types, nil
+}
+
+// isValidSearchType checks that a search type is one of the known constants
+func isValidSearchType(t models.SearchResultType) bool {
+	for _, known := range models.SearchTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"log"
 	"time"
 
 	"github.com/timebankingskill/backend/internal/dto"
@@ -15,6 +16,12 @@ type SessionService struct {
 	skillRepo          *repository.SkillRepository
 	transactionRepo    *repository.TransactionRepository
 	notificationService *NotificationService
+	fraudService        *FraudService
+	badgeService        *BadgeService
+	streakService       *StreakService
+	challengeService    *ChallengeService
+	xpService           *XPService
+	audit               *AuditScope
 }
 
 func NewSessionService(
@@ -23,6 +30,11 @@ func NewSessionService(
 	skillRepo *repository.SkillRepository,
 	transactionRepo *repository.TransactionRepository,
 	notificationService *NotificationService,
+	fraudService *FraudService,
+	badgeService *BadgeService,
+	streakService *StreakService,
+	challengeService *ChallengeService,
+	xpService *XPService,
 ) *SessionService {
 	return &SessionService{
 		sessionRepo:         sessionRepo,
@@ -30,9 +42,21 @@ func NewSessionService(
 		skillRepo:           skillRepo,
 		transactionRepo:     transactionRepo,
 		notificationService: notificationService,
+		fraudService:        fraudService,
+		badgeService:        badgeService,
+		streakService:       streakService,
+		challengeService:    challengeService,
+		xpService:           xpService,
 	}
 }
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *SessionService) WithAudit(audit *AuditScope) *SessionService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
 // BookSession creates a new session request from student to teacher
 // This is the entry point for students to request learning sessions with tutors
 //
@@ -130,6 +154,7 @@ func (s *SessionService) BookSession(studentID uint, req *dto.CreateSessionReque
 	if err := s.sessionRepo.Create(session); err != nil {
 		return nil, errors.New("failed to create session")
 	}
+	s.audit.Record(models.AuditActionCreate, "sessions", session.ID, nil, session)
 
 	// Reload session with relationships
 	session, err = s.sessionRepo.GetByID(session.ID)
@@ -198,6 +223,7 @@ func (s *SessionService) ApproveSession(teacherID, sessionID uint, req *dto.Appr
 	if err != nil {
 		return nil, errors.New("session not found")
 	}
+	before := *session
 
 	// Authorization check: verify teacher owns this session
 	if session.TeacherID != teacherID {
@@ -209,6 +235,11 @@ func (s *SessionService) ApproveSession(teacherID, sessionID uint, req *dto.Appr
 		return nil, errors.New("session is not pending approval")
 	}
 
+	// Swap legs are approved together when every participant accepts the swap
+	if session.SkillSwapID != nil {
+		return nil, errors.New("swap sessions are approved by accepting the skill swap")
+	}
+
 	// Fetch student to verify credit availability
 	student, err := s.userRepo.GetByID(session.StudentID)
 	if err != nil {
@@ -263,6 +294,7 @@ func (s *SessionService) ApproveSession(teacherID, sessionID uint, req *dto.Appr
 	if err := s.sessionRepo.Update(session); err != nil {
 		return nil, errors.New("failed to approve session")
 	}
+	s.audit.Record(models.AuditActionUpdate, "sessions", session.ID, &before, session)
 
 	// Send notification to student about session approval
 	teacher, _ := s.userRepo.GetByID(teacherID)
@@ -310,6 +342,7 @@ func (s *SessionService) RejectSession(teacherID, sessionID uint, req *dto.Rejec
 	if err != nil {
 		return nil, errors.New("session not found")
 	}
+	before := *session
 
 	// Verify teacher owns this session
 	if session.TeacherID != teacherID {
@@ -321,6 +354,10 @@ func (s *SessionService) RejectSession(teacherID, sessionID uint, req *dto.Rejec
 		return nil, errors.New("session is not pending")
 	}
 
+	if session.SkillSwapID != nil {
+		return nil, errors.New("swap sessions are rejected by declining the skill swap")
+	}
+
 	// Update session
 	session.Status = models.StatusRejected
 	session.CancellationReason = req.Reason
@@ -329,6 +366,7 @@ func (s *SessionService) RejectSession(teacherID, sessionID uint, req *dto.Rejec
 	if err := s.sessionRepo.Update(session); err != nil {
 		return nil, errors.New("failed to reject session")
 	}
+	s.audit.Record(models.AuditActionUpdate, "sessions", session.ID, &before, session)
 
 	return dto.MapSessionToResponse(session), nil
 }
@@ -351,15 +389,17 @@ func (s *SessionService) RejectSession(teacherID, sessionID uint, req *dto.Rejec
 // Parameters:
 //   - userID: ID of user checking in (teacher or student)
 //   - sessionID: ID of session to check in to
+//   - clientIP: IP address of the check-in request (used for fraud detection)
 //
 // Returns:
 //   - *SessionResponse: Updated session (may be in_progress if both checked in)
 //   - error: If user not authorized or session cannot be checked in
-func (s *SessionService) CheckIn(userID, sessionID uint) (*dto.SessionResponse, error) {
+func (s *SessionService) CheckIn(userID, sessionID uint, clientIP string) (*dto.SessionResponse, error) {
 	session, err := s.sessionRepo.GetByID(sessionID)
 	if err != nil {
 		return nil, errors.New("session not found")
 	}
+	before := *session
 
 	// Verify user is part of this session
 	isTeacher := session.TeacherID == userID
@@ -382,6 +422,7 @@ func (s *SessionService) CheckIn(userID, sessionID uint) (*dto.SessionResponse,
 		}
 		session.TeacherCheckedIn = true
 		session.TeacherCheckedInAt = &now
+		session.TeacherCheckInIP = clientIP
 	}
 	if isStudent {
 		if session.StudentCheckedIn {
@@ -389,6 +430,7 @@ func (s *SessionService) CheckIn(userID, sessionID uint) (*dto.SessionResponse,
 		}
 		session.StudentCheckedIn = true
 		session.StudentCheckedInAt = &now
+		session.StudentCheckInIP = clientIP
 	}
 
 	// Check if both parties have now checked in
@@ -454,6 +496,7 @@ func (s *SessionService) CheckIn(userID, sessionID uint) (*dto.SessionResponse,
 	if err := s.sessionRepo.Update(session); err != nil {
 		return nil, errors.New("failed to check in")
 	}
+	s.audit.Record(models.AuditActionUpdate, "sessions", session.ID, &before, session)
 
 	// Reload session with relationships
 	session, _ = s.sessionRepo.GetByID(sessionID)
@@ -489,6 +532,7 @@ func (s *SessionService) StartSession(userID, sessionID uint) (*dto.SessionRespo
 	if err != nil {
 		return nil, errors.New("session not found")
 	}
+	before := *session
 
 	// Verify user is part of this session
 	if session.TeacherID != userID && session.StudentID != userID {
@@ -508,6 +552,7 @@ func (s *SessionService) StartSession(userID, sessionID uint) (*dto.SessionRespo
 	if err := s.sessionRepo.Update(session); err != nil {
 		return nil, errors.New("failed to start session")
 	}
+	s.audit.Record(models.AuditActionUpdate, "sessions", session.ID, &before, session)
 
 	return dto.MapSessionToResponse(session), nil
 }
@@ -541,6 +586,7 @@ func (s *SessionService) ConfirmCompletion(userID, sessionID uint, req *dto.Comp
 	if err != nil {
 		return nil, errors.New("session not found")
 	}
+	before := *session
 
 	// Verify user is part of this session
 	isTeacher := session.TeacherID == userID
@@ -581,6 +627,7 @@ func (s *SessionService) ConfirmCompletion(userID, sessionID uint, req *dto.Comp
 			return nil, errors.New("failed to confirm completion")
 		}
 	}
+	s.audit.Record(models.AuditActionUpdate, "sessions", session.ID, &before, session)
 
 	// Reload session
 	session, _ = s.sessionRepo.GetByID(sessionID)
@@ -589,12 +636,96 @@ func (s *SessionService) ConfirmCompletion(userID, sessionID uint, req *dto.Comp
 
 // completeSession finalizes the session and transfers credits
 // This is called when both teacher and student confirm session completion
-// CREDIT RELEASE PHASE: Transfers held credits from escrow to teacher
+// CREDIT RELEASE PHASE: Transfers held credits from escrow to teacher,
+// unless fraud detection flags the session, in which case credits stay
+// held until an admin clears or confirms the flag
 func (s *SessionService) completeSession(session *models.Session) error {
 	// Mark session as completed with current timestamp
 	now := time.Now()
 	session.Status = models.StatusCompleted
 	session.CompletedAt = &now
+
+	// FRAUD CHECK: Score the session before moving any credits
+	// Swap legs carry no credits, and their reciprocal teaching is by design
+	// If scoring fails the session is held for manual review rather than released unchecked
+	var assessment *FraudAssessment
+	if s.fraudService != nil && session.SkillSwapID == nil {
+		var err error
+		assessment, err = s.fraudService.ScoreSession(session)
+		if err != nil {
+			log.Printf("Failed to score session %d for fraud, holding it for review: %v", session.ID, err)
+			assessment = &FraudAssessment{
+				Flagged: true,
+				Signals: map[models.FraudSignal]float64{models.FraudSignalScoringFailed: 0},
+			}
+		}
+	}
+
+	if assessment != nil && assessment.Flagged {
+		session.FraudHold = true
+	} else if session.SkillSwapID == nil {
+		if err := s.releaseCredits(session); err != nil {
+			return err
+		}
+	}
+
+	// Persist all session changes to database
+	// The teaching skill's and both parties' session counters are refreshed with it,
+	// and a held session's review queue entry is created in the same transaction
+	if session.FraudHold {
+		if err := s.sessionRepo.HoldForReview(session, s.fraudService.NewFlag(session, assessment)); err != nil {
+			return errors.New("failed to queue session for review")
+		}
+	} else if err := s.sessionRepo.UpdateCompletion(session); err != nil {
+		return err
+	}
+
+	if session.FraudHold {
+		notificationData := map[string]interface{}{
+			"sessionID": session.ID,
+		}
+		for _, userID := range []uint{session.TeacherID, session.StudentID} {
+			_, _ = s.notificationService.CreateNotification(
+				userID,
+				models.NotificationTypeCredit,
+				"Credits Under Review",
+				"Session \""+session.Title+"\" is completed. Its credits are held while our team reviews it.",
+				notificationData,
+			)
+		}
+	}
+
+	// BADGES, STREAKS, CHALLENGES & XP: Sessions held for fraud review count once cleared
+	// Skill swap legs skip fraud scoring, so they earn no progress either
+	if !session.FraudHold && session.SkillSwapID == nil {
+		s.recordBadgeEvent(BadgeEventSessionCompleted, session.TeacherID, session.StudentID)
+		s.recordActivity(session)
+	}
+
+	return nil
+}
+
+// recordBadgeEvent updates the users' badge progress after a domain event
+func (s *SessionService) recordBadgeEvent(event BadgeEvent, userIDs ...uint) {
+	if s.badgeService == nil {
+		return
+	}
+	for _, userID := range userIDs {
+		s.badgeService.HandleEvent(userID, event)
+	}
+}
+
+// recordActivity updates both parties' streaks, challenge progress and XP after a session completes
+func (s *SessionService) recordActivity(session *models.Session) {
+	s.streakService.RecordActivity(session.TeacherID, session.StudentID)
+	s.challengeService.RecordActivity(session.TeacherID, session.StudentID)
+	s.xpService.Award(session.TeacherID, models.XPSessionCompleted, "session", session.ID)
+	s.xpService.Award(session.StudentID, models.XPSessionCompleted, "session", session.ID)
+}
+
+// releaseCredits moves a session's held credits from the student to the teacher
+// and records the ledger transactions. Does not persist the session itself.
+func (s *SessionService) releaseCredits(session *models.Session) error {
 	session.CreditReleased = true
 
 	// CREDIT TRANSFER: Release held credits to teacher
@@ -648,16 +779,7 @@ func (s *SessionService) completeSession(session *models.Session) error {
 	}
 	_ = s.transactionRepo.Create(spentTransaction)
 
-	// Update skill statistics
-	// Increment session count for this teaching skill
-	userSkill, _ := s.skillRepo.GetUserSkillByID(session.UserSkillID)
-	if userSkill != nil {
-		userSkill.TotalSessions++
-		_ = s.skillRepo.UpdateUserSkill(userSkill)
-	}
-
-	// Persist all session changes to database
-	return s.sessionRepo.Update(session)
+	return nil
 }
 
 // CancelSession allows either party to cancel a session
@@ -688,6 +810,7 @@ func (s *SessionService) CancelSession(userID, sessionID uint, req *dto.CancelSe
 	if err != nil {
 		return nil, errors.New("session not found")
 	}
+	before := *session
 
 	// Verify user is part of this session
 	if session.TeacherID != userID && session.StudentID != userID {
@@ -699,6 +822,11 @@ func (s *SessionService) CancelSession(userID, sessionID uint, req *dto.CancelSe
 		return nil, errors.New("session cannot be cancelled")
 	}
 
+	// Cancelling one leg would leave the other participants teaching for free
+	if session.SkillSwapID != nil {
+		return nil, errors.New("swap sessions are cancelled by cancelling the skill swap")
+	}
+
 	// If credits were held, release them back to student's available balance
 	if session.CreditHeld && !session.CreditReleased {
 		student, err := s.userRepo.GetByID(session.StudentID)
@@ -732,6 +860,7 @@ func (s *SessionService) CancelSession(userID, sessionID uint, req *dto.CancelSe
 	if err := s.sessionRepo.Update(session); err != nil {
 		return nil, errors.New("failed to cancel session")
 	}
+	s.audit.Record(models.AuditActionUpdate, "sessions", session.ID, &before, session)
 
 	return dto.MapSessionToResponse(session), nil
 }
@@ -812,3 +941,81 @@ func (s *SessionService) GetPendingRequests(teacherID uint) ([]dto.SessionRespon
 	}
 	return dto.MapSessionsToResponse(sessions), nil
 }
+
+// ReviewFraudFlag resolves a fraud flag from the admin review queue
+//
+// Decisions:
+//   - cleared: Session was legitimate; held credits are released to the teacher
+//   - confirmed: Session was credit farming; held credits return to the student
+//     and the session is marked disputed
+//
+// Parameters:
+//   - adminID: ID of reviewing admin
+//   - flagID: ID of the fraud flag
+//   - clear: true to clear the session, false to confirm fraud
+//   - note: Review note stored on the flag
+//
+// Returns:
+//   - *FraudFlag: Resolved flag
+//   - error: If flag not found, already reviewed, or credit movement fails
+func (s *SessionService) ReviewFraudFlag(adminID, flagID uint, clear bool, note string) (*models.FraudFlag, error) {
+	if s.fraudService == nil {
+		return nil, errors.New("fraud detection is not enabled")
+	}
+
+	flag, err := s.fraudService.GetFlag(flagID)
+	if err != nil {
+		return nil, err
+	}
+	flagBefore := *flag
+
+	session, err := s.sessionRepo.GetByID(flag.SessionID)
+	if err != nil {
+		return nil, errors.New("session not found")
+	}
+	before := *session
+
+	// The flag is checked to be pending, and its session on hold, under lock while settling,
+	// so only one of two concurrent reviews can release the held credits
+	status := models.FraudFlagCleared
+	if !clear {
+		status = models.FraudFlagConfirmed
+	}
+	flag, session, err = s.fraudService.ResolveFlag(flagID, adminID, status, note)
+	if err != nil {
+		return nil, err
+	}
+	flag.Session, flag.Teacher, flag.Student = *session, flagBefore.Teacher, flagBefore.Student
+	s.audit.Record(models.AuditActionUpdate, "sessions", session.ID, &before, session)
+	s.audit.Record(models.AuditActionUpdate, "fraud_flags", flag.ID, &flagBefore, flag)
+
+	// Notify both parties of the outcome
+	title := "Session Review Completed"
+	message := "Session \"" + session.Title + "\" passed review and its credits have been released."
+	if !clear {
+		message = "Session \"" + session.Title + "\" failed review. Held credits were returned to the student."
+	}
+	notificationData := map[string]interface{}{
+		"sessionID": session.ID,
+		"status":    status,
+	}
+	for _, userID := range []uint{session.TeacherID, session.StudentID} {
+		_, _ = s.notificationService.CreateNotification(
+			userID,
+			models.NotificationTypeCredit,
+			title,
+			message,
+			notificationData,
+		)
+	}
+
+	// BADGE PROGRESS: A cleared session now counts towards session badges,
+	// and its released credits towards credit badges
+	if clear {
+		s.recordBadgeEvent(BadgeEventSessionCompleted, session.TeacherID, session.StudentID)
+		s.recordBadgeEvent(BadgeEventCreditsEarned, session.TeacherID, session.StudentID)
+		s.recordActivity(session)
+	}
+
+	return flag, nil
+}
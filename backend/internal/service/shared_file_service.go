@@ -16,6 +16,7 @@ type SharedFileService struct {
 	sessionRepo   *repository.SessionRepository
 	userRepo      *repository.UserRepository
 	notificationService *NotificationService
+	audit         *AuditScope
 }
 
 // NewSharedFileService creates a new shared file service
@@ -46,6 +47,13 @@ func NewSharedFileServiceWithNotification(
 	}
 }
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *SharedFileService) WithAudit(audit *AuditScope) *SharedFileService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
 // UploadFile uploads a file to a session
 func (s *SharedFileService) UploadFile(
 	userID uint,
@@ -92,6 +100,7 @@ func (s *SharedFileService) UploadFile(
 	if err := s.fileRepo.Create(sharedFile); err != nil {
 		return nil, err
 	}
+	s.audit.Record(models.AuditActionCreate, "shared_files", sharedFile.ID, nil, sharedFile)
 
 	// Send notification to other participant
 	if s.notificationService != nil {
@@ -158,7 +167,11 @@ func (s *SharedFileService) DeleteFile(userID uint, fileID uint) error {
 		return errors.New("unauthorized to delete this file")
 	}
 
-	return s.fileRepo.Delete(fileID)
+	if err := s.fileRepo.Delete(fileID); err != nil {
+		return err
+	}
+	s.audit.Record(models.AuditActionDelete, "shared_files", file.ID, file, nil)
+	return nil
 }
 
 // GetSessionFileStats gets file statistics for a session
@@ -0,0 +1,555 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Assessment rule defaults and bounds
+const (
+	defaultAssessmentTimeLimit  = 15  // Minutes
+	maxAssessmentTimeLimit      = 180 // Minutes
+	defaultAssessmentPassScore  = 70  // Percent
+	defaultAssessmentCooldown   = 24  // Hours
+	maxAssessmentCooldown       = 720 // Hours
+	assessmentSubmitGracePeriod = 30 * time.Second
+)
+
+// AssessmentEditor identifies who is authoring an assessment
+// Teachers are identified by user ID, admins by admin ID
+type AssessmentEditor struct {
+	UserID  uint
+	AdminID uint
+}
+
+// IsAdmin checks if the editor is an admin
+func (e AssessmentEditor) IsAdmin() bool {
+	return e.AdminID != 0
+}
+
+// SkillAssessmentService handles placement assessments and their timed attempts
+// Admins and teachers verified for a skill author multiple-choice quizzes; users
+// take them against the clock and are scored server-side. A pass confirms the
+// level a teacher claims or sets a learner's starting level.
+type SkillAssessmentService struct {
+	assessmentRepo      *repository.SkillAssessmentRepository
+	skillRepo           *repository.SkillRepository
+	notificationService *NotificationService
+	audit               *AuditScope
+}
+
+// NewSkillAssessmentService creates a new skill assessment service
+func NewSkillAssessmentService(
+	assessmentRepo *repository.SkillAssessmentRepository,
+	skillRepo *repository.SkillRepository,
+	notificationService *NotificationService,
+) *SkillAssessmentService {
+	return &SkillAssessmentService{
+		assessmentRepo:      assessmentRepo,
+		skillRepo:           skillRepo,
+		notificationService: notificationService,
+	}
+}
+
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *SkillAssessmentService) WithAudit(audit *AuditScope) *SkillAssessmentService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
+// CreateAssessment authors a new, unpublished assessment
+// Teachers may only author assessments for skills they are currently verified in
+func (s *SkillAssessmentService) CreateAssessment(editor AssessmentEditor, req *dto.CreateAssessmentRequest) (*models.SkillAssessment, error) {
+	if _, err := s.skillRepo.GetByID(req.SkillID); err != nil {
+		return nil, errors.New("skill not found")
+	}
+
+	if !editor.IsAdmin() {
+		userSkill, err := s.skillRepo.GetUserSkill(editor.UserID, req.SkillID)
+		if err != nil || !userSkill.IsCurrentlyVerified(time.Now()) {
+			return nil, errors.New("only teachers verified for this skill can author its assessments")
+		}
+	}
+
+	assessment := &models.SkillAssessment{
+		SkillID:             req.SkillID,
+		Title:               strings.TrimSpace(req.Title),
+		Description:         strings.TrimSpace(req.Description),
+		Level:               models.SkillLevel(req.Level),
+		TimeLimitMinutes:    req.TimeLimitMinutes,
+		PassScore:           req.PassScore,
+		RetakeCooldownHours: defaultAssessmentCooldown,
+	}
+	if req.RetakeCooldownHours != nil {
+		assessment.RetakeCooldownHours = *req.RetakeCooldownHours
+	}
+	if editor.IsAdmin() {
+		assessment.AdminID = &editor.AdminID
+	} else {
+		assessment.AuthorID = &editor.UserID
+	}
+	if err := applyAssessmentRules(assessment); err != nil {
+		return nil, err
+	}
+
+	questions, err := buildAssessmentQuestions(req.Questions)
+	if err != nil {
+		return nil, err
+	}
+	assessment.Questions = questions
+
+	if err := s.assessmentRepo.Create(assessment); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(models.AuditActionCreate, "skill_assessments", assessment.ID, nil, assessment)
+	return s.assessmentRepo.GetByID(assessment.ID)
+}
+
+// UpdateAssessment edits an assessment's rules and optionally replaces its questions
+// Refused while attempts are running so nobody is scored against changed questions
+func (s *SkillAssessmentService) UpdateAssessment(editor AssessmentEditor, assessmentID uint, req *dto.UpdateAssessmentRequest) (*models.SkillAssessment, error) {
+	assessment, err := s.getEditable(editor, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	before := *assessment
+
+	if req.Title != nil {
+		assessment.Title = strings.TrimSpace(*req.Title)
+		if assessment.Title == "" {
+			return nil, errors.New("title is required")
+		}
+	}
+	if req.Description != nil {
+		assessment.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Level != nil {
+		assessment.Level = models.SkillLevel(*req.Level)
+	}
+	if req.TimeLimitMinutes != nil {
+		assessment.TimeLimitMinutes = *req.TimeLimitMinutes
+	}
+	if req.PassScore != nil {
+		assessment.PassScore = *req.PassScore
+	}
+	if req.RetakeCooldownHours != nil {
+		assessment.RetakeCooldownHours = *req.RetakeCooldownHours
+	}
+	if err := applyAssessmentRules(assessment); err != nil {
+		return nil, err
+	}
+
+	var questions []models.AssessmentQuestion
+	if req.Questions != nil {
+		if questions, err = buildAssessmentQuestions(req.Questions); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.assessmentRepo.Update(assessment, questions); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(models.AuditActionUpdate, "skill_assessments", assessment.ID, &before, assessment)
+	return s.assessmentRepo.GetByID(assessment.ID)
+}
+
+// SetPublished makes an assessment available to takers or withdraws it
+func (s *SkillAssessmentService) SetPublished(editor AssessmentEditor, assessmentID uint, published bool) (*models.SkillAssessment, error) {
+	assessment, err := s.getOwned(editor, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment.IsPublished == published {
+		return assessment, nil
+	}
+	if published && len(assessment.Questions) == 0 {
+		return nil, errors.New("assessment has no questions")
+	}
+
+	if err := s.assessmentRepo.SetPublished(assessment.ID, published); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(models.AuditActionUpdate, "skill_assessments", assessment.ID,
+		map[string]interface{}{"is_published": assessment.IsPublished},
+		map[string]interface{}{"is_published": published})
+	assessment.IsPublished = published
+	return assessment, nil
+}
+
+// DeleteAssessment deletes an assessment that has no attempts running
+func (s *SkillAssessmentService) DeleteAssessment(editor AssessmentEditor, assessmentID uint) error {
+	assessment, err := s.getEditable(editor, assessmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.assessmentRepo.Delete(assessment.ID); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionDelete, "skill_assessments", assessment.ID, assessment, nil)
+	return nil
+}
+
+// GetAssessmentForEditor gets an assessment including its correct answers
+func (s *SkillAssessmentService) GetAssessmentForEditor(editor AssessmentEditor, assessmentID uint) (*models.SkillAssessment, error) {
+	return s.getOwned(editor, assessmentID)
+}
+
+// ListAuthored lists a teacher's own assessments, published or not
+func (s *SkillAssessmentService) ListAuthored(userID uint, limit, offset int) ([]models.SkillAssessment, int64, error) {
+	limit, offset = clampAssessmentPage(limit, offset)
+	return s.assessmentRepo.List(nil, &userID, false, limit, offset)
+}
+
+// ListAll lists every assessment for moderators, optionally for one skill
+func (s *SkillAssessmentService) ListAll(skillID *uint, limit, offset int) ([]models.SkillAssessment, int64, error) {
+	limit, offset = clampAssessmentPage(limit, offset)
+	return s.assessmentRepo.List(skillID, nil, false, limit, offset)
+}
+
+// ListPublished lists assessments open to takers, optionally for one skill
+func (s *SkillAssessmentService) ListPublished(skillID *uint, limit, offset int) (*dto.AssessmentListResponse, error) {
+	limit, offset = clampAssessmentPage(limit, offset)
+
+	assessments, total, err := s.assessmentRepo.List(skillID, nil, true, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AssessmentListResponse{
+		Assessments: make([]dto.AssessmentResponse, len(assessments)),
+		Total:       total,
+		Limit:       limit,
+		Offset:      offset,
+	}
+	for i := range assessments {
+		response.Assessments[i] = dto.ToAssessmentResponse(&assessments[i])
+	}
+	return response, nil
+}
+
+// GetPublished gets a published assessment as shown to takers
+func (s *SkillAssessmentService) GetPublished(assessmentID uint) (*dto.AssessmentResponse, error) {
+	assessment, err := s.getAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if !assessment.IsPublished {
+		return nil, errors.New("assessment not found")
+	}
+
+	response := dto.ToAssessmentResponse(assessment)
+	return &response, nil
+}
+
+// StartAttempt starts a timed attempt, or resumes the user's running one
+//
+// Flow:
+//   1. Expires the user's attempts whose time ran out
+//   2. Returns the running attempt if there is one
+//   3. Refuses users who already passed or are still in the retake cooldown
+//   4. Starts a new attempt with the assessment's time limit
+func (s *SkillAssessmentService) StartAttempt(userID, assessmentID uint) (*dto.AssessmentAttemptResponse, error) {
+	assessment, err := s.getAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if !assessment.IsPublished {
+		return nil, errors.New("assessment not found")
+	}
+
+	now := time.Now()
+	if err := s.assessmentRepo.ExpireAttempts(userID, now.Add(-assessmentSubmitGracePeriod)); err != nil {
+		return nil, err
+	}
+
+	if open, err := s.assessmentRepo.GetOpenAttempt(assessment.ID, userID, now); err == nil {
+		open.Assessment = *assessment
+		response := dto.ToAssessmentAttemptResponse(open)
+		return &response, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	passed, err := s.assessmentRepo.HasPassed(assessment.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if passed {
+		return nil, errors.New("you have already passed this assessment")
+	}
+
+	last, err := s.assessmentRepo.GetLastFinishedAttempt(assessment.ID, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if last != nil {
+		last.Assessment = *assessment
+		if retakeAt := dto.ToAssessmentAttemptResponse(last).RetakeAvailableAt; retakeAt != nil && now.Before(*retakeAt) {
+			return nil, fmt.Errorf("you can retake this assessment after %s", retakeAt.Format(time.RFC3339))
+		}
+	}
+
+	attempt := &models.AssessmentAttempt{
+		AssessmentID:   assessment.ID,
+		UserID:         userID,
+		Status:         models.AttemptInProgress,
+		StartedAt:      now,
+		Deadline:       now.Add(time.Duration(assessment.TimeLimitMinutes) * time.Minute),
+		TotalQuestions: len(assessment.Questions),
+	}
+	if err := s.assessmentRepo.CreateAttempt(attempt); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(models.AuditActionCreate, "assessment_attempts", attempt.ID, nil, attempt)
+	attempt.Assessment = *assessment
+	response := dto.ToAssessmentAttemptResponse(attempt)
+	return &response, nil
+}
+
+// SubmitAttempt scores an attempt and applies the level when it passes
+//
+// Flow:
+//   1. Verifies the attempt belongs to the user and is still running
+//   2. Marks it expired if submitted after the deadline (plus a short grace period)
+//   3. Otherwise scores the answers against the stored correct options
+//   4. A pass records the assessed level on the user's teaching skill and/or
+//      the placement level of their learning progress
+func (s *SkillAssessmentService) SubmitAttempt(userID, attemptID uint, req *dto.SubmitAttemptRequest) (*dto.AssessmentAttemptResponse, error) {
+	attempt, err := s.getAttempt(userID, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt.Status != models.AttemptInProgress {
+		return nil, fmt.Errorf("attempt is already %s", attempt.Status)
+	}
+	before := *attempt
+
+	now := time.Now()
+	attempt.SubmittedAt = &now
+	attempt.TotalQuestions = len(attempt.Assessment.Questions)
+
+	if now.After(attempt.Deadline.Add(assessmentSubmitGracePeriod)) {
+		attempt.Status = models.AttemptExpired
+	} else {
+		attempt.Status = models.AttemptSubmitted
+		attempt.Answers = datatypes.JSONMap{}
+		attempt.CorrectCount = 0
+		for _, question := range attempt.Assessment.Questions {
+			answer, ok := req.Answers[question.ID]
+			if !ok {
+				continue
+			}
+			attempt.Answers[strconv.FormatUint(uint64(question.ID), 10)] = answer
+			if answer == question.CorrectOption {
+				attempt.CorrectCount++
+			}
+		}
+		if attempt.TotalQuestions > 0 {
+			attempt.Score = math.Round(float64(attempt.CorrectCount)/float64(attempt.TotalQuestions)*10000) / 100
+		}
+		attempt.Passed = attempt.TotalQuestions > 0 && attempt.Score >= attempt.Assessment.PassScore
+	}
+
+	if err := s.assessmentRepo.FinishAttempt(attempt, attempt.Assessment.SkillID, attempt.Assessment.Level); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(models.AuditActionUpdate, "assessment_attempts", attempt.ID, &before, attempt)
+
+	if attempt.Passed {
+		_, _ = s.notificationService.CreateNotification(
+			userID,
+			models.NotificationTypeAchievement,
+			"Assessment Passed",
+			fmt.Sprintf("You passed %s and were placed at %s level in %s",
+				attempt.Assessment.Title, attempt.Assessment.Level, attempt.Assessment.Skill.Name),
+			map[string]interface{}{
+				"attempt_id":    attempt.ID,
+				"assessment_id": attempt.AssessmentID,
+				"skill_id":      attempt.Assessment.SkillID,
+				"level":         attempt.Assessment.Level,
+				"score":         attempt.Score,
+			},
+		)
+	}
+
+	response := dto.ToAssessmentAttemptResponse(attempt)
+	return &response, nil
+}
+
+// GetAttempt gets one of the user's attempts
+func (s *SkillAssessmentService) GetAttempt(userID, attemptID uint) (*dto.AssessmentAttemptResponse, error) {
+	attempt, err := s.getAttempt(userID, attemptID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.ToAssessmentAttemptResponse(attempt)
+	return &response, nil
+}
+
+// GetUserAttempts lists the user's attempts, newest first
+func (s *SkillAssessmentService) GetUserAttempts(userID uint, limit, offset int) (*dto.AssessmentAttemptListResponse, error) {
+	limit, offset = clampAssessmentPage(limit, offset)
+
+	if err := s.assessmentRepo.ExpireAttempts(userID, time.Now().Add(-assessmentSubmitGracePeriod)); err != nil {
+		return nil, err
+	}
+
+	attempts, total, err := s.assessmentRepo.ListAttemptsByUser(userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AssessmentAttemptListResponse{
+		Attempts: make([]dto.AssessmentAttemptResponse, len(attempts)),
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}
+	for i := range attempts {
+		response.Attempts[i] = dto.ToAssessmentAttemptResponse(&attempts[i])
+	}
+	return response, nil
+}
+
+// getAssessment loads an assessment, mapping a missing row to "assessment not found"
+func (s *SkillAssessmentService) getAssessment(assessmentID uint) (*models.SkillAssessment, error) {
+	assessment, err := s.assessmentRepo.GetByID(assessmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("assessment not found")
+		}
+		return nil, err
+	}
+	return assessment, nil
+}
+
+// getOwned loads an assessment the editor may manage; teachers only manage their own
+func (s *SkillAssessmentService) getOwned(editor AssessmentEditor, assessmentID uint) (*models.SkillAssessment, error) {
+	assessment, err := s.getAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if !editor.IsAdmin() && (assessment.AuthorID == nil || *assessment.AuthorID != editor.UserID) {
+		return nil, errors.New("assessment not found")
+	}
+	return assessment, nil
+}
+
+// getEditable loads an owned assessment and verifies nobody is currently taking it
+func (s *SkillAssessmentService) getEditable(editor AssessmentEditor, assessmentID uint) (*models.SkillAssessment, error) {
+	assessment, err := s.getOwned(editor, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := s.assessmentRepo.CountOpenAttempts(assessment.ID, time.Now().Add(-assessmentSubmitGracePeriod))
+	if err != nil {
+		return nil, err
+	}
+	if open > 0 {
+		return nil, errors.New("assessment has attempts in progress; try again once they finish")
+	}
+	return assessment, nil
+}
+
+// getAttempt loads one of the user's attempts, mapping a missing or foreign row to "attempt not found"
+func (s *SkillAssessmentService) getAttempt(userID, attemptID uint) (*models.AssessmentAttempt, error) {
+	attempt, err := s.assessmentRepo.GetAttempt(attemptID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("attempt not found")
+		}
+		return nil, err
+	}
+	if attempt.UserID != userID {
+		return nil, errors.New("attempt not found")
+	}
+	return attempt, nil
+}
+
+// applyAssessmentRules validates an assessment's level and fills in default rules
+func applyAssessmentRules(assessment *models.SkillAssessment) error {
+	if !isValidSkillLevel(assessment.Level) {
+		return errors.New("invalid skill level")
+	}
+
+	if assessment.TimeLimitMinutes == 0 {
+		assessment.TimeLimitMinutes = defaultAssessmentTimeLimit
+	}
+	if assessment.TimeLimitMinutes < 1 || assessment.TimeLimitMinutes > maxAssessmentTimeLimit {
+		return fmt.Errorf("time limit must be between 1 and %d minutes", maxAssessmentTimeLimit)
+	}
+
+	if assessment.PassScore == 0 {
+		assessment.PassScore = defaultAssessmentPassScore
+	}
+	if assessment.PassScore < 1 || assessment.PassScore > 100 {
+		return errors.New("pass score must be between 1 and 100")
+	}
+
+	if assessment.RetakeCooldownHours < 0 || assessment.RetakeCooldownHours > maxAssessmentCooldown {
+		return fmt.Errorf("retake cooldown must be between 0 and %d hours", maxAssessmentCooldown)
+	}
+	return nil
+}
+
+// buildAssessmentQuestions validates questions and numbers them in the given order
+func buildAssessmentQuestions(requests []dto.AssessmentQuestionRequest) ([]models.AssessmentQuestion, error) {
+	questions := make([]models.AssessmentQuestion, len(requests))
+	for i, req := range requests {
+		prompt := strings.TrimSpace(req.Prompt)
+		if prompt == "" {
+			return nil, fmt.Errorf("question %d has no prompt", i+1)
+		}
+
+		options := make(models.JSONArray, len(req.Options))
+		for j, option := range req.Options {
+			options[j] = strings.TrimSpace(option)
+			if options[j] == "" {
+				return nil, fmt.Errorf("question %d has an empty option", i+1)
+			}
+		}
+
+		if req.CorrectOption == nil || *req.CorrectOption < 0 || *req.CorrectOption >= len(options) {
+			return nil, fmt.Errorf("question %d has no valid correct option", i+1)
+		}
+
+		questions[i] = models.AssessmentQuestion{
+			Position:      i + 1,
+			Prompt:        prompt,
+			Options:       options,
+			CorrectOption: *req.CorrectOption,
+		}
+	}
+	return questions, nil
+}
+
+// clampAssessmentPage applies the default and maximum page size
+func clampAssessmentPage(limit, offset int) (int, int) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
@@ -15,6 +15,7 @@ type SkillProgressService struct {
 	skillRepo      *repository.SkillRepository
 	sessionRepo    *repository.SessionRepository
 	notificationService *NotificationService
+	audit          *AuditScope
 }
 
 // NewSkillProgressService creates a new skill progress service
@@ -32,6 +33,13 @@ func NewSkillProgressService(
 	}
 }
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *SkillProgressService) WithAudit(audit *AuditScope) *SkillProgressService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
 // GetProgress gets progress for a user's skill
 func (s *SkillProgressService) GetProgress(userID, skillID uint) (*dto.SkillProgressResponse, error) {
 	progress, err := s.progressRepo.GetByUserAndSkill(userID, skillID)
@@ -95,12 +103,16 @@ func (s *SkillProgressService) UpdateProgress(userID, skillID uint, req *dto.Upd
 		if err := s.progressRepo.Create(progress); err != nil {
 			return nil, err
 		}
+		s.audit.Record(models.AuditActionCreate, "skill_progress", progress.ID, nil, progress)
 	} else {
+		before := *progress
+
 		// Update existing progress
 		progress.SessionsCompleted = req.SessionsCompleted
 		progress.TotalHoursSpent = req.TotalHoursSpent
 		progress.ProgressPercentage = s.calculateProgress(req.SessionsCompleted, req.TotalHoursSpent)
-		progress.CurrentLevel = s.calculateLevel(req.TotalHoursSpent)
+		// A passed placement assessment sets a floor the hour-based level can't go below
+		progress.CurrentLevel = higherLevel(s.calculateLevel(req.TotalHoursSpent), progress.PlacementLevel)
 		progress.LastActivityAt = getCurrentTimestamp()
 
 		// Update estimated completion
@@ -109,6 +121,7 @@ func (s *SkillProgressService) UpdateProgress(userID, skillID uint, req *dto.Upd
 		if err := s.progressRepo.Update(progress); err != nil {
 			return nil, err
 		}
+		s.audit.Record(models.AuditActionUpdate, "skill_progress", progress.ID, &before, progress)
 
 		// Check and award milestones
 		s.checkAndAwardMilestones(progress)
@@ -184,6 +197,14 @@ func (s *SkillProgressService) calculateLevel(hoursSpent float64) string {
 	return "expert"
 }
 
+// higherLevel returns the higher ranked of two skill levels
+func higherLevel(a, b string) string {
+	if models.SkillLevel(b).Rank() > models.SkillLevel(a).Rank() {
+		return b
+	}
+	return a
+}
+
 func (s *SkillProgressService) calculateEstimatedCompletion(currentProgress float64, lastActivity int64) int64 {
 	// Estimate based on current progress rate
 	// Assume 10% progress per week
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	maxPendingSkillProposals = 5 // Per user, to keep the moderation queue manageable
+	maxPossibleDuplicates    = 5
+)
+
+// SkillProposalService handles user-proposed skills and their moderation queue
+type SkillProposalService struct {
+	proposalRepo        *repository.SkillProposalRepository
+	skillRepo           *repository.SkillRepository
+	notificationService *NotificationService
+	audit               *AuditScope
+}
+
+// NewSkillProposalService creates a new skill proposal service
+func NewSkillProposalService(
+	proposalRepo *repository.SkillProposalRepository,
+	skillRepo *repository.SkillRepository,
+	notificationService *NotificationService,
+) *SkillProposalService {
+	return &SkillProposalService{
+		proposalRepo:        proposalRepo,
+		skillRepo:           skillRepo,
+		notificationService: notificationService,
+	}
+}
+
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *SkillProposalService) WithAudit(audit *AuditScope) *SkillProposalService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
+// ProposeSkill submits a new skill for moderation
+//
+// Flow:
+//   1. Validates category and the optional teach/learn attachment
+//   2. Rejects names that already resolve to a skill (by name or alias)
+//   3. Rejects names already waiting in the queue
+//   4. Stores the proposal and returns it with similar existing skills
+//
+// Returns:
+//   - *SkillProposalResponse: Created proposal with possible duplicates
+//   - error: If validation fails or the skill already exists or is proposed
+func (s *SkillProposalService) ProposeSkill(userID uint, req *dto.CreateSkillProposalRequest) (*dto.SkillProposalResponse, error) {
+	normalized := models.NormalizeSkillName(req.Name)
+	if normalized == "" {
+		return nil, errors.New("skill name is required")
+	}
+
+	category := models.SkillCategory(req.Category)
+	if !isValidSkillCategory(category) {
+		return nil, errors.New("invalid skill category")
+	}
+
+	proposal := &models.SkillProposal{
+		ProposerID:  userID,
+		Name:        req.Name,
+		Normalized:  normalized,
+		Category:    category,
+		Description: req.Description,
+		Status:      models.SkillProposalPending,
+		AttachAs:    models.SkillProposalAttachment(req.AttachAs),
+		Level:       models.SkillLevel(req.Level),
+		HourlyRate:  1.0,
+	}
+
+	switch proposal.AttachAs {
+	case models.AttachNone:
+	case models.AttachTeach:
+		if !isValidSkillLevel(proposal.Level) {
+			return nil, errors.New("a valid skill level is required to teach the skill")
+		}
+		if req.HourlyRate < 0 {
+			return nil, errors.New("hourly rate must be non-negative")
+		}
+		if req.HourlyRate > 0 {
+			proposal.HourlyRate = req.HourlyRate
+		}
+	case models.AttachLearn:
+		if proposal.Level != "" && !isValidSkillLevel(proposal.Level) {
+			return nil, errors.New("invalid desired level")
+		}
+		if req.Priority < 0 || req.Priority > 5 {
+			return nil, errors.New("priority must be between 1 and 5")
+		}
+		proposal.Priority = req.Priority
+	default:
+		return nil, errors.New("attach_as must be \"teach\" or \"learn\"")
+	}
+
+	if existing, err := s.skillRepo.FindByNameOrAlias(req.Name); err == nil {
+		return nil, fmt.Errorf("skill already exists as %q", existing.Name)
+	}
+	if pending, err := s.proposalRepo.FindPendingByName(normalized); err == nil {
+		if pending.ProposerID == userID {
+			return nil, errors.New("you have already proposed this skill")
+		}
+		return nil, errors.New("this skill has already been proposed and is awaiting review")
+	}
+
+	pendingCount, err := s.proposalRepo.CountPendingByProposer(userID)
+	if err != nil {
+		return nil, err
+	}
+	if pendingCount >= maxPendingSkillProposals {
+		return nil, fmt.Errorf("you can have at most %d proposals awaiting review", maxPendingSkillProposals)
+	}
+
+	if err := s.proposalRepo.Create(proposal); err != nil {
+		return nil, fmt.Errorf("failed to create proposal: %w", err)
+	}
+
+	s.audit.Record(models.AuditActionCreate, "skill_proposals", proposal.ID, nil, proposal)
+	return s.withDuplicates(proposal), nil
+}
+
+// GetUserProposals lists the proposals a user has submitted
+func (s *SkillProposalService) GetUserProposals(userID uint, limit, offset int) (*dto.SkillProposalListResponse, error) {
+	if limit <= 0 || limit > 50 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	proposals, total, err := s.proposalRepo.ListByProposer(userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.SkillProposalListResponse{
+		Proposals: make([]dto.SkillProposalResponse, 0, len(proposals)),
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	for _, proposal := range proposals {
+		response.Proposals = append(response.Proposals, dto.SkillProposalResponse{SkillProposal: proposal})
+	}
+	return response, nil
+}
+
+// ListProposals lists the moderation queue filtered by status
+// Pending proposals include existing skills they may duplicate
+func (s *SkillProposalService) ListProposals(status string, limit, offset int) (*dto.SkillProposalListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	proposalStatus := models.SkillProposalStatus(status)
+	switch proposalStatus {
+	case "", models.SkillProposalPending, models.SkillProposalApproved, models.SkillProposalRejected:
+	default:
+		return nil, errors.New("invalid proposal status")
+	}
+
+	proposals, total, err := s.proposalRepo.List(proposalStatus, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.SkillProposalListResponse{
+		Proposals: make([]dto.SkillProposalResponse, 0, len(proposals)),
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	for i := range proposals {
+		response.Proposals = append(response.Proposals, *s.withDuplicates(&proposals[i]))
+	}
+	return response, nil
+}
+
+// GetProposal gets a proposal with possible duplicates
+func (s *SkillProposalService) GetProposal(proposalID uint) (*dto.SkillProposalResponse, error) {
+	proposal, err := s.getProposal(proposalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withDuplicates(proposal), nil
+}
+
+// ApproveProposal accepts a proposal and attaches the skill to the proposer
+//
+// By default a new skill is created from the proposal, with any edits from the
+// request applied. When ExistingSkillID is set the proposal is linked to that
+// skill instead (and the proposed name optionally becomes an alias of it).
+// Either way the proposer's pending teaching offer or wishlist entry is created.
+func (s *SkillProposalService) ApproveProposal(adminID, proposalID uint, req *dto.ApproveSkillProposalRequest) (*dto.SkillProposalResponse, error) {
+	proposal, err := s.getPending(proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	var skill *models.Skill
+	var alias *models.SkillAlias
+
+	if req.ExistingSkillID != nil {
+		skill, err = s.skillRepo.GetByID(*req.ExistingSkillID)
+		if err != nil {
+			return nil, errors.New("existing skill not found")
+		}
+		if req.AddAlias && models.NormalizeSkillName(skill.Name) != proposal.Normalized {
+			if other, err := s.skillRepo.FindByNameOrAlias(proposal.Name); err == nil && other.ID != skill.ID {
+				return nil, fmt.Errorf("%q already resolves to skill %q", proposal.Name, other.Name)
+			}
+			alias = &models.SkillAlias{
+				Alias:      proposal.Name,
+				Normalized: proposal.Normalized,
+			}
+		}
+	} else {
+		skill = &models.Skill{
+			Name:        proposal.Name,
+			Category:    proposal.Category,
+			Description: proposal.Description,
+			Icon:        req.Icon,
+			ParentID:    req.ParentID,
+		}
+		if req.Name != "" {
+			skill.Name = req.Name
+		}
+		if req.Category != "" {
+			skill.Category = models.SkillCategory(req.Category)
+			if !isValidSkillCategory(skill.Category) {
+				return nil, errors.New("invalid skill category")
+			}
+		}
+		if req.Description != "" {
+			skill.Description = req.Description
+		}
+
+		// The catalogue may have changed since the proposal was submitted
+		if existing, err := s.skillRepo.FindByNameOrAlias(skill.Name); err == nil {
+			return nil, fmt.Errorf("skill already exists as %q; approve with existing_skill_id %d to link it", existing.Name, existing.ID)
+		}
+		if skill.ParentID != nil {
+			if _, err := s.skillRepo.GetByID(*skill.ParentID); err != nil {
+				return nil, errors.New("parent skill not found")
+			}
+		}
+		if skill.Tags, err = normalizeSkillTags(req.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	created := skill.ID == 0
+	if err := s.proposalRepo.Approve(proposal.ID, adminID, req.Note, skill, alias); err != nil {
+		return nil, err
+	}
+
+	after, err := s.getProposal(proposal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		s.audit.Record(models.AuditActionCreate, "skills", skill.ID, nil, skill)
+	}
+	if alias != nil && alias.ID != 0 {
+		s.audit.Record(models.AuditActionCreate, "skill_aliases", alias.ID, nil, alias)
+	}
+	s.audit.Record(models.AuditActionUpdate, "skill_proposals", after.ID, proposal, after)
+
+	message := fmt.Sprintf("Your proposed skill \"%s\" was approved", proposal.Name)
+	switch proposal.AttachAs {
+	case models.AttachTeach:
+		message += " and added to the skills you teach"
+	case models.AttachLearn:
+		message += " and added to your learning wishlist"
+	}
+	_, _ = s.notificationService.CreateNotification(
+		proposal.ProposerID,
+		models.NotificationTypeSocial,
+		"Skill Proposal Approved",
+		message,
+		map[string]interface{}{
+			"proposal_id": proposal.ID,
+			"skill_id":    skill.ID,
+		},
+	)
+
+	return &dto.SkillProposalResponse{SkillProposal: *after}, nil
+}
+
+// RejectProposal declines a proposal and tells the proposer why
+func (s *SkillProposalService) RejectProposal(adminID, proposalID uint, note string) (*dto.SkillProposalResponse, error) {
+	proposal, err := s.getPending(proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.proposalRepo.Reject(proposal.ID, adminID, note); err != nil {
+		return nil, err
+	}
+
+	after, err := s.getProposal(proposal.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(models.AuditActionUpdate, "skill_proposals", after.ID, proposal, after)
+
+	_, _ = s.notificationService.CreateNotification(
+		proposal.ProposerID,
+		models.NotificationTypeSocial,
+		"Skill Proposal Rejected",
+		fmt.Sprintf("Your proposed skill \"%s\" was not approved: %s", proposal.Name, note),
+		map[string]interface{}{
+			"proposal_id": proposal.ID,
+		},
+	)
+
+	return &dto.SkillProposalResponse{SkillProposal: *after}, nil
+}
+
+// getProposal loads a proposal, mapping a missing row to "proposal not found"
+func (s *SkillProposalService) getProposal(proposalID uint) (*models.SkillProposal, error) {
+	proposal, err := s.proposalRepo.GetByID(proposalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("proposal not found")
+		}
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// getPending loads a proposal and verifies it is still pending
+func (s *SkillProposalService) getPending(proposalID uint) (*models.SkillProposal, error) {
+	proposal, err := s.getProposal(proposalID)
+	if err != nil {
+		return nil, err
+	}
+	if !proposal.IsPending() {
+		return nil, fmt.Errorf("proposal is already %s", proposal.Status)
+	}
+	return proposal, nil
+}
+
+// withDuplicates wraps a proposal with similar existing skills while it is pending
+// Lookup failures only drop the hints; they never fail the request
+func (s *SkillProposalService) withDuplicates(proposal *models.SkillProposal) *dto.SkillProposalResponse {
+	response := &dto.SkillProposalResponse{SkillProposal: *proposal}
+	if !proposal.IsPending() {
+		return response
+	}
+
+	similar, err := s.proposalRepo.FindSimilarSkills(proposal.Name, maxPossibleDuplicates)
+	if err != nil {
+		return response
+	}
+	for i := range similar {
+		response.PossibleDuplicates = append(response.PossibleDuplicates, dto.ToSkillResponse(&similar[i]))
+	}
+	return response
+}
+
+// isValidSkillCategory checks a category against the known skill categories
+func isValidSkillCategory(category models.SkillCategory) bool {
+	switch category {
+	case models.CategoryAcademic, models.CategoryTechnical, models.CategoryCreative,
+		models.CategoryLanguage, models.CategorySports, models.CategoryOther:
+		return true
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/timebankingskill/backend/internal/models"
 	"github.com/timebankingskill/backend/internal/repository"
@@ -11,6 +12,7 @@ import (
 type SkillService struct {
 	skillRepo repository.SkillRepositoryInterface
 	userRepo  repository.UserRepositoryInterface
+	audit     *AuditScope
 }
 
 func NewSkillService(skillRepo repository.SkillRepositoryInterface, userRepo repository.UserRepositoryInterface) *SkillService {
@@ -20,16 +22,36 @@ func NewSkillService(skillRepo repository.SkillRepositoryInterface, userRepo rep
 	}
 }
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *SkillService) WithAudit(audit *AuditScope) *SkillService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
 // GetAllSkills retrieves all skills with pagination and filters
-func (s *SkillService) GetAllSkills(limit, offset int, category, search string, dayOfWeek *int, minRating *float64, location, sortBy string) ([]models.Skill, int64, error) {
-	return s.skillRepo.GetAllWithFilters(limit, offset, category, search, dayOfWeek, minRating, location, sortBy)
+func (s *SkillService) GetAllSkills(limit, offset int, category, search, tag string, dayOfWeek *int, minRating *float64, location, sortBy string) ([]models.Skill, int64, error) {
+	return s.skillRepo.GetAllWithFilters(limit, offset, category, search, tag, dayOfWeek, minRating, location, sortBy)
 }
 
-// GetSkillByID retrieves a skill by ID
+// GetSkillByID retrieves a skill by ID, with its aliases when the repository supports it
 func (s *SkillService) GetSkillByID(id uint) (*models.Skill, error) {
+	if repo, ok := s.skillRepo.(*repository.SkillRepository); ok {
+		return repo.GetByIDWithAliases(id)
+	}
 	return s.skillRepo.GetByID(id)
 }
 
+// findDuplicate returns the skill a name already resolves to (by name or alias), if any
+func (s *SkillService) findDuplicate(name string) *models.Skill {
+	if repo, ok := s.skillRepo.(*repository.SkillRepository); ok {
+		if existing, err := repo.FindByNameOrAlias(name); err == nil {
+			return existing
+		}
+	}
+	return nil
+}
+
 // GetSkillTeachers retrieves all teachers for a specific skill
 func (s *SkillService) GetSkillTeachers(skillID uint) ([]models.UserSkill, error) {
 	// Use type assertion to access the concrete method
@@ -49,7 +71,29 @@ func (s *SkillService) CreateSkill(skill *models.Skill) error {
 		return errors.New("skill category is required")
 	}
 
-	return s.skillRepo.Create(skill)
+	// Names and aliases resolve to one canonical skill ("JS" → "JavaScript")
+	if existing := s.findDuplicate(skill.Name); existing != nil {
+		return fmt.Errorf("skill already exists as %q", existing.Name)
+	}
+
+	if skill.ParentID != nil {
+		if _, err := s.skillRepo.GetByID(*skill.ParentID); err != nil {
+			return errors.New("parent skill not found")
+		}
+	}
+
+	tags, err := normalizeSkillTags(skill.Tags)
+	if err != nil {
+		return err
+	}
+	skill.Tags = tags
+
+	if err := s.skillRepo.Create(skill); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionCreate, "skills", skill.ID, nil, skill)
+	return nil
 }
 
 // UpdateSkill updates an existing skill (admin only)
@@ -62,9 +106,13 @@ func (s *SkillService) UpdateSkill(id uint, updates *models.Skill) error {
 		}
 		return err
 	}
+	before := *existingSkill
 
 	// Update fields
 	if updates.Name != "" {
+		if duplicate := s.findDuplicate(updates.Name); duplicate != nil && duplicate.ID != id {
+			return fmt.Errorf("skill already exists as %q", duplicate.Name)
+		}
 		existingSkill.Name = updates.Name
 	}
 	if updates.Category != "" {
@@ -77,13 +125,18 @@ func (s *SkillService) UpdateSkill(id uint, updates *models.Skill) error {
 		existingSkill.Icon = updates.Icon
 	}
 
-	return s.skillRepo.Update(existingSkill)
+	if err := s.skillRepo.Update(existingSkill); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionUpdate, "skills", id, &before, existingSkill)
+	return nil
 }
 
 // DeleteSkill deletes a skill (admin only)
 func (s *SkillService) DeleteSkill(id uint) error {
 	// Check if skill exists
-	_, err := s.skillRepo.GetByID(id)
+	existingSkill, err := s.skillRepo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("skill not found")
@@ -91,7 +144,12 @@ func (s *SkillService) DeleteSkill(id uint) error {
 		return err
 	}
 
-	return s.skillRepo.Delete(id)
+	if err := s.skillRepo.Delete(id); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionDelete, "skills", id, existingSkill, nil)
+	return nil
 }
 
 // User Skills Management
@@ -155,7 +213,12 @@ func (s *SkillService) AddUserSkill(userID uint, userSkill *models.UserSkill) er
 		return errors.New("user already has this skill")
 	}
 
-	return s.skillRepo.CreateUserSkill(userSkill)
+	if err := s.skillRepo.CreateUserSkill(userSkill); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionCreate, "user_skills", userSkill.ID, nil, userSkill)
+	return nil
 }
 
 // GetUserSkills retrieves all skills that user can teach
@@ -173,6 +236,7 @@ func (s *SkillService) UpdateUserSkill(userID uint, skillID uint, updates *model
 		}
 		return err
 	}
+	before := *existing
 
 	// Update fields
 	if updates.Level != "" {
@@ -197,13 +261,18 @@ func (s *SkillService) UpdateUserSkill(userID uint, skillID uint, updates *model
 	existing.OfflineOnly = updates.OfflineOnly
 	existing.IsAvailable = updates.IsAvailable
 
-	return s.skillRepo.UpdateUserSkill(existing)
+	if err := s.skillRepo.UpdateUserSkill(existing); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionUpdate, "user_skills", existing.ID, &before, existing)
+	return nil
 }
 
 // DeleteUserSkill removes a skill from user's teaching skills
 func (s *SkillService) DeleteUserSkill(userID uint, skillID uint) error {
 	// Check if user skill exists
-	_, err := s.skillRepo.GetUserSkill(userID, skillID)
+	existing, err := s.skillRepo.GetUserSkill(userID, skillID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("user skill not found")
@@ -211,7 +280,12 @@ func (s *SkillService) DeleteUserSkill(userID uint, skillID uint) error {
 		return err
 	}
 
-	return s.skillRepo.DeleteUserSkill(userID, skillID)
+	if err := s.skillRepo.DeleteUserSkill(userID, skillID); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionDelete, "user_skills", existing.ID, existing, nil)
+	return nil
 }
 
 // Learning Skills Management
@@ -245,7 +319,12 @@ func (s *SkillService) AddLearningSkill(userID uint, learningSkill *models.Learn
 		return errors.New("skill already in learning wishlist")
 	}
 
-	return s.skillRepo.CreateLearningSkill(learningSkill)
+	if err := s.skillRepo.CreateLearningSkill(learningSkill); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionCreate, "learning_skills", learningSkill.ID, nil, learningSkill)
+	return nil
 }
 
 // GetLearningSkills retrieves user's learning wishlist
@@ -256,7 +335,7 @@ func (s *SkillService) GetLearningSkills(userID uint) ([]models.LearningSkill, e
 // DeleteLearningSkill removes a skill from learning wishlist
 func (s *SkillService) DeleteLearningSkill(userID uint, skillID uint) error {
 	// Check if learning skill exists
-	_, err := s.skillRepo.GetLearningSkill(userID, skillID)
+	existing, err := s.skillRepo.GetLearningSkill(userID, skillID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("learning skill not found")
@@ -264,5 +343,10 @@ func (s *SkillService) DeleteLearningSkill(userID uint, skillID uint) error {
 		return err
 	}
 
-	return s.skillRepo.DeleteLearningSkill(userID, skillID)
+	if err := s.skillRepo.DeleteLearningSkill(userID, skillID); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionDelete, "learning_skills", existing.ID, existing, nil)
+	return nil
 }
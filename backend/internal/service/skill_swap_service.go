@@ -0,0 +1,458 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+)
+
+const (
+	// Swap match limits per type (pairs, cycles)
+	defaultSwapMatchLimit = 10
+	maxSwapMatchLimit     = 20
+
+	swapMatchTypePair  = "pair"
+	swapMatchTypeCycle = "cycle"
+)
+
+// SkillSwapService handles reciprocal skill swaps
+// A swap books one session per participant, each teaching the next one in the
+// ring for the same duration. Swap sessions carry no credits: everyone teaches
+// and learns equally, so the exchange nets to zero.
+type SkillSwapService struct {
+	swapRepo            *repository.SkillSwapRepository
+	skillRepo           *repository.SkillRepository
+	sessionRepo         *repository.SessionRepository
+	notificationService *NotificationService
+	audit               *AuditScope
+}
+
+// NewSkillSwapService creates a new skill swap service
+func NewSkillSwapService(
+	swapRepo *repository.SkillSwapRepository,
+	skillRepo *repository.SkillRepository,
+	sessionRepo *repository.SessionRepository,
+	notificationService *NotificationService,
+) *SkillSwapService {
+	return &SkillSwapService{
+		swapRepo:            swapRepo,
+		skillRepo:           skillRepo,
+		sessionRepo:         sessionRepo,
+		notificationService: notificationService,
+	}
+}
+
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *SkillSwapService) WithAudit(audit *AuditScope) *SkillSwapService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
+// FindSwaps suggests swaps for a user
+// Pairs: the partner teaches something on the user's wishlist and wants to learn
+// something the user teaches. Cycles: three users where each teaches the next.
+func (s *SkillSwapService) FindSwaps(userID uint, limit int) (*dto.SwapMatchesResponse, error) {
+	if limit <= 0 {
+		limit = defaultSwapMatchLimit
+	}
+	if limit > maxSwapMatchLimit {
+		limit = maxSwapMatchLimit
+	}
+
+	pairs, err := s.swapRepo.FindPairs(userID, limit)
+	if err != nil {
+		return nil, errors.New("failed to find swap partners")
+	}
+	cycles, err := s.swapRepo.FindCycles(userID, limit)
+	if err != nil {
+		return nil, errors.New("failed to find swap cycles")
+	}
+
+	var ids []uint
+	for _, candidate := range append(pairs, cycles...) {
+		ids = append(ids, candidate.UserSkillIDs...)
+	}
+	userSkills, err := s.swapRepo.GetUserSkillsByIDs(ids)
+	if err != nil {
+		return nil, errors.New("failed to load swap offers")
+	}
+	offers := make(map[uint]*models.UserSkill, len(userSkills))
+	for i := range userSkills {
+		offers[userSkills[i].ID] = &userSkills[i]
+	}
+
+	return &dto.SwapMatchesResponse{
+		Pairs:  toSwapMatches(swapMatchTypePair, pairs, offers),
+		Cycles: toSwapMatches(swapMatchTypeCycle, cycles, offers),
+	}, nil
+}
+
+// ProposeSwap books a swap with one pending session per leg
+//
+// Validation:
+//   - The proposer owns one of the offers and every participant owns exactly one
+//   - Every offer is available and its learner has the skill on their wishlist
+//   - No leg duplicates an active session for the same offer and learner
+//
+// The proposer's own leg is accepted immediately; the other participants are notified.
+func (s *SkillSwapService) ProposeSwap(userID uint, req *dto.ProposeSwapRequest) (*dto.SkillSwapResponse, error) {
+	if !req.ScheduledAt.After(time.Now()) {
+		return nil, errors.New("scheduled time must be in the future")
+	}
+
+	userSkills, err := s.swapRepo.GetUserSkillsByIDs(req.UserSkillIDs)
+	if err != nil {
+		return nil, errors.New("skill not found")
+	}
+	offers := make(map[uint]*models.UserSkill, len(userSkills))
+	for i := range userSkills {
+		offers[userSkills[i].ID] = &userSkills[i]
+	}
+
+	// Order offers as requested; each owner appears once
+	ring := make([]*models.UserSkill, len(req.UserSkillIDs))
+	participants := make(map[uint]bool, len(ring))
+	for i, id := range req.UserSkillIDs {
+		ring[i] = offers[id]
+		if ring[i] == nil {
+			return nil, errors.New("skill not found")
+		}
+		if participants[ring[i].UserID] {
+			return nil, errors.New("each participant must teach exactly one skill in the swap")
+		}
+		participants[ring[i].UserID] = true
+	}
+	if !participants[userID] {
+		return nil, errors.New("you must teach one of the skills in the swap")
+	}
+
+	now := time.Now()
+	swap := &models.SkillSwap{
+		ProposerID:  userID,
+		Status:      models.SwapStatusProposed,
+		Duration:    req.Duration,
+		Mode:        models.SessionMode(req.Mode),
+		ScheduledAt: req.ScheduledAt,
+		Message:     req.Message,
+	}
+	sessions := make([]*models.Session, len(ring))
+
+	for i, offer := range ring {
+		student := ring[(i+1)%len(ring)].User
+
+		if !offer.IsAvailable || !offer.User.IsActive {
+			return nil, fmt.Errorf("%s by %s is currently not available", offer.Skill.Name, offer.User.FullName)
+		}
+		if !student.IsActive {
+			return nil, fmt.Errorf("%s is not an active user", student.FullName)
+		}
+		if _, err := s.skillRepo.GetLearningSkill(student.ID, offer.SkillID); err != nil {
+			return nil, fmt.Errorf("%s does not want to learn %s", student.FullName, offer.Skill.Name)
+		}
+		exists, err := s.sessionRepo.ExistsActiveSession(offer.UserID, student.ID, offer.ID)
+		if err != nil {
+			return nil, errors.New("failed to check existing sessions")
+		}
+		if exists {
+			return nil, fmt.Errorf("%s already has an active %s session with %s", student.FullName, offer.Skill.Name, offer.User.FullName)
+		}
+
+		leg := models.SkillSwapLeg{
+			Position:    i,
+			TeacherID:   offer.UserID,
+			StudentID:   student.ID,
+			UserSkillID: offer.ID,
+		}
+		if offer.UserID == userID {
+			leg.Accepted = true
+			leg.AcceptedAt = &now
+		}
+		swap.Legs = append(swap.Legs, leg)
+
+		sessions[i] = &models.Session{
+			TeacherID:    offer.UserID,
+			StudentID:    student.ID,
+			UserSkillID:  offer.ID,
+			Title:        "Skill swap: " + offer.Skill.Name,
+			Description:  req.Message,
+			Duration:     req.Duration,
+			Mode:         models.SessionMode(req.Mode),
+			ScheduledAt:  &req.ScheduledAt,
+			Status:       models.StatusPending,
+			Location:     req.Location,
+			MeetingLink:  req.MeetingLink,
+			CreditAmount: 0,
+		}
+	}
+
+	if err := s.swapRepo.Create(swap, sessions); err != nil {
+		return nil, errors.New("failed to create skill swap")
+	}
+	s.audit.Record(models.AuditActionCreate, "skill_swaps", swap.ID, nil, swapAuditSnapshot(swap))
+
+	var proposer string
+	for _, offer := range ring {
+		if offer.UserID == userID {
+			proposer = offer.User.FullName
+		}
+	}
+	for i, offer := range ring {
+		if offer.UserID == userID {
+			continue
+		}
+		learning := ring[(i+len(ring)-1)%len(ring)].Skill.Name
+		s.notify(offer.UserID, "Skill Swap Proposed",
+			fmt.Sprintf("%s proposed a skill swap: you teach %s and learn %s.", proposer, offer.Skill.Name, learning),
+			swap.ID)
+	}
+
+	return s.GetSwap(userID, swap.ID)
+}
+
+// AcceptSwap accepts a proposed swap on behalf of the participant teaching one of its legs
+// Once everyone has accepted, all swap sessions are approved together
+func (s *SkillSwapService) AcceptSwap(userID, swapID uint) (*dto.SkillSwapResponse, error) {
+	swap, err := s.getParticipantSwap(userID, swapID)
+	if err != nil {
+		return nil, err
+	}
+	if swap.Status != models.SwapStatusProposed {
+		return nil, errors.New("skill swap is no longer awaiting acceptance")
+	}
+	before := swapAuditSnapshot(swap)
+
+	allAccepted, err := s.swapRepo.AcceptLeg(swapID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.swapRepo.GetByID(swapID)
+	if err != nil {
+		return nil, errors.New("skill swap not found")
+	}
+	s.audit.Record(models.AuditActionUpdate, "skill_swaps", swapID, before, swapAuditSnapshot(updated))
+
+	if allAccepted {
+		for _, leg := range updated.Legs {
+			s.notify(leg.TeacherID, "Skill Swap Confirmed",
+				"Everyone accepted the skill swap. Your swap sessions are now scheduled.", swapID)
+		}
+	}
+
+	return toSkillSwapResponse(updated), nil
+}
+
+// DeclineSwap declines a proposed swap and rejects its sessions
+func (s *SkillSwapService) DeclineSwap(userID, swapID uint, req *dto.CloseSwapRequest) (*dto.SkillSwapResponse, error) {
+	swap, err := s.getParticipantSwap(userID, swapID)
+	if err != nil {
+		return nil, err
+	}
+	if swap.Status != models.SwapStatusProposed {
+		return nil, errors.New("only proposed skill swaps can be declined")
+	}
+
+	return s.close(userID, swap, models.SwapStatusDeclined, models.StatusRejected, req.Reason, "Skill Swap Declined")
+}
+
+// CancelSwap cancels a proposed or accepted swap and all of its sessions
+// Not allowed once any swap session has started, so nobody ends up teaching without learning
+func (s *SkillSwapService) CancelSwap(userID, swapID uint, req *dto.CloseSwapRequest) (*dto.SkillSwapResponse, error) {
+	swap, err := s.getParticipantSwap(userID, swapID)
+	if err != nil {
+		return nil, err
+	}
+	if !swap.IsOpen() {
+		return nil, errors.New("skill swap cannot be cancelled")
+	}
+	for _, leg := range swap.Legs {
+		if leg.Session.Status == models.StatusInProgress || leg.Session.Status == models.StatusCompleted {
+			return nil, errors.New("skill swap sessions have already started")
+		}
+	}
+
+	return s.close(userID, swap, models.SwapStatusCancelled, models.StatusCancelled, req.Reason, "Skill Swap Cancelled")
+}
+
+// GetSwap retrieves a swap the user takes part in
+func (s *SkillSwapService) GetSwap(userID, swapID uint) (*dto.SkillSwapResponse, error) {
+	swap, err := s.getParticipantSwap(userID, swapID)
+	if err != nil {
+		return nil, err
+	}
+	return toSkillSwapResponse(swap), nil
+}
+
+// GetUserSwaps retrieves swaps the user takes part in, optionally filtered by status
+func (s *SkillSwapService) GetUserSwaps(userID uint, status string, limit, offset int) (*dto.SkillSwapListResponse, error) {
+	swaps, total, err := s.swapRepo.ListByUser(userID, models.SkillSwapStatus(status), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.SkillSwapResponse, len(swaps))
+	for i := range swaps {
+		responses[i] = *toSkillSwapResponse(&swaps[i])
+	}
+
+	return &dto.SkillSwapListResponse{
+		Swaps: responses,
+		Total: total,
+		Page:  offset/limit + 1,
+		Limit: limit,
+	}, nil
+}
+
+// close declines or cancels a swap and notifies the other participants
+func (s *SkillSwapService) close(userID uint, swap *models.SkillSwap, status models.SkillSwapStatus, sessionStatus models.SessionStatus, reason, title string) (*dto.SkillSwapResponse, error) {
+	before := swapAuditSnapshot(swap)
+
+	if err := s.swapRepo.Close(swap.ID, userID, status, sessionStatus, reason); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.swapRepo.GetByID(swap.ID)
+	if err != nil {
+		return nil, errors.New("skill swap not found")
+	}
+	s.audit.Record(models.AuditActionUpdate, "skill_swaps", swap.ID, before, swapAuditSnapshot(updated))
+
+	var actor string
+	for _, leg := range updated.Legs {
+		if leg.TeacherID == userID {
+			actor = leg.Teacher.FullName
+		}
+	}
+	for _, leg := range updated.Legs {
+		if leg.TeacherID != userID {
+			s.notify(leg.TeacherID, title,
+				fmt.Sprintf("%s %s the skill swap: %s", actor, status, reason), swap.ID)
+		}
+	}
+
+	return toSkillSwapResponse(updated), nil
+}
+
+// getParticipantSwap loads a swap and checks the user teaches one of its legs
+func (s *SkillSwapService) getParticipantSwap(userID, swapID uint) (*models.SkillSwap, error) {
+	swap, err := s.swapRepo.GetByID(swapID)
+	if err != nil {
+		return nil, errors.New("skill swap not found")
+	}
+	for _, leg := range swap.Legs {
+		if leg.TeacherID == userID {
+			return swap, nil
+		}
+	}
+	return nil, errors.New("you are not part of this skill swap")
+}
+
+// notify sends a session notification about a swap
+func (s *SkillSwapService) notify(userID uint, title, message string, swapID uint) {
+	if s.notificationService == nil {
+		return
+	}
+	_, _ = s.notificationService.CreateNotification(
+		userID,
+		models.NotificationTypeSession,
+		title,
+		message,
+		map[string]interface{}{"swapID": swapID},
+	)
+}
+
+// swapAuditSnapshot strips preloaded legs so audit entries hold only the swap row
+func swapAuditSnapshot(swap *models.SkillSwap) models.SkillSwap {
+	snapshot := *swap
+	snapshot.Legs = nil
+	return snapshot
+}
+
+// toSwapMatches converts finder candidates, skipping any whose offers disappeared
+func toSwapMatches(matchType string, candidates []repository.SwapCandidate, offers map[uint]*models.UserSkill) []dto.SwapMatch {
+	matches := make([]dto.SwapMatch, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		ring := make([]*models.UserSkill, len(candidate.UserSkillIDs))
+		complete := true
+		for i, id := range candidate.UserSkillIDs {
+			ring[i] = offers[id]
+			complete = complete && ring[i] != nil
+		}
+		if !complete {
+			continue
+		}
+
+		legs := make([]dto.SwapLegPreview, len(ring))
+		for i, offer := range ring {
+			legs[i] = dto.SwapLegPreview{
+				UserSkillID: offer.ID,
+				SkillID:     offer.SkillID,
+				SkillName:   offer.Skill.Name,
+				Level:       string(offer.Level),
+				Teacher:     toPublicProfile(&offer.User),
+				Student:     toPublicProfile(&ring[(i+1)%len(ring)].User),
+			}
+		}
+
+		matches = append(matches, dto.SwapMatch{
+			Type:         matchType,
+			UserSkillIDs: candidate.UserSkillIDs,
+			Priority:     candidate.Priority,
+			Legs:         legs,
+		})
+	}
+
+	return matches
+}
+
+// toSkillSwapResponse converts a swap with preloaded legs to its response
+func toSkillSwapResponse(swap *models.SkillSwap) *dto.SkillSwapResponse {
+	legs := make([]dto.SkillSwapLegResponse, len(swap.Legs))
+	for i, leg := range swap.Legs {
+		legs[i] = dto.SkillSwapLegResponse{
+			Position:      leg.Position,
+			UserSkillID:   leg.UserSkillID,
+			SkillID:       leg.UserSkill.SkillID,
+			SkillName:     leg.UserSkill.Skill.Name,
+			Teacher:       toPublicProfile(&leg.Teacher),
+			Student:       toPublicProfile(&leg.Student),
+			SessionID:     leg.SessionID,
+			SessionStatus: string(leg.Session.Status),
+			Accepted:      leg.Accepted,
+			AcceptedAt:    leg.AcceptedAt,
+		}
+	}
+
+	return &dto.SkillSwapResponse{
+		ID:                 swap.ID,
+		ProposerID:         swap.ProposerID,
+		Status:             string(swap.Status),
+		Duration:           swap.Duration,
+		Mode:               string(swap.Mode),
+		ScheduledAt:        swap.ScheduledAt,
+		Message:            swap.Message,
+		CancelledBy:        swap.CancelledBy,
+		CancellationReason: swap.CancellationReason,
+		Legs:               legs,
+		CreatedAt:          swap.CreatedAt,
+		UpdatedAt:          swap.UpdatedAt,
+	}
+}
+
+// toPublicProfile converts a user to the public profile shown to other users
+func toPublicProfile(user *models.User) dto.UserPublicProfile {
+	return dto.UserPublicProfile{
+		ID:       user.ID,
+		FullName: user.FullName,
+		Username: user.Username,
+		Avatar:   user.Avatar,
+		School:   user.School,
+		Grade:    user.Grade,
+	}
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	maxSkillTags      = 20
+	maxSkillTagLength = 50
+	maxSkillTreeDepth = 32 // Bounds ancestor walks
+)
+
+// SkillMergeOutcome is the surviving skill after a merge with the rows that moved to it
+type SkillMergeOutcome struct {
+	Skill  dto.SkillResponse            `json:"skill"`
+	Merged *repository.SkillMergeResult `json:"merged"`
+}
+
+// SkillTaxonomyService handles the skill hierarchy, tags, aliases and merges
+type SkillTaxonomyService struct {
+	taxonomyRepo *repository.SkillTaxonomyRepository
+	skillRepo    *repository.SkillRepository
+	audit        *AuditScope
+}
+
+// NewSkillTaxonomyService creates a new skill taxonomy service
+func NewSkillTaxonomyService(taxonomyRepo *repository.SkillTaxonomyRepository, skillRepo *repository.SkillRepository) *SkillTaxonomyService {
+	return &SkillTaxonomyService{
+		taxonomyRepo: taxonomyRepo,
+		skillRepo:    skillRepo,
+	}
+}
+
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *SkillTaxonomyService) WithAudit(audit *AuditScope) *SkillTaxonomyService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
+// GetTree returns all skills nested under their parents, roots sorted by name
+// Skills whose parent is missing are shown as roots
+func (s *SkillTaxonomyService) GetTree() ([]dto.SkillTreeNode, error) {
+	skills, err := s.taxonomyRepo.GetAllSkills()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[uint]bool, len(skills))
+	for _, skill := range skills {
+		known[skill.ID] = true
+	}
+
+	children := make(map[uint][]*models.Skill)
+	var roots []*models.Skill
+	for i := range skills {
+		skill := &skills[i]
+		if skill.ParentID != nil && known[*skill.ParentID] {
+			children[*skill.ParentID] = append(children[*skill.ParentID], skill)
+		} else {
+			roots = append(roots, skill)
+		}
+	}
+
+	var build func(skill *models.Skill) dto.SkillTreeNode
+	build = func(skill *models.Skill) dto.SkillTreeNode {
+		node := dto.SkillTreeNode{
+			SkillResponse: dto.ToSkillResponse(skill),
+			Children:      make([]dto.SkillTreeNode, 0, len(children[skill.ID])),
+		}
+		for _, child := range children[skill.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	tree := make([]dto.SkillTreeNode, 0, len(roots))
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+	return tree, nil
+}
+
+// ResolveSkill finds the canonical skill for a name or alias
+func (s *SkillTaxonomyService) ResolveSkill(name string) (*models.Skill, error) {
+	if models.NormalizeSkillName(name) == "" {
+		return nil, errors.New("name is required")
+	}
+
+	skill, err := s.skillRepo.FindByNameOrAlias(name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("skill not found")
+		}
+		return nil, err
+	}
+	return s.skillRepo.GetByIDWithAliases(skill.ID)
+}
+
+// SetParent moves a skill under another skill, or to the root when parentID is nil
+// Rejects moves that would make a skill its own ancestor
+func (s *SkillTaxonomyService) SetParent(skillID uint, parentID *uint) (*models.Skill, error) {
+	skill, err := s.getSkill(skillID)
+	if err != nil {
+		return nil, err
+	}
+	before := *skill
+
+	if parentID != nil {
+		if _, err := s.getSkill(*parentID); err != nil {
+			return nil, errors.New("parent skill not found")
+		}
+
+		// Walk up from the new parent; reaching the skill means a cycle
+		ancestorID := parentID
+		for depth := 0; ancestorID != nil && depth < maxSkillTreeDepth; depth++ {
+			if *ancestorID == skillID {
+				return nil, errors.New("a skill cannot be placed under itself or one of its children")
+			}
+			ancestor, err := s.skillRepo.GetByID(*ancestorID)
+			if err != nil {
+				break
+			}
+			ancestorID = ancestor.ParentID
+		}
+	}
+
+	if err := s.taxonomyRepo.UpdateParent(skillID, parentID); err != nil {
+		return nil, err
+	}
+	skill.ParentID = parentID
+
+	s.audit.Record(models.AuditActionUpdate, "skills", skillID, &before, skill)
+	return skill, nil
+}
+
+// SetTags replaces a skill's tags
+// Tags are lowercased and de-duplicated; blank tags are dropped
+func (s *SkillTaxonomyService) SetTags(skillID uint, tags []string) (*models.Skill, error) {
+	skill, err := s.getSkill(skillID)
+	if err != nil {
+		return nil, err
+	}
+	before := *skill
+
+	normalized, err := normalizeSkillTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.taxonomyRepo.UpdateTags(skillID, normalized); err != nil {
+		return nil, err
+	}
+	skill.Tags = normalized
+
+	s.audit.Record(models.AuditActionUpdate, "skills", skillID, &before, skill)
+	return skill, nil
+}
+
+// GetAliases retrieves a skill's aliases
+func (s *SkillTaxonomyService) GetAliases(skillID uint) ([]models.SkillAlias, error) {
+	if _, err := s.getSkill(skillID); err != nil {
+		return nil, err
+	}
+	return s.taxonomyRepo.GetAliases(skillID)
+}
+
+// AddAlias adds a synonym that resolves to the skill
+// The alias may not match the name or an alias of any skill
+func (s *SkillTaxonomyService) AddAlias(skillID uint, alias string) (*models.SkillAlias, error) {
+	if _, err := s.getSkill(skillID); err != nil {
+		return nil, err
+	}
+
+	normalized := models.NormalizeSkillName(alias)
+	if normalized == "" {
+		return nil, errors.New("alias is required")
+	}
+	if existing, err := s.skillRepo.FindByNameOrAlias(alias); err == nil {
+		return nil, fmt.Errorf("%q already resolves to skill %q", alias, existing.Name)
+	}
+
+	skillAlias := &models.SkillAlias{
+		SkillID:    skillID,
+		Alias:      alias,
+		Normalized: normalized,
+	}
+	if err := s.taxonomyRepo.CreateAlias(skillAlias); err != nil {
+		return nil, errors.New("failed to create alias")
+	}
+
+	s.audit.Record(models.AuditActionCreate, "skill_aliases", skillAlias.ID, nil, skillAlias)
+	return skillAlias, nil
+}
+
+// DeleteAlias removes one of a skill's aliases
+func (s *SkillTaxonomyService) DeleteAlias(skillID, aliasID uint) error {
+	if err := s.taxonomyRepo.DeleteAlias(skillID, aliasID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("alias not found")
+		}
+		return err
+	}
+
+	s.audit.Record(models.AuditActionDelete, "skill_aliases", aliasID, nil, nil)
+	return nil
+}
+
+// MergeSkills folds a duplicate skill into the surviving one
+// See SkillTaxonomyRepository.MergeSkills for how conflicting rows are combined
+func (s *SkillTaxonomyService) MergeSkills(sourceID, targetID uint) (*SkillMergeOutcome, error) {
+	if sourceID == targetID {
+		return nil, errors.New("a skill cannot be merged into itself")
+	}
+
+	source, err := s.getSkill(sourceID)
+	if err != nil {
+		return nil, errors.New("source skill not found")
+	}
+	target, err := s.getSkill(targetID)
+	if err != nil {
+		return nil, errors.New("target skill not found")
+	}
+	before := *target
+
+	result, err := s.taxonomyRepo.MergeSkills(sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err = s.skillRepo.GetByIDWithAliases(targetID)
+	if err != nil {
+		return nil, errors.New("target skill not found")
+	}
+
+	s.audit.Record(models.AuditActionDelete, "skills", sourceID, source, nil)
+	s.audit.Record(models.AuditActionUpdate, "skills", targetID, &before, target)
+
+	return &SkillMergeOutcome{
+		Skill:  dto.ToSkillResponse(target),
+		Merged: result,
+	}, nil
+}
+
+// getSkill loads a skill, mapping a missing row to "skill not found"
+func (s *SkillTaxonomyService) getSkill(id uint) (*models.Skill, error) {
+	skill, err := s.skillRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("skill not found")
+		}
+		return nil, err
+	}
+	return skill, nil
+}
+
+// normalizeSkillTags lowercases, trims and de-duplicates tags
+func normalizeSkillTags(tags []string) (models.JSONArray, error) {
+	normalized := models.JSONArray{}
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = models.NormalizeSkillName(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len(tag) > maxSkillTagLength {
+			return nil, fmt.Errorf("tags must be at most %d characters", maxSkillTagLength)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxSkillTags {
+		return nil, fmt.Errorf("a skill can have at most %d tags", maxSkillTags)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,409 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/config"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// verificationSweepInterval is how often lapsed verifications are expired and renewal reminders sent
+const verificationSweepInterval = time.Hour
+
+// proofFileExtensions lists the file types accepted as proof
+var proofFileExtensions = map[string]bool{
+	".pdf":  true,
+	".png":  true,
+	".jpg":  true,
+	".jpeg": true,
+	".webp": true,
+}
+
+// SkillVerificationService handles teacher credential verification
+// Teachers submit proof for a skill they teach; moderators approve or reject it.
+// Approval verifies the skill for a configurable period, after which it lapses
+// unless the teacher renews it with fresh proof.
+type SkillVerificationService struct {
+	verificationRepo    *repository.SkillVerificationRepository
+	skillRepo           *repository.SkillRepository
+	notificationService *NotificationService
+	config              config.VerificationConfig
+	audit               *AuditScope
+}
+
+// NewSkillVerificationService creates a new skill verification service
+func NewSkillVerificationService(
+	verificationRepo *repository.SkillVerificationRepository,
+	skillRepo *repository.SkillRepository,
+	notificationService *NotificationService,
+	cfg config.VerificationConfig,
+) *SkillVerificationService {
+	return &SkillVerificationService{
+		verificationRepo:    verificationRepo,
+		skillRepo:           skillRepo,
+		notificationService: notificationService,
+		config:              cfg,
+	}
+}
+
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *SkillVerificationService) WithAudit(audit *AuditScope) *SkillVerificationService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
+// SubmitVerification submits proof for one of the teacher's skills
+//
+// Flow:
+//   1. Validates the teacher has the skill and no proof is already in review
+//   2. Refuses renewals before the renewal window opens
+//   3. Stores the uploaded file (if any) under the configured upload directory
+//   4. Queues the verification for moderation
+//
+// Parameters:
+//   - userID: Teacher submitting proof
+//   - skillID: Skill (not user skill) the proof is for
+//   - req: Proof type, optional external link and notes
+//   - file: Optional uploaded certificate or portfolio file
+//
+// Returns:
+//   - *SkillVerification: Pending verification
+//   - error: If validation fails or the file cannot be stored
+func (s *SkillVerificationService) SubmitVerification(userID, skillID uint, req *dto.SubmitSkillVerificationRequest, file *multipart.FileHeader) (*models.SkillVerification, error) {
+	userSkill, err := s.skillRepo.GetUserSkill(userID, skillID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user skill not found")
+		}
+		return nil, err
+	}
+
+	switch req.ProofType {
+	case models.ProofTypeCertificate, models.ProofTypePortfolio, models.ProofTypeProject:
+	default:
+		return nil, errors.New("proof_type must be certificate, portfolio or project")
+	}
+
+	externalURL := strings.TrimSpace(req.ExternalURL)
+	if externalURL == "" && file == nil {
+		// Fall back to the link already on the offer
+		externalURL = userSkill.ProofURL
+	}
+	if externalURL == "" && file == nil {
+		return nil, errors.New("a proof file or external_url is required")
+	}
+	if externalURL != "" {
+		parsed, err := url.Parse(externalURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, errors.New("external_url must be an http(s) link")
+		}
+	}
+
+	pending, err := s.verificationRepo.HasPending(userSkill.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, errors.New("proof for this skill is already awaiting review")
+	}
+
+	now := time.Now()
+	renewalOpens := now.AddDate(0, 0, s.config.RenewalWindowDays)
+	if userSkill.IsCurrentlyVerified(now) && userSkill.VerificationExpiresAt.After(renewalOpens) {
+		return nil, fmt.Errorf("skill is verified until %s; renewal opens %d days before expiry",
+			userSkill.VerificationExpiresAt.Format("2006-01-02"), s.config.RenewalWindowDays)
+	}
+
+	verification := &models.SkillVerification{
+		UserSkillID: userSkill.ID,
+		UserID:      userID,
+		ProofType:   req.ProofType,
+		ExternalURL: externalURL,
+		Notes:       req.Notes,
+		Status:      models.VerificationPending,
+	}
+
+	if file != nil {
+		path, err := s.saveProofFile(userID, file)
+		if err != nil {
+			return nil, err
+		}
+		verification.FileName = filepath.Base(file.Filename)
+		verification.FilePath = path
+		verification.FileSize = file.Size
+	}
+
+	if err := s.verificationRepo.Create(verification); err != nil {
+		if verification.HasFile() {
+			_ = os.Remove(verification.FilePath)
+		}
+		return nil, fmt.Errorf("failed to submit verification: %w", err)
+	}
+
+	s.audit.Record(models.AuditActionCreate, "skill_verifications", verification.ID, nil, verification)
+	return verification, nil
+}
+
+// GetUserVerifications lists a teacher's verification requests
+func (s *SkillVerificationService) GetUserVerifications(userID uint) ([]models.SkillVerification, error) {
+	return s.verificationRepo.ListByUser(userID)
+}
+
+// ListVerifications lists the moderation queue filtered by status and/or teacher
+func (s *SkillVerificationService) ListVerifications(status string, userID *uint, limit, offset int) ([]models.SkillVerification, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	verificationStatus := models.VerificationStatus(status)
+	switch verificationStatus {
+	case "", models.VerificationPending, models.VerificationApproved, models.VerificationRejected, models.VerificationExpired:
+	default:
+		return nil, 0, errors.New("invalid verification status")
+	}
+
+	return s.verificationRepo.List(verificationStatus, userID, limit, offset)
+}
+
+// GetVerification gets a verification request
+func (s *SkillVerificationService) GetVerification(verificationID uint) (*models.SkillVerification, error) {
+	verification, err := s.verificationRepo.GetByID(verificationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("verification not found")
+		}
+		return nil, err
+	}
+	return verification, nil
+}
+
+// GetProofFile gets a verification's proof file for download
+// Teachers may only download their own proof; ownerID is nil for moderators
+func (s *SkillVerificationService) GetProofFile(verificationID uint, ownerID *uint) (*models.SkillVerification, error) {
+	verification, err := s.GetVerification(verificationID)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID != nil && verification.UserID != *ownerID {
+		return nil, errors.New("verification not found")
+	}
+	if !verification.HasFile() {
+		return nil, errors.New("verification has no proof file")
+	}
+	return verification, nil
+}
+
+// ApproveVerification verifies the skill for the configured validity period
+func (s *SkillVerificationService) ApproveVerification(adminID, verificationID uint, note string) (*models.SkillVerification, error) {
+	verification, err := s.getPending(verificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().AddDate(0, 0, s.config.ValidityDays)
+	if err := s.verificationRepo.Approve(verification.ID, adminID, note, expiresAt); err != nil {
+		return nil, err
+	}
+
+	after, err := s.recordReview(verification)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _ = s.notificationService.CreateNotification(
+		verification.UserID,
+		models.NotificationTypeAchievement,
+		"Skill Verified",
+		fmt.Sprintf("Your %s skill is now verified until %s", verification.UserSkill.Skill.Name, expiresAt.Format("2006-01-02")),
+		map[string]interface{}{
+			"verification_id": verification.ID,
+			"user_skill_id":   verification.UserSkillID,
+			"skill_id":        verification.UserSkill.SkillID,
+			"expires_at":      expiresAt,
+		},
+	)
+
+	return after, nil
+}
+
+// RejectVerification rejects the proof and tells the teacher why
+func (s *SkillVerificationService) RejectVerification(adminID, verificationID uint, note string) (*models.SkillVerification, error) {
+	verification, err := s.getPending(verificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.verificationRepo.Reject(verification.ID, adminID, note); err != nil {
+		return nil, err
+	}
+
+	after, err := s.recordReview(verification)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _ = s.notificationService.CreateNotification(
+		verification.UserID,
+		models.NotificationTypeAchievement,
+		"Skill Verification Rejected",
+		fmt.Sprintf("Your proof for %s was not accepted: %s", verification.UserSkill.Skill.Name, note),
+		map[string]interface{}{
+			"verification_id": verification.ID,
+			"user_skill_id":   verification.UserSkillID,
+			"skill_id":        verification.UserSkill.SkillID,
+		},
+	)
+
+	return after, nil
+}
+
+// StartExpiryWorker expires lapsed verifications and sends renewal reminders in the background
+func (s *SkillVerificationService) StartExpiryWorker() {
+	go func() {
+		ticker := time.NewTicker(verificationSweepInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			expired, reminded, err := s.ProcessExpirations(time.Now())
+			if err != nil {
+				log.Printf("Failed to process skill verification expirations: %v", err)
+			}
+			if expired > 0 || reminded > 0 {
+				log.Printf("Expired %d skill verifications, sent %d renewal reminders", expired, reminded)
+			}
+		}
+	}()
+}
+
+// ProcessExpirations unverifies lapsed skills and reminds teachers whose verification expires soon
+//
+// Returns:
+//   - int: Number of user skills that lost their verified flag
+//   - int: Number of renewal reminders sent
+//   - error: If the database sweep fails
+func (s *SkillVerificationService) ProcessExpirations(now time.Time) (int, int, error) {
+	expired, err := s.verificationRepo.ExpireDue(now)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, userSkill := range expired {
+		_, _ = s.notificationService.CreateNotification(
+			userSkill.UserID,
+			models.NotificationTypeAchievement,
+			"Skill Verification Expired",
+			fmt.Sprintf("Your %s verification has expired. Submit new proof to get verified again.", userSkill.Skill.Name),
+			map[string]interface{}{
+				"user_skill_id": userSkill.ID,
+				"skill_id":      userSkill.SkillID,
+			},
+		)
+	}
+
+	windowEnd := now.AddDate(0, 0, s.config.RenewalWindowDays)
+	due, err := s.verificationRepo.ClaimRenewalReminders(now, windowEnd)
+	for _, verification := range due {
+		_, _ = s.notificationService.CreateNotification(
+			verification.UserID,
+			models.NotificationTypeAchievement,
+			"Skill Verification Expiring",
+			fmt.Sprintf("Your %s verification expires on %s. Submit new proof to renew it.",
+				verification.UserSkill.Skill.Name, verification.ExpiresAt.Format("2006-01-02")),
+			map[string]interface{}{
+				"verification_id": verification.ID,
+				"user_skill_id":   verification.UserSkillID,
+				"skill_id":        verification.UserSkill.SkillID,
+				"expires_at":      verification.ExpiresAt,
+			},
+		)
+	}
+
+	return len(expired), len(due), err
+}
+
+// getPending loads a verification and verifies it is still pending
+func (s *SkillVerificationService) getPending(verificationID uint) (*models.SkillVerification, error) {
+	verification, err := s.GetVerification(verificationID)
+	if err != nil {
+		return nil, err
+	}
+	if !verification.IsPending() {
+		return nil, fmt.Errorf("verification is already %s", verification.Status)
+	}
+	return verification, nil
+}
+
+// recordReview reloads a reviewed verification and records the status change
+func (s *SkillVerificationService) recordReview(before *models.SkillVerification) (*models.SkillVerification, error) {
+	after, err := s.verificationRepo.GetByID(before.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(models.AuditActionUpdate, "skill_verifications", after.ID, before, after)
+	return after, nil
+}
+
+// saveProofFile validates an uploaded proof and stores it under a random name
+// Files are grouped per teacher: <upload dir>/<user id>/<random>.<ext>
+func (s *SkillVerificationService) saveProofFile(userID uint, file *multipart.FileHeader) (string, error) {
+	maxSize := int64(s.config.MaxProofSizeMB) * 1024 * 1024
+	if file.Size > maxSize {
+		return "", fmt.Errorf("proof file exceeds %dMB limit", s.config.MaxProofSizeMB)
+	}
+
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if !proofFileExtensions[ext] {
+		return "", errors.New("proof file must be a PDF or an image (png, jpg, webp)")
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(s.config.UploadDir, strconv.FormatUint(uint64(userID), 10))
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to store proof file: %w", err)
+	}
+	path := filepath.Join(dir, hex.EncodeToString(buf)+ext)
+
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", fmt.Errorf("failed to store proof file: %w", err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		_ = os.Remove(path)
+		return "", fmt.Errorf("failed to store proof file: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(path)
+		return "", fmt.Errorf("failed to store proof file: %w", err)
+	}
+
+	return path, nil
+}
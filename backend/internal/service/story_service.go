@@ -14,6 +14,7 @@ type StoryService struct {
 	storyRepo            *repository.StoryRepository
 	userRepo             *repository.UserRepository
 	notificationService  *NotificationService
+	audit                *AuditScope
 }
 
 // NewStoryService creates a new story service
@@ -43,6 +44,13 @@ func NewStoryServiceWithNotification(
 
 // ===== STORY OPERATIONS =====
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *StoryService) WithAudit(audit *AuditScope) *StoryService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
 // CreateStory creates a new success story
 func (s *StoryService) CreateStory(userID uint, req *dto.CreateStoryRequest) (*models.SuccessStory, error) {
 	// Validate user exists
@@ -63,6 +71,7 @@ func (s *StoryService) CreateStory(userID uint, req *dto.CreateStoryRequest) (*m
 	if err := s.storyRepo.CreateStory(story); err != nil {
 		return nil, fmt.Errorf("failed to create story: %w", err)
 	}
+	s.audit.Record(models.AuditActionCreate, "success_stories", story.ID, nil, story)
 
 	return s.storyRepo.GetStoryByID(story.ID)
 }
@@ -113,6 +122,7 @@ func (s *StoryService) UpdateStory(storyID, userID uint, req *dto.UpdateStoryReq
 		return nil, errors.New("unauthorized")
 	}
 
+	before := *story
 	story.Title = req.Title
 	story.Description = req.Description
 	story.Images = req.Images
@@ -122,6 +132,7 @@ func (s *StoryService) UpdateStory(storyID, userID uint, req *dto.UpdateStoryReq
 	if err := s.storyRepo.UpdateStory(story); err != nil {
 		return nil, fmt.Errorf("failed to update story: %w", err)
 	}
+	s.audit.Record(models.AuditActionUpdate, "success_stories", story.ID, &before, story)
 
 	return story, nil
 }
@@ -138,7 +149,11 @@ func (s *StoryService) DeleteStory(storyID, userID uint) error {
 		return errors.New("unauthorized")
 	}
 
-	return s.storyRepo.DeleteStory(storyID)
+	if err := s.storyRepo.DeleteStory(storyID); err != nil {
+		return err
+	}
+	s.audit.Record(models.AuditActionDelete, "success_stories", story.ID, story, nil)
+	return nil
 }
 
 // ===== COMMENT OPERATIONS =====
@@ -166,6 +181,7 @@ func (s *StoryService) CreateComment(userID uint, req *dto.CreateCommentRequest)
 	if err := s.storyRepo.CreateComment(comment); err != nil {
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
+	s.audit.Record(models.AuditActionCreate, "story_comments", comment.ID, nil, comment)
 
 	return comment, nil
 }
@@ -184,7 +200,11 @@ func (s *StoryService) GetCommentsByStory(storyID uint, limit, offset int) ([]mo
 
 // DeleteComment deletes a comment
 func (s *StoryService) DeleteComment(commentID, userID uint) error {
-	return s.storyRepo.DeleteComment(commentID)
+	if err := s.storyRepo.DeleteComment(commentID); err != nil {
+		return err
+	}
+	s.audit.Record(models.AuditActionDelete, "story_comments", commentID, nil, nil)
+	return nil
 }
 
 // LikeStory increments like count
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/config"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// StreakService handles weekly activity streaks
+// A week counts when the user completes a session in it, in either role; weeks are ISO weeks in UTC
+type StreakService struct {
+	streakRepo          *repository.StreakRepository
+	notificationService *NotificationService
+	config              config.StreakConfig
+}
+
+// NewStreakService creates a new streak service
+func NewStreakService(
+	streakRepo *repository.StreakRepository,
+	notificationService *NotificationService,
+	cfg config.StreakConfig,
+) *StreakService {
+	return &StreakService{
+		streakRepo:          streakRepo,
+		notificationService: notificationService,
+		config:              cfg,
+	}
+}
+
+// GetStreak gets a user's streak, building it from their session history the first time
+func (s *StreakService) GetStreak(userID uint) (*dto.StreakResponse, error) {
+	streak, err := s.streakRepo.GetByUserID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		streak, err = s.refreshStreak(userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch streak: %w", err)
+	}
+
+	thisWeek := leaderboardWeekStart(time.Now())
+	resp := &dto.StreakResponse{
+		LongestWeeks:     streak.LongestWeeks,
+		TotalActiveWeeks: streak.TotalActiveWeeks,
+		WeekEndsAt:       thisWeek.AddDate(0, 0, 7).Format(time.RFC3339),
+	}
+	if streak.LastActiveWeek != nil {
+		lastActive := streak.LastActiveWeek.Format("2006-01-02")
+		resp.LastActiveWeek = &lastActive
+	}
+	if streak.IsAlive(thisWeek) {
+		resp.CurrentWeeks = streak.CurrentWeeks
+		resp.ActiveThisWeek = !streak.LastActiveWeek.Before(thisWeek)
+		resp.AtRisk = !resp.ActiveThisWeek
+	}
+	return resp, nil
+}
+
+// RecordActivity updates the streaks of users who just completed a session
+// Streaks are rebuilt from session history, so late completions and cleared fraud
+// holds land in the right week. Failures are logged rather than returned so they
+// never fail the session flow.
+func (s *StreakService) RecordActivity(userIDs ...uint) {
+	if s == nil {
+		return
+	}
+	for _, userID := range userIDs {
+		if _, err := s.refreshStreak(userID); err != nil {
+			log.Printf("Failed to update streak of user %d: %v", userID, err)
+		}
+	}
+}
+
+// refreshStreak recomputes and stores a user's streak from their completed sessions
+func (s *StreakService) refreshStreak(userID uint) (*models.UserStreak, error) {
+	times, err := s.streakRepo.GetSessionCompletionTimes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	streak := computeStreak(times)
+	streak.UserID = userID
+	if err := s.streakRepo.SaveStreak(streak); err != nil {
+		return nil, err
+	}
+	return streak, nil
+}
+
+// StartWarningJob checks hourly for streaks about to break and warns their users
+func (s *StreakService) StartWarningJob() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if sent, err := s.SendWarnings(time.Now()); err != nil {
+				log.Printf("Failed to send streak warnings: %v", err)
+			} else if sent > 0 {
+				log.Printf("Sent %d streak warnings", sent)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// SendWarnings resets streaks that broke and, within WarningHours of the week's end,
+// notifies users whose streak breaks unless they complete a session this week
+// Each streak is warned about at most once a week. Returns the warnings sent.
+func (s *StreakService) SendWarnings(now time.Time) (int, error) {
+	thisWeek := leaderboardWeekStart(now)
+	lastWeek := thisWeek.AddDate(0, 0, -7)
+
+	if _, err := s.streakRepo.ResetBrokenStreaks(lastWeek); err != nil {
+		return 0, fmt.Errorf("failed to reset broken streaks: %w", err)
+	}
+
+	weekEnd := thisWeek.AddDate(0, 0, 7)
+	if weekEnd.Sub(now) > time.Duration(s.config.WarningHours)*time.Hour {
+		return 0, nil
+	}
+
+	streaks, err := s.streakRepo.GetStreaksAtRisk(lastWeek, thisWeek, s.config.MinWeeks)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch streaks at risk: %w", err)
+	}
+
+	sent := 0
+	for _, streak := range streaks {
+		_, err := s.notificationService.CreateNotification(
+			streak.UserID,
+			models.NotificationTypeAchievement,
+			"Your streak is about to end 🔥",
+			fmt.Sprintf("You've been active %d weeks in a row. Complete a session before the week ends to keep your streak going!", streak.CurrentWeeks),
+			map[string]interface{}{
+				"currentWeeks": streak.CurrentWeeks,
+				"weekEndsAt":   weekEnd.Format(time.RFC3339),
+			},
+		)
+		if err != nil {
+			log.Printf("Failed to warn user %d about their streak: %v", streak.UserID, err)
+			continue
+		}
+		if err := s.streakRepo.MarkWarned(streak.ID, thisWeek); err != nil {
+			log.Printf("Failed to mark streak %d as warned: %v", streak.ID, err)
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// computeStreak builds streak counters from session completion times
+// CurrentWeeks is the run ending at the latest active week; whether that run is
+// still alive depends on the current date and is decided when the streak is read
+func computeStreak(times []time.Time) *models.UserStreak {
+	seen := make(map[time.Time]bool, len(times))
+	weeks := make([]time.Time, 0, len(times))
+	for _, t := range times {
+		week := leaderboardWeekStart(t)
+		if !seen[week] {
+			seen[week] = true
+			weeks = append(weeks, week)
+		}
+	}
+	sort.Slice(weeks, func(i, j int) bool { return weeks[i].Before(weeks[j]) })
+
+	streak := &models.UserStreak{TotalActiveWeeks: len(weeks)}
+	run := 0
+	for i, week := range weeks {
+		if i > 0 && weeks[i-1].AddDate(0, 0, 7).Equal(week) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > streak.LongestWeeks {
+			streak.LongestWeeks = run
+		}
+	}
+	if len(weeks) > 0 {
+		last := weeks[len(weeks)-1]
+		streak.LastActiveWeek = &last
+		streak.CurrentWeeks = run
+	}
+	return streak
+}
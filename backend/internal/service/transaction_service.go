@@ -1,13 +1,26 @@
 package service
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/timebankingskill/backend/internal/dto"
 	"github.com/timebankingskill/backend/internal/models"
 	"github.com/timebankingskill/backend/internal/repository"
 )
 
+// Errors returned by SearchUserTransactions for bad client input
+var (
+	// ErrInvalidCursor is returned for a cursor that wasn't produced by a previous page
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidTransactionFilter is wrapped by every rejected search filter
+	ErrInvalidTransactionFilter = errors.New("invalid transaction filter")
+)
+
 // TransactionService handles transaction business logic
 type TransactionService struct {
 	transactionRepo     *repository.TransactionRepository
@@ -343,6 +356,167 @@ func (s *TransactionService) GetUserTransactionHistory(
 	return transactions, total, nil
 }
 
+// SearchUserTransactions searches a user's transaction history with filters and cursor pagination
+// Returns one page of transactions plus aggregate totals for the whole filtered set
+//
+// Pagination:
+//   - Rows are ordered by (created_at DESC, id DESC)
+//   - next_cursor encodes the last row of the page; pass it back as ?cursor= for the next page
+//   - Keyset pagination stays stable when new transactions are inserted between requests
+//   - Legacy ?offset= is still honoured when no cursor is given
+//
+// Parameters:
+//   - userID: Owner of the transactions
+//   - req: Filters (type, date range, amount range, session, counterparty, search) and paging
+//
+// Returns:
+//   - *TransactionListResponse: Page of transactions, totals and next cursor
+//   - error: If filters are invalid or database error
+func (s *TransactionService) SearchUserTransactions(userID uint, req *dto.TransactionSearchRequest) (*dto.TransactionListResponse, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	filter, err := buildTransactionFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor *repository.TransactionCursor
+	if req.Cursor != "" {
+		cursor, err = decodeTransactionCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		offset = 0
+	}
+
+	// Fetch one extra row to know whether another page exists
+	transactions, err := s.transactionRepo.SearchUserTransactions(userID, filter, cursor, limit+1, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search transactions: %w", err)
+	}
+
+	hasMore := len(transactions) > limit
+	if hasMore {
+		transactions = transactions[:limit]
+	}
+
+	typeTotals, err := s.transactionRepo.GetUserTransactionTotals(userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate transactions: %w", err)
+	}
+
+	totals := dto.TransactionTotals{ByType: make(map[string]float64)}
+	for _, t := range typeTotals {
+		totals.Count += t.Count
+		totals.TotalCredit += t.Credit
+		totals.TotalDebit += t.Debit
+		totals.ByType[string(t.Type)] = t.Credit - t.Debit
+	}
+	totals.Net = totals.TotalCredit - totals.TotalDebit
+
+	response := &dto.TransactionListResponse{
+		Transactions: transactions,
+		Total:        totals.Count,
+		Limit:        limit,
+		Offset:       offset,
+		HasMore:      hasMore,
+		Totals:       totals,
+	}
+	if hasMore && len(transactions) > 0 {
+		last := transactions[len(transactions)-1]
+		response.NextCursor = encodeTransactionCursor(last.CreatedAt, last.ID)
+	}
+
+	return response, nil
+}
+
+// buildTransactionFilter validates search request fields and converts them to a repository filter
+func buildTransactionFilter(req *dto.TransactionSearchRequest) (repository.TransactionFilter, error) {
+	filter := repository.TransactionFilter{
+		From:           req.From,
+		To:             req.To,
+		MinAmount:      req.MinAmount,
+		MaxAmount:      req.MaxAmount,
+		SessionID:      req.SessionID,
+		CounterpartyID: req.CounterpartyID,
+		Search:         strings.TrimSpace(req.Search),
+	}
+
+	// Types may be repeated (?type=earned&type=spent) or comma-separated (?type=earned,spent)
+	for _, raw := range req.Types {
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.TrimSpace(t)
+			if t == "" {
+				continue
+			}
+			if !isValidTransactionType(models.TransactionType(t)) {
+				return filter, fmt.Errorf("%w: unknown type %s", ErrInvalidTransactionFilter, t)
+			}
+			filter.Types = append(filter.Types, models.TransactionType(t))
+		}
+	}
+
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return filter, fmt.Errorf("%w: from must be before to", ErrInvalidTransactionFilter)
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return filter, fmt.Errorf("%w: min_amount must not exceed max_amount", ErrInvalidTransactionFilter)
+	}
+
+	return filter, nil
+}
+
+// isValidTransactionType checks that a transaction type is one of the known constants
+func isValidTransactionType(t models.TransactionType) bool {
+	switch t {
+	case models.TransactionEarned, models.TransactionSpent, models.TransactionBonus,
+		models.TransactionRefund, models.TransactionPenalty, models.TransactionInitial,
+		models.TransactionHold, models.TransactionAdjustment:
+		return true
+	}
+	return false
+}
+
+// encodeTransactionCursor encodes the position of a row as an opaque cursor string
+func encodeTransactionCursor(createdAt time.Time, id uint) string {
+	raw := fmt.Sprintf("%d:%d", createdAt.UnixNano(), id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeTransactionCursor parses a cursor produced by encodeTransactionCursor
+func decodeTransactionCursor(cursor string) (*repository.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return nil, ErrInvalidCursor
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &repository.TransactionCursor{
+		CreatedAt: time.Unix(0, nanos),
+		ID:        uint(id),
+	}, nil
+}
+
 // GetTransaction gets a specific transaction by ID
 func (s *TransactionService) GetTransaction(id uint) (*models.Transaction, error) {
 	transaction, err := s.transactionRepo.GetByID(id)
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/dto"
+)
+
+func TestTransactionCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, 3, 14, 15, 9, 26, 535897932, time.UTC)
+
+	cursor, err := decodeTransactionCursor(encodeTransactionCursor(createdAt, 42))
+	if err != nil {
+		t.Fatalf("decoding an encoded cursor failed: %v", err)
+	}
+	if !cursor.CreatedAt.Equal(createdAt) {
+		t.Errorf("CreatedAt = %v, want %v", cursor.CreatedAt, createdAt)
+	}
+	if cursor.ID != 42 {
+		t.Errorf("ID = %d, want 42", cursor.ID)
+	}
+}
+
+func TestDecodeTransactionCursorRejectsMalformedInput(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := map[string]string{
+		"not base64":        "!!!",
+		"no separator":      encode("1700000000000000000"),
+		"bad timestamp":     encode("yesterday:42"),
+		"bad id":            encode("1700000000000000000:abc"),
+		"negative id":       encode("1700000000000000000:-1"),
+		"id out of range":   encode("1700000000000000000:4294967296"),
+		"padded base64 url": base64.URLEncoding.EncodeToString([]byte("1:10")),
+	}
+
+	for name, cursor := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := decodeTransactionCursor(cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("decodeTransactionCursor(%q) error = %v, want ErrInvalidCursor", cursor, err)
+			}
+		})
+	}
+}
+
+func TestBuildTransactionFilter(t *testing.T) {
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	min, max := 10.0, 5.0
+
+	t.Run("splits comma separated types", func(t *testing.T) {
+		filter, err := buildTransactionFilter(&dto.TransactionSearchRequest{Types: []string{"earned, spent", "bonus"}, Search: "  tutoring "})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(filter.Types) != 3 || filter.Types[0] != "earned" || filter.Types[1] != "spent" || filter.Types[2] != "bonus" {
+			t.Errorf("Types = %v, want [earned spent bonus]", filter.Types)
+		}
+		if filter.Search != "tutoring" {
+			t.Errorf("Search = %q, want it trimmed", filter.Search)
+		}
+	})
+
+	invalid := map[string]*dto.TransactionSearchRequest{
+		"unknown type":     {Types: []string{"earned,stolen"}},
+		"inverted range":   {From: &from, To: &to},
+		"inverted amounts": {MinAmount: &min, MaxAmount: &max},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := buildTransactionFilter(req); !errors.Is(err, ErrInvalidTransactionFilter) {
+				t.Errorf("error = %v, want ErrInvalidTransactionFilter", err)
+			}
+		})
+	}
+}
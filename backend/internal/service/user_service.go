@@ -13,6 +13,7 @@ import (
 type UserService struct {
 	userRepo    repository.UserRepositoryInterface
 	sessionRepo *repository.SessionRepository
+	audit       *AuditScope
 }
 
 func NewUserService(userRepo repository.UserRepositoryInterface) *UserService {
@@ -30,6 +31,13 @@ func NewUserServiceWithSession(userRepo repository.UserRepositoryInterface, sess
 	}
 }
 
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *UserService) WithAudit(audit *AuditScope) *UserService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
 // GetUserProfile retrieves user profile by ID
 func (s *UserService) GetUserProfile(userID uint) (*models.User, error) {
 	return s.userRepo.GetByID(userID)
@@ -68,6 +76,7 @@ func (s *UserService) UpdateUserProfile(userID uint, updates *models.User) error
 		}
 		return err
 	}
+	before := *existingUser
 
 	// Update allowed fields
 	if updates.FullName != "" {
@@ -103,7 +112,12 @@ func (s *UserService) UpdateUserProfile(userID uint, updates *models.User) error
 		existingUser.Location = updates.Location
 	}
 
-	return s.userRepo.Update(existingUser)
+	if err := s.userRepo.Update(existingUser); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionUpdate, "users", userID, &before, existingUser)
+	return nil
 }
 
 // ChangePassword changes user password with security validation
@@ -156,7 +170,13 @@ func (s *UserService) ChangePassword(userID uint, oldPassword, newPassword strin
 
 	// Update password
 	user.Password = hashedPassword
-	return s.userRepo.Update(user)
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	// Password hashes are never written to the audit log, only the fact of the change
+	s.audit.Record(models.AuditActionUpdate, "users", userID, nil, map[string]interface{}{"password": "changed"})
+	return nil
 }
 
 // GetUserStats retrieves comprehensive user statistics
@@ -256,8 +276,14 @@ func (s *UserService) UpdateAvatar(userID uint, avatarURL string) error {
 		return err
 	}
 
+	before := *user
 	user.Avatar = avatarURL
-	return s.userRepo.Update(user)
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	s.audit.Record(models.AuditActionUpdate, "users", userID, &before, user)
+	return nil
 }
 
 // GetUserByUsername retrieves user by username (for public profile)
@@ -18,6 +18,7 @@ type VideoSessionService struct {
 	userRepo         *repository.UserRepository
 	notificationSvc  *NotificationService
 	config           *config.Config
+	audit            *AuditScope
 }
  
 // NewVideoSessionServiceWithNotification creates a new video session service
@@ -37,6 +38,13 @@ func NewVideoSessionServiceWithNotification(
 	}
 }
  
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *VideoSessionService) WithAudit(audit *AuditScope) *VideoSessionService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+ 
 // StartVideoSession starts a new video call for an existing session
 func (s *VideoSessionService) StartVideoSession(userID uint, sessionID uint) (*dto.StartVideoSessionResponse, error) {
 	session, err := s.sessionRepo.GetByID(sessionID)
@@ -64,6 +72,7 @@ func (s *VideoSessionService) StartVideoSession(userID uint, sessionID uint) (*d
 	if err != nil {
 		return nil, err
 	}
+	s.audit.Record(models.AuditActionCreate, "video_sessions", created.ID, nil, created)
  
 	// Generate Jitsi URL
 	jitsiURL := fmt.Sprintf("%s/%s", s.config.Jitsi.BaseURL, roomID)
@@ -84,6 +93,7 @@ func (s *VideoSessionService) EndVideoSession(userID uint, sessionID uint, durat
 		return nil, err
 	}
  
+	before := *videoSession
 	now := time.Now()
 	videoSession.EndedAt = &now
 	videoSession.Duration = duration
@@ -93,6 +103,7 @@ func (s *VideoSessionService) EndVideoSession(userID uint, sessionID uint, durat
 	if err != nil {
 		return nil, err
 	}
+	s.audit.Record(models.AuditActionUpdate, "video_sessions", updated.ID, &before, updated)
  
 	return &dto.VideoSessionResponse{
 		ID:               updated.ID,
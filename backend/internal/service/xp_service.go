@@ -0,0 +1,260 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/config"
+	"github.com/timebankingskill/backend/internal/dto"
+	"github.com/timebankingskill/backend/internal/models"
+	"github.com/timebankingskill/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// XPService handles experience points and levels
+// Actions across the platform award XP according to admin-tuned rules, with a daily
+// cap per action so repeating an action can't be farmed
+type XPService struct {
+	xpRepo              *repository.XPRepository
+	notificationService *NotificationService
+	config              config.XPConfig
+	audit               *AuditScope
+}
+
+// NewXPService creates a new XP service
+func NewXPService(
+	xpRepo *repository.XPRepository,
+	notificationService *NotificationService,
+	cfg config.XPConfig,
+) *XPService {
+	return &XPService{
+		xpRepo:              xpRepo,
+		notificationService: notificationService,
+		config:              cfg,
+	}
+}
+
+// WithAudit returns a copy of the service that records mutations in the audit log
+func (s *XPService) WithAudit(audit *AuditScope) *XPService {
+	scoped := *s
+	scoped.audit = audit
+	return &scoped
+}
+
+// Award gives a user the XP of an action, once per source
+// sourceType and sourceID identify what earned it (e.g. "session", 12) so retries and
+// repeated hooks don't award twice. Inactive rules award nothing. Failures are logged
+// rather than returned so they never fail the flow that earned the XP.
+func (s *XPService) Award(userID uint, action models.XPAction, sourceType string, sourceID uint) {
+	if s == nil {
+		return
+	}
+
+	rule, err := s.xpRepo.GetRule(action)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Failed to fetch XP rule %s: %v", action, err)
+		}
+		return
+	}
+	if !rule.IsActive || rule.Points <= 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	event, totalBefore, err := s.xpRepo.AwardXP(userID, action, sourceType, sourceID, rule.Points, rule.DailyCap, xpDayStart(now))
+	if err != nil {
+		log.Printf("Failed to award %s XP to user %d: %v", action, userID, err)
+		return
+	}
+	if event == nil || event.Points == 0 {
+		return
+	}
+
+	before, after := s.levelFor(totalBefore), s.levelFor(event.TotalAfter)
+	if after > before {
+		_, _ = s.notificationService.CreateNotification(
+			userID,
+			models.NotificationTypeAchievement,
+			"Level Up! ⬆️",
+			fmt.Sprintf("You reached level %d with %d XP!", after, event.TotalAfter),
+			map[string]interface{}{
+				"level":   after,
+				"totalXP": event.TotalAfter,
+			},
+		)
+	}
+}
+
+// GetSummary gets a user's XP, level and progress to the next level
+// includeDaily adds how much of each action's daily cap the user has used today
+func (s *XPService) GetSummary(userID uint, includeDaily bool) (*dto.XPSummaryResponse, error) {
+	totalXP := 0
+	total, err := s.xpRepo.GetUserXP(userID)
+	if err == nil {
+		totalXP = total.TotalXP
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to fetch XP: %w", err)
+	}
+
+	resp := &dto.XPSummaryResponse{
+		UserID:  userID,
+		TotalXP: totalXP,
+		Level:   s.levelFor(totalXP),
+	}
+	if resp.Level > 1 {
+		resp.LevelXP = s.config.LevelThresholds[resp.Level-2]
+	}
+	if resp.Level-1 < len(s.config.LevelThresholds) {
+		next := s.config.LevelThresholds[resp.Level-1]
+		resp.NextLevelXP = &next
+		resp.Progress = math.Round(float64(totalXP-resp.LevelXP)/float64(next-resp.LevelXP)*1000) / 10
+	} else {
+		resp.IsMaxLevel = true
+		resp.Progress = 100
+	}
+
+	if !includeDaily {
+		return resp, nil
+	}
+
+	now := time.Now().UTC()
+	rules, err := s.xpRepo.GetRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch XP rules: %w", err)
+	}
+	earned, err := s.xpRepo.GetEarnedSince(userID, xpDayStart(now))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch today's XP: %w", err)
+	}
+	resp.Today = make([]dto.XPDailyProgress, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.IsActive {
+			continue
+		}
+		resp.Today = append(resp.Today, dto.XPDailyProgress{
+			Action:   string(rule.Action),
+			Earned:   earned[rule.Action],
+			DailyCap: rule.DailyCap,
+		})
+	}
+	resp.DayResetsAt = xpDayStart(now).AddDate(0, 0, 1).Format(time.RFC3339)
+	return resp, nil
+}
+
+// GetHistory gets a page of a user's XP history, newest first
+func (s *XPService) GetHistory(userID uint, query *dto.XPHistoryQuery) ([]dto.XPEventResponse, int64, error) {
+	limit := query.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset := query.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	var since, until *time.Time
+	if !query.From.IsZero() {
+		from := xpDayStart(query.From)
+		since = &from
+	}
+	if !query.To.IsZero() {
+		to := xpDayStart(query.To).AddDate(0, 0, 1)
+		until = &to
+	}
+	if since != nil && until != nil && !until.After(*since) {
+		return nil, 0, errors.New("from must not be after to")
+	}
+
+	events, total, err := s.xpRepo.GetEvents(userID, models.XPAction(query.Action), since, until, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch XP history: %w", err)
+	}
+	return dto.MapXPEventsToResponse(events), total, nil
+}
+
+// ListRules lists the XP rule of every action
+func (s *XPService) ListRules() ([]dto.XPRuleResponse, error) {
+	rules, err := s.xpRepo.GetRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch XP rules: %w", err)
+	}
+
+	responses := make([]dto.XPRuleResponse, len(rules))
+	for i := range rules {
+		responses[i] = *dto.MapXPRuleToResponse(&rules[i])
+	}
+	return responses, nil
+}
+
+// UpdateRule tunes the XP an action earns
+// Actions without a rule yet get one, so every registered action can be configured
+func (s *XPService) UpdateRule(action string, req *dto.UpdateXPRuleRequest) (*dto.XPRuleResponse, error) {
+	xpAction := models.XPAction(action)
+	if !isValidXPAction(xpAction) {
+		return nil, errors.New("XP action not found")
+	}
+
+	rule, err := s.xpRepo.GetRule(xpAction)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		rule = &models.XPRule{Action: xpAction, IsActive: true}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to fetch XP rule: %w", err)
+	}
+	before := *rule
+
+	if req.Points != nil {
+		rule.Points = *req.Points
+	}
+	if req.DailyCap != nil {
+		rule.DailyCap = *req.DailyCap
+	}
+	if req.Description != nil {
+		rule.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	if err := s.xpRepo.SaveRule(rule); err != nil {
+		return nil, fmt.Errorf("failed to update XP rule: %w", err)
+	}
+	if before.ID == 0 {
+		s.audit.Record(models.AuditActionCreate, "xp_rules", rule.ID, nil, rule)
+	} else {
+		s.audit.Record(models.AuditActionUpdate, "xp_rules", rule.ID, before, rule)
+	}
+	return dto.MapXPRuleToResponse(rule), nil
+}
+
+// levelFor gets the level a total XP reaches; everyone starts at level 1
+func (s *XPService) levelFor(totalXP int) int {
+	level := 1
+	for _, threshold := range s.config.LevelThresholds {
+		if totalXP < threshold {
+			break
+		}
+		level++
+	}
+	return level
+}
+
+// isValidXPAction reports whether the action can earn XP
+func isValidXPAction(action models.XPAction) bool {
+	for _, a := range models.XPActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// xpDayStart gets the start of t's day, 00:00 UTC, which daily caps reset at
+func xpDayStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/timebankingskill/backend/internal/config"
+)
+
+func TestLevelFor(t *testing.T) {
+	s := &XPService{config: config.XPConfig{LevelThresholds: []int{100, 250, 500}}}
+
+	tests := []struct {
+		totalXP int
+		want    int
+	}{
+		{0, 1},
+		{99, 1},
+		{100, 2},
+		{249, 2},
+		{250, 3},
+		{500, 4},
+		{1000000, 4},
+	}
+
+	for _, tt := range tests {
+		if got := s.levelFor(tt.totalXP); got != tt.want {
+			t.Errorf("levelFor(%d) = %d, want %d", tt.totalXP, got, tt.want)
+		}
+	}
+
+	if got := (&XPService{}).levelFor(1000); got != 1 {
+		t.Errorf("without thresholds everyone should stay at level 1, got %d", got)
+	}
+}
+
+func TestXPDayStart(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	tests := []struct {
+		at   time.Time
+		want time.Time
+	}{
+		{time.Date(2026, 5, 3, 23, 59, 59, 0, time.UTC), time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC), time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC)},
+		// 05:00 in UTC+7 is still the previous day in UTC
+		{time.Date(2026, 5, 3, 5, 0, 0, 0, jakarta), time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := xpDayStart(tt.at); !got.Equal(tt.want) {
+			t.Errorf("xpDayStart(%v) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+}
@@ -8,15 +8,33 @@ import (
   "github.com/golang-jwt/jwt/v5"
 )
 
+// Token roles tell user and admin tokens apart; users and admins live in
+// separate tables, so their IDs can collide
+const (
+  TokenRoleUser  = "user"
+  TokenRoleAdmin = "admin"
+)
+
 // JWTClaims represents the claims in JWT token
 type JWTClaims struct {
   UserID uint   `json:"user_id"`
   Email  string `json:"email"`
+  Role   string `json:"role"`
   jwt.RegisteredClaims
 }
 
 // GenerateToken generates a new JWT token for a user
 func GenerateToken(userID uint, email string) (string, error) {
+  return generateToken(userID, email, TokenRoleUser)
+}
+
+// GenerateAdminToken generates a new JWT token for an admin
+func GenerateAdminToken(adminID uint, email string) (string, error) {
+  return generateToken(adminID, email, TokenRoleAdmin)
+}
+
+// generateToken generates a signed JWT token carrying the account's role
+func generateToken(userID uint, email, role string) (string, error) {
   secret := os.Getenv("JWT_SECRET")
   if secret == "" {
     secret = "your-secret-key-change-this-in-production"
@@ -26,6 +44,7 @@ func GenerateToken(userID uint, email string) (string, error) {
   claims := JWTClaims{
     UserID: userID,
     Email:  email,
+    Role:   role,
     RegisteredClaims: jwt.RegisteredClaims{
       ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
       IssuedAt:  jwt.NewNumericDate(time.Now()),